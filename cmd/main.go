@@ -2,11 +2,14 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log"
 	"net"
 	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"sync"
 	"syscall"
 
@@ -14,7 +17,9 @@ import (
 	"github.com/moLIart/go-course/internal"
 	"github.com/moLIart/go-course/internal/grpc/generated"
 	"github.com/moLIart/go-course/internal/grpc/services"
+	"github.com/moLIart/go-course/internal/mailer"
 	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/oidc"
 	"github.com/moLIart/go-course/internal/repository"
 	"google.golang.org/grpc"
 )
@@ -26,13 +31,47 @@ import (
 // @securityDefinitions.apikey	BearerAuth
 // @in							header
 // @name						Authorization
+//
+// @securityDefinitions.apikey	APIKeyAuth
+// @in							header
+// @name						X-API-Key
 func main() {
 	err := godotenv.Load("../.env")
 	if err != nil {
 		log.Fatal("Error loading .env file")
 	}
 
-	middlewares.SetJwtSecret(os.Getenv("JWT_SECRET"))
+	jwtKeys, err := middlewares.ParseHMACKeys(os.Getenv("JWT_KEYS"))
+	if err != nil {
+		log.Fatalf("Invalid JWT_KEYS: %v", err)
+	}
+	err = middlewares.ConfigureJWT(middlewares.JWTConfig{
+		Secret:       os.Getenv("JWT_SECRET"),
+		Keys:         jwtKeys,
+		SigningKeyID: os.Getenv("JWT_SIGNING_KEY_ID"),
+		Issuer:       os.Getenv("JWT_ISSUER"),
+		Audience:     os.Getenv("JWT_AUDIENCE"),
+		JWKSFile:     os.Getenv("JWT_JWKS_FILE"),
+	})
+	if err != nil {
+		log.Fatalf("Invalid JWT configuration: %v", err)
+	}
+
+	proxies, err := middlewares.ParseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+	if err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
+	middlewares.SetTrustedProxies(proxies)
+
+	if err := oidc.Configure(oidcConfigs()); err != nil {
+		log.Fatalf("Invalid OIDC configuration: %v", err)
+	}
+
+	m, err := newMailer()
+	if err != nil {
+		log.Fatalf("Invalid mailer configuration: %v", err)
+	}
+	mailer.Configure(m, os.Getenv("PUBLIC_URL"))
 
 	repository.Startup(os.Getenv("MONGO_DS"), os.Getenv("REDIS_DS"))
 
@@ -41,11 +80,17 @@ func main() {
 		Handler: internal.RegisterHTTPRoutes(),
 	}
 
-	grpcSvc := grpc.NewServer()
+	grpcSvc := grpc.NewServer(
+		grpc.UnaryInterceptor(middlewares.UnaryAuth(services.MethodAccess)),
+		grpc.StreamInterceptor(middlewares.StreamAuth(services.MethodAccess)),
+	)
+	generated.RegisterAdminServiceServer(grpcSvc, &services.AdminService{})
+	generated.RegisterAuthServiceServer(grpcSvc, &services.AuthService{})
 	generated.RegisterBoardServiceServer(grpcSvc, &services.BoardService{})
 	generated.RegisterGameServiceServer(grpcSvc, &services.GameService{})
 	generated.RegisterPlayerServiceServer(grpcSvc, &services.PlayerService{})
 	generated.RegisterRoomServiceServer(grpcSvc, &services.RoomService{})
+	generated.RegisterSimulServiceServer(grpcSvc, &services.SimulService{})
 
 	signalChan := make(chan os.Signal, 1)
 	signal.Notify(signalChan, os.Interrupt, syscall.SIGTERM)
@@ -87,3 +132,61 @@ func main() {
 	wg.Wait()
 	log.Println("Server gracefully stopped")
 }
+
+// newMailer reads the mailer named in MAILER, which has no default. "smtp"
+// sends through SMTP_ADDR with SMTP_USERNAME and SMTP_PASSWORD, "file" writes
+// the messages to MAIL_DIR, and "log" prints them. Messages hold the tokens
+// of password resets and email checks, so "log" is only for local
+// development. All send as MAIL_FROM.
+func newMailer() (mailer.Mailer, error) {
+	switch kind := os.Getenv("MAILER"); kind {
+	case "smtp":
+		if os.Getenv("SMTP_ADDR") == "" || os.Getenv("MAIL_FROM") == "" {
+			return nil, errors.New("SMTP_ADDR and MAIL_FROM are required")
+		}
+		return mailer.SMTPMailer{
+			Addr:     os.Getenv("SMTP_ADDR"),
+			Username: os.Getenv("SMTP_USERNAME"),
+			Password: os.Getenv("SMTP_PASSWORD"),
+			From:     os.Getenv("MAIL_FROM"),
+		}, nil
+	case "file":
+		if os.Getenv("MAIL_DIR") == "" {
+			return nil, errors.New("MAIL_DIR is required")
+		}
+		return mailer.FileMailer{Dir: os.Getenv("MAIL_DIR"), From: os.Getenv("MAIL_FROM")}, nil
+	case "log":
+		log.Println("MAILER=log prints emails with their tokens to the log, only use it for local development")
+		return mailer.FileMailer{From: os.Getenv("MAIL_FROM")}, nil
+	case "":
+		return nil, errors.New(`MAILER must be "smtp", "file" or "log"`)
+	default:
+		return nil, fmt.Errorf("unknown mailer %q", kind)
+	}
+}
+
+// oidcConfigs reads the providers named in OIDC_PROVIDERS, e.g. "google,mock",
+// from OIDC_<NAME>_ISSUER, _CLIENT_ID, _CLIENT_SECRET, _REDIRECT_URL and
+// _SCOPES, the scopes being separated by spaces or commas.
+func oidcConfigs() []oidc.Config {
+	var configs []oidc.Config
+	for _, name := range strings.Split(os.Getenv("OIDC_PROVIDERS"), ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(strings.ReplaceAll(name, "-", "_")) + "_"
+		configs = append(configs, oidc.Config{
+			Name:         name,
+			IssuerURL:    os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+			Scopes: strings.FieldsFunc(os.Getenv(prefix+"SCOPES"), func(r rune) bool {
+				return r == ' ' || r == ','
+			}),
+		})
+	}
+	return configs
+}
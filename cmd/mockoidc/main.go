@@ -0,0 +1,41 @@
+// Command mockoidc serves an OpenID provider that logs anyone in, for trying
+// out and testing the OIDC login of the server without a real provider.
+//
+// Configure the server with
+//
+//	OIDC_PROVIDERS=mock
+//	OIDC_MOCK_ISSUER=http://localhost:8082
+//	OIDC_MOCK_CLIENT_ID=go-course
+//	OIDC_MOCK_CLIENT_SECRET=secret
+//	OIDC_MOCK_REDIRECT_URL=http://localhost:8080/auth/oidc/mock/callback
+//
+// and open http://localhost:8080/auth/oidc/mock/login. The player is logged
+// in as the "login_hint" query parameter of the authorization request, or as
+// "mock-user".
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/moLIart/go-course/internal/oidc"
+)
+
+func main() {
+	addr := flag.String("addr", ":8082", "address to listen on")
+	issuer := flag.String("issuer", "http://localhost:8082", "URL the issuer is reached at")
+	clientID := flag.String("client-id", "go-course", "ID of the only client")
+	clientSecret := flag.String("client-secret", "secret", "secret of the client, empty for a public client")
+	flag.Parse()
+
+	issuerHandler, err := oidc.NewMockIssuer(*issuer, *clientID, *clientSecret)
+	if err != nil {
+		log.Fatalf("Failed to create mock issuer: %v", err)
+	}
+
+	log.Printf("Starting mock OIDC issuer %s on %s", *issuer, *addr)
+	if err := http.ListenAndServe(*addr, issuerHandler); err != nil {
+		log.Fatalf("Could not start mock OIDC issuer: %s", err)
+	}
+}
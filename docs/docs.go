@@ -15,41 +15,14 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/boards": {
-            "get": {
-                "description": "Returns a list of all boards.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "boards"
-                ],
-                "summary": "Get all boards",
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.GetBoardDto"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Failed to encode boards",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            },
+        "/admin/games/{id}/adjudicate": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new board with the given size and adds it to the repository.",
+                "description": "Ends a game in progress with a win for black or white, or a draw.",
                 "consumes": [
                     "application/json"
                 ],
@@ -57,36 +30,60 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "boards"
+                    "admin"
                 ],
-                "summary": "Create a new board (Requires authorization)",
+                "summary": "Adjudicate game (Requires admin role)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Authorization",
-                        "name": "Authorization",
-                        "in": "header",
+                        "type": "integer",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Board size",
-                        "name": "board",
+                        "description": "Decided result",
+                        "name": "result",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateBoardDto"
+                            "$ref": "#/definitions/dto.AdjudicateGameDto"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetBoardDto"
+                            "$ref": "#/definitions/dto.GetRoomDto"
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid id parameter or request body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid Authorization header",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Requires the admin role",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Game is not in progress",
                         "schema": {
                             "type": "string"
                         }
@@ -94,30 +91,72 @@ const docTemplate = `{
                 }
             }
         },
-        "/boards/{id}": {
+        "/admin/users": {
             "get": {
-                "description": "Returns a board by its ID.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the accounts of every registered player with their role.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "boards"
+                    "admin"
                 ],
-                "summary": "Get board by ID",
+                "summary": "Get all users (Requires admin role)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.UserDto"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid Authorization header",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Requires the admin role",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/users/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the player, their account and their sessions.",
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Delete user (Requires admin role)",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Board ID",
+                        "description": "Player ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetBoardDto"
+                            "type": "string"
                         }
                     },
                     "400": {
@@ -126,50 +165,60 @@ const docTemplate = `{
                             "type": "string"
                         }
                     },
+                    "401": {
+                        "description": "Missing or invalid Authorization header",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Requires the admin role",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     "404": {
-                        "description": "Board not found",
+                        "description": "User not found",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/admin/users/{id}/role": {
             "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the size of a board by its ID.",
+                "description": "Gives the account another role and logs the player out everywhere so that the role applies at once. Admins cannot change their own role.",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "boards"
+                    "admin"
                 ],
-                "summary": "Update board by ID (Requires authorization)",
+                "summary": "Set user role (Requires admin role)",
                 "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Authorization",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
                     {
                         "type": "integer",
-                        "description": "Board ID",
+                        "description": "Player ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated board size",
-                        "name": "board",
+                        "description": "New role",
+                        "name": "role",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateBoardDto"
+                            "$ref": "#/definitions/dto.SetUserRoleDto"
                         }
                     }
                 ],
@@ -177,212 +226,158 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.UserDto"
                         }
                     },
                     "400": {
-                        "description": "Invalid id parameter or request body",
+                        "description": "Invalid id parameter, request body or role",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid Authorization header",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Requires the admin role",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Board not found",
+                        "description": "User not found",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/2fa/disable": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a board by its ID.",
+                "description": "Checks a code of the authenticator app, or a recovery code, and stops requiring codes to log in.",
+                "consumes": [
+                    "application/json"
+                ],
                 "tags": [
-                    "boards"
+                    "auth"
                 ],
-                "summary": "Delete board by ID (Requires authorization)",
+                "summary": "Disable two-factor authentication (Requires authorization)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Authorization",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Board ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Code or recovery code",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorCodeDto"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "204": {
+                        "description": "No Content",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "400": {
-                        "description": "Invalid id parameter",
+                        "description": "Invalid request body or code",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Board not found",
+                    "401": {
+                        "description": "Token does not identify a player",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
-            }
-        },
-        "/games": {
-            "get": {
-                "description": "Returns a list of all games.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "games"
-                ],
-                "summary": "Get all games",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "403": {
+                        "description": "API keys cannot be used here",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.GetGameDto"
-                            }
+                            "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Failed to encode games",
+                    "409": {
+                        "description": "Two-factor authentication is not enabled",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many wrong two-factor codes",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/2fa/enable": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new game and adds it to the repository.",
-                "tags": [
-                    "games"
-                ],
-                "summary": "Create a new game (Requires authorization)",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Authorization",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    }
+                "description": "Checks a code of the authenticator app set up with /auth/2fa/setup and requires codes to log in from now on. Returns the recovery codes, which are only shown once.",
+                "consumes": [
+                    "application/json"
                 ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/dto.GetGameDto"
-                        }
-                    }
-                }
-            }
-        },
-        "/games/{id}": {
-            "get": {
-                "description": "Returns a game by its ID.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "games"
+                    "auth"
                 ],
-                "summary": "Get game by ID",
+                "summary": "Enable two-factor authentication (Requires authorization)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Game ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Code of the authenticator app",
+                        "name": "code",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorCodeDto"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetGameDto"
+                            "$ref": "#/definitions/dto.RecoveryCodesDto"
                         }
                     },
                     "400": {
-                        "description": "Invalid id parameter",
+                        "description": "Invalid request body or code",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Game not found",
-                        "schema": {
-                            "type": "string"
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Deletes a game by its ID.",
-                "tags": [
-                    "games"
-                ],
-                "summary": "Delete game by ID (Requires authorization)",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Authorization",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Game ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    "401": {
+                        "description": "Token does not identify a player",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Invalid id parameter",
+                    "403": {
+                        "description": "API keys cannot be used here",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Game not found",
+                    "409": {
+                        "description": "Two-factor authentication is already enabled or was not set up",
                         "schema": {
                             "type": "string"
                         }
@@ -390,9 +385,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/login": {
+        "/auth/2fa/recovery-codes": {
             "post": {
-                "description": "Authenticates user with login and password from environment variables. Returns JWT token on success.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Checks a code of the authenticator app, or a recovery code, and replaces every recovery code with new ones, which are only shown once.",
                 "consumes": [
                     "application/json"
                 ],
@@ -402,15 +402,15 @@ const docTemplate = `{
                 "tags": [
                     "auth"
                 ],
-                "summary": "User login",
+                "summary": "Regenerate recovery codes (Requires authorization)",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "login",
+                        "description": "Code or recovery code",
+                        "name": "code",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/handlers.LoginRequest"
+                            "$ref": "#/definitions/dto.TwoFactorCodeDto"
                         }
                     }
                 ],
@@ -418,95 +418,78 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LoginResponse"
+                            "$ref": "#/definitions/dto.RecoveryCodesDto"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request body or code",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Token does not identify a player",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Could not generate token",
+                    "403": {
+                        "description": "API keys cannot be used here",
                         "schema": {
                             "type": "string"
                         }
-                    }
-                }
-            }
-        },
-        "/players": {
-            "get": {
-                "description": "Returns a list of all players.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "players"
-                ],
-                "summary": "Get all players",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "409": {
+                        "description": "Two-factor authentication is not enabled",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.GetPlayerDto"
-                            }
+                            "type": "string"
                         }
                     },
-                    "500": {
-                        "description": "Failed to encode players",
+                    "429": {
+                        "description": "Too many wrong two-factor codes",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/auth/2fa/setup": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new player with the given name and adds it to the repository.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns a new TOTP secret with its provisioning URI, to show as a QR code to authenticator apps. Logins need codes once the enrollment is enabled with a first code.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "players"
+                    "auth"
                 ],
-                "summary": "Create a new player (Requires authorization)",
-                "parameters": [
-                    {
-                        "description": "Player name",
-                        "name": "player",
-                        "in": "body",
-                        "required": true,
+                "summary": "Set up two-factor authentication (Requires authorization)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreatePlayerDto"
+                            "$ref": "#/definitions/dto.TwoFactorSetupDto"
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetPlayerDto"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "403": {
+                        "description": "API keys cannot be used here",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Two-factor authentication is already enabled",
                         "schema": {
                             "type": "string"
                         }
@@ -514,40 +497,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/players/{id}": {
+        "/auth/email": {
             "get": {
-                "description": "Returns a player by their ID.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the email address of the player's account and whether it was verified. Passwords can only be reset by email once it was.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "players"
-                ],
-                "summary": "Get player by ID",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Player ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Get email address (Requires authorization)",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetPlayerDto"
+                            "$ref": "#/definitions/dto.AccountEmailDto"
                         }
                     },
-                    "400": {
-                        "description": "Invalid id parameter",
+                    "401": {
+                        "description": "Token does not identify a player",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Player not found",
+                    "403": {
+                        "description": "API keys cannot be used here",
                         "schema": {
                             "type": "string"
                         }
@@ -560,29 +539,25 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the name of a player by their ID.",
+                "description": "Sets the email address of the player's account and sends a link to verify it, at most once a minute. Addresses are unique regardless of case.",
                 "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "players"
+                    "auth"
                 ],
-                "summary": "Update player by ID (Requires authorization)",
+                "summary": "Set email address (Requires authorization)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Player ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Updated player name",
-                        "name": "player",
+                        "description": "Email address",
+                        "name": "email",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdatePlayerDto"
+                            "$ref": "#/definitions/dto.EmailDto"
                         }
                     }
                 ],
@@ -590,58 +565,75 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.AccountEmailDto"
                         }
                     },
                     "400": {
-                        "description": "Invalid id parameter or request body",
+                        "description": "Invalid request body or email address",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Player not found",
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "API keys cannot be used here",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Email address is already used by another account",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/auth/email/verification": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a player by their ID.",
+                "description": "Sends another link to verify the email address of the player's account, at most once a minute.",
                 "tags": [
-                    "players"
-                ],
-                "summary": "Delete player by ID (Requires authorization)",
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "Player ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Resend verification email (Requires authorization)",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Invalid id parameter",
+                    "401": {
+                        "description": "Token does not identify a player",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Player not found",
+                    "403": {
+                        "description": "API keys cannot be used here",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Account has no email address, or it is already verified",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "An email was sent less than a minute ago",
                         "schema": {
                             "type": "string"
                         }
@@ -649,78 +641,74 @@ const docTemplate = `{
                 }
             }
         },
-        "/rooms": {
+        "/auth/email/verify": {
             "get": {
-                "description": "Returns a list of all rooms.",
+                "description": "Marks the email address as verified. This is the link sent by email, so it works without authorization for 24 hours, as long as the address did not change.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "rooms"
+                    "auth"
+                ],
+                "summary": "Verify email address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all rooms",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dto.GetRoomDto"
-                            }
+                            "$ref": "#/definitions/dto.AccountEmailDto"
                         }
                     },
-                    "500": {
-                        "description": "Failed to encode rooms",
+                    "401": {
+                        "description": "Invalid or expired token",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/auth/identities": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new room with the given code and adds it to the repository.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the identities at providers the player can log in with, the oldest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "rooms"
+                    "auth"
                 ],
-                "summary": "Create a new room (Requires authorization)",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Authorization",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "Room code",
-                        "name": "room",
-                        "in": "body",
-                        "required": true,
+                "summary": "List linked identities (Requires authorization)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.CreateRoomDto"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.IdentityDto"
+                            }
                         }
-                    }
-                ],
-                "responses": {
-                    "201": {
-                        "description": "Created",
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetRoomDto"
+                            "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request body",
+                    "403": {
+                        "description": "API keys cannot be used here",
                         "schema": {
                             "type": "string"
                         }
@@ -728,267 +716,4942 @@ const docTemplate = `{
                 }
             }
         },
-        "/rooms/{id}": {
-            "get": {
-                "description": "Returns a room by its ID.",
-                "produces": [
-                    "application/json"
+        "/auth/identities/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Stops the identity from logging in as the player. Accounts without password keep at least one identity.",
                 "tags": [
-                    "rooms"
+                    "auth"
                 ],
-                "summary": "Get room by ID",
+                "summary": "Unlink identity (Requires authorization)",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Room ID",
+                        "type": "string",
+                        "description": "Identity ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "API keys cannot be used here",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Identity not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Cannot unlink the only way to log in",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Returns an access token carrying the player ID in its sub claim, to be sent as \"Authorization: Bearer \u003ctoken\u003e\". Accounts with two-factor authentication get a two-factor token instead, to send with their code to /auth/login/2fa.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log in",
+                "parameters": [
+                    {
+                        "description": "Username and password",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CredentialsDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.GetRoomDto"
+                            "$ref": "#/definitions/dto.TokenDto"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorChallengeDto"
                         }
                     },
                     "400": {
-                        "description": "Invalid id parameter",
+                        "description": "Invalid request body",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "404": {
-                        "description": "Room not found",
+                    "401": {
+                        "description": "Invalid username or password",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/auth/login/2fa": {
+            "post": {
+                "description": "Exchanges the two-factor token of a login and a code of the authenticator app, or a recovery code, for tokens. A login is dropped after 5 wrong codes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log in with a two-factor code",
+                "parameters": [
+                    {
+                        "description": "Two-factor token and code",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorLoginDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TokenDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid two-factor code, or the login expired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many wrong two-factor codes",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the code of a room by its ID.",
-                "consumes": [
+                "description": "Revokes the session of the token. Its access and refresh tokens are rejected from now on.",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log out (Requires authorization)",
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout-all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes every session of the player identified by the token, including the current one.",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log out everywhere (Requires authorization)",
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc": {
+            "get": {
+                "description": "Returns the OpenID Connect providers players may log in with.",
+                "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "rooms"
+                    "auth"
                 ],
-                "summary": "Update room by ID (Requires authorization)",
+                "summary": "Get identity providers",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.OIDCProviderDto"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/{provider}/callback": {
+            "get": {
+                "description": "Exchanges the code the provider sent the player back with and verifies the ID token. Logins return tokens, or a two-factor token, like the password login. Links return the linked identity.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Identity provider callback",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "Room ID",
-                        "name": "id",
-                        "in": "path",
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
                         "required": true
                     },
                     {
-                        "description": "Updated room code",
-                        "name": "room",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "State of the login",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dto.UpdateRoomDto"
+                            "$ref": "#/definitions/dto.TokenDto"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TwoFactorChallengeDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Login expired or was started elsewhere",
+                        "schema": {
+                            "type": "string"
                         }
+                    },
+                    "401": {
+                        "description": "Login was refused by the identity provider or the ID token is invalid",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown identity provider",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Identity is already linked to another player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "502": {
+                        "description": "Failed to reach the identity provider",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/{provider}/link": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns where to send the browser of the player to log in with the provider. Coming back to the callback route links the identity to the player instead of logging in.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Link an identity (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.AuthorizationURLDto"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
                         "schema": {
                             "type": "string"
                         }
                     },
-                    "400": {
-                        "description": "Invalid id parameter or request body",
+                    "403": {
+                        "description": "API keys cannot be used here",
                         "schema": {
                             "type": "string"
                         }
                     },
                     "404": {
-                        "description": "Room not found",
+                        "description": "Unknown identity provider",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "502": {
+                        "description": "Failed to reach the identity provider",
                         "schema": {
                             "type": "string"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Deletes a room by its ID.",
-                "tags": [
-                    "rooms"
-                ],
-                "summary": "Delete room by ID (Requires authorization)",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Authorization",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "integer",
-                        "description": "Room ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid id parameter",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
-                    "404": {
-                        "description": "Room not found",
-                        "schema": {
-                            "type": "string"
-                        }
+            }
+        },
+        "/auth/oidc/{provider}/login": {
+            "get": {
+                "description": "Redirects to the provider, which sends the player back to the callback route. Players logging in for the first time get an account without password.",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log in with an identity provider",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider name",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Account to suggest to the provider",
+                        "name": "login_hint",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the provider",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown identity provider",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "502": {
+                        "description": "Failed to reach the identity provider",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/forgot": {
+            "post": {
+                "description": "Sends a token to reset the password to the verified email address of an account, at most once a minute. The response is the same whether or not such an account exists.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Request a password reset",
+                "parameters": [
+                    {
+                        "description": "Email address",
+                        "name": "email",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.EmailDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/reset": {
+            "post": {
+                "description": "Sets a new password with the token sent by /auth/password/forgot and logs the player out everywhere. A token works for an hour and only until the password changed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Reset password",
+                "parameters": [
+                    {
+                        "description": "Token and new password",
+                        "name": "reset",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResetPasswordDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or password",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Returns a new access token and a new refresh token for the session. Each refresh token can only be used once; using one again revokes its session.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Refresh tokens",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "refresh",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshTokenDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TokenDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid refresh token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Creates a player named after the username and returns an access token for it. Usernames are unique regardless of case.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Register",
+                "parameters": [
+                    {
+                        "description": "Username and password",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CredentialsDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.TokenDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid username or password",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Username is already taken",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the active sessions of the player identified by the token, the most recently used first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List sessions (Requires authorization)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.SessionDto"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes a session of the player identified by the token, logging out the device it belongs to.",
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Revoke session (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/boards": {
+            "get": {
+                "description": "Returns a list of all boards.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "boards"
+                ],
+                "summary": "Get all boards",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetBoardDto"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to encode boards",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new board with the given size and adds it to the repository.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "boards"
+                ],
+                "summary": "Create a new board (Requires authorization)",
+                "parameters": [
+                    {
+                        "description": "Board size",
+                        "name": "board",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateBoardDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetBoardDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/boards/{id}": {
+            "get": {
+                "description": "Returns a board by its ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "boards"
+                ],
+                "summary": "Get board by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Board ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetBoardDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Board not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Updates the size of a board by its ID. Only the player who created the board or an admin can update it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "boards"
+                ],
+                "summary": "Update board by ID (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Board ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated board size",
+                        "name": "board",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateBoardDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter or request body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the board owner can change the board",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Board not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a board by its ID. Only the player who created the board or an admin can delete it.",
+                "tags": [
+                    "boards"
+                ],
+                "summary": "Delete board by ID (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Board ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the board owner can change the board",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Board not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bots": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the bots managed by the caller.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bots"
+                ],
+                "summary": "Get my bots (Requires authorization)",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.BotDto"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid Authorization header",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "API keys cannot be used here",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a player named after the username with a bot account managed by the caller. Bots have no password and authenticate with the API keys of their owner.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bots"
+                ],
+                "summary": "Create a bot (Requires authorization)",
+                "parameters": [
+                    {
+                        "description": "Bot username",
+                        "name": "bot",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateBotDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.BotDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or username",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid Authorization header",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "API keys cannot be used here",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Username is already taken",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bots/{id}/keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the keys of the bot, revoked ones included, the newest first. The keys themselves are never returned again.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bots"
+                ],
+                "summary": "Get bot API keys (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Bot player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.APIKeyDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid Authorization header",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the bot owner can manage its keys",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Bot not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a key the bot sends in the X-API-Key header. The key is only returned once, only its hash is stored. Keys with the play scope may do everything the bot could, read-only keys only read.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "bots"
+                ],
+                "summary": "Create a bot API key (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Bot player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Key name and scopes",
+                        "name": "key",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateAPIKeyDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.NewAPIKeyDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter, request body, name or scopes",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid Authorization header",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the bot owner can manage its keys",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Bot not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/bots/{id}/keys/{keyId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes the key at once. Revoked keys are kept with the time they were revoked.",
+                "tags": [
+                    "bots"
+                ],
+                "summary": "Revoke a bot API key (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Bot player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "API key ID",
+                        "name": "keyId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing or invalid Authorization header",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the bot owner can manage its keys",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Bot or API key not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/games": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Returns a list of all games. Games in progress are shown with the spectator delay of their room unless the token belongs to a seated player.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Get all games",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetGameDto"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to encode games",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new game and adds it to the repository.",
+                "tags": [
+                    "games"
+                ],
+                "summary": "Create a new game (Requires authorization)",
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetGameDto"
+                        }
+                    }
+                }
+            }
+        },
+        "/games/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Returns a game by its ID. A game in progress is shown with the spectator delay of its room unless the token belongs to a seated player.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "games"
+                ],
+                "summary": "Get game by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetGameDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a game by its ID. Players can delete the games they played once they are over, admins any game.",
+                "tags": [
+                    "games"
+                ],
+                "summary": "Delete game by ID (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Game ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Not a player of the finished game nor an admin",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Game not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/join/{code}": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Seats the player identified by the token in the room shared through the invite code.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Join room by invite code (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Invite code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Room is full or player is already in the room",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "410": {
+                        "description": "Invite code has expired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/players": {
+            "get": {
+                "description": "Returns a list of all players.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Get all players",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetPlayerDto"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to encode players",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new player with the given name and adds it to the repository.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Create a new player (Requires authorization)",
+                "parameters": [
+                    {
+                        "description": "Player name",
+                        "name": "player",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreatePlayerDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetPlayerDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/players/{id}": {
+            "get": {
+                "description": "Returns a player by their ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Get player by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetPlayerDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Player not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Updates the name of a player by their ID. Players can only update themselves, unless they are admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "players"
+                ],
+                "summary": "Update player by ID (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated player name",
+                        "name": "player",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdatePlayerDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter or request body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Players can only change themselves",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Player not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a player by their ID along with their account, sessions and API keys. Players can only delete themselves, unless they are admins.",
+                "tags": [
+                    "players"
+                ],
+                "summary": "Delete player by ID (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Player ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Players can only change themselves",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Player not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Returns a list of all rooms, optionally only those in the given comma separated states. Games are shown with the spectator delay unless the token belongs to a seated player.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Get all rooms",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "States to list: open, ready, playing, scoring, finished, abandoned",
+                        "name": "state",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetRoomDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid state parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to encode rooms",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Creates a new room with a server generated invite code and adds it to the repository.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Create a new room (Requires authorization)",
+                "parameters": [
+                    {
+                        "description": "Invite code lifetime and room settings",
+                        "name": "room",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateRoomDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or settings",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Returns a room by its ID. The game is shown with the spectator delay unless the token belongs to a seated player.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Get room by ID",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Updates the code and settings of a room by its ID. Only the room owner or an admin can update it, and settings cannot change while a game is in progress.",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Update room by ID (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated room code and settings",
+                        "name": "room",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.UpdateRoomDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter, request body or settings",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the room owner can change the room",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Invite code is already used by another room or a game is in progress",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Deletes a room by its ID. Only the room owner or an admin can delete it.",
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Delete room by ID (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the room owner can change the room",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/chat": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Returns the messages of a chat channel, oldest first. Pass the returned before value to get older messages. Players of a game in progress cannot read the spectators channel.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Get chat history",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "players (default) or spectators",
+                        "name": "channel",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only return messages older than this message ID",
+                        "name": "before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of messages, 50 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChatPageDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameters",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "The viewer cannot read this channel",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Seated players write in the players channel and spectators in the spectators channel. A player may write 5 messages every 10 seconds in a room.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Write chat message (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Message",
+                        "name": "message",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PostChatMessageDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ChatMessageDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or message",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Player cannot write in this channel or is muted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many messages",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/chat/mute": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Stops a player from writing in either chat channel of the room. Only the room owner or a moderator can mute players.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Mute player (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Player to mute",
+                        "name": "player",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MuteChatPlayerDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the room owner or a moderator can moderate the chat",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Player is already muted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/chat/unmute": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Lets a muted player write in the chat of the room again. Only the room owner or a moderator can unmute players.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Unmute player (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Player to unmute",
+                        "name": "player",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MuteChatPlayerDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the room owner or a moderator can moderate the chat",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Player is not muted",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/chat/{messageId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Removes a message from the chat history. Only the room owner or a moderator can delete messages.",
+                "tags": [
+                    "chat"
+                ],
+                "summary": "Delete chat message (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Message ID",
+                        "name": "messageId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the room owner or a moderator can moderate the chat",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room or message not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Streams the same messages as the WebSocket endpoint, named after their type. Events kept in the room's event log carry an increasing id.\nA client reconnecting with the Last-Event-ID header receives the events it missed instead of a new room.snapshot. It gets a new snapshot when the bounded log no longer goes back to that event, and spectators kept behind by a spectator delay always do.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Watch a room with Server-Sent Events",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ID of the last event received",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT, when the Authorization header cannot be set",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/join": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Seats the player identified by the token in the room. Anyone but the owner sends the room's current invite code in the body.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Join room by ID (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Invite code",
+                        "name": "join",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JoinRoomDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter or request body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Invite code is missing",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Room is full or player is already in the room",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "410": {
+                        "description": "Invite code has expired",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/leave": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Removes the player identified by the token from the room.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Leave room by ID (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Player is not in the room",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/move": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Places a stone for the player identified by the token. X is the column and Y the row, both starting at 0.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "play"
+                ],
+                "summary": "Play a move (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Move",
+                        "name": "move",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MoveDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter, request body or point",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Player is not seated in the game",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Move is not allowed now",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/nigiri": {
+            "get": {
+                "description": "Returns the past nigiri of a room followed by the current one, with every step of each exchange.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "nigiri"
+                ],
+                "summary": "Get nigiri audit log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetNigiriDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/nigiri/claim": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Once the stone holder let 2 minutes pass after the guess without revealing, the guesser picks the color they play.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "nigiri"
+                ],
+                "summary": "Claim an unrevealed nigiri (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Color of the guesser",
+                        "name": "claim",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.NigiriClaimDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter, request body or color",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "No nigiri waits for a reveal by the opponent, or the holder can still reveal",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/nigiri/commit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "The player holding the stones publishes SHA-256(\"\u003cstones\u003e:\u003cnonce\u003e\") as a hex string. The opponent then guesses odd or even.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "nigiri"
+                ],
+                "summary": "Commit nigiri stones (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Commitment",
+                        "name": "commit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.NigiriCommitDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter, request body or commitment",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Nigiri cannot be started now",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/nigiri/guess": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "The opponent of the stone holder guesses whether the committed stone count is odd or even.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "nigiri"
+                ],
+                "summary": "Guess nigiri parity (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Guess",
+                        "name": "guess",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.NigiriGuessDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter, request body or guess",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "No nigiri is waiting for this player's guess",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/nigiri/reveal": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "The stone holder reveals the stone count and nonce. The server verifies them against the commitment and gives black to the guesser if the guess was right.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "nigiri"
+                ],
+                "summary": "Reveal nigiri stones (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Stones and nonce",
+                        "name": "reveal",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.NigiriRevealDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter or request body, or reveal does not match the commitment",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "No nigiri is waiting for this player's reveal",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/pass": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Passes the turn of the player identified by the token. Two consecutive passes move the room to scoring.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "play"
+                ],
+                "summary": "Pass (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Player is not seated in the game",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Pass is not allowed now",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/resign": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Resigns the game for the player identified by the token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "play"
+                ],
+                "summary": "Resign (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Player is not seated in the game",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "No game is being played",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/score/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Agrees with the area count of the current position. The game finishes once both players agree.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "play"
+                ],
+                "summary": "Accept the score (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Player is not seated in the game",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Game is not being scored",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/score/resume": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Disputes the count and resumes play so dead stones can be settled on the board.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "play"
+                ],
+                "summary": "Resume play (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Player is not seated in the game",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Game is not being scored",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/spectators/join": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Adds the player identified by the token to the spectators of the room. The game is shown with the room's spectator delay.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "spectators"
+                ],
+                "summary": "Spectate room (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Room does not allow spectators, is full or the player is already in it",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/spectators/leave": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Removes the player identified by the token from the spectators of the room.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "spectators"
+                ],
+                "summary": "Stop spectating room (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Player is not spectating the room",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/start": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Creates the game of a full room. Colors are picked by the owner of an unrated room (\"choice\"), swapped from the previous game (\"alternate\") or drawn by the revealed nigiri of the room (\"nigiri\"), which the first game of \"alternate\" also uses. Unrated rooms without a revealed nigiri draw at random, rated rooms need one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Start a game in a room (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Color assignment",
+                        "name": "start",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.StartGameDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter or request body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the owner of an unrated room can choose colors",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Room is not full, a game is in progress or the player is not in the room",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/takeback": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Asks the opponent to undo the last move when it was played by the player identified by the token and the room allows takebacks. The move is only undone once the opponent accepts, and the request lapses when they move instead.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "play"
+                ],
+                "summary": "Request a takeback (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Player is not seated in the game",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Takebacks are not allowed, the last move is the opponent's or it was already requested",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/takeback/accept": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Undoes the move the opponent asked to take back, gives them the turn again and puts both clocks back to where they stood before that move.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "play"
+                ],
+                "summary": "Accept a takeback (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Player is not seated in the game",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Takebacks are not allowed or the opponent did not ask for one",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/takeback/decline": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Refuses the takeback the opponent asked for, leaving the move on the board.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "play"
+                ],
+                "summary": "Decline a takeback (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetRoomDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Player is not seated in the game",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "The opponent did not ask for a takeback",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/rooms/{id}/ws": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Upgrades to a WebSocket that first sends a room.snapshot message, then pushes room.* and game.* events (joins and leaves, moves, takebacks, clock ticks and game over) as JSON.\nSeated players may send {\"type\":\"move\",\"x\":3,\"y\":3}, {\"type\":\"pass\"} or {\"type\":\"resign\"}, and seated players and spectators {\"type\":\"chat\",\"channel\":\"players\",\"text\":\"hi\"}; refused commands are answered with a command.error message. Chat arrives as chat.* events.\nBrowsers that cannot set the Authorization header may pass the token in the access_token query parameter. Anonymous viewers and bots whose API key lacks the play scope only receive events.",
+                "tags": [
+                    "rooms"
+                ],
+                "summary": "Watch a room over WebSocket",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Room ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "JWT, when the Authorization header cannot be set",
+                        "name": "access_token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "101": {
+                        "description": "Switching Protocols",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid token",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Room not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/simuls": {
+            "get": {
+                "description": "Returns a list of all simuls without the games of their boards.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "simuls"
+                ],
+                "summary": "Get all simuls",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.GetSimulDto"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to encode simuls",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Creates a simultaneous exhibition hosted by the player identified by the token. Opponents join until the host starts it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "simuls"
+                ],
+                "summary": "Create a simul (Requires authorization)",
+                "parameters": [
+                    {
+                        "description": "Simul",
+                        "name": "simul",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.CreateSimulDto"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetSimulDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body or settings",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/simuls/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Returns a simul with the game of every board and the results so far. Games are shown with the spectator delay unless the token belongs to the host or the opponent of the board.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "simuls"
+                ],
+                "summary": "Get simul overview",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Simul ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetSimulDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Simul not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/simuls/{id}/join": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Adds the player identified by the token to the opponents of an open simul.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "simuls"
+                ],
+                "summary": "Join a simul (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Simul ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetSimulDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Simul not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Simul is full, started or the player already joined",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/simuls/{id}/leave": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Removes the player identified by the token from the opponents of a simul that has not started.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "simuls"
+                ],
+                "summary": "Leave a simul (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Simul ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetSimulDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Simul not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Simul started or the player did not join it",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/simuls/{id}/queue": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Returns the boards where it is the host's turn, the one waiting longest first. Only the host can see the queue.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "simuls"
+                ],
+                "summary": "Get host queue (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Simul ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.SimulBoardDto"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the host can do this",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Simul not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        },
+        "/simuls/{id}/start": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "APIKeyAuth": []
+                    }
+                ],
+                "description": "Opens a room with a started game between the host and every opponent. Each board is then played through the room endpoints.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "simuls"
+                ],
+                "summary": "Start a simul (Requires authorization)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Simul ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dto.GetSimulDto"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid id parameter",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "401": {
+                        "description": "Token does not identify a player",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the host can do this",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "404": {
+                        "description": "Simul not found",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "409": {
+                        "description": "Simul already started or has no opponents",
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.APIKeyDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.AccountEmailDto": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "verified": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dto.AdjudicateGameDto": {
+            "type": "object",
+            "properties": {
+                "winner": {
+                    "description": "Winner is \"black\", \"white\" or \"draw\".",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.AuthorizationURLDto": {
+            "type": "object",
+            "properties": {
+                "authorization_url": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.BotDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChatMessageDto": {
+            "type": "object",
+            "properties": {
+                "channel": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "player_name": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ChatPageDto": {
+            "type": "object",
+            "properties": {
+                "before": {
+                    "description": "Before is passed back to fetch the previous page, it is omitted on the\nfirst page of the history.",
+                    "type": "integer"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.ChatMessageDto"
+                    }
+                }
+            }
+        },
+        "dto.CreateAPIKeyDto": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "description": "Scopes are \"read\" and \"play\", play allowing to read as well.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.CreateBoardDto": {
+            "type": "object",
+            "properties": {
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.CreateBotDto": {
+            "type": "object",
+            "properties": {
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreatePlayerDto": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.CreateRoomDto": {
+            "type": "object",
+            "properties": {
+                "code_ttl": {
+                    "description": "CodeTTL is the lifetime of the invite code in seconds, 0 means it never expires.",
+                    "type": "integer"
+                },
+                "settings": {
+                    "description": "Settings left out use the defaults: chinese rules on 19x19, 7.5 komi,\nno time control, unrated and open to spectators.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.RoomSettingsDto"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.CreateSimulDto": {
+            "type": "object",
+            "properties": {
+                "host_color": {
+                    "description": "HostColor is \"black\" or \"white\" and defaults to \"white\".",
+                    "type": "string"
+                },
+                "max_opponents": {
+                    "description": "MaxOpponents is the number of boards, from 1 to 30.",
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "settings": {
+                    "description": "Settings are applied to every board. Pair go is not allowed.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dto.RoomSettingsDto"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.CredentialsDto": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.EmailDto": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetBoardDto": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GetClockDto": {
+            "type": "object",
+            "properties": {
+                "black_periods": {
+                    "type": "integer"
+                },
+                "black_remaining_ms": {
+                    "type": "integer"
+                },
+                "white_periods": {
+                    "type": "integer"
+                },
+                "white_remaining_ms": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GetGameDto": {
+            "type": "object",
+            "properties": {
+                "black_captures": {
+                    "type": "integer"
+                },
+                "black_id": {
+                    "type": "integer"
+                },
+                "black_partner_id": {
+                    "description": "Partners are only set in pair go games.",
+                    "type": "integer"
+                },
+                "board": {
+                    "description": "Board has one string per row: \".\" empty, \"X\" black, \"O\" white.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "clock": {
+                    "$ref": "#/definitions/dto.GetClockDto"
+                },
+                "current_player_id": {
+                    "type": "integer"
+                },
+                "current_turn": {
+                    "type": "string"
+                },
+                "handicap": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "komi": {
+                    "type": "number"
+                },
+                "move_count": {
+                    "type": "integer"
+                },
+                "result": {
+                    "type": "string"
+                },
+                "ruleset": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "takeback_requested_by": {
+                    "description": "TakebackRequestedBy is the player whose takeback request awaits the\nopponent's answer.",
+                    "type": "integer"
+                },
+                "white_captures": {
+                    "type": "integer"
+                },
+                "white_id": {
+                    "type": "integer"
+                },
+                "white_partner_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GetNigiriDto": {
+            "type": "object",
+            "properties": {
+                "black_id": {
+                    "type": "integer"
+                },
+                "commitment": {
+                    "type": "string"
+                },
+                "game_id": {
+                    "type": "integer"
+                },
+                "guess": {
+                    "type": "string"
+                },
+                "guesser_id": {
+                    "type": "integer"
+                },
+                "holder_id": {
+                    "type": "integer"
+                },
+                "log": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.NigiriEventDto"
+                    }
+                },
+                "nonce": {
+                    "type": "string"
+                },
+                "reveal_deadline": {
+                    "description": "RevealDeadline is when the guesser may claim the colors if the holder\nhas not revealed.",
+                    "type": "string"
+                },
+                "stones": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.GetPlayerDto": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.GetRoomDto": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "code_expires_at": {
+                    "type": "string"
+                },
+                "game": {
+                    "$ref": "#/definitions/dto.GetGameDto"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "muted": {
+                    "description": "Muted lists the IDs of the players who may not write in the chat.",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "nigiri": {
+                    "$ref": "#/definitions/dto.GetNigiriDto"
+                },
+                "owner_id": {
+                    "type": "integer"
+                },
+                "players": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GetPlayerDto"
+                    }
+                },
+                "settings": {
+                    "$ref": "#/definitions/dto.GetRoomSettingsDto"
+                },
+                "spectator_count": {
+                    "type": "integer"
+                },
+                "state": {
+                    "type": "string"
+                },
+                "teams": {
+                    "description": "Teams lists the seats of each team of a pair go room in playing order.",
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "$ref": "#/definitions/dto.GetPlayerDto"
+                        }
+                    }
+                }
+            }
+        },
+        "dto.GetRoomSettingsDto": {
+            "type": "object",
+            "properties": {
+                "board_size": {
+                    "type": "integer"
+                },
+                "handicap": {
+                    "type": "integer"
+                },
+                "komi": {
+                    "type": "number"
+                },
+                "max_spectators": {
+                    "type": "integer"
+                },
+                "pair_go": {
+                    "type": "boolean"
+                },
+                "rated": {
+                    "type": "boolean"
+                },
+                "ruleset": {
+                    "type": "string"
+                },
+                "spectator_delay": {
+                    "type": "integer"
+                },
+                "spectators_allowed": {
+                    "type": "boolean"
+                },
+                "takebacks_allowed": {
+                    "type": "boolean"
+                },
+                "time_control": {
+                    "$ref": "#/definitions/dto.TimeControlDto"
+                }
+            }
+        },
+        "dto.GetSimulDto": {
+            "type": "object",
+            "properties": {
+                "boards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.SimulBoardDto"
+                    }
+                },
+                "draws": {
+                    "type": "integer"
+                },
+                "host_color": {
+                    "type": "string"
+                },
+                "host_id": {
+                    "type": "integer"
+                },
+                "host_losses": {
+                    "type": "integer"
+                },
+                "host_wins": {
+                    "description": "Results of the finished boards from the host's side.",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "max_opponents": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "opponents": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.GetPlayerDto"
+                    }
+                },
+                "settings": {
+                    "$ref": "#/definitions/dto.GetRoomSettingsDto"
+                },
+                "state": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.IdentityDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "provider": {
+                    "type": "string"
+                },
+                "subject": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.JoinRoomDto": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is the invite code of the room. Anyone but the owner is only seated\nif it is still the room's code and has not expired.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MoveDto": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "type": "integer"
+                },
+                "y": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.MuteChatPlayerDto": {
+            "type": "object",
+            "properties": {
+                "player_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.NewAPIKeyDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "key": {
+                    "description": "Key is sent by the bot in the X-API-Key header. It is only shown once.",
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.CreateBoardDto": {
+        },
+        "dto.NigiriClaimDto": {
             "type": "object",
             "properties": {
-                "size": {
+                "color": {
+                    "description": "Color is \"black\" or \"white\", the color the guesser wants to play.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.NigiriCommitDto": {
+            "type": "object",
+            "properties": {
+                "commitment": {
+                    "description": "Commitment is the hex encoded SHA-256 of \"\u003cstones\u003e:\u003cnonce\u003e\".",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.NigiriEventDto": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "at": {
+                    "type": "string"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "player_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.CreatePlayerDto": {
+        "dto.NigiriGuessDto": {
+            "type": "object",
+            "properties": {
+                "guess": {
+                    "description": "Guess is \"odd\" or \"even\".",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.NigiriRevealDto": {
+            "type": "object",
+            "properties": {
+                "nonce": {
+                    "type": "string"
+                },
+                "stones": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.OIDCProviderDto": {
             "type": "object",
             "properties": {
+                "login_url": {
+                    "description": "LoginURL is where to send the browser of the player to log in.",
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 }
             }
         },
-        "dto.CreateRoomDto": {
+        "dto.PostChatMessageDto": {
             "type": "object",
             "properties": {
-                "code": {
+                "channel": {
+                    "description": "Channel is \"players\" for seated players or \"spectators\" for spectators.",
+                    "type": "string"
+                },
+                "text": {
                     "type": "string"
                 }
             }
         },
-        "dto.GetBoardDto": {
+        "dto.RecoveryCodesDto": {
             "type": "object",
             "properties": {
-                "id": {
+                "recovery_codes": {
+                    "description": "RecoveryCodes each replace a code once. They are only shown once.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "dto.RefreshTokenDto": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.ResetPasswordDto": {
+            "type": "object",
+            "properties": {
+                "password": {
+                    "type": "string"
+                },
+                "token": {
+                    "description": "Token is the password reset token sent by email.",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RoomSettingsDto": {
+            "type": "object",
+            "properties": {
+                "board_size": {
+                    "description": "BoardSize is 9, 13 or 19.",
                     "type": "integer"
                 },
-                "size": {
+                "handicap": {
+                    "description": "Handicap is 0 or the number of black stones, from 2 to 9.",
+                    "type": "integer"
+                },
+                "komi": {
+                    "type": "number"
+                },
+                "max_spectators": {
+                    "description": "MaxSpectators is at most 500.",
+                    "type": "integer"
+                },
+                "pair_go": {
+                    "description": "PairGo seats two teams of two players.",
+                    "type": "boolean"
+                },
+                "rated": {
+                    "type": "boolean"
+                },
+                "ruleset": {
+                    "description": "Ruleset is one of \"chinese\", \"aga\", \"japanese\" or \"korean\".",
+                    "type": "string"
+                },
+                "spectator_delay": {
+                    "description": "SpectatorDelay is the number of moves spectators are kept behind, at most 50.",
                     "type": "integer"
+                },
+                "spectators_allowed": {
+                    "type": "boolean"
+                },
+                "takebacks_allowed": {
+                    "type": "boolean"
+                },
+                "time_control": {
+                    "$ref": "#/definitions/dto.TimeControlDto"
                 }
             }
         },
-        "dto.GetGameDto": {
+        "dto.SessionDto": {
             "type": "object",
             "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "current": {
+                    "description": "Current is set on the session of the token used to list the sessions.",
+                    "type": "boolean"
+                },
                 "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "user_agent": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SetUserRoleDto": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "description": "Role is \"player\", \"moderator\" or \"admin\".",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.SimulBoardDto": {
+            "type": "object",
+            "properties": {
+                "game": {
+                    "$ref": "#/definitions/dto.GetGameDto"
+                },
+                "host_to_move": {
+                    "description": "HostToMove is set on boards waiting for the host.",
+                    "type": "boolean"
+                },
+                "opponent_id": {
+                    "type": "integer"
+                },
+                "room_id": {
                     "type": "integer"
                 }
             }
         },
-        "dto.GetPlayerDto": {
+        "dto.StartGameDto": {
             "type": "object",
             "properties": {
-                "id": {
+                "color": {
+                    "description": "Color is the color wanted by the room owner with the \"choice\" assignment.",
+                    "type": "string"
+                },
+                "color_assignment": {
+                    "description": "ColorAssignment is one of \"choice\", \"alternate\" or \"nigiri\".",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.TimeControlDto": {
+            "type": "object",
+            "properties": {
+                "increment": {
                     "type": "integer"
                 },
-                "name": {
+                "main_time": {
+                    "type": "integer"
+                },
+                "period_time": {
+                    "type": "integer"
+                },
+                "periods": {
+                    "type": "integer"
+                },
+                "type": {
+                    "description": "Type is one of \"none\", \"absolute\", \"fischer\" or \"byoyomi\".",
                     "type": "string"
                 }
             }
         },
-        "dto.GetRoomDto": {
+        "dto.TokenDto": {
             "type": "object",
             "properties": {
-                "code": {
+                "access_token": {
                     "type": "string"
                 },
-                "id": {
+                "expires_in": {
+                    "description": "ExpiresIn is the number of seconds the access token is valid for.",
                     "type": "integer"
+                },
+                "player": {
+                    "$ref": "#/definitions/dto.GetPlayerDto"
+                },
+                "refresh_token": {
+                    "description": "RefreshToken gets the next access token. It can only be used once.",
+                    "type": "string"
+                },
+                "session_id": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
                 }
             }
         },
-        "dto.UpdateBoardDto": {
+        "dto.TwoFactorChallengeDto": {
             "type": "object",
             "properties": {
-                "size": {
+                "expires_in": {
+                    "description": "ExpiresIn is the number of seconds left to send the code.",
                     "type": "integer"
+                },
+                "two_factor_token": {
+                    "description": "TwoFactorToken is sent with the code to /auth/login/2fa to get the tokens.",
+                    "type": "string"
                 }
             }
         },
-        "dto.UpdatePlayerDto": {
+        "dto.TwoFactorCodeDto": {
             "type": "object",
             "properties": {
-                "name": {
+                "code": {
                     "type": "string"
                 }
             }
         },
-        "dto.UpdateRoomDto": {
+        "dto.TwoFactorLoginDto": {
             "type": "object",
             "properties": {
                 "code": {
+                    "description": "Code is a code of the authenticator app or a recovery code.",
+                    "type": "string"
+                },
+                "two_factor_token": {
                     "type": "string"
                 }
             }
         },
-        "handlers.LoginRequest": {
+        "dto.TwoFactorSetupDto": {
             "type": "object",
             "properties": {
-                "login": {
+                "provisioning_uri": {
+                    "description": "ProvisioningURI is the otpauth URI to show as a QR code to authenticator apps.",
                     "type": "string"
                 },
-                "password": {
+                "secret": {
                     "type": "string"
                 }
             }
         },
-        "handlers.LoginResponse": {
+        "dto.UpdateBoardDto": {
             "type": "object",
             "properties": {
-                "token": {
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dto.UpdatePlayerDto": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "dto.UpdateRoomDto": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code is left unchanged when empty.",
+                    "type": "string"
+                },
+                "settings": {
+                    "$ref": "#/definitions/dto.RoomSettingsDto"
+                }
+            }
+        },
+        "dto.UserDto": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "player_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         }
     },
     "securityDefinitions": {
+        "APIKeyAuth": {
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "type": "apiKey",
             "name": "Authorization",
@@ -1,11 +1,69 @@
 package dto
 
+import "time"
+
 type CreatePlayerDto struct {
 	Name string `json:"name"`
 }
 
 type CreateRoomDto struct {
-	Code string `json:"code"`
+	// CodeTTL is the lifetime of the invite code in seconds, 0 means it never expires.
+	CodeTTL int `json:"code_ttl"`
+	// Settings left out use the defaults: chinese rules on 19x19, 7.5 komi,
+	// no time control, unrated and open to spectators.
+	Settings *RoomSettingsDto `json:"settings,omitempty"`
+}
+
+type JoinRoomDto struct {
+	// Code is the invite code of the room. Anyone but the owner is only seated
+	// if it is still the room's code and has not expired.
+	Code string `json:"code,omitempty"`
+}
+
+// RoomSettingsDto changes the settings of a room. Fields left out keep their
+// current value.
+type RoomSettingsDto struct {
+	// Ruleset is one of "chinese", "aga", "japanese" or "korean".
+	Ruleset *string `json:"ruleset,omitempty"`
+	// BoardSize is 9, 13 or 19.
+	BoardSize *int     `json:"board_size,omitempty"`
+	Komi      *float64 `json:"komi,omitempty"`
+	// Handicap is 0 or the number of black stones, from 2 to 9.
+	Handicap    *int            `json:"handicap,omitempty"`
+	TimeControl *TimeControlDto `json:"time_control,omitempty"`
+	// PairGo seats two teams of two players.
+	PairGo            *bool `json:"pair_go,omitempty"`
+	Rated             *bool `json:"rated,omitempty"`
+	TakebacksAllowed  *bool `json:"takebacks_allowed,omitempty"`
+	SpectatorsAllowed *bool `json:"spectators_allowed,omitempty"`
+	// MaxSpectators is at most 500.
+	MaxSpectators *int `json:"max_spectators,omitempty"`
+	// SpectatorDelay is the number of moves spectators are kept behind, at most 50.
+	SpectatorDelay *int `json:"spectator_delay,omitempty"`
+}
+
+// TimeControlDto durations are in seconds.
+type TimeControlDto struct {
+	// Type is one of "none", "absolute", "fischer" or "byoyomi".
+	Type       string `json:"type"`
+	MainTime   int    `json:"main_time"`
+	Increment  int    `json:"increment,omitempty"`
+	Periods    int    `json:"periods,omitempty"`
+	PeriodTime int    `json:"period_time,omitempty"`
+}
+
+type GetRoomSettingsDto struct {
+	Ruleset           string         `json:"ruleset"`
+	BoardSize         int            `json:"board_size"`
+	Komi              float64        `json:"komi"`
+	Handicap          int            `json:"handicap"`
+	TimeControl       TimeControlDto `json:"time_control"`
+	PairGo            bool           `json:"pair_go"`
+	Rated             bool           `json:"rated"`
+	TakebacksAllowed  bool           `json:"takebacks_allowed"`
+	SpectatorsAllowed bool           `json:"spectators_allowed"`
+	MaxSpectators     int            `json:"max_spectators"`
+	SpectatorDelay    int            `json:"spectator_delay"`
 }
 
 type CreateBoardDto struct {
@@ -18,8 +76,20 @@ type GetPlayerDto struct {
 }
 
 type GetRoomDto struct {
-	ID   int    `json:"id"`
-	Code string `json:"code"`
+	ID             int                `json:"id"`
+	Code           string             `json:"code"`
+	CodeExpiresAt  *time.Time         `json:"code_expires_at,omitempty"`
+	OwnerID        int                `json:"owner_id"`
+	State          string             `json:"state"`
+	Settings       GetRoomSettingsDto `json:"settings"`
+	Players        []GetPlayerDto     `json:"players"`
+	SpectatorCount int                `json:"spectator_count"`
+	// Teams lists the seats of each team of a pair go room in playing order.
+	Teams  [][]GetPlayerDto `json:"teams,omitempty"`
+	Game   *GetGameDto      `json:"game,omitempty"`
+	Nigiri *GetNigiriDto    `json:"nigiri,omitempty"`
+	// Muted lists the IDs of the players who may not write in the chat.
+	Muted []int `json:"muted,omitempty"`
 }
 
 type GetBoardDto struct {
@@ -28,5 +98,287 @@ type GetBoardDto struct {
 }
 
 type GetGameDto struct {
-	ID int `json:"id"`
+	ID      int `json:"id"`
+	BlackID int `json:"black_id"`
+	WhiteID int `json:"white_id"`
+	// Partners are only set in pair go games.
+	BlackPartnerID  int    `json:"black_partner_id,omitempty"`
+	WhitePartnerID  int    `json:"white_partner_id,omitempty"`
+	CurrentPlayerID int    `json:"current_player_id"`
+	CurrentTurn     string `json:"current_turn"`
+	Status          string `json:"status"`
+	Result          string `json:"result,omitempty"`
+	MoveCount       int    `json:"move_count"`
+	BlackCaptures   int    `json:"black_captures"`
+	WhiteCaptures   int    `json:"white_captures"`
+	// Board has one string per row: "." empty, "X" black, "O" white.
+	Board    []string     `json:"board"`
+	Ruleset  string       `json:"ruleset"`
+	Komi     float64      `json:"komi"`
+	Handicap int          `json:"handicap"`
+	Clock    *GetClockDto `json:"clock,omitempty"`
+	// TakebackRequestedBy is the player whose takeback request awaits the
+	// opponent's answer.
+	TakebackRequestedBy int `json:"takeback_requested_by,omitempty"`
+}
+
+// GetClockDto holds the time left to each color when the response was made.
+type GetClockDto struct {
+	BlackRemainingMs int64 `json:"black_remaining_ms"`
+	WhiteRemainingMs int64 `json:"white_remaining_ms"`
+	BlackPeriods     int   `json:"black_periods"`
+	WhitePeriods     int   `json:"white_periods"`
+}
+
+type MoveDto struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type StartGameDto struct {
+	// ColorAssignment is one of "choice", "alternate" or "nigiri".
+	ColorAssignment string `json:"color_assignment"`
+	// Color is the color wanted by the room owner with the "choice" assignment.
+	Color string `json:"color,omitempty"`
+}
+
+type NigiriCommitDto struct {
+	// Commitment is the hex encoded SHA-256 of "<stones>:<nonce>".
+	Commitment string `json:"commitment"`
+}
+
+type NigiriGuessDto struct {
+	// Guess is "odd" or "even".
+	Guess string `json:"guess"`
+}
+
+type NigiriRevealDto struct {
+	Stones int    `json:"stones"`
+	Nonce  string `json:"nonce"`
+}
+
+type NigiriClaimDto struct {
+	// Color is "black" or "white", the color the guesser wants to play.
+	Color string `json:"color"`
+}
+
+type NigiriEventDto struct {
+	At       time.Time `json:"at"`
+	PlayerID int       `json:"player_id"`
+	Action   string    `json:"action"`
+	Detail   string    `json:"detail,omitempty"`
+}
+
+type GetNigiriDto struct {
+	HolderID   int    `json:"holder_id"`
+	GuesserID  int    `json:"guesser_id"`
+	Commitment string `json:"commitment"`
+	Guess      string `json:"guess,omitempty"`
+	Stones     int    `json:"stones,omitempty"`
+	Nonce      string `json:"nonce,omitempty"`
+	BlackID    int    `json:"black_id,omitempty"`
+	GameID     int    `json:"game_id,omitempty"`
+	// RevealDeadline is when the guesser may claim the colors if the holder
+	// has not revealed.
+	RevealDeadline *time.Time       `json:"reveal_deadline,omitempty"`
+	Log            []NigiriEventDto `json:"log"`
+}
+
+type CreateSimulDto struct {
+	Name string `json:"name"`
+	// MaxOpponents is the number of boards, from 1 to 30.
+	MaxOpponents int `json:"max_opponents"`
+	// HostColor is "black" or "white" and defaults to "white".
+	HostColor string `json:"host_color,omitempty"`
+	// Settings are applied to every board. Pair go is not allowed.
+	Settings *RoomSettingsDto `json:"settings,omitempty"`
+}
+
+type SimulBoardDto struct {
+	RoomID     int `json:"room_id"`
+	OpponentID int `json:"opponent_id"`
+	// HostToMove is set on boards waiting for the host.
+	HostToMove bool        `json:"host_to_move"`
+	Game       *GetGameDto `json:"game,omitempty"`
+}
+
+type GetSimulDto struct {
+	ID           int                `json:"id"`
+	Name         string             `json:"name"`
+	HostID       int                `json:"host_id"`
+	HostColor    string             `json:"host_color"`
+	MaxOpponents int                `json:"max_opponents"`
+	State        string             `json:"state"`
+	Settings     GetRoomSettingsDto `json:"settings"`
+	Opponents    []GetPlayerDto     `json:"opponents"`
+	Boards       []SimulBoardDto    `json:"boards"`
+	// Results of the finished boards from the host's side.
+	HostWins   int `json:"host_wins"`
+	HostLosses int `json:"host_losses"`
+	Draws      int `json:"draws"`
+}
+
+type PostChatMessageDto struct {
+	// Channel is "players" for seated players or "spectators" for spectators.
+	Channel string `json:"channel"`
+	Text    string `json:"text"`
+}
+
+type ChatMessageDto struct {
+	ID         int       `json:"id"`
+	Channel    string    `json:"channel"`
+	PlayerID   int       `json:"player_id"`
+	PlayerName string    `json:"player_name"`
+	Text       string    `json:"text"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type ChatPageDto struct {
+	Messages []ChatMessageDto `json:"messages"`
+	// Before is passed back to fetch the previous page, it is omitted on the
+	// first page of the history.
+	Before int `json:"before,omitempty"`
+}
+
+type MuteChatPlayerDto struct {
+	PlayerID int `json:"player_id"`
+}
+
+type CredentialsDto struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type TokenDto struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	// ExpiresIn is the number of seconds the access token is valid for.
+	ExpiresIn int `json:"expires_in"`
+	// RefreshToken gets the next access token. It can only be used once.
+	RefreshToken string       `json:"refresh_token"`
+	SessionID    string       `json:"session_id"`
+	Player       GetPlayerDto `json:"player"`
+}
+
+type RefreshTokenDto struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type SessionDto struct {
+	ID         string    `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	// Current is set on the session of the token used to list the sessions.
+	Current bool `json:"current"`
+}
+
+type AdjudicateGameDto struct {
+	// Winner is "black", "white" or "draw".
+	Winner string `json:"winner"`
+}
+
+type UserDto struct {
+	PlayerID  int       `json:"player_id"`
+	Username  string    `json:"username"`
+	Role      string    `json:"role"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type SetUserRoleDto struct {
+	// Role is "player", "moderator" or "admin".
+	Role string `json:"role"`
+}
+
+type CreateBotDto struct {
+	Username string `json:"username"`
+}
+
+type BotDto struct {
+	PlayerID  int       `json:"player_id"`
+	Username  string    `json:"username"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type CreateAPIKeyDto struct {
+	Name string `json:"name"`
+	// Scopes are "read" and "play", play allowing to read as well.
+	Scopes []string `json:"scopes"`
+}
+
+type APIKeyDto struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+type NewAPIKeyDto struct {
+	APIKeyDto
+	// Key is sent by the bot in the X-API-Key header. It is only shown once.
+	Key string `json:"key"`
+}
+
+type OIDCProviderDto struct {
+	Name string `json:"name"`
+	// LoginURL is where to send the browser of the player to log in.
+	LoginURL string `json:"login_url"`
+}
+
+type AuthorizationURLDto struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+type IdentityDto struct {
+	ID        string    `json:"id"`
+	Provider  string    `json:"provider"`
+	Subject   string    `json:"subject"`
+	Email     string    `json:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type TwoFactorChallengeDto struct {
+	// TwoFactorToken is sent with the code to /auth/login/2fa to get the tokens.
+	TwoFactorToken string `json:"two_factor_token"`
+	// ExpiresIn is the number of seconds left to send the code.
+	ExpiresIn int `json:"expires_in"`
+}
+
+type TwoFactorLoginDto struct {
+	TwoFactorToken string `json:"two_factor_token"`
+	// Code is a code of the authenticator app or a recovery code.
+	Code string `json:"code"`
+}
+
+type TwoFactorSetupDto struct {
+	Secret string `json:"secret"`
+	// ProvisioningURI is the otpauth URI to show as a QR code to authenticator apps.
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type TwoFactorCodeDto struct {
+	Code string `json:"code"`
+}
+
+type RecoveryCodesDto struct {
+	// RecoveryCodes each replace a code once. They are only shown once.
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type EmailDto struct {
+	Email string `json:"email"`
+}
+
+type AccountEmailDto struct {
+	Email    string `json:"email"`
+	Verified bool   `json:"verified"`
+}
+
+type ResetPasswordDto struct {
+	// Token is the password reset token sent by email.
+	Token    string `json:"token"`
+	Password string `json:"password"`
 }
@@ -5,7 +5,9 @@ type UpdatePlayerDto struct {
 }
 
 type UpdateRoomDto struct {
-	Code string `json:"code"`
+	// Code is left unchanged when empty.
+	Code     string           `json:"code,omitempty"`
+	Settings *RoomSettingsDto `json:"settings,omitempty"`
 }
 
 type UpdateBoardDto struct {
@@ -0,0 +1,50 @@
+package events
+
+import "encoding/json"
+
+// UnmarshalJSON decodes the data of known event types into their data
+// structs, so events read back from Redis look like the published ones.
+func (e *Event) UnmarshalJSON(payload []byte) error {
+	type event Event
+	var raw struct {
+		event
+		Data json.RawMessage `json:"data,omitempty"`
+	}
+	if err := json.Unmarshal(payload, &raw); err != nil {
+		return err
+	}
+
+	*e = Event(raw.event)
+	if len(raw.Data) == 0 {
+		return nil
+	}
+
+	var err error
+	switch e.Type {
+	case RoomStateChanged:
+		e.Data, err = decodeData[StateChange](raw.Data)
+	case PlayerJoined, PlayerLeft, SpectatorJoined, SpectatorLeft, ChatMuted, ChatUnmuted, TakebackAsked, TakebackDeclined:
+		e.Data, err = decodeData[Player](raw.Data)
+	case MovePlayed:
+		e.Data, err = decodeData[Move](raw.Data)
+	case MovesTakenBack:
+		e.Data, err = decodeData[Takeback](raw.Data)
+	case ClockTick:
+		e.Data, err = decodeData[Clock](raw.Data)
+	case GameOver:
+		e.Data, err = decodeData[Result](raw.Data)
+	case ChatMessage:
+		e.Data, err = decodeData[Chat](raw.Data)
+	case ChatDeleted:
+		e.Data, err = decodeData[ChatDeletion](raw.Data)
+	default:
+		e.Data = raw.Data
+	}
+	return err
+}
+
+func decodeData[T any](raw json.RawMessage) (any, error) {
+	var data T
+	err := json.Unmarshal(raw, &data)
+	return data, err
+}
@@ -0,0 +1,212 @@
+package events
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"github.com/moLIart/go-course/internal/model/game"
+)
+
+// Event types.
+const (
+	RoomStateChanged = "room.state_changed"
+	PlayerJoined     = "room.player_joined"
+	PlayerLeft       = "room.player_left"
+	SpectatorJoined  = "room.spectator_joined"
+	SpectatorLeft    = "room.spectator_left"
+	MovePlayed       = "game.move"
+	MovesTakenBack   = "game.takeback"
+	TakebackAsked    = "game.takeback_requested"
+	TakebackDeclined = "game.takeback_declined"
+	ClockTick        = "game.clock"
+	GameOver         = "game.over"
+	ChatMessage      = "chat.message"
+	ChatDeleted      = "chat.deleted"
+	ChatMuted        = "chat.muted"
+	ChatUnmuted      = "chat.unmuted"
+)
+
+// subscriberBuffer is how many events a slow subscriber may lag behind
+// before it is closed.
+const subscriberBuffer = 64
+
+// Event is a change to a room or its game. Events logged by the repository
+// carry the ID they were logged with, which increases with every event of
+// the room.
+type Event struct {
+	ID     string    `json:"id,omitempty"`
+	Type   string    `json:"type"`
+	RoomID int       `json:"room_id,omitempty"`
+	GameID int       `json:"game_id,omitempty"`
+	Data   any       `json:"data,omitempty"`
+	Time   time.Time `json:"time"`
+}
+
+type StateChange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Player is the data of join and leave events.
+type Player struct {
+	PlayerID int    `json:"player_id"`
+	Name     string `json:"name,omitempty"`
+}
+
+// Move is the data of move events. Number counts the moves of the game from 1.
+type Move struct {
+	Number   int    `json:"number"`
+	PlayerID int    `json:"player_id"`
+	Color    string `json:"color"`
+	Pass     bool   `json:"pass,omitempty"`
+	X        int    `json:"x"`
+	Y        int    `json:"y"`
+}
+
+// NewMove describes the i-th move of a game, counting from 0.
+func NewMove(g *game.Game, i int) Move {
+	m := g.Moves[i]
+	move := Move{Number: i + 1, PlayerID: m.PlayerID, Color: m.Color.String(), Pass: m.IsPass()}
+	if m.Point != nil {
+		move.X, move.Y = m.Point.X, m.Point.Y
+	}
+	return move
+}
+
+// Takeback is the data of takeback events, MoveCount being the number of
+// moves left in the game.
+type Takeback struct {
+	MoveCount int `json:"move_count"`
+}
+
+// Clock is the data of clock ticks.
+type Clock struct {
+	CurrentTurn      string `json:"current_turn"`
+	BlackRemainingMs int64  `json:"black_remaining_ms"`
+	WhiteRemainingMs int64  `json:"white_remaining_ms"`
+	BlackPeriods     int    `json:"black_periods"`
+	WhitePeriods     int    `json:"white_periods"`
+}
+
+// Result is the data of game over events.
+type Result struct {
+	Status string `json:"status"`
+	Result string `json:"result"`
+}
+
+// Chat is the data of chat message events.
+type Chat struct {
+	MessageID  int    `json:"message_id"`
+	Channel    string `json:"channel"`
+	PlayerID   int    `json:"player_id"`
+	PlayerName string `json:"player_name"`
+	Text       string `json:"text"`
+}
+
+// ChatDeletion is the data of events removing a message from the chat.
+type ChatDeletion struct {
+	MessageID int    `json:"message_id"`
+	Channel   string `json:"channel"`
+}
+
+var (
+	mu          sync.RWMutex
+	subscribers = map[chan Event]struct{}{}
+	bus         Bus
+)
+
+// Bus carries events between the server instances. Every instance hands the
+// events it receives, including its own, to Deliver.
+type Bus interface {
+	Send(e Event) error
+}
+
+// SetBus makes Publish send events through the bus instead of delivering them
+// to the local subscribers directly.
+func SetBus(b Bus) {
+	mu.Lock()
+	bus = b
+	mu.Unlock()
+}
+
+// Publish sends the event to the subscribers of every instance. If the bus
+// fails, only the local subscribers receive it.
+func Publish(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	mu.RLock()
+	b := bus
+	mu.RUnlock()
+	if b != nil {
+		err := b.Send(e)
+		if err == nil {
+			return
+		}
+		log.Printf("Failed to send %s event to other instances: %v", e.Type, err)
+	}
+	Deliver(e)
+}
+
+// PublishLocal delivers the event to the subscribers of this instance only,
+// for events that every instance produces itself such as clock ticks.
+func PublishLocal(e Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	Deliver(e)
+}
+
+// Deliver hands the event to every local subscriber without blocking. A
+// subscriber whose buffer is full would miss the event, so it is closed
+// instead, which tells its client to reconnect and catch up.
+func Deliver(e Event) {
+	var lagging []chan Event
+	mu.RLock()
+	for ch := range subscribers {
+		select {
+		case ch <- e:
+		default:
+			lagging = append(lagging, ch)
+		}
+	}
+	mu.RUnlock()
+	if len(lagging) == 0 {
+		return
+	}
+
+	log.Printf("Closing %d subscribers that lag more than %d events behind", len(lagging), subscriberBuffer)
+	mu.Lock()
+	for _, ch := range lagging {
+		unsubscribe(ch)
+	}
+	mu.Unlock()
+}
+
+// Subscribe returns a channel receiving every published event and a function
+// that stops the subscription. The channel is closed early if the subscriber
+// lags behind.
+func Subscribe() (<-chan Event, func()) {
+	ch := make(chan Event, subscriberBuffer)
+
+	mu.Lock()
+	subscribers[ch] = struct{}{}
+	mu.Unlock()
+
+	return ch, func() {
+		mu.Lock()
+		unsubscribe(ch)
+		mu.Unlock()
+	}
+}
+
+// unsubscribe removes a subscriber and closes its channel, unless Deliver
+// already did. mu must be held for writing.
+func unsubscribe(ch chan Event) {
+	if _, ok := subscribers[ch]; ok {
+		delete(subscribers, ch)
+		close(ch)
+	}
+}
@@ -0,0 +1,28 @@
+package events
+
+import "testing"
+
+func TestDeliverClosesLaggingSubscriber(t *testing.T) {
+	lagging, stopLagging := Subscribe()
+	reading, stopReading := Subscribe()
+	defer stopReading()
+
+	for i := 0; i <= subscriberBuffer; i++ {
+		Deliver(Event{Type: MovePlayed, RoomID: i})
+		if e := <-reading; e.RoomID != i {
+			t.Fatalf("reading subscriber got room %d, want %d", e.RoomID, i)
+		}
+	}
+
+	received := 0
+	for range lagging {
+		received++
+	}
+	if received != subscriberBuffer {
+		t.Errorf("lagging subscriber got %d events before being closed, want %d", received, subscriberBuffer)
+	}
+
+	// Stopping a subscription that was closed for lagging must not panic.
+	stopLagging()
+	stopLagging()
+}
@@ -214,28 +214,3391 @@ func (x *GetPlayerDto) GetName() string {
 	return ""
 }
 
-type CreateRoomDto struct {
+type CredentialsDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
+	Password      string                 `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CredentialsDto) Reset() {
+	*x = CredentialsDto{}
+	mi := &file_contract_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CredentialsDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CredentialsDto) ProtoMessage() {}
+
+func (x *CredentialsDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CredentialsDto.ProtoReflect.Descriptor instead.
+func (*CredentialsDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *CredentialsDto) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *CredentialsDto) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type TokenDto struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	AccessToken string                 `protobuf:"bytes,1,opt,name=access_token,json=accessToken,proto3" json:"access_token,omitempty"`
+	TokenType   string                 `protobuf:"bytes,2,opt,name=token_type,json=tokenType,proto3" json:"token_type,omitempty"`
+	// Number of seconds the access token is valid for.
+	ExpiresIn int32         `protobuf:"varint,3,opt,name=expires_in,json=expiresIn,proto3" json:"expires_in,omitempty"`
+	Player    *GetPlayerDto `protobuf:"bytes,4,opt,name=player,proto3" json:"player,omitempty"`
+	// Gets the next access token with Refresh. It can only be used once.
+	RefreshToken string `protobuf:"bytes,5,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	SessionId    string `protobuf:"bytes,6,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	// Set instead of the tokens when the account has two-factor
+	// authentication. The login completes with VerifyTwoFactor.
+	TwoFactorToken string `protobuf:"bytes,7,opt,name=two_factor_token,json=twoFactorToken,proto3" json:"two_factor_token,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *TokenDto) Reset() {
+	*x = TokenDto{}
+	mi := &file_contract_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TokenDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TokenDto) ProtoMessage() {}
+
+func (x *TokenDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TokenDto.ProtoReflect.Descriptor instead.
+func (*TokenDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *TokenDto) GetAccessToken() string {
+	if x != nil {
+		return x.AccessToken
+	}
+	return ""
+}
+
+func (x *TokenDto) GetTokenType() string {
+	if x != nil {
+		return x.TokenType
+	}
+	return ""
+}
+
+func (x *TokenDto) GetExpiresIn() int32 {
+	if x != nil {
+		return x.ExpiresIn
+	}
+	return 0
+}
+
+func (x *TokenDto) GetPlayer() *GetPlayerDto {
+	if x != nil {
+		return x.Player
+	}
+	return nil
+}
+
+func (x *TokenDto) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+func (x *TokenDto) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *TokenDto) GetTwoFactorToken() string {
+	if x != nil {
+		return x.TwoFactorToken
+	}
+	return ""
+}
+
+type TwoFactorLoginDto struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TwoFactorToken string                 `protobuf:"bytes,1,opt,name=two_factor_token,json=twoFactorToken,proto3" json:"two_factor_token,omitempty"`
+	// Code of the authenticator app or recovery code.
+	Code          string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TwoFactorLoginDto) Reset() {
+	*x = TwoFactorLoginDto{}
+	mi := &file_contract_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TwoFactorLoginDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TwoFactorLoginDto) ProtoMessage() {}
+
+func (x *TwoFactorLoginDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TwoFactorLoginDto.ProtoReflect.Descriptor instead.
+func (*TwoFactorLoginDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *TwoFactorLoginDto) GetTwoFactorToken() string {
+	if x != nil {
+		return x.TwoFactorToken
+	}
+	return ""
+}
+
+func (x *TwoFactorLoginDto) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type TwoFactorSetupDto struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Secret string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"`
+	// otpauth URI to show as a QR code to authenticator apps.
+	ProvisioningUri string `protobuf:"bytes,2,opt,name=provisioning_uri,json=provisioningUri,proto3" json:"provisioning_uri,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *TwoFactorSetupDto) Reset() {
+	*x = TwoFactorSetupDto{}
+	mi := &file_contract_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TwoFactorSetupDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TwoFactorSetupDto) ProtoMessage() {}
+
+func (x *TwoFactorSetupDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TwoFactorSetupDto.ProtoReflect.Descriptor instead.
+func (*TwoFactorSetupDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *TwoFactorSetupDto) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *TwoFactorSetupDto) GetProvisioningUri() string {
+	if x != nil {
+		return x.ProvisioningUri
+	}
+	return ""
+}
+
+type TwoFactorCodeDto struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateRoomDto) Reset() {
-	*x = CreateRoomDto{}
-	mi := &file_contract_proto_msgTypes[4]
+func (x *TwoFactorCodeDto) Reset() {
+	*x = TwoFactorCodeDto{}
+	mi := &file_contract_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TwoFactorCodeDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TwoFactorCodeDto) ProtoMessage() {}
+
+func (x *TwoFactorCodeDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TwoFactorCodeDto.ProtoReflect.Descriptor instead.
+func (*TwoFactorCodeDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *TwoFactorCodeDto) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type RecoveryCodesDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Each replaces a code once. They are only shown once.
+	RecoveryCodes []string `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RecoveryCodesDto) Reset() {
+	*x = RecoveryCodesDto{}
+	mi := &file_contract_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RecoveryCodesDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RecoveryCodesDto) ProtoMessage() {}
+
+func (x *RecoveryCodesDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RecoveryCodesDto.ProtoReflect.Descriptor instead.
+func (*RecoveryCodesDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *RecoveryCodesDto) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+type EmailDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmailDto) Reset() {
+	*x = EmailDto{}
+	mi := &file_contract_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmailDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmailDto) ProtoMessage() {}
+
+func (x *EmailDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmailDto.ProtoReflect.Descriptor instead.
+func (*EmailDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EmailDto) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+type AccountEmailDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Email         string                 `protobuf:"bytes,1,opt,name=email,proto3" json:"email,omitempty"`
+	Verified      bool                   `protobuf:"varint,2,opt,name=verified,proto3" json:"verified,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AccountEmailDto) Reset() {
+	*x = AccountEmailDto{}
+	mi := &file_contract_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AccountEmailDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AccountEmailDto) ProtoMessage() {}
+
+func (x *AccountEmailDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AccountEmailDto.ProtoReflect.Descriptor instead.
+func (*AccountEmailDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *AccountEmailDto) GetEmail() string {
+	if x != nil {
+		return x.Email
+	}
+	return ""
+}
+
+func (x *AccountEmailDto) GetVerified() bool {
+	if x != nil {
+		return x.Verified
+	}
+	return false
+}
+
+type VerifyEmailDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Token of the link sent by SetEmail or ResendVerificationEmail.
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyEmailDto) Reset() {
+	*x = VerifyEmailDto{}
+	mi := &file_contract_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyEmailDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyEmailDto) ProtoMessage() {}
+
+func (x *VerifyEmailDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyEmailDto.ProtoReflect.Descriptor instead.
+func (*VerifyEmailDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *VerifyEmailDto) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+type ResetPasswordDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Token sent by RequestPasswordReset.
+	Token         string `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	Password      string `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetPasswordDto) Reset() {
+	*x = ResetPasswordDto{}
+	mi := &file_contract_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetPasswordDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetPasswordDto) ProtoMessage() {}
+
+func (x *ResetPasswordDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetPasswordDto.ProtoReflect.Descriptor instead.
+func (*ResetPasswordDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ResetPasswordDto) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *ResetPasswordDto) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+type RefreshTokenDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RefreshToken  string                 `protobuf:"bytes,1,opt,name=refresh_token,json=refreshToken,proto3" json:"refresh_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefreshTokenDto) Reset() {
+	*x = RefreshTokenDto{}
+	mi := &file_contract_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefreshTokenDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefreshTokenDto) ProtoMessage() {}
+
+func (x *RefreshTokenDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefreshTokenDto.ProtoReflect.Descriptor instead.
+func (*RefreshTokenDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *RefreshTokenDto) GetRefreshToken() string {
+	if x != nil {
+		return x.RefreshToken
+	}
+	return ""
+}
+
+type SessionDto struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	Id               string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserAgent        string                 `protobuf:"bytes,2,opt,name=user_agent,json=userAgent,proto3" json:"user_agent,omitempty"`
+	Ip               string                 `protobuf:"bytes,3,opt,name=ip,proto3" json:"ip,omitempty"`
+	CreatedAtUnixMs  int64                  `protobuf:"varint,4,opt,name=created_at_unix_ms,json=createdAtUnixMs,proto3" json:"created_at_unix_ms,omitempty"`
+	LastUsedAtUnixMs int64                  `protobuf:"varint,5,opt,name=last_used_at_unix_ms,json=lastUsedAtUnixMs,proto3" json:"last_used_at_unix_ms,omitempty"`
+	// Set on the session of the token used to list the sessions.
+	Current       bool `protobuf:"varint,6,opt,name=current,proto3" json:"current,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionDto) Reset() {
+	*x = SessionDto{}
+	mi := &file_contract_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionDto) ProtoMessage() {}
+
+func (x *SessionDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionDto.ProtoReflect.Descriptor instead.
+func (*SessionDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SessionDto) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SessionDto) GetUserAgent() string {
+	if x != nil {
+		return x.UserAgent
+	}
+	return ""
+}
+
+func (x *SessionDto) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *SessionDto) GetCreatedAtUnixMs() int64 {
+	if x != nil {
+		return x.CreatedAtUnixMs
+	}
+	return 0
+}
+
+func (x *SessionDto) GetLastUsedAtUnixMs() int64 {
+	if x != nil {
+		return x.LastUsedAtUnixMs
+	}
+	return 0
+}
+
+func (x *SessionDto) GetCurrent() bool {
+	if x != nil {
+		return x.Current
+	}
+	return false
+}
+
+type SessionList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Sessions      []*SessionDto          `protobuf:"bytes,1,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SessionList) Reset() {
+	*x = SessionList{}
+	mi := &file_contract_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SessionList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SessionList) ProtoMessage() {}
+
+func (x *SessionList) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SessionList.ProtoReflect.Descriptor instead.
+func (*SessionList) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SessionList) GetSessions() []*SessionDto {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type RevokeSessionDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeSessionDto) Reset() {
+	*x = RevokeSessionDto{}
+	mi := &file_contract_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeSessionDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeSessionDto) ProtoMessage() {}
+
+func (x *RevokeSessionDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeSessionDto.ProtoReflect.Descriptor instead.
+func (*RevokeSessionDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RevokeSessionDto) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type AdjudicateGameDto struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	GameId int32                  `protobuf:"varint,1,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	// "black", "white" or "draw".
+	Winner        string `protobuf:"bytes,2,opt,name=winner,proto3" json:"winner,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjudicateGameDto) Reset() {
+	*x = AdjudicateGameDto{}
+	mi := &file_contract_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjudicateGameDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjudicateGameDto) ProtoMessage() {}
+
+func (x *AdjudicateGameDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjudicateGameDto.ProtoReflect.Descriptor instead.
+func (*AdjudicateGameDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *AdjudicateGameDto) GetGameId() int32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *AdjudicateGameDto) GetWinner() string {
+	if x != nil {
+		return x.Winner
+	}
+	return ""
+}
+
+type UserDto struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId int32                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Username string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	// "player", "moderator" or "admin".
+	Role            string `protobuf:"bytes,3,opt,name=role,proto3" json:"role,omitempty"`
+	CreatedAtUnixMs int64  `protobuf:"varint,4,opt,name=created_at_unix_ms,json=createdAtUnixMs,proto3" json:"created_at_unix_ms,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *UserDto) Reset() {
+	*x = UserDto{}
+	mi := &file_contract_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserDto) ProtoMessage() {}
+
+func (x *UserDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserDto.ProtoReflect.Descriptor instead.
+func (*UserDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UserDto) GetPlayerId() int32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *UserDto) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *UserDto) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+func (x *UserDto) GetCreatedAtUnixMs() int64 {
+	if x != nil {
+		return x.CreatedAtUnixMs
+	}
+	return 0
+}
+
+type UserList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserDto             `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserList) Reset() {
+	*x = UserList{}
+	mi := &file_contract_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserList) ProtoMessage() {}
+
+func (x *UserList) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserList.ProtoReflect.Descriptor instead.
+func (*UserList) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UserList) GetUsers() []*UserDto {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type SetUserRoleDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PlayerId      int32                  `protobuf:"varint,1,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Role          string                 `protobuf:"bytes,2,opt,name=role,proto3" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetUserRoleDto) Reset() {
+	*x = SetUserRoleDto{}
+	mi := &file_contract_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetUserRoleDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetUserRoleDto) ProtoMessage() {}
+
+func (x *SetUserRoleDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetUserRoleDto.ProtoReflect.Descriptor instead.
+func (*SetUserRoleDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SetUserRoleDto) GetPlayerId() int32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *SetUserRoleDto) GetRole() string {
+	if x != nil {
+		return x.Role
+	}
+	return ""
+}
+
+type CreateRoomDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Lifetime of the invite code in seconds, 0 means it never expires.
+	CodeTtl int32 `protobuf:"varint,2,opt,name=code_ttl,json=codeTtl,proto3" json:"code_ttl,omitempty"`
+	// Settings left out use the defaults.
+	Settings      *RoomSettingsDto `protobuf:"bytes,3,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateRoomDto) Reset() {
+	*x = CreateRoomDto{}
+	mi := &file_contract_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateRoomDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateRoomDto) ProtoMessage() {}
+
+func (x *CreateRoomDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateRoomDto.ProtoReflect.Descriptor instead.
+func (*CreateRoomDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *CreateRoomDto) GetCodeTtl() int32 {
+	if x != nil {
+		return x.CodeTtl
+	}
+	return 0
+}
+
+func (x *CreateRoomDto) GetSettings() *RoomSettingsDto {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+// Durations are in seconds.
+type TimeControlDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of "none", "absolute", "fischer" or "byoyomi".
+	Type          string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	MainTime      int32  `protobuf:"varint,2,opt,name=main_time,json=mainTime,proto3" json:"main_time,omitempty"`
+	Increment     int32  `protobuf:"varint,3,opt,name=increment,proto3" json:"increment,omitempty"`
+	Periods       int32  `protobuf:"varint,4,opt,name=periods,proto3" json:"periods,omitempty"`
+	PeriodTime    int32  `protobuf:"varint,5,opt,name=period_time,json=periodTime,proto3" json:"period_time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TimeControlDto) Reset() {
+	*x = TimeControlDto{}
+	mi := &file_contract_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TimeControlDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TimeControlDto) ProtoMessage() {}
+
+func (x *TimeControlDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TimeControlDto.ProtoReflect.Descriptor instead.
+func (*TimeControlDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *TimeControlDto) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *TimeControlDto) GetMainTime() int32 {
+	if x != nil {
+		return x.MainTime
+	}
+	return 0
+}
+
+func (x *TimeControlDto) GetIncrement() int32 {
+	if x != nil {
+		return x.Increment
+	}
+	return 0
+}
+
+func (x *TimeControlDto) GetPeriods() int32 {
+	if x != nil {
+		return x.Periods
+	}
+	return 0
+}
+
+func (x *TimeControlDto) GetPeriodTime() int32 {
+	if x != nil {
+		return x.PeriodTime
+	}
+	return 0
+}
+
+// Fields left out of a create or update keep their current value.
+type RoomSettingsDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of "chinese", "aga", "japanese" or "korean".
+	Ruleset *string `protobuf:"bytes,1,opt,name=ruleset,proto3,oneof" json:"ruleset,omitempty"`
+	// 9, 13 or 19.
+	BoardSize *int32   `protobuf:"varint,2,opt,name=board_size,json=boardSize,proto3,oneof" json:"board_size,omitempty"`
+	Komi      *float64 `protobuf:"fixed64,3,opt,name=komi,proto3,oneof" json:"komi,omitempty"`
+	// 0 or the number of black stones, from 2 to 9.
+	Handicap          *int32          `protobuf:"varint,4,opt,name=handicap,proto3,oneof" json:"handicap,omitempty"`
+	TimeControl       *TimeControlDto `protobuf:"bytes,5,opt,name=time_control,json=timeControl,proto3" json:"time_control,omitempty"`
+	Rated             *bool           `protobuf:"varint,6,opt,name=rated,proto3,oneof" json:"rated,omitempty"`
+	TakebacksAllowed  *bool           `protobuf:"varint,7,opt,name=takebacks_allowed,json=takebacksAllowed,proto3,oneof" json:"takebacks_allowed,omitempty"`
+	SpectatorsAllowed *bool           `protobuf:"varint,8,opt,name=spectators_allowed,json=spectatorsAllowed,proto3,oneof" json:"spectators_allowed,omitempty"`
+	// At most 500.
+	MaxSpectators *int32 `protobuf:"varint,9,opt,name=max_spectators,json=maxSpectators,proto3,oneof" json:"max_spectators,omitempty"`
+	// Number of moves spectators are kept behind, at most 50.
+	SpectatorDelay *int32 `protobuf:"varint,10,opt,name=spectator_delay,json=spectatorDelay,proto3,oneof" json:"spectator_delay,omitempty"`
+	// Seats two teams of two players.
+	PairGo        *bool `protobuf:"varint,11,opt,name=pair_go,json=pairGo,proto3,oneof" json:"pair_go,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomSettingsDto) Reset() {
+	*x = RoomSettingsDto{}
+	mi := &file_contract_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomSettingsDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomSettingsDto) ProtoMessage() {}
+
+func (x *RoomSettingsDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomSettingsDto.ProtoReflect.Descriptor instead.
+func (*RoomSettingsDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *RoomSettingsDto) GetRuleset() string {
+	if x != nil && x.Ruleset != nil {
+		return *x.Ruleset
+	}
+	return ""
+}
+
+func (x *RoomSettingsDto) GetBoardSize() int32 {
+	if x != nil && x.BoardSize != nil {
+		return *x.BoardSize
+	}
+	return 0
+}
+
+func (x *RoomSettingsDto) GetKomi() float64 {
+	if x != nil && x.Komi != nil {
+		return *x.Komi
+	}
+	return 0
+}
+
+func (x *RoomSettingsDto) GetHandicap() int32 {
+	if x != nil && x.Handicap != nil {
+		return *x.Handicap
+	}
+	return 0
+}
+
+func (x *RoomSettingsDto) GetTimeControl() *TimeControlDto {
+	if x != nil {
+		return x.TimeControl
+	}
+	return nil
+}
+
+func (x *RoomSettingsDto) GetRated() bool {
+	if x != nil && x.Rated != nil {
+		return *x.Rated
+	}
+	return false
+}
+
+func (x *RoomSettingsDto) GetTakebacksAllowed() bool {
+	if x != nil && x.TakebacksAllowed != nil {
+		return *x.TakebacksAllowed
+	}
+	return false
+}
+
+func (x *RoomSettingsDto) GetSpectatorsAllowed() bool {
+	if x != nil && x.SpectatorsAllowed != nil {
+		return *x.SpectatorsAllowed
+	}
+	return false
+}
+
+func (x *RoomSettingsDto) GetMaxSpectators() int32 {
+	if x != nil && x.MaxSpectators != nil {
+		return *x.MaxSpectators
+	}
+	return 0
+}
+
+func (x *RoomSettingsDto) GetSpectatorDelay() int32 {
+	if x != nil && x.SpectatorDelay != nil {
+		return *x.SpectatorDelay
+	}
+	return 0
+}
+
+func (x *RoomSettingsDto) GetPairGo() bool {
+	if x != nil && x.PairGo != nil {
+		return *x.PairGo
+	}
+	return false
+}
+
+type TeamDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Seated players in playing order.
+	Players       []*GetPlayerDto `protobuf:"bytes,1,rep,name=players,proto3" json:"players,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TeamDto) Reset() {
+	*x = TeamDto{}
+	mi := &file_contract_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TeamDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TeamDto) ProtoMessage() {}
+
+func (x *TeamDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TeamDto.ProtoReflect.Descriptor instead.
+func (*TeamDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *TeamDto) GetPlayers() []*GetPlayerDto {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+type JoinRoomByCodeDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRoomByCodeDto) Reset() {
+	*x = JoinRoomByCodeDto{}
+	mi := &file_contract_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRoomByCodeDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRoomByCodeDto) ProtoMessage() {}
+
+func (x *JoinRoomByCodeDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRoomByCodeDto.ProtoReflect.Descriptor instead.
+func (*JoinRoomByCodeDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *JoinRoomByCodeDto) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type UpdateRoomDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// Left unchanged when empty.
+	Code          string           `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Settings      *RoomSettingsDto `protobuf:"bytes,3,opt,name=settings,proto3" json:"settings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRoomDto) Reset() {
+	*x = UpdateRoomDto{}
+	mi := &file_contract_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRoomDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoomDto) ProtoMessage() {}
+
+func (x *UpdateRoomDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoomDto.ProtoReflect.Descriptor instead.
+func (*UpdateRoomDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *UpdateRoomDto) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateRoomDto) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *UpdateRoomDto) GetSettings() *RoomSettingsDto {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type NigiriCommitDto struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId int32                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// Hex encoded SHA-256 of "<stones>:<nonce>".
+	Commitment    string `protobuf:"bytes,2,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NigiriCommitDto) Reset() {
+	*x = NigiriCommitDto{}
+	mi := &file_contract_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NigiriCommitDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NigiriCommitDto) ProtoMessage() {}
+
+func (x *NigiriCommitDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NigiriCommitDto.ProtoReflect.Descriptor instead.
+func (*NigiriCommitDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *NigiriCommitDto) GetRoomId() int32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *NigiriCommitDto) GetCommitment() string {
+	if x != nil {
+		return x.Commitment
+	}
+	return ""
+}
+
+type NigiriGuessDto struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId int32                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// "odd" or "even".
+	Guess         string `protobuf:"bytes,2,opt,name=guess,proto3" json:"guess,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NigiriGuessDto) Reset() {
+	*x = NigiriGuessDto{}
+	mi := &file_contract_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NigiriGuessDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NigiriGuessDto) ProtoMessage() {}
+
+func (x *NigiriGuessDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NigiriGuessDto.ProtoReflect.Descriptor instead.
+func (*NigiriGuessDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *NigiriGuessDto) GetRoomId() int32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *NigiriGuessDto) GetGuess() string {
+	if x != nil {
+		return x.Guess
+	}
+	return ""
+}
+
+type NigiriRevealDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int32                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Stones        int32                  `protobuf:"varint,2,opt,name=stones,proto3" json:"stones,omitempty"`
+	Nonce         string                 `protobuf:"bytes,3,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NigiriRevealDto) Reset() {
+	*x = NigiriRevealDto{}
+	mi := &file_contract_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NigiriRevealDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NigiriRevealDto) ProtoMessage() {}
+
+func (x *NigiriRevealDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NigiriRevealDto.ProtoReflect.Descriptor instead.
+func (*NigiriRevealDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *NigiriRevealDto) GetRoomId() int32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *NigiriRevealDto) GetStones() int32 {
+	if x != nil {
+		return x.Stones
+	}
+	return 0
+}
+
+func (x *NigiriRevealDto) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+type NigiriClaimDto struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId int32                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// "black" or "white", the color the guesser wants to play.
+	Color         string `protobuf:"bytes,2,opt,name=color,proto3" json:"color,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NigiriClaimDto) Reset() {
+	*x = NigiriClaimDto{}
+	mi := &file_contract_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NigiriClaimDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NigiriClaimDto) ProtoMessage() {}
+
+func (x *NigiriClaimDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NigiriClaimDto.ProtoReflect.Descriptor instead.
+func (*NigiriClaimDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *NigiriClaimDto) GetRoomId() int32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *NigiriClaimDto) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+type NigiriEventDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Unix time of the step.
+	At            int64  `protobuf:"varint,1,opt,name=at,proto3" json:"at,omitempty"`
+	PlayerId      int32  `protobuf:"varint,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Action        string `protobuf:"bytes,3,opt,name=action,proto3" json:"action,omitempty"`
+	Detail        string `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NigiriEventDto) Reset() {
+	*x = NigiriEventDto{}
+	mi := &file_contract_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NigiriEventDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NigiriEventDto) ProtoMessage() {}
+
+func (x *NigiriEventDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NigiriEventDto.ProtoReflect.Descriptor instead.
+func (*NigiriEventDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *NigiriEventDto) GetAt() int64 {
+	if x != nil {
+		return x.At
+	}
+	return 0
+}
+
+func (x *NigiriEventDto) GetPlayerId() int32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *NigiriEventDto) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *NigiriEventDto) GetDetail() string {
+	if x != nil {
+		return x.Detail
+	}
+	return ""
+}
+
+type GetNigiriDto struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	HolderId   int32                  `protobuf:"varint,1,opt,name=holder_id,json=holderId,proto3" json:"holder_id,omitempty"`
+	GuesserId  int32                  `protobuf:"varint,2,opt,name=guesser_id,json=guesserId,proto3" json:"guesser_id,omitempty"`
+	Commitment string                 `protobuf:"bytes,3,opt,name=commitment,proto3" json:"commitment,omitempty"`
+	Guess      string                 `protobuf:"bytes,4,opt,name=guess,proto3" json:"guess,omitempty"`
+	Stones     int32                  `protobuf:"varint,5,opt,name=stones,proto3" json:"stones,omitempty"`
+	Nonce      string                 `protobuf:"bytes,6,opt,name=nonce,proto3" json:"nonce,omitempty"`
+	BlackId    int32                  `protobuf:"varint,7,opt,name=black_id,json=blackId,proto3" json:"black_id,omitempty"`
+	GameId     int32                  `protobuf:"varint,8,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	Log        []*NigiriEventDto      `protobuf:"bytes,9,rep,name=log,proto3" json:"log,omitempty"`
+	// Unix time after which the guesser may claim the colors if the holder has
+	// not revealed, 0 unless a guess waits for the reveal.
+	RevealDeadline int64 `protobuf:"varint,10,opt,name=reveal_deadline,json=revealDeadline,proto3" json:"reveal_deadline,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *GetNigiriDto) Reset() {
+	*x = GetNigiriDto{}
+	mi := &file_contract_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetNigiriDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetNigiriDto) ProtoMessage() {}
+
+func (x *GetNigiriDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetNigiriDto.ProtoReflect.Descriptor instead.
+func (*GetNigiriDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetNigiriDto) GetHolderId() int32 {
+	if x != nil {
+		return x.HolderId
+	}
+	return 0
+}
+
+func (x *GetNigiriDto) GetGuesserId() int32 {
+	if x != nil {
+		return x.GuesserId
+	}
+	return 0
+}
+
+func (x *GetNigiriDto) GetCommitment() string {
+	if x != nil {
+		return x.Commitment
+	}
+	return ""
+}
+
+func (x *GetNigiriDto) GetGuess() string {
+	if x != nil {
+		return x.Guess
+	}
+	return ""
+}
+
+func (x *GetNigiriDto) GetStones() int32 {
+	if x != nil {
+		return x.Stones
+	}
+	return 0
+}
+
+func (x *GetNigiriDto) GetNonce() string {
+	if x != nil {
+		return x.Nonce
+	}
+	return ""
+}
+
+func (x *GetNigiriDto) GetBlackId() int32 {
+	if x != nil {
+		return x.BlackId
+	}
+	return 0
+}
+
+func (x *GetNigiriDto) GetGameId() int32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *GetNigiriDto) GetLog() []*NigiriEventDto {
+	if x != nil {
+		return x.Log
+	}
+	return nil
+}
+
+func (x *GetNigiriDto) GetRevealDeadline() int64 {
+	if x != nil {
+		return x.RevealDeadline
+	}
+	return 0
+}
+
+type NigiriList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Nigiri        []*GetNigiriDto        `protobuf:"bytes,1,rep,name=nigiri,proto3" json:"nigiri,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NigiriList) Reset() {
+	*x = NigiriList{}
+	mi := &file_contract_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NigiriList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NigiriList) ProtoMessage() {}
+
+func (x *NigiriList) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NigiriList.ProtoReflect.Descriptor instead.
+func (*NigiriList) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *NigiriList) GetNigiri() []*GetNigiriDto {
+	if x != nil {
+		return x.Nigiri
+	}
+	return nil
+}
+
+type GetRoomDto struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Code    string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Players []*GetPlayerDto        `protobuf:"bytes,3,rep,name=players,proto3" json:"players,omitempty"`
+	// Unix time the invite code expires at, 0 if it never expires.
+	CodeExpiresAt  int64            `protobuf:"varint,4,opt,name=code_expires_at,json=codeExpiresAt,proto3" json:"code_expires_at,omitempty"`
+	OwnerId        int32            `protobuf:"varint,5,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	Game           *GetGameDto      `protobuf:"bytes,6,opt,name=game,proto3" json:"game,omitempty"`
+	Nigiri         *GetNigiriDto    `protobuf:"bytes,7,opt,name=nigiri,proto3" json:"nigiri,omitempty"`
+	State          string           `protobuf:"bytes,8,opt,name=state,proto3" json:"state,omitempty"`
+	Settings       *RoomSettingsDto `protobuf:"bytes,9,opt,name=settings,proto3" json:"settings,omitempty"`
+	SpectatorCount int32            `protobuf:"varint,10,opt,name=spectator_count,json=spectatorCount,proto3" json:"spectator_count,omitempty"`
+	// Teams of a pair go room.
+	Teams         []*TeamDto `protobuf:"bytes,11,rep,name=teams,proto3" json:"teams,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoomDto) Reset() {
+	*x = GetRoomDto{}
+	mi := &file_contract_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoomDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoomDto) ProtoMessage() {}
+
+func (x *GetRoomDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoomDto.ProtoReflect.Descriptor instead.
+func (*GetRoomDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetRoomDto) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetRoomDto) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GetRoomDto) GetPlayers() []*GetPlayerDto {
+	if x != nil {
+		return x.Players
+	}
+	return nil
+}
+
+func (x *GetRoomDto) GetCodeExpiresAt() int64 {
+	if x != nil {
+		return x.CodeExpiresAt
+	}
+	return 0
+}
+
+func (x *GetRoomDto) GetOwnerId() int32 {
+	if x != nil {
+		return x.OwnerId
+	}
+	return 0
+}
+
+func (x *GetRoomDto) GetGame() *GetGameDto {
+	if x != nil {
+		return x.Game
+	}
+	return nil
+}
+
+func (x *GetRoomDto) GetNigiri() *GetNigiriDto {
+	if x != nil {
+		return x.Nigiri
+	}
+	return nil
+}
+
+func (x *GetRoomDto) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *GetRoomDto) GetSettings() *RoomSettingsDto {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *GetRoomDto) GetSpectatorCount() int32 {
+	if x != nil {
+		return x.SpectatorCount
+	}
+	return 0
+}
+
+func (x *GetRoomDto) GetTeams() []*TeamDto {
+	if x != nil {
+		return x.Teams
+	}
+	return nil
+}
+
+type CreateBoardDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Size          int32                  `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateBoardDto) Reset() {
+	*x = CreateBoardDto{}
+	mi := &file_contract_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateBoardDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateBoardDto) ProtoMessage() {}
+
+func (x *CreateBoardDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateBoardDto.ProtoReflect.Descriptor instead.
+func (*CreateBoardDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CreateBoardDto) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type UpdateBoardDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateBoardDto) Reset() {
+	*x = UpdateBoardDto{}
+	mi := &file_contract_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateBoardDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateBoardDto) ProtoMessage() {}
+
+func (x *UpdateBoardDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateBoardDto.ProtoReflect.Descriptor instead.
+func (*UpdateBoardDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UpdateBoardDto) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateBoardDto) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type GetBoardDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetBoardDto) Reset() {
+	*x = GetBoardDto{}
+	mi := &file_contract_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetBoardDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetBoardDto) ProtoMessage() {}
+
+func (x *GetBoardDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetBoardDto.ProtoReflect.Descriptor instead.
+func (*GetBoardDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *GetBoardDto) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetBoardDto) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type GetGameDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	BlackId       int32                  `protobuf:"varint,2,opt,name=black_id,json=blackId,proto3" json:"black_id,omitempty"`
+	WhiteId       int32                  `protobuf:"varint,3,opt,name=white_id,json=whiteId,proto3" json:"white_id,omitempty"`
+	CurrentTurn   string                 `protobuf:"bytes,4,opt,name=current_turn,json=currentTurn,proto3" json:"current_turn,omitempty"`
+	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Result        string                 `protobuf:"bytes,6,opt,name=result,proto3" json:"result,omitempty"`
+	MoveCount     int32                  `protobuf:"varint,7,opt,name=move_count,json=moveCount,proto3" json:"move_count,omitempty"`
+	BlackCaptures int32                  `protobuf:"varint,8,opt,name=black_captures,json=blackCaptures,proto3" json:"black_captures,omitempty"`
+	WhiteCaptures int32                  `protobuf:"varint,9,opt,name=white_captures,json=whiteCaptures,proto3" json:"white_captures,omitempty"`
+	// One string per row: "." empty, "X" black, "O" white.
+	Board    []string  `protobuf:"bytes,10,rep,name=board,proto3" json:"board,omitempty"`
+	Ruleset  string    `protobuf:"bytes,11,opt,name=ruleset,proto3" json:"ruleset,omitempty"`
+	Komi     float64   `protobuf:"fixed64,12,opt,name=komi,proto3" json:"komi,omitempty"`
+	Handicap int32     `protobuf:"varint,13,opt,name=handicap,proto3" json:"handicap,omitempty"`
+	Clock    *ClockDto `protobuf:"bytes,14,opt,name=clock,proto3" json:"clock,omitempty"`
+	// Partners are only set in pair go games.
+	BlackPartnerId  int32 `protobuf:"varint,15,opt,name=black_partner_id,json=blackPartnerId,proto3" json:"black_partner_id,omitempty"`
+	WhitePartnerId  int32 `protobuf:"varint,16,opt,name=white_partner_id,json=whitePartnerId,proto3" json:"white_partner_id,omitempty"`
+	CurrentPlayerId int32 `protobuf:"varint,17,opt,name=current_player_id,json=currentPlayerId,proto3" json:"current_player_id,omitempty"`
+	// Player whose takeback request awaits the opponent's answer.
+	TakebackRequestedBy int32 `protobuf:"varint,18,opt,name=takeback_requested_by,json=takebackRequestedBy,proto3" json:"takeback_requested_by,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetGameDto) Reset() {
+	*x = GetGameDto{}
+	mi := &file_contract_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetGameDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetGameDto) ProtoMessage() {}
+
+func (x *GetGameDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetGameDto.ProtoReflect.Descriptor instead.
+func (*GetGameDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *GetGameDto) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *GetGameDto) GetBlackId() int32 {
+	if x != nil {
+		return x.BlackId
+	}
+	return 0
+}
+
+func (x *GetGameDto) GetWhiteId() int32 {
+	if x != nil {
+		return x.WhiteId
+	}
+	return 0
+}
+
+func (x *GetGameDto) GetCurrentTurn() string {
+	if x != nil {
+		return x.CurrentTurn
+	}
+	return ""
+}
+
+func (x *GetGameDto) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GetGameDto) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+func (x *GetGameDto) GetMoveCount() int32 {
+	if x != nil {
+		return x.MoveCount
+	}
+	return 0
+}
+
+func (x *GetGameDto) GetBlackCaptures() int32 {
+	if x != nil {
+		return x.BlackCaptures
+	}
+	return 0
+}
+
+func (x *GetGameDto) GetWhiteCaptures() int32 {
+	if x != nil {
+		return x.WhiteCaptures
+	}
+	return 0
+}
+
+func (x *GetGameDto) GetBoard() []string {
+	if x != nil {
+		return x.Board
+	}
+	return nil
+}
+
+func (x *GetGameDto) GetRuleset() string {
+	if x != nil {
+		return x.Ruleset
+	}
+	return ""
+}
+
+func (x *GetGameDto) GetKomi() float64 {
+	if x != nil {
+		return x.Komi
+	}
+	return 0
+}
+
+func (x *GetGameDto) GetHandicap() int32 {
+	if x != nil {
+		return x.Handicap
+	}
+	return 0
+}
+
+func (x *GetGameDto) GetClock() *ClockDto {
+	if x != nil {
+		return x.Clock
+	}
+	return nil
+}
+
+func (x *GetGameDto) GetBlackPartnerId() int32 {
+	if x != nil {
+		return x.BlackPartnerId
+	}
+	return 0
+}
+
+func (x *GetGameDto) GetWhitePartnerId() int32 {
+	if x != nil {
+		return x.WhitePartnerId
+	}
+	return 0
+}
+
+func (x *GetGameDto) GetCurrentPlayerId() int32 {
+	if x != nil {
+		return x.CurrentPlayerId
+	}
+	return 0
+}
+
+func (x *GetGameDto) GetTakebackRequestedBy() int32 {
+	if x != nil {
+		return x.TakebackRequestedBy
+	}
+	return 0
+}
+
+// Time left to each color when the response was made.
+type ClockDto struct {
+	state            protoimpl.MessageState `protogen:"open.v1"`
+	BlackRemainingMs int64                  `protobuf:"varint,1,opt,name=black_remaining_ms,json=blackRemainingMs,proto3" json:"black_remaining_ms,omitempty"`
+	WhiteRemainingMs int64                  `protobuf:"varint,2,opt,name=white_remaining_ms,json=whiteRemainingMs,proto3" json:"white_remaining_ms,omitempty"`
+	BlackPeriods     int32                  `protobuf:"varint,3,opt,name=black_periods,json=blackPeriods,proto3" json:"black_periods,omitempty"`
+	WhitePeriods     int32                  `protobuf:"varint,4,opt,name=white_periods,json=whitePeriods,proto3" json:"white_periods,omitempty"`
+	// Only set in the clock updates of WatchGame.
+	CurrentTurn   string `protobuf:"bytes,5,opt,name=current_turn,json=currentTurn,proto3" json:"current_turn,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClockDto) Reset() {
+	*x = ClockDto{}
+	mi := &file_contract_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClockDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClockDto) ProtoMessage() {}
+
+func (x *ClockDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClockDto.ProtoReflect.Descriptor instead.
+func (*ClockDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ClockDto) GetBlackRemainingMs() int64 {
+	if x != nil {
+		return x.BlackRemainingMs
+	}
+	return 0
+}
+
+func (x *ClockDto) GetWhiteRemainingMs() int64 {
+	if x != nil {
+		return x.WhiteRemainingMs
+	}
+	return 0
+}
+
+func (x *ClockDto) GetBlackPeriods() int32 {
+	if x != nil {
+		return x.BlackPeriods
+	}
+	return 0
+}
+
+func (x *ClockDto) GetWhitePeriods() int32 {
+	if x != nil {
+		return x.WhitePeriods
+	}
+	return 0
+}
+
+func (x *ClockDto) GetCurrentTurn() string {
+	if x != nil {
+		return x.CurrentTurn
+	}
+	return ""
+}
+
+type GameMoveDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Counts the moves of the game from 1.
+	Number        int32  `protobuf:"varint,1,opt,name=number,proto3" json:"number,omitempty"`
+	PlayerId      int32  `protobuf:"varint,2,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	Color         string `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
+	Pass          bool   `protobuf:"varint,4,opt,name=pass,proto3" json:"pass,omitempty"`
+	X             int32  `protobuf:"varint,5,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32  `protobuf:"varint,6,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameMoveDto) Reset() {
+	*x = GameMoveDto{}
+	mi := &file_contract_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameMoveDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameMoveDto) ProtoMessage() {}
+
+func (x *GameMoveDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameMoveDto.ProtoReflect.Descriptor instead.
+func (*GameMoveDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *GameMoveDto) GetNumber() int32 {
+	if x != nil {
+		return x.Number
+	}
+	return 0
+}
+
+func (x *GameMoveDto) GetPlayerId() int32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *GameMoveDto) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+func (x *GameMoveDto) GetPass() bool {
+	if x != nil {
+		return x.Pass
+	}
+	return false
+}
+
+func (x *GameMoveDto) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *GameMoveDto) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type GameResultDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Status        string                 `protobuf:"bytes,1,opt,name=status,proto3" json:"status,omitempty"`
+	Result        string                 `protobuf:"bytes,2,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameResultDto) Reset() {
+	*x = GameResultDto{}
+	mi := &file_contract_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameResultDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameResultDto) ProtoMessage() {}
+
+func (x *GameResultDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameResultDto.ProtoReflect.Descriptor instead.
+func (*GameResultDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *GameResultDto) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *GameResultDto) GetResult() string {
+	if x != nil {
+		return x.Result
+	}
+	return ""
+}
+
+type GameEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// One of "game.snapshot", "game.move", "game.takeback",
+	// "game.takeback_requested", "game.takeback_declined", "game.clock" or
+	// "game.over". Passes are moves with pass set, resignations end the game
+	// with an "R" result.
+	Type       string `protobuf:"bytes,1,opt,name=type,proto3" json:"type,omitempty"`
+	GameId     int32  `protobuf:"varint,2,opt,name=game_id,json=gameId,proto3" json:"game_id,omitempty"`
+	RoomId     int32  `protobuf:"varint,3,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	TimeUnixMs int64  `protobuf:"varint,4,opt,name=time_unix_ms,json=timeUnixMs,proto3" json:"time_unix_ms,omitempty"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*GameEvent_Game
+	//	*GameEvent_Move
+	//	*GameEvent_MoveCount
+	//	*GameEvent_Clock
+	//	*GameEvent_Result
+	//	*GameEvent_PlayerId
+	Payload       isGameEvent_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GameEvent) Reset() {
+	*x = GameEvent{}
+	mi := &file_contract_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GameEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GameEvent) ProtoMessage() {}
+
+func (x *GameEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GameEvent.ProtoReflect.Descriptor instead.
+func (*GameEvent) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *GameEvent) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *GameEvent) GetGameId() int32 {
+	if x != nil {
+		return x.GameId
+	}
+	return 0
+}
+
+func (x *GameEvent) GetRoomId() int32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *GameEvent) GetTimeUnixMs() int64 {
+	if x != nil {
+		return x.TimeUnixMs
+	}
+	return 0
+}
+
+func (x *GameEvent) GetPayload() isGameEvent_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *GameEvent) GetGame() *GetGameDto {
+	if x != nil {
+		if x, ok := x.Payload.(*GameEvent_Game); ok {
+			return x.Game
+		}
+	}
+	return nil
+}
+
+func (x *GameEvent) GetMove() *GameMoveDto {
+	if x != nil {
+		if x, ok := x.Payload.(*GameEvent_Move); ok {
+			return x.Move
+		}
+	}
+	return nil
+}
+
+func (x *GameEvent) GetMoveCount() int32 {
+	if x != nil {
+		if x, ok := x.Payload.(*GameEvent_MoveCount); ok {
+			return x.MoveCount
+		}
+	}
+	return 0
+}
+
+func (x *GameEvent) GetClock() *ClockDto {
+	if x != nil {
+		if x, ok := x.Payload.(*GameEvent_Clock); ok {
+			return x.Clock
+		}
+	}
+	return nil
+}
+
+func (x *GameEvent) GetResult() *GameResultDto {
+	if x != nil {
+		if x, ok := x.Payload.(*GameEvent_Result); ok {
+			return x.Result
+		}
+	}
+	return nil
+}
+
+func (x *GameEvent) GetPlayerId() int32 {
+	if x != nil {
+		if x, ok := x.Payload.(*GameEvent_PlayerId); ok {
+			return x.PlayerId
+		}
+	}
+	return 0
+}
+
+type isGameEvent_Payload interface {
+	isGameEvent_Payload()
+}
+
+type GameEvent_Game struct {
+	Game *GetGameDto `protobuf:"bytes,5,opt,name=game,proto3,oneof"`
+}
+
+type GameEvent_Move struct {
+	Move *GameMoveDto `protobuf:"bytes,6,opt,name=move,proto3,oneof"`
+}
+
+type GameEvent_MoveCount struct {
+	// Number of moves left after a takeback.
+	MoveCount int32 `protobuf:"varint,7,opt,name=move_count,json=moveCount,proto3,oneof"`
+}
+
+type GameEvent_Clock struct {
+	Clock *ClockDto `protobuf:"bytes,8,opt,name=clock,proto3,oneof"`
+}
+
+type GameEvent_Result struct {
+	Result *GameResultDto `protobuf:"bytes,9,opt,name=result,proto3,oneof"`
+}
+
+type GameEvent_PlayerId struct {
+	// Player who asked for the takeback in "game.takeback_requested" and
+	// "game.takeback_declined".
+	PlayerId int32 `protobuf:"varint,10,opt,name=player_id,json=playerId,proto3,oneof"`
+}
+
+func (*GameEvent_Game) isGameEvent_Payload() {}
+
+func (*GameEvent_Move) isGameEvent_Payload() {}
+
+func (*GameEvent_MoveCount) isGameEvent_Payload() {}
+
+func (*GameEvent_Clock) isGameEvent_Payload() {}
+
+func (*GameEvent_Result) isGameEvent_Payload() {}
+
+func (*GameEvent_PlayerId) isGameEvent_Payload() {}
+
+type MoveDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        int32                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	X             int32                  `protobuf:"varint,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32                  `protobuf:"varint,3,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveDto) Reset() {
+	*x = MoveDto{}
+	mi := &file_contract_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveDto) ProtoMessage() {}
+
+func (x *MoveDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveDto.ProtoReflect.Descriptor instead.
+func (*MoveDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *MoveDto) GetRoomId() int32 {
+	if x != nil {
+		return x.RoomId
+	}
+	return 0
+}
+
+func (x *MoveDto) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *MoveDto) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+type PointDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	X             int32                  `protobuf:"varint,1,opt,name=x,proto3" json:"x,omitempty"`
+	Y             int32                  `protobuf:"varint,2,opt,name=y,proto3" json:"y,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PointDto) Reset() {
+	*x = PointDto{}
+	mi := &file_contract_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PointDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PointDto) ProtoMessage() {}
+
+func (x *PointDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PointDto.ProtoReflect.Descriptor instead.
+func (*PointDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *PointDto) GetX() int32 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *PointDto) GetY() int32 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+// A command of a PlaySession. Every command but sit acts on the room the
+// session sits in.
+type PlayCommand struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Chosen by the client and echoed in the error a command causes.
+	Seq int32 `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// Types that are valid to be assigned to Command:
+	//
+	//	*PlayCommand_Sit
+	//	*PlayCommand_Move
+	//	*PlayCommand_Pass
+	//	*PlayCommand_Resign
+	//	*PlayCommand_Takeback
+	//	*PlayCommand_AcceptScore
+	//	*PlayCommand_ResumePlay
+	//	*PlayCommand_Chat
+	//	*PlayCommand_AcceptTakeback
+	//	*PlayCommand_DeclineTakeback
+	Command       isPlayCommand_Command `protobuf_oneof:"command"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayCommand) Reset() {
+	*x = PlayCommand{}
+	mi := &file_contract_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayCommand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayCommand) ProtoMessage() {}
+
+func (x *PlayCommand) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayCommand.ProtoReflect.Descriptor instead.
+func (*PlayCommand) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *PlayCommand) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *PlayCommand) GetCommand() isPlayCommand_Command {
+	if x != nil {
+		return x.Command
+	}
+	return nil
+}
+
+func (x *PlayCommand) GetSit() *RequestEntity {
+	if x != nil {
+		if x, ok := x.Command.(*PlayCommand_Sit); ok {
+			return x.Sit
+		}
+	}
+	return nil
+}
+
+func (x *PlayCommand) GetMove() *PointDto {
+	if x != nil {
+		if x, ok := x.Command.(*PlayCommand_Move); ok {
+			return x.Move
+		}
+	}
+	return nil
+}
+
+func (x *PlayCommand) GetPass() *emptypb.Empty {
+	if x != nil {
+		if x, ok := x.Command.(*PlayCommand_Pass); ok {
+			return x.Pass
+		}
+	}
+	return nil
+}
+
+func (x *PlayCommand) GetResign() *emptypb.Empty {
+	if x != nil {
+		if x, ok := x.Command.(*PlayCommand_Resign); ok {
+			return x.Resign
+		}
+	}
+	return nil
+}
+
+func (x *PlayCommand) GetTakeback() *emptypb.Empty {
+	if x != nil {
+		if x, ok := x.Command.(*PlayCommand_Takeback); ok {
+			return x.Takeback
+		}
+	}
+	return nil
+}
+
+func (x *PlayCommand) GetAcceptScore() *emptypb.Empty {
+	if x != nil {
+		if x, ok := x.Command.(*PlayCommand_AcceptScore); ok {
+			return x.AcceptScore
+		}
+	}
+	return nil
+}
+
+func (x *PlayCommand) GetResumePlay() *emptypb.Empty {
+	if x != nil {
+		if x, ok := x.Command.(*PlayCommand_ResumePlay); ok {
+			return x.ResumePlay
+		}
+	}
+	return nil
+}
+
+func (x *PlayCommand) GetChat() *PostChatMessageDto {
+	if x != nil {
+		if x, ok := x.Command.(*PlayCommand_Chat); ok {
+			return x.Chat
+		}
+	}
+	return nil
+}
+
+func (x *PlayCommand) GetAcceptTakeback() *emptypb.Empty {
+	if x != nil {
+		if x, ok := x.Command.(*PlayCommand_AcceptTakeback); ok {
+			return x.AcceptTakeback
+		}
+	}
+	return nil
+}
+
+func (x *PlayCommand) GetDeclineTakeback() *emptypb.Empty {
+	if x != nil {
+		if x, ok := x.Command.(*PlayCommand_DeclineTakeback); ok {
+			return x.DeclineTakeback
+		}
+	}
+	return nil
+}
+
+type isPlayCommand_Command interface {
+	isPlayCommand_Command()
+}
+
+type PlayCommand_Sit struct {
+	// Takes a seat in the room with the given ID, or just watches it if the
+	// player is already seated there. Only the owner can take a seat without
+	// the invite code, the others join with JoinRoomByCode first.
+	Sit *RequestEntity `protobuf:"bytes,2,opt,name=sit,proto3,oneof"`
+}
+
+type PlayCommand_Move struct {
+	Move *PointDto `protobuf:"bytes,3,opt,name=move,proto3,oneof"`
+}
+
+type PlayCommand_Pass struct {
+	Pass *emptypb.Empty `protobuf:"bytes,4,opt,name=pass,proto3,oneof"`
+}
+
+type PlayCommand_Resign struct {
+	Resign *emptypb.Empty `protobuf:"bytes,5,opt,name=resign,proto3,oneof"`
+}
+
+type PlayCommand_Takeback struct {
+	// Asks the opponent to undo the player's last move.
+	Takeback *emptypb.Empty `protobuf:"bytes,6,opt,name=takeback,proto3,oneof"`
+}
+
+type PlayCommand_AcceptScore struct {
+	AcceptScore *emptypb.Empty `protobuf:"bytes,7,opt,name=accept_score,json=acceptScore,proto3,oneof"`
+}
+
+type PlayCommand_ResumePlay struct {
+	ResumePlay *emptypb.Empty `protobuf:"bytes,8,opt,name=resume_play,json=resumePlay,proto3,oneof"`
+}
+
+type PlayCommand_Chat struct {
+	Chat *PostChatMessageDto `protobuf:"bytes,9,opt,name=chat,proto3,oneof"`
+}
+
+type PlayCommand_AcceptTakeback struct {
+	AcceptTakeback *emptypb.Empty `protobuf:"bytes,10,opt,name=accept_takeback,json=acceptTakeback,proto3,oneof"`
+}
+
+type PlayCommand_DeclineTakeback struct {
+	DeclineTakeback *emptypb.Empty `protobuf:"bytes,11,opt,name=decline_takeback,json=declineTakeback,proto3,oneof"`
+}
+
+func (*PlayCommand_Sit) isPlayCommand_Command() {}
+
+func (*PlayCommand_Move) isPlayCommand_Command() {}
+
+func (*PlayCommand_Pass) isPlayCommand_Command() {}
+
+func (*PlayCommand_Resign) isPlayCommand_Command() {}
+
+func (*PlayCommand_Takeback) isPlayCommand_Command() {}
+
+func (*PlayCommand_AcceptScore) isPlayCommand_Command() {}
+
+func (*PlayCommand_ResumePlay) isPlayCommand_Command() {}
+
+func (*PlayCommand_Chat) isPlayCommand_Command() {}
+
+func (*PlayCommand_AcceptTakeback) isPlayCommand_Command() {}
+
+func (*PlayCommand_DeclineTakeback) isPlayCommand_Command() {}
+
+type PostChatMessageDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "players" for seated players or "spectators" for spectators.
+	Channel       string `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	Text          string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PostChatMessageDto) Reset() {
+	*x = PostChatMessageDto{}
+	mi := &file_contract_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PostChatMessageDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PostChatMessageDto) ProtoMessage() {}
+
+func (x *PostChatMessageDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PostChatMessageDto.ProtoReflect.Descriptor instead.
+func (*PostChatMessageDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *PostChatMessageDto) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *PostChatMessageDto) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type ChatMessageDto struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Channel       string                 `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`
+	PlayerId      int32                  `protobuf:"varint,3,opt,name=player_id,json=playerId,proto3" json:"player_id,omitempty"`
+	PlayerName    string                 `protobuf:"bytes,4,opt,name=player_name,json=playerName,proto3" json:"player_name,omitempty"`
+	Text          string                 `protobuf:"bytes,5,opt,name=text,proto3" json:"text,omitempty"`
+	TimeUnixMs    int64                  `protobuf:"varint,6,opt,name=time_unix_ms,json=timeUnixMs,proto3" json:"time_unix_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ChatMessageDto) Reset() {
+	*x = ChatMessageDto{}
+	mi := &file_contract_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ChatMessageDto) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ChatMessageDto) ProtoMessage() {}
+
+func (x *ChatMessageDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ChatMessageDto.ProtoReflect.Descriptor instead.
+func (*ChatMessageDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ChatMessageDto) GetId() int32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ChatMessageDto) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *ChatMessageDto) GetPlayerId() int32 {
+	if x != nil {
+		return x.PlayerId
+	}
+	return 0
+}
+
+func (x *ChatMessageDto) GetPlayerName() string {
+	if x != nil {
+		return x.PlayerName
+	}
+	return ""
+}
+
+func (x *ChatMessageDto) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *ChatMessageDto) GetTimeUnixMs() int64 {
+	if x != nil {
+		return x.TimeUnixMs
+	}
+	return 0
+}
+
+// A refused command. The session stays open.
+type PlayError struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Seq   int32                  `protobuf:"varint,1,opt,name=seq,proto3" json:"seq,omitempty"`
+	// Name of the gRPC status code the unary RPC would fail with, such as
+	// "FailedPrecondition".
+	Code          string `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Message       string `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayError) Reset() {
+	*x = PlayError{}
+	mi := &file_contract_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayError) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayError) ProtoMessage() {}
+
+func (x *PlayError) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayError.ProtoReflect.Descriptor instead.
+func (*PlayError) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *PlayError) GetSeq() int32 {
+	if x != nil {
+		return x.Seq
+	}
+	return 0
+}
+
+func (x *PlayError) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *PlayError) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type PlayEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*PlayEvent_Room
+	//	*PlayEvent_Game
+	//	*PlayEvent_Error
+	//	*PlayEvent_Chat
+	//	*PlayEvent_DeletedChatMessageId
+	Event         isPlayEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlayEvent) Reset() {
+	*x = PlayEvent{}
+	mi := &file_contract_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlayEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlayEvent) ProtoMessage() {}
+
+func (x *PlayEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlayEvent.ProtoReflect.Descriptor instead.
+func (*PlayEvent) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *PlayEvent) GetEvent() isPlayEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *PlayEvent) GetRoom() *GetRoomDto {
+	if x != nil {
+		if x, ok := x.Event.(*PlayEvent_Room); ok {
+			return x.Room
+		}
+	}
+	return nil
+}
+
+func (x *PlayEvent) GetGame() *GameEvent {
+	if x != nil {
+		if x, ok := x.Event.(*PlayEvent_Game); ok {
+			return x.Game
+		}
+	}
+	return nil
+}
+
+func (x *PlayEvent) GetError() *PlayError {
+	if x != nil {
+		if x, ok := x.Event.(*PlayEvent_Error); ok {
+			return x.Error
+		}
+	}
+	return nil
+}
+
+func (x *PlayEvent) GetChat() *ChatMessageDto {
+	if x != nil {
+		if x, ok := x.Event.(*PlayEvent_Chat); ok {
+			return x.Chat
+		}
+	}
+	return nil
+}
+
+func (x *PlayEvent) GetDeletedChatMessageId() int32 {
+	if x != nil {
+		if x, ok := x.Event.(*PlayEvent_DeletedChatMessageId); ok {
+			return x.DeletedChatMessageId
+		}
+	}
+	return 0
+}
+
+type isPlayEvent_Event interface {
+	isPlayEvent_Event()
+}
+
+type PlayEvent_Room struct {
+	// The room once seated and after every change to its seats or state.
+	Room *GetRoomDto `protobuf:"bytes,1,opt,name=room,proto3,oneof"`
+}
+
+type PlayEvent_Game struct {
+	// Moves, takebacks, clock updates and results of the room's games.
+	Game *GameEvent `protobuf:"bytes,2,opt,name=game,proto3,oneof"`
+}
+
+type PlayEvent_Error struct {
+	Error *PlayError `protobuf:"bytes,3,opt,name=error,proto3,oneof"`
+}
+
+type PlayEvent_Chat struct {
+	Chat *ChatMessageDto `protobuf:"bytes,4,opt,name=chat,proto3,oneof"`
+}
+
+type PlayEvent_DeletedChatMessageId struct {
+	// ID of a message a moderator removed from the chat.
+	DeletedChatMessageId int32 `protobuf:"varint,5,opt,name=deleted_chat_message_id,json=deletedChatMessageId,proto3,oneof"`
+}
+
+func (*PlayEvent_Room) isPlayEvent_Event() {}
+
+func (*PlayEvent_Game) isPlayEvent_Event() {}
+
+func (*PlayEvent_Error) isPlayEvent_Event() {}
+
+func (*PlayEvent_Chat) isPlayEvent_Event() {}
+
+func (*PlayEvent_DeletedChatMessageId) isPlayEvent_Event() {}
+
+type RoomFilterDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Only list rooms in these states, all rooms if empty.
+	States        []string `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomFilterDto) Reset() {
+	*x = RoomFilterDto{}
+	mi := &file_contract_proto_msgTypes[51]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateRoomDto) String() string {
+func (x *RoomFilterDto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateRoomDto) ProtoMessage() {}
+func (*RoomFilterDto) ProtoMessage() {}
 
-func (x *CreateRoomDto) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_proto_msgTypes[4]
+func (x *RoomFilterDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[51]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -246,41 +3609,44 @@ func (x *CreateRoomDto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateRoomDto.ProtoReflect.Descriptor instead.
-func (*CreateRoomDto) Descriptor() ([]byte, []int) {
-	return file_contract_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use RoomFilterDto.ProtoReflect.Descriptor instead.
+func (*RoomFilterDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{51}
 }
 
-func (x *CreateRoomDto) GetCode() string {
+func (x *RoomFilterDto) GetStates() []string {
 	if x != nil {
-		return x.Code
+		return x.States
 	}
-	return ""
+	return nil
 }
 
-type UpdateRoomDto struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+type StartGameDto struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId int32                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// One of "choice", "alternate" or "nigiri".
+	ColorAssignment string `protobuf:"bytes,2,opt,name=color_assignment,json=colorAssignment,proto3" json:"color_assignment,omitempty"`
+	// Color wanted by the room owner with the "choice" assignment.
+	Color         string `protobuf:"bytes,3,opt,name=color,proto3" json:"color,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateRoomDto) Reset() {
-	*x = UpdateRoomDto{}
-	mi := &file_contract_proto_msgTypes[5]
+func (x *StartGameDto) Reset() {
+	*x = StartGameDto{}
+	mi := &file_contract_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateRoomDto) String() string {
+func (x *StartGameDto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateRoomDto) ProtoMessage() {}
+func (*StartGameDto) ProtoMessage() {}
 
-func (x *UpdateRoomDto) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_proto_msgTypes[5]
+func (x *StartGameDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -291,48 +3657,60 @@ func (x *UpdateRoomDto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateRoomDto.ProtoReflect.Descriptor instead.
-func (*UpdateRoomDto) Descriptor() ([]byte, []int) {
-	return file_contract_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use StartGameDto.ProtoReflect.Descriptor instead.
+func (*StartGameDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{52}
 }
 
-func (x *UpdateRoomDto) GetId() int32 {
+func (x *StartGameDto) GetRoomId() int32 {
 	if x != nil {
-		return x.Id
+		return x.RoomId
 	}
 	return 0
 }
 
-func (x *UpdateRoomDto) GetCode() string {
+func (x *StartGameDto) GetColorAssignment() string {
 	if x != nil {
-		return x.Code
+		return x.ColorAssignment
 	}
 	return ""
 }
 
-type GetRoomDto struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+func (x *StartGameDto) GetColor() string {
+	if x != nil {
+		return x.Color
+	}
+	return ""
+}
+
+type CreateSimulDto struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Name  string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// Number of boards, from 1 to 30.
+	MaxOpponents int32 `protobuf:"varint,2,opt,name=max_opponents,json=maxOpponents,proto3" json:"max_opponents,omitempty"`
+	// "black" or "white", defaults to "white".
+	HostColor string `protobuf:"bytes,3,opt,name=host_color,json=hostColor,proto3" json:"host_color,omitempty"`
+	// Applied to every board. Pair go is not allowed.
+	Settings      *RoomSettingsDto `protobuf:"bytes,4,opt,name=settings,proto3" json:"settings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetRoomDto) Reset() {
-	*x = GetRoomDto{}
-	mi := &file_contract_proto_msgTypes[6]
+func (x *CreateSimulDto) Reset() {
+	*x = CreateSimulDto{}
+	mi := &file_contract_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRoomDto) String() string {
+func (x *CreateSimulDto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRoomDto) ProtoMessage() {}
+func (*CreateSimulDto) ProtoMessage() {}
 
-func (x *GetRoomDto) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_proto_msgTypes[6]
+func (x *CreateSimulDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -343,47 +3721,65 @@ func (x *GetRoomDto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRoomDto.ProtoReflect.Descriptor instead.
-func (*GetRoomDto) Descriptor() ([]byte, []int) {
-	return file_contract_proto_rawDescGZIP(), []int{6}
+// Deprecated: Use CreateSimulDto.ProtoReflect.Descriptor instead.
+func (*CreateSimulDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{53}
 }
 
-func (x *GetRoomDto) GetId() int32 {
+func (x *CreateSimulDto) GetName() string {
 	if x != nil {
-		return x.Id
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSimulDto) GetMaxOpponents() int32 {
+	if x != nil {
+		return x.MaxOpponents
 	}
 	return 0
 }
 
-func (x *GetRoomDto) GetCode() string {
+func (x *CreateSimulDto) GetHostColor() string {
 	if x != nil {
-		return x.Code
+		return x.HostColor
 	}
 	return ""
 }
 
-type CreateBoardDto struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Size          int32                  `protobuf:"varint,1,opt,name=size,proto3" json:"size,omitempty"`
+func (x *CreateSimulDto) GetSettings() *RoomSettingsDto {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+type SimulBoardDto struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	RoomId     int32                  `protobuf:"varint,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	OpponentId int32                  `protobuf:"varint,2,opt,name=opponent_id,json=opponentId,proto3" json:"opponent_id,omitempty"`
+	// Set on boards waiting for the host.
+	HostToMove    bool        `protobuf:"varint,3,opt,name=host_to_move,json=hostToMove,proto3" json:"host_to_move,omitempty"`
+	Game          *GetGameDto `protobuf:"bytes,4,opt,name=game,proto3" json:"game,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *CreateBoardDto) Reset() {
-	*x = CreateBoardDto{}
-	mi := &file_contract_proto_msgTypes[7]
+func (x *SimulBoardDto) Reset() {
+	*x = SimulBoardDto{}
+	mi := &file_contract_proto_msgTypes[54]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *CreateBoardDto) String() string {
+func (x *SimulBoardDto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CreateBoardDto) ProtoMessage() {}
+func (*SimulBoardDto) ProtoMessage() {}
 
-func (x *CreateBoardDto) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_proto_msgTypes[7]
+func (x *SimulBoardDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[54]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -394,41 +3790,73 @@ func (x *CreateBoardDto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CreateBoardDto.ProtoReflect.Descriptor instead.
-func (*CreateBoardDto) Descriptor() ([]byte, []int) {
-	return file_contract_proto_rawDescGZIP(), []int{7}
+// Deprecated: Use SimulBoardDto.ProtoReflect.Descriptor instead.
+func (*SimulBoardDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{54}
 }
 
-func (x *CreateBoardDto) GetSize() int32 {
+func (x *SimulBoardDto) GetRoomId() int32 {
 	if x != nil {
-		return x.Size
+		return x.RoomId
 	}
 	return 0
 }
 
-type UpdateBoardDto struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+func (x *SimulBoardDto) GetOpponentId() int32 {
+	if x != nil {
+		return x.OpponentId
+	}
+	return 0
+}
+
+func (x *SimulBoardDto) GetHostToMove() bool {
+	if x != nil {
+		return x.HostToMove
+	}
+	return false
+}
+
+func (x *SimulBoardDto) GetGame() *GetGameDto {
+	if x != nil {
+		return x.Game
+	}
+	return nil
+}
+
+type GetSimulDto struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	HostId       int32                  `protobuf:"varint,3,opt,name=host_id,json=hostId,proto3" json:"host_id,omitempty"`
+	HostColor    string                 `protobuf:"bytes,4,opt,name=host_color,json=hostColor,proto3" json:"host_color,omitempty"`
+	MaxOpponents int32                  `protobuf:"varint,5,opt,name=max_opponents,json=maxOpponents,proto3" json:"max_opponents,omitempty"`
+	State        string                 `protobuf:"bytes,6,opt,name=state,proto3" json:"state,omitempty"`
+	Settings     *RoomSettingsDto       `protobuf:"bytes,7,opt,name=settings,proto3" json:"settings,omitempty"`
+	Opponents    []*GetPlayerDto        `protobuf:"bytes,8,rep,name=opponents,proto3" json:"opponents,omitempty"`
+	Boards       []*SimulBoardDto       `protobuf:"bytes,9,rep,name=boards,proto3" json:"boards,omitempty"`
+	// Results of the finished boards from the host's side.
+	HostWins      int32 `protobuf:"varint,10,opt,name=host_wins,json=hostWins,proto3" json:"host_wins,omitempty"`
+	HostLosses    int32 `protobuf:"varint,11,opt,name=host_losses,json=hostLosses,proto3" json:"host_losses,omitempty"`
+	Draws         int32 `protobuf:"varint,12,opt,name=draws,proto3" json:"draws,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UpdateBoardDto) Reset() {
-	*x = UpdateBoardDto{}
-	mi := &file_contract_proto_msgTypes[8]
+func (x *GetSimulDto) Reset() {
+	*x = GetSimulDto{}
+	mi := &file_contract_proto_msgTypes[55]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UpdateBoardDto) String() string {
+func (x *GetSimulDto) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UpdateBoardDto) ProtoMessage() {}
+func (*GetSimulDto) ProtoMessage() {}
 
-func (x *UpdateBoardDto) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_proto_msgTypes[8]
+func (x *GetSimulDto) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[55]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -439,48 +3867,117 @@ func (x *UpdateBoardDto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UpdateBoardDto.ProtoReflect.Descriptor instead.
-func (*UpdateBoardDto) Descriptor() ([]byte, []int) {
-	return file_contract_proto_rawDescGZIP(), []int{8}
+// Deprecated: Use GetSimulDto.ProtoReflect.Descriptor instead.
+func (*GetSimulDto) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{55}
 }
 
-func (x *UpdateBoardDto) GetId() int32 {
+func (x *GetSimulDto) GetId() int32 {
 	if x != nil {
 		return x.Id
 	}
 	return 0
 }
 
-func (x *UpdateBoardDto) GetSize() int32 {
+func (x *GetSimulDto) GetName() string {
 	if x != nil {
-		return x.Size
+		return x.Name
+	}
+	return ""
+}
+
+func (x *GetSimulDto) GetHostId() int32 {
+	if x != nil {
+		return x.HostId
 	}
 	return 0
 }
 
-type GetBoardDto struct {
+func (x *GetSimulDto) GetHostColor() string {
+	if x != nil {
+		return x.HostColor
+	}
+	return ""
+}
+
+func (x *GetSimulDto) GetMaxOpponents() int32 {
+	if x != nil {
+		return x.MaxOpponents
+	}
+	return 0
+}
+
+func (x *GetSimulDto) GetState() string {
+	if x != nil {
+		return x.State
+	}
+	return ""
+}
+
+func (x *GetSimulDto) GetSettings() *RoomSettingsDto {
+	if x != nil {
+		return x.Settings
+	}
+	return nil
+}
+
+func (x *GetSimulDto) GetOpponents() []*GetPlayerDto {
+	if x != nil {
+		return x.Opponents
+	}
+	return nil
+}
+
+func (x *GetSimulDto) GetBoards() []*SimulBoardDto {
+	if x != nil {
+		return x.Boards
+	}
+	return nil
+}
+
+func (x *GetSimulDto) GetHostWins() int32 {
+	if x != nil {
+		return x.HostWins
+	}
+	return 0
+}
+
+func (x *GetSimulDto) GetHostLosses() int32 {
+	if x != nil {
+		return x.HostLosses
+	}
+	return 0
+}
+
+func (x *GetSimulDto) GetDraws() int32 {
+	if x != nil {
+		return x.Draws
+	}
+	return 0
+}
+
+type SimulList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	Size          int32                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	Simuls        []*GetSimulDto         `protobuf:"bytes,1,rep,name=simuls,proto3" json:"simuls,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetBoardDto) Reset() {
-	*x = GetBoardDto{}
-	mi := &file_contract_proto_msgTypes[9]
+func (x *SimulList) Reset() {
+	*x = SimulList{}
+	mi := &file_contract_proto_msgTypes[56]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetBoardDto) String() string {
+func (x *SimulList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetBoardDto) ProtoMessage() {}
+func (*SimulList) ProtoMessage() {}
 
-func (x *GetBoardDto) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_proto_msgTypes[9]
+func (x *SimulList) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[56]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -491,47 +3988,40 @@ func (x *GetBoardDto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetBoardDto.ProtoReflect.Descriptor instead.
-func (*GetBoardDto) Descriptor() ([]byte, []int) {
-	return file_contract_proto_rawDescGZIP(), []int{9}
-}
-
-func (x *GetBoardDto) GetId() int32 {
-	if x != nil {
-		return x.Id
-	}
-	return 0
+// Deprecated: Use SimulList.ProtoReflect.Descriptor instead.
+func (*SimulList) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{56}
 }
 
-func (x *GetBoardDto) GetSize() int32 {
+func (x *SimulList) GetSimuls() []*GetSimulDto {
 	if x != nil {
-		return x.Size
+		return x.Simuls
 	}
-	return 0
+	return nil
 }
 
-type GetGameDto struct {
+type SimulBoardList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            int32                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Boards        []*SimulBoardDto       `protobuf:"bytes,1,rep,name=boards,proto3" json:"boards,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetGameDto) Reset() {
-	*x = GetGameDto{}
-	mi := &file_contract_proto_msgTypes[10]
+func (x *SimulBoardList) Reset() {
+	*x = SimulBoardList{}
+	mi := &file_contract_proto_msgTypes[57]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetGameDto) String() string {
+func (x *SimulBoardList) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetGameDto) ProtoMessage() {}
+func (*SimulBoardList) ProtoMessage() {}
 
-func (x *GetGameDto) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_proto_msgTypes[10]
+func (x *SimulBoardList) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_proto_msgTypes[57]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -542,16 +4032,16 @@ func (x *GetGameDto) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetGameDto.ProtoReflect.Descriptor instead.
-func (*GetGameDto) Descriptor() ([]byte, []int) {
-	return file_contract_proto_rawDescGZIP(), []int{10}
+// Deprecated: Use SimulBoardList.ProtoReflect.Descriptor instead.
+func (*SimulBoardList) Descriptor() ([]byte, []int) {
+	return file_contract_proto_rawDescGZIP(), []int{57}
 }
 
-func (x *GetGameDto) GetId() int32 {
+func (x *SimulBoardList) GetBoards() []*SimulBoardDto {
 	if x != nil {
-		return x.Id
+		return x.Boards
 	}
-	return 0
+	return nil
 }
 
 type PlayerList struct {
@@ -563,7 +4053,7 @@ type PlayerList struct {
 
 func (x *PlayerList) Reset() {
 	*x = PlayerList{}
-	mi := &file_contract_proto_msgTypes[11]
+	mi := &file_contract_proto_msgTypes[58]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -575,7 +4065,7 @@ func (x *PlayerList) String() string {
 func (*PlayerList) ProtoMessage() {}
 
 func (x *PlayerList) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_proto_msgTypes[11]
+	mi := &file_contract_proto_msgTypes[58]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -588,7 +4078,7 @@ func (x *PlayerList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PlayerList.ProtoReflect.Descriptor instead.
 func (*PlayerList) Descriptor() ([]byte, []int) {
-	return file_contract_proto_rawDescGZIP(), []int{11}
+	return file_contract_proto_rawDescGZIP(), []int{58}
 }
 
 func (x *PlayerList) GetPlayers() []*GetPlayerDto {
@@ -607,7 +4097,7 @@ type RoomList struct {
 
 func (x *RoomList) Reset() {
 	*x = RoomList{}
-	mi := &file_contract_proto_msgTypes[12]
+	mi := &file_contract_proto_msgTypes[59]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -619,7 +4109,7 @@ func (x *RoomList) String() string {
 func (*RoomList) ProtoMessage() {}
 
 func (x *RoomList) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_proto_msgTypes[12]
+	mi := &file_contract_proto_msgTypes[59]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -632,7 +4122,7 @@ func (x *RoomList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoomList.ProtoReflect.Descriptor instead.
 func (*RoomList) Descriptor() ([]byte, []int) {
-	return file_contract_proto_rawDescGZIP(), []int{12}
+	return file_contract_proto_rawDescGZIP(), []int{59}
 }
 
 func (x *RoomList) GetRooms() []*GetRoomDto {
@@ -651,7 +4141,7 @@ type BoardList struct {
 
 func (x *BoardList) Reset() {
 	*x = BoardList{}
-	mi := &file_contract_proto_msgTypes[13]
+	mi := &file_contract_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -663,7 +4153,7 @@ func (x *BoardList) String() string {
 func (*BoardList) ProtoMessage() {}
 
 func (x *BoardList) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_proto_msgTypes[13]
+	mi := &file_contract_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -676,7 +4166,7 @@ func (x *BoardList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BoardList.ProtoReflect.Descriptor instead.
 func (*BoardList) Descriptor() ([]byte, []int) {
-	return file_contract_proto_rawDescGZIP(), []int{13}
+	return file_contract_proto_rawDescGZIP(), []int{60}
 }
 
 func (x *BoardList) GetBoards() []*GetBoardDto {
@@ -695,7 +4185,7 @@ type GameList struct {
 
 func (x *GameList) Reset() {
 	*x = GameList{}
-	mi := &file_contract_proto_msgTypes[14]
+	mi := &file_contract_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -707,7 +4197,7 @@ func (x *GameList) String() string {
 func (*GameList) ProtoMessage() {}
 
 func (x *GameList) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_proto_msgTypes[14]
+	mi := &file_contract_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -720,7 +4210,7 @@ func (x *GameList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GameList.ProtoReflect.Descriptor instead.
 func (*GameList) Descriptor() ([]byte, []int) {
-	return file_contract_proto_rawDescGZIP(), []int{14}
+	return file_contract_proto_rawDescGZIP(), []int{61}
 }
 
 func (x *GameList) GetGames() []*GetGameDto {
@@ -744,16 +4234,165 @@ const file_contract_proto_rawDesc = "" +
 	"\x04name\x18\x02 \x01(\tR\x04name\"2\n" +
 	"\fGetPlayerDto\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"#\n" +
-	"\rCreateRoomDto\x12\x12\n" +
-	"\x04code\x18\x01 \x01(\tR\x04code\"3\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"H\n" +
+	"\x0eCredentialsDto\x12\x1a\n" +
+	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"\x8d\x02\n" +
+	"\bTokenDto\x12!\n" +
+	"\faccess_token\x18\x01 \x01(\tR\vaccessToken\x12\x1d\n" +
+	"\n" +
+	"token_type\x18\x02 \x01(\tR\ttokenType\x12\x1d\n" +
+	"\n" +
+	"expires_in\x18\x03 \x01(\x05R\texpiresIn\x122\n" +
+	"\x06player\x18\x04 \x01(\v2\x1a.api.contract.GetPlayerDtoR\x06player\x12#\n" +
+	"\rrefresh_token\x18\x05 \x01(\tR\frefreshToken\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x06 \x01(\tR\tsessionId\x12(\n" +
+	"\x10two_factor_token\x18\a \x01(\tR\x0etwoFactorToken\"Q\n" +
+	"\x11TwoFactorLoginDto\x12(\n" +
+	"\x10two_factor_token\x18\x01 \x01(\tR\x0etwoFactorToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\"V\n" +
+	"\x11TwoFactorSetupDto\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12)\n" +
+	"\x10provisioning_uri\x18\x02 \x01(\tR\x0fprovisioningUri\"&\n" +
+	"\x10TwoFactorCodeDto\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"9\n" +
+	"\x10RecoveryCodesDto\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\" \n" +
+	"\bEmailDto\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\"C\n" +
+	"\x0fAccountEmailDto\x12\x14\n" +
+	"\x05email\x18\x01 \x01(\tR\x05email\x12\x1a\n" +
+	"\bverified\x18\x02 \x01(\bR\bverified\"&\n" +
+	"\x0eVerifyEmailDto\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"D\n" +
+	"\x10ResetPasswordDto\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\"6\n" +
+	"\x0fRefreshTokenDto\x12#\n" +
+	"\rrefresh_token\x18\x01 \x01(\tR\frefreshToken\"\xc2\x01\n" +
+	"\n" +
+	"SessionDto\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1d\n" +
+	"\n" +
+	"user_agent\x18\x02 \x01(\tR\tuserAgent\x12\x0e\n" +
+	"\x02ip\x18\x03 \x01(\tR\x02ip\x12+\n" +
+	"\x12created_at_unix_ms\x18\x04 \x01(\x03R\x0fcreatedAtUnixMs\x12.\n" +
+	"\x14last_used_at_unix_ms\x18\x05 \x01(\x03R\x10lastUsedAtUnixMs\x12\x18\n" +
+	"\acurrent\x18\x06 \x01(\bR\acurrent\"C\n" +
+	"\vSessionList\x124\n" +
+	"\bsessions\x18\x01 \x03(\v2\x18.api.contract.SessionDtoR\bsessions\"\"\n" +
+	"\x10RevokeSessionDto\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"D\n" +
+	"\x11AdjudicateGameDto\x12\x17\n" +
+	"\agame_id\x18\x01 \x01(\x05R\x06gameId\x12\x16\n" +
+	"\x06winner\x18\x02 \x01(\tR\x06winner\"\x83\x01\n" +
+	"\aUserDto\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\x05R\bplayerId\x12\x1a\n" +
+	"\busername\x18\x02 \x01(\tR\busername\x12\x12\n" +
+	"\x04role\x18\x03 \x01(\tR\x04role\x12+\n" +
+	"\x12created_at_unix_ms\x18\x04 \x01(\x03R\x0fcreatedAtUnixMs\"7\n" +
+	"\bUserList\x12+\n" +
+	"\x05users\x18\x01 \x03(\v2\x15.api.contract.UserDtoR\x05users\"A\n" +
+	"\x0eSetUserRoleDto\x12\x1b\n" +
+	"\tplayer_id\x18\x01 \x01(\x05R\bplayerId\x12\x12\n" +
+	"\x04role\x18\x02 \x01(\tR\x04role\"k\n" +
+	"\rCreateRoomDto\x12\x19\n" +
+	"\bcode_ttl\x18\x02 \x01(\x05R\acodeTtl\x129\n" +
+	"\bsettings\x18\x03 \x01(\v2\x1d.api.contract.RoomSettingsDtoR\bsettingsJ\x04\b\x01\x10\x02\"\x9a\x01\n" +
+	"\x0eTimeControlDto\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x1b\n" +
+	"\tmain_time\x18\x02 \x01(\x05R\bmainTime\x12\x1c\n" +
+	"\tincrement\x18\x03 \x01(\x05R\tincrement\x12\x18\n" +
+	"\aperiods\x18\x04 \x01(\x05R\aperiods\x12\x1f\n" +
+	"\vperiod_time\x18\x05 \x01(\x05R\n" +
+	"periodTime\"\xe3\x04\n" +
+	"\x0fRoomSettingsDto\x12\x1d\n" +
+	"\aruleset\x18\x01 \x01(\tH\x00R\aruleset\x88\x01\x01\x12\"\n" +
+	"\n" +
+	"board_size\x18\x02 \x01(\x05H\x01R\tboardSize\x88\x01\x01\x12\x17\n" +
+	"\x04komi\x18\x03 \x01(\x01H\x02R\x04komi\x88\x01\x01\x12\x1f\n" +
+	"\bhandicap\x18\x04 \x01(\x05H\x03R\bhandicap\x88\x01\x01\x12?\n" +
+	"\ftime_control\x18\x05 \x01(\v2\x1c.api.contract.TimeControlDtoR\vtimeControl\x12\x19\n" +
+	"\x05rated\x18\x06 \x01(\bH\x04R\x05rated\x88\x01\x01\x120\n" +
+	"\x11takebacks_allowed\x18\a \x01(\bH\x05R\x10takebacksAllowed\x88\x01\x01\x122\n" +
+	"\x12spectators_allowed\x18\b \x01(\bH\x06R\x11spectatorsAllowed\x88\x01\x01\x12*\n" +
+	"\x0emax_spectators\x18\t \x01(\x05H\aR\rmaxSpectators\x88\x01\x01\x12,\n" +
+	"\x0fspectator_delay\x18\n" +
+	" \x01(\x05H\bR\x0espectatorDelay\x88\x01\x01\x12\x1c\n" +
+	"\apair_go\x18\v \x01(\bH\tR\x06pairGo\x88\x01\x01B\n" +
+	"\n" +
+	"\b_rulesetB\r\n" +
+	"\v_board_sizeB\a\n" +
+	"\x05_komiB\v\n" +
+	"\t_handicapB\b\n" +
+	"\x06_ratedB\x14\n" +
+	"\x12_takebacks_allowedB\x15\n" +
+	"\x13_spectators_allowedB\x11\n" +
+	"\x0f_max_spectatorsB\x12\n" +
+	"\x10_spectator_delayB\n" +
+	"\n" +
+	"\b_pair_go\"?\n" +
+	"\aTeamDto\x124\n" +
+	"\aplayers\x18\x01 \x03(\v2\x1a.api.contract.GetPlayerDtoR\aplayers\"'\n" +
+	"\x11JoinRoomByCodeDto\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"n\n" +
 	"\rUpdateRoomDto\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04code\x18\x02 \x01(\tR\x04code\"0\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x129\n" +
+	"\bsettings\x18\x03 \x01(\v2\x1d.api.contract.RoomSettingsDtoR\bsettings\"J\n" +
+	"\x0fNigiriCommitDto\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x05R\x06roomId\x12\x1e\n" +
+	"\n" +
+	"commitment\x18\x02 \x01(\tR\n" +
+	"commitment\"?\n" +
+	"\x0eNigiriGuessDto\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x05R\x06roomId\x12\x14\n" +
+	"\x05guess\x18\x02 \x01(\tR\x05guess\"X\n" +
+	"\x0fNigiriRevealDto\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x05R\x06roomId\x12\x16\n" +
+	"\x06stones\x18\x02 \x01(\x05R\x06stones\x12\x14\n" +
+	"\x05nonce\x18\x03 \x01(\tR\x05nonce\"?\n" +
+	"\x0eNigiriClaimDto\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x05R\x06roomId\x12\x14\n" +
+	"\x05color\x18\x02 \x01(\tR\x05color\"m\n" +
+	"\x0eNigiriEventDto\x12\x0e\n" +
+	"\x02at\x18\x01 \x01(\x03R\x02at\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\x05R\bplayerId\x12\x16\n" +
+	"\x06action\x18\x03 \x01(\tR\x06action\x12\x16\n" +
+	"\x06detail\x18\x04 \x01(\tR\x06detail\"\xbb\x02\n" +
+	"\fGetNigiriDto\x12\x1b\n" +
+	"\tholder_id\x18\x01 \x01(\x05R\bholderId\x12\x1d\n" +
+	"\n" +
+	"guesser_id\x18\x02 \x01(\x05R\tguesserId\x12\x1e\n" +
+	"\n" +
+	"commitment\x18\x03 \x01(\tR\n" +
+	"commitment\x12\x14\n" +
+	"\x05guess\x18\x04 \x01(\tR\x05guess\x12\x16\n" +
+	"\x06stones\x18\x05 \x01(\x05R\x06stones\x12\x14\n" +
+	"\x05nonce\x18\x06 \x01(\tR\x05nonce\x12\x19\n" +
+	"\bblack_id\x18\a \x01(\x05R\ablackId\x12\x17\n" +
+	"\agame_id\x18\b \x01(\x05R\x06gameId\x12.\n" +
+	"\x03log\x18\t \x03(\v2\x1c.api.contract.NigiriEventDtoR\x03log\x12'\n" +
+	"\x0freveal_deadline\x18\n" +
+	" \x01(\x03R\x0erevealDeadline\"@\n" +
+	"\n" +
+	"NigiriList\x122\n" +
+	"\x06nigiri\x18\x01 \x03(\v2\x1a.api.contract.GetNigiriDtoR\x06nigiri\"\xb2\x03\n" +
 	"\n" +
 	"GetRoomDto\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04code\x18\x02 \x01(\tR\x04code\"$\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x124\n" +
+	"\aplayers\x18\x03 \x03(\v2\x1a.api.contract.GetPlayerDtoR\aplayers\x12&\n" +
+	"\x0fcode_expires_at\x18\x04 \x01(\x03R\rcodeExpiresAt\x12\x19\n" +
+	"\bowner_id\x18\x05 \x01(\x05R\aownerId\x12,\n" +
+	"\x04game\x18\x06 \x01(\v2\x18.api.contract.GetGameDtoR\x04game\x122\n" +
+	"\x06nigiri\x18\a \x01(\v2\x1a.api.contract.GetNigiriDtoR\x06nigiri\x12\x14\n" +
+	"\x05state\x18\b \x01(\tR\x05state\x129\n" +
+	"\bsettings\x18\t \x01(\v2\x1d.api.contract.RoomSettingsDtoR\bsettings\x12'\n" +
+	"\x0fspectator_count\x18\n" +
+	" \x01(\x05R\x0espectatorCount\x12+\n" +
+	"\x05teams\x18\v \x03(\v2\x15.api.contract.TeamDtoR\x05teams\"$\n" +
 	"\x0eCreateBoardDto\x12\x12\n" +
 	"\x04size\x18\x01 \x01(\x05R\x04size\"4\n" +
 	"\x0eUpdateBoardDto\x12\x0e\n" +
@@ -761,10 +4400,144 @@ const file_contract_proto_rawDesc = "" +
 	"\x04size\x18\x02 \x01(\x05R\x04size\"1\n" +
 	"\vGetBoardDto\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
-	"\x04size\x18\x02 \x01(\x05R\x04size\"\x1c\n" +
+	"\x04size\x18\x02 \x01(\x05R\x04size\"\xd4\x04\n" +
 	"\n" +
 	"GetGameDto\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\x05R\x02id\"B\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x19\n" +
+	"\bblack_id\x18\x02 \x01(\x05R\ablackId\x12\x19\n" +
+	"\bwhite_id\x18\x03 \x01(\x05R\awhiteId\x12!\n" +
+	"\fcurrent_turn\x18\x04 \x01(\tR\vcurrentTurn\x12\x16\n" +
+	"\x06status\x18\x05 \x01(\tR\x06status\x12\x16\n" +
+	"\x06result\x18\x06 \x01(\tR\x06result\x12\x1d\n" +
+	"\n" +
+	"move_count\x18\a \x01(\x05R\tmoveCount\x12%\n" +
+	"\x0eblack_captures\x18\b \x01(\x05R\rblackCaptures\x12%\n" +
+	"\x0ewhite_captures\x18\t \x01(\x05R\rwhiteCaptures\x12\x14\n" +
+	"\x05board\x18\n" +
+	" \x03(\tR\x05board\x12\x18\n" +
+	"\aruleset\x18\v \x01(\tR\aruleset\x12\x12\n" +
+	"\x04komi\x18\f \x01(\x01R\x04komi\x12\x1a\n" +
+	"\bhandicap\x18\r \x01(\x05R\bhandicap\x12,\n" +
+	"\x05clock\x18\x0e \x01(\v2\x16.api.contract.ClockDtoR\x05clock\x12(\n" +
+	"\x10black_partner_id\x18\x0f \x01(\x05R\x0eblackPartnerId\x12(\n" +
+	"\x10white_partner_id\x18\x10 \x01(\x05R\x0ewhitePartnerId\x12*\n" +
+	"\x11current_player_id\x18\x11 \x01(\x05R\x0fcurrentPlayerId\x122\n" +
+	"\x15takeback_requested_by\x18\x12 \x01(\x05R\x13takebackRequestedBy\"\xd3\x01\n" +
+	"\bClockDto\x12,\n" +
+	"\x12black_remaining_ms\x18\x01 \x01(\x03R\x10blackRemainingMs\x12,\n" +
+	"\x12white_remaining_ms\x18\x02 \x01(\x03R\x10whiteRemainingMs\x12#\n" +
+	"\rblack_periods\x18\x03 \x01(\x05R\fblackPeriods\x12#\n" +
+	"\rwhite_periods\x18\x04 \x01(\x05R\fwhitePeriods\x12!\n" +
+	"\fcurrent_turn\x18\x05 \x01(\tR\vcurrentTurn\"\x88\x01\n" +
+	"\vGameMoveDto\x12\x16\n" +
+	"\x06number\x18\x01 \x01(\x05R\x06number\x12\x1b\n" +
+	"\tplayer_id\x18\x02 \x01(\x05R\bplayerId\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\x12\x12\n" +
+	"\x04pass\x18\x04 \x01(\bR\x04pass\x12\f\n" +
+	"\x01x\x18\x05 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x06 \x01(\x05R\x01y\"?\n" +
+	"\rGameResultDto\x12\x16\n" +
+	"\x06status\x18\x01 \x01(\tR\x06status\x12\x16\n" +
+	"\x06result\x18\x02 \x01(\tR\x06result\"\x86\x03\n" +
+	"\tGameEvent\x12\x12\n" +
+	"\x04type\x18\x01 \x01(\tR\x04type\x12\x17\n" +
+	"\agame_id\x18\x02 \x01(\x05R\x06gameId\x12\x17\n" +
+	"\aroom_id\x18\x03 \x01(\x05R\x06roomId\x12 \n" +
+	"\ftime_unix_ms\x18\x04 \x01(\x03R\n" +
+	"timeUnixMs\x12.\n" +
+	"\x04game\x18\x05 \x01(\v2\x18.api.contract.GetGameDtoH\x00R\x04game\x12/\n" +
+	"\x04move\x18\x06 \x01(\v2\x19.api.contract.GameMoveDtoH\x00R\x04move\x12\x1f\n" +
+	"\n" +
+	"move_count\x18\a \x01(\x05H\x00R\tmoveCount\x12.\n" +
+	"\x05clock\x18\b \x01(\v2\x16.api.contract.ClockDtoH\x00R\x05clock\x125\n" +
+	"\x06result\x18\t \x01(\v2\x1b.api.contract.GameResultDtoH\x00R\x06result\x12\x1d\n" +
+	"\tplayer_id\x18\n" +
+	" \x01(\x05H\x00R\bplayerIdB\t\n" +
+	"\apayload\">\n" +
+	"\aMoveDto\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x05R\x06roomId\x12\f\n" +
+	"\x01x\x18\x02 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x03 \x01(\x05R\x01y\"&\n" +
+	"\bPointDto\x12\f\n" +
+	"\x01x\x18\x01 \x01(\x05R\x01x\x12\f\n" +
+	"\x01y\x18\x02 \x01(\x05R\x01y\"\xd7\x04\n" +
+	"\vPlayCommand\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x05R\x03seq\x12/\n" +
+	"\x03sit\x18\x02 \x01(\v2\x1b.api.contract.RequestEntityH\x00R\x03sit\x12,\n" +
+	"\x04move\x18\x03 \x01(\v2\x16.api.contract.PointDtoH\x00R\x04move\x12,\n" +
+	"\x04pass\x18\x04 \x01(\v2\x16.google.protobuf.EmptyH\x00R\x04pass\x120\n" +
+	"\x06resign\x18\x05 \x01(\v2\x16.google.protobuf.EmptyH\x00R\x06resign\x124\n" +
+	"\btakeback\x18\x06 \x01(\v2\x16.google.protobuf.EmptyH\x00R\btakeback\x12;\n" +
+	"\faccept_score\x18\a \x01(\v2\x16.google.protobuf.EmptyH\x00R\vacceptScore\x129\n" +
+	"\vresume_play\x18\b \x01(\v2\x16.google.protobuf.EmptyH\x00R\n" +
+	"resumePlay\x126\n" +
+	"\x04chat\x18\t \x01(\v2 .api.contract.PostChatMessageDtoH\x00R\x04chat\x12A\n" +
+	"\x0faccept_takeback\x18\n" +
+	" \x01(\v2\x16.google.protobuf.EmptyH\x00R\x0eacceptTakeback\x12C\n" +
+	"\x10decline_takeback\x18\v \x01(\v2\x16.google.protobuf.EmptyH\x00R\x0fdeclineTakebackB\t\n" +
+	"\acommand\"B\n" +
+	"\x12PostChatMessageDto\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\x12\x12\n" +
+	"\x04text\x18\x02 \x01(\tR\x04text\"\xae\x01\n" +
+	"\x0eChatMessageDto\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x18\n" +
+	"\achannel\x18\x02 \x01(\tR\achannel\x12\x1b\n" +
+	"\tplayer_id\x18\x03 \x01(\x05R\bplayerId\x12\x1f\n" +
+	"\vplayer_name\x18\x04 \x01(\tR\n" +
+	"playerName\x12\x12\n" +
+	"\x04text\x18\x05 \x01(\tR\x04text\x12 \n" +
+	"\ftime_unix_ms\x18\x06 \x01(\x03R\n" +
+	"timeUnixMs\"K\n" +
+	"\tPlayError\x12\x10\n" +
+	"\x03seq\x18\x01 \x01(\x05R\x03seq\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\"\x91\x02\n" +
+	"\tPlayEvent\x12.\n" +
+	"\x04room\x18\x01 \x01(\v2\x18.api.contract.GetRoomDtoH\x00R\x04room\x12-\n" +
+	"\x04game\x18\x02 \x01(\v2\x17.api.contract.GameEventH\x00R\x04game\x12/\n" +
+	"\x05error\x18\x03 \x01(\v2\x17.api.contract.PlayErrorH\x00R\x05error\x122\n" +
+	"\x04chat\x18\x04 \x01(\v2\x1c.api.contract.ChatMessageDtoH\x00R\x04chat\x127\n" +
+	"\x17deleted_chat_message_id\x18\x05 \x01(\x05H\x00R\x14deletedChatMessageIdB\a\n" +
+	"\x05event\"'\n" +
+	"\rRoomFilterDto\x12\x16\n" +
+	"\x06states\x18\x01 \x03(\tR\x06states\"h\n" +
+	"\fStartGameDto\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x05R\x06roomId\x12)\n" +
+	"\x10color_assignment\x18\x02 \x01(\tR\x0fcolorAssignment\x12\x14\n" +
+	"\x05color\x18\x03 \x01(\tR\x05color\"\xa3\x01\n" +
+	"\x0eCreateSimulDto\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\rmax_opponents\x18\x02 \x01(\x05R\fmaxOpponents\x12\x1d\n" +
+	"\n" +
+	"host_color\x18\x03 \x01(\tR\thostColor\x129\n" +
+	"\bsettings\x18\x04 \x01(\v2\x1d.api.contract.RoomSettingsDtoR\bsettings\"\x99\x01\n" +
+	"\rSimulBoardDto\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\x05R\x06roomId\x12\x1f\n" +
+	"\vopponent_id\x18\x02 \x01(\x05R\n" +
+	"opponentId\x12 \n" +
+	"\fhost_to_move\x18\x03 \x01(\bR\n" +
+	"hostToMove\x12,\n" +
+	"\x04game\x18\x04 \x01(\v2\x18.api.contract.GetGameDtoR\x04game\"\xa2\x03\n" +
+	"\vGetSimulDto\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x05R\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x17\n" +
+	"\ahost_id\x18\x03 \x01(\x05R\x06hostId\x12\x1d\n" +
+	"\n" +
+	"host_color\x18\x04 \x01(\tR\thostColor\x12#\n" +
+	"\rmax_opponents\x18\x05 \x01(\x05R\fmaxOpponents\x12\x14\n" +
+	"\x05state\x18\x06 \x01(\tR\x05state\x129\n" +
+	"\bsettings\x18\a \x01(\v2\x1d.api.contract.RoomSettingsDtoR\bsettings\x128\n" +
+	"\topponents\x18\b \x03(\v2\x1a.api.contract.GetPlayerDtoR\topponents\x123\n" +
+	"\x06boards\x18\t \x03(\v2\x1b.api.contract.SimulBoardDtoR\x06boards\x12\x1b\n" +
+	"\thost_wins\x18\n" +
+	" \x01(\x05R\bhostWins\x12\x1f\n" +
+	"\vhost_losses\x18\v \x01(\x05R\n" +
+	"hostLosses\x12\x14\n" +
+	"\x05draws\x18\f \x01(\x05R\x05draws\">\n" +
+	"\tSimulList\x121\n" +
+	"\x06simuls\x18\x01 \x03(\v2\x19.api.contract.GetSimulDtoR\x06simuls\"E\n" +
+	"\x0eSimulBoardList\x123\n" +
+	"\x06boards\x18\x01 \x03(\v2\x1b.api.contract.SimulBoardDtoR\x06boards\"B\n" +
 	"\n" +
 	"PlayerList\x124\n" +
 	"\aplayers\x18\x01 \x03(\v2\x1a.api.contract.GetPlayerDtoR\aplayers\":\n" +
@@ -773,35 +4546,93 @@ const file_contract_proto_rawDesc = "" +
 	"\tBoardList\x121\n" +
 	"\x06boards\x18\x01 \x03(\v2\x19.api.contract.GetBoardDtoR\x06boards\":\n" +
 	"\bGameList\x12.\n" +
-	"\x05games\x18\x01 \x03(\v2\x18.api.contract.GetGameDtoR\x05games2\xf3\x02\n" +
+	"\x05games\x18\x01 \x03(\v2\x18.api.contract.GetGameDtoR\x05games2\x99\n" +
+	"\n" +
+	"\vAuthService\x12@\n" +
+	"\bRegister\x12\x1c.api.contract.CredentialsDto\x1a\x16.api.contract.TokenDto\x12=\n" +
+	"\x05Login\x12\x1c.api.contract.CredentialsDto\x1a\x16.api.contract.TokenDto\x12@\n" +
+	"\aRefresh\x12\x1d.api.contract.RefreshTokenDto\x1a\x16.api.contract.TokenDto\x128\n" +
+	"\x06Logout\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12B\n" +
+	"\x10LogoutEverywhere\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\fListSessions\x12\x16.google.protobuf.Empty\x1a\x19.api.contract.SessionList\x12G\n" +
+	"\rRevokeSession\x12\x1e.api.contract.RevokeSessionDto\x1a\x16.google.protobuf.Empty\x12J\n" +
+	"\x0fVerifyTwoFactor\x12\x1f.api.contract.TwoFactorLoginDto\x1a\x16.api.contract.TokenDto\x12I\n" +
+	"\x0eSetupTwoFactor\x12\x16.google.protobuf.Empty\x1a\x1f.api.contract.TwoFactorSetupDto\x12Q\n" +
+	"\x0fEnableTwoFactor\x12\x1e.api.contract.TwoFactorCodeDto\x1a\x1e.api.contract.RecoveryCodesDto\x12J\n" +
+	"\x10DisableTwoFactor\x12\x1e.api.contract.TwoFactorCodeDto\x1a\x16.google.protobuf.Empty\x12Y\n" +
+	"\x17RegenerateRecoveryCodes\x12\x1e.api.contract.TwoFactorCodeDto\x1a\x1e.api.contract.RecoveryCodesDto\x12A\n" +
+	"\bGetEmail\x12\x16.google.protobuf.Empty\x1a\x1d.api.contract.AccountEmailDto\x12A\n" +
+	"\bSetEmail\x12\x16.api.contract.EmailDto\x1a\x1d.api.contract.AccountEmailDto\x12I\n" +
+	"\x17ResendVerificationEmail\x12\x16.google.protobuf.Empty\x1a\x16.google.protobuf.Empty\x12J\n" +
+	"\vVerifyEmail\x12\x1c.api.contract.VerifyEmailDto\x1a\x1d.api.contract.AccountEmailDto\x12F\n" +
+	"\x14RequestPasswordReset\x12\x16.api.contract.EmailDto\x1a\x16.google.protobuf.Empty\x12G\n" +
+	"\rResetPassword\x12\x1e.api.contract.ResetPasswordDto\x1a\x16.google.protobuf.Empty2\xf3\x02\n" +
 	"\rPlayerService\x12D\n" +
 	"\tGetPlayer\x12\x1b.api.contract.RequestEntity\x1a\x1a.api.contract.GetPlayerDto\x12A\n" +
 	"\rGetAllPlayers\x12\x16.google.protobuf.Empty\x1a\x18.api.contract.PlayerList\x12I\n" +
 	"\fCreatePlayer\x12\x1d.api.contract.CreatePlayerDto\x1a\x1a.api.contract.GetPlayerDto\x12I\n" +
 	"\fUpdatePlayer\x12\x1d.api.contract.UpdatePlayerDto\x1a\x1a.api.contract.GetPlayerDto\x12C\n" +
-	"\fDeletePlayer\x12\x1b.api.contract.RequestEntity\x1a\x16.google.protobuf.Empty2\xdb\x02\n" +
+	"\fDeletePlayer\x12\x1b.api.contract.RequestEntity\x1a\x16.google.protobuf.Empty2\xd1\r\n" +
 	"\vRoomService\x12@\n" +
-	"\aGetRoom\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.GetRoomDto\x12=\n" +
-	"\vGetAllRooms\x12\x16.google.protobuf.Empty\x1a\x16.api.contract.RoomList\x12C\n" +
+	"\aGetRoom\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.GetRoomDto\x12B\n" +
+	"\vGetAllRooms\x12\x1b.api.contract.RoomFilterDto\x1a\x16.api.contract.RoomList\x12C\n" +
 	"\n" +
 	"CreateRoom\x12\x1b.api.contract.CreateRoomDto\x1a\x18.api.contract.GetRoomDto\x12C\n" +
 	"\n" +
 	"UpdateRoom\x12\x1b.api.contract.UpdateRoomDto\x1a\x18.api.contract.GetRoomDto\x12A\n" +
 	"\n" +
-	"DeleteRoom\x12\x1b.api.contract.RequestEntity\x1a\x16.google.protobuf.Empty2\xe7\x02\n" +
+	"DeleteRoom\x12\x1b.api.contract.RequestEntity\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\bJoinRoom\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.GetRoomDto\x12K\n" +
+	"\x0eJoinRoomByCode\x12\x1f.api.contract.JoinRoomByCodeDto\x1a\x18.api.contract.GetRoomDto\x12B\n" +
+	"\tLeaveRoom\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.GetRoomDto\x12A\n" +
+	"\bSpectate\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.GetRoomDto\x12G\n" +
+	"\x0eStopSpectating\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.GetRoomDto\x12A\n" +
+	"\tStartGame\x12\x1a.api.contract.StartGameDto\x1a\x18.api.contract.GetRoomDto\x12;\n" +
+	"\bPlayMove\x12\x15.api.contract.MoveDto\x1a\x18.api.contract.GetRoomDto\x12=\n" +
+	"\x04Pass\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.GetRoomDto\x12?\n" +
+	"\x06Resign\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.GetRoomDto\x12D\n" +
+	"\vAcceptScore\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.GetRoomDto\x12C\n" +
+	"\n" +
+	"ResumePlay\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.GetRoomDto\x12A\n" +
+	"\bTakeback\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.GetRoomDto\x12G\n" +
+	"\x0eAcceptTakeback\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.GetRoomDto\x12H\n" +
+	"\x0fDeclineTakeback\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.GetRoomDto\x12G\n" +
+	"\fCommitNigiri\x12\x1d.api.contract.NigiriCommitDto\x1a\x18.api.contract.GetRoomDto\x12E\n" +
+	"\vGuessNigiri\x12\x1c.api.contract.NigiriGuessDto\x1a\x18.api.contract.GetRoomDto\x12G\n" +
+	"\fRevealNigiri\x12\x1d.api.contract.NigiriRevealDto\x1a\x18.api.contract.GetRoomDto\x12E\n" +
+	"\vClaimNigiri\x12\x1c.api.contract.NigiriClaimDto\x1a\x18.api.contract.GetRoomDto\x12G\n" +
+	"\x0eGetNigiriAudit\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.NigiriList\x12E\n" +
+	"\vPlaySession\x12\x19.api.contract.PlayCommand\x1a\x17.api.contract.PlayEvent(\x010\x012\xf8\x03\n" +
+	"\fSimulService\x12B\n" +
+	"\bGetSimul\x12\x1b.api.contract.RequestEntity\x1a\x19.api.contract.GetSimulDto\x12?\n" +
+	"\fGetAllSimuls\x12\x16.google.protobuf.Empty\x1a\x17.api.contract.SimulList\x12F\n" +
+	"\vCreateSimul\x12\x1c.api.contract.CreateSimulDto\x1a\x19.api.contract.GetSimulDto\x12C\n" +
+	"\tJoinSimul\x12\x1b.api.contract.RequestEntity\x1a\x19.api.contract.GetSimulDto\x12D\n" +
+	"\n" +
+	"LeaveSimul\x12\x1b.api.contract.RequestEntity\x1a\x19.api.contract.GetSimulDto\x12D\n" +
+	"\n" +
+	"StartSimul\x12\x1b.api.contract.RequestEntity\x1a\x19.api.contract.GetSimulDto\x12J\n" +
+	"\rGetSimulQueue\x12\x1b.api.contract.RequestEntity\x1a\x1c.api.contract.SimulBoardList2\xe7\x02\n" +
 	"\fBoardService\x12B\n" +
 	"\bGetBoard\x12\x1b.api.contract.RequestEntity\x1a\x19.api.contract.GetBoardDto\x12?\n" +
 	"\fGetAllBoards\x12\x16.google.protobuf.Empty\x1a\x17.api.contract.BoardList\x12F\n" +
 	"\vCreateBoard\x12\x1c.api.contract.CreateBoardDto\x1a\x19.api.contract.GetBoardDto\x12F\n" +
 	"\vUpdateBoard\x12\x1c.api.contract.UpdateBoardDto\x1a\x19.api.contract.GetBoardDto\x12B\n" +
-	"\vDeleteBoard\x12\x1b.api.contract.RequestEntity\x1a\x16.google.protobuf.Empty2\x91\x02\n" +
+	"\vDeleteBoard\x12\x1b.api.contract.RequestEntity\x1a\x16.google.protobuf.Empty2\xd6\x02\n" +
 	"\vGameService\x12@\n" +
 	"\aGetGame\x12\x1b.api.contract.RequestEntity\x1a\x18.api.contract.GetGameDto\x12=\n" +
 	"\vGetAllGames\x12\x16.google.protobuf.Empty\x1a\x16.api.contract.GameList\x12>\n" +
 	"\n" +
 	"CreateGame\x12\x16.google.protobuf.Empty\x1a\x18.api.contract.GetGameDto\x12A\n" +
 	"\n" +
-	"DeleteGame\x12\x1b.api.contract.RequestEntity\x1a\x16.google.protobuf.EmptyB\x1bZ\x19./internal/grpc/generatedb\x06proto3"
+	"DeleteGame\x12\x1b.api.contract.RequestEntity\x1a\x16.google.protobuf.Empty\x12C\n" +
+	"\tWatchGame\x12\x1b.api.contract.RequestEntity\x1a\x17.api.contract.GameEvent0\x012\x9f\x02\n" +
+	"\fAdminService\x12K\n" +
+	"\x0eAdjudicateGame\x12\x1f.api.contract.AdjudicateGameDto\x1a\x18.api.contract.GetRoomDto\x12;\n" +
+	"\tListUsers\x12\x16.google.protobuf.Empty\x1a\x16.api.contract.UserList\x12B\n" +
+	"\vSetUserRole\x12\x1c.api.contract.SetUserRoleDto\x1a\x15.api.contract.UserDto\x12A\n" +
+	"\n" +
+	"DeleteUser\x12\x1b.api.contract.RequestEntity\x1a\x16.google.protobuf.EmptyB\x1bZ\x19./internal/grpc/generatedb\x06proto3"
 
 var (
 	file_contract_proto_rawDescOnce sync.Once
@@ -815,73 +4646,260 @@ func file_contract_proto_rawDescGZIP() []byte {
 	return file_contract_proto_rawDescData
 }
 
-var file_contract_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_contract_proto_msgTypes = make([]protoimpl.MessageInfo, 62)
 var file_contract_proto_goTypes = []any{
-	(*RequestEntity)(nil),   // 0: api.contract.RequestEntity
-	(*CreatePlayerDto)(nil), // 1: api.contract.CreatePlayerDto
-	(*UpdatePlayerDto)(nil), // 2: api.contract.UpdatePlayerDto
-	(*GetPlayerDto)(nil),    // 3: api.contract.GetPlayerDto
-	(*CreateRoomDto)(nil),   // 4: api.contract.CreateRoomDto
-	(*UpdateRoomDto)(nil),   // 5: api.contract.UpdateRoomDto
-	(*GetRoomDto)(nil),      // 6: api.contract.GetRoomDto
-	(*CreateBoardDto)(nil),  // 7: api.contract.CreateBoardDto
-	(*UpdateBoardDto)(nil),  // 8: api.contract.UpdateBoardDto
-	(*GetBoardDto)(nil),     // 9: api.contract.GetBoardDto
-	(*GetGameDto)(nil),      // 10: api.contract.GetGameDto
-	(*PlayerList)(nil),      // 11: api.contract.PlayerList
-	(*RoomList)(nil),        // 12: api.contract.RoomList
-	(*BoardList)(nil),       // 13: api.contract.BoardList
-	(*GameList)(nil),        // 14: api.contract.GameList
-	(*emptypb.Empty)(nil),   // 15: google.protobuf.Empty
+	(*RequestEntity)(nil),      // 0: api.contract.RequestEntity
+	(*CreatePlayerDto)(nil),    // 1: api.contract.CreatePlayerDto
+	(*UpdatePlayerDto)(nil),    // 2: api.contract.UpdatePlayerDto
+	(*GetPlayerDto)(nil),       // 3: api.contract.GetPlayerDto
+	(*CredentialsDto)(nil),     // 4: api.contract.CredentialsDto
+	(*TokenDto)(nil),           // 5: api.contract.TokenDto
+	(*TwoFactorLoginDto)(nil),  // 6: api.contract.TwoFactorLoginDto
+	(*TwoFactorSetupDto)(nil),  // 7: api.contract.TwoFactorSetupDto
+	(*TwoFactorCodeDto)(nil),   // 8: api.contract.TwoFactorCodeDto
+	(*RecoveryCodesDto)(nil),   // 9: api.contract.RecoveryCodesDto
+	(*EmailDto)(nil),           // 10: api.contract.EmailDto
+	(*AccountEmailDto)(nil),    // 11: api.contract.AccountEmailDto
+	(*VerifyEmailDto)(nil),     // 12: api.contract.VerifyEmailDto
+	(*ResetPasswordDto)(nil),   // 13: api.contract.ResetPasswordDto
+	(*RefreshTokenDto)(nil),    // 14: api.contract.RefreshTokenDto
+	(*SessionDto)(nil),         // 15: api.contract.SessionDto
+	(*SessionList)(nil),        // 16: api.contract.SessionList
+	(*RevokeSessionDto)(nil),   // 17: api.contract.RevokeSessionDto
+	(*AdjudicateGameDto)(nil),  // 18: api.contract.AdjudicateGameDto
+	(*UserDto)(nil),            // 19: api.contract.UserDto
+	(*UserList)(nil),           // 20: api.contract.UserList
+	(*SetUserRoleDto)(nil),     // 21: api.contract.SetUserRoleDto
+	(*CreateRoomDto)(nil),      // 22: api.contract.CreateRoomDto
+	(*TimeControlDto)(nil),     // 23: api.contract.TimeControlDto
+	(*RoomSettingsDto)(nil),    // 24: api.contract.RoomSettingsDto
+	(*TeamDto)(nil),            // 25: api.contract.TeamDto
+	(*JoinRoomByCodeDto)(nil),  // 26: api.contract.JoinRoomByCodeDto
+	(*UpdateRoomDto)(nil),      // 27: api.contract.UpdateRoomDto
+	(*NigiriCommitDto)(nil),    // 28: api.contract.NigiriCommitDto
+	(*NigiriGuessDto)(nil),     // 29: api.contract.NigiriGuessDto
+	(*NigiriRevealDto)(nil),    // 30: api.contract.NigiriRevealDto
+	(*NigiriClaimDto)(nil),     // 31: api.contract.NigiriClaimDto
+	(*NigiriEventDto)(nil),     // 32: api.contract.NigiriEventDto
+	(*GetNigiriDto)(nil),       // 33: api.contract.GetNigiriDto
+	(*NigiriList)(nil),         // 34: api.contract.NigiriList
+	(*GetRoomDto)(nil),         // 35: api.contract.GetRoomDto
+	(*CreateBoardDto)(nil),     // 36: api.contract.CreateBoardDto
+	(*UpdateBoardDto)(nil),     // 37: api.contract.UpdateBoardDto
+	(*GetBoardDto)(nil),        // 38: api.contract.GetBoardDto
+	(*GetGameDto)(nil),         // 39: api.contract.GetGameDto
+	(*ClockDto)(nil),           // 40: api.contract.ClockDto
+	(*GameMoveDto)(nil),        // 41: api.contract.GameMoveDto
+	(*GameResultDto)(nil),      // 42: api.contract.GameResultDto
+	(*GameEvent)(nil),          // 43: api.contract.GameEvent
+	(*MoveDto)(nil),            // 44: api.contract.MoveDto
+	(*PointDto)(nil),           // 45: api.contract.PointDto
+	(*PlayCommand)(nil),        // 46: api.contract.PlayCommand
+	(*PostChatMessageDto)(nil), // 47: api.contract.PostChatMessageDto
+	(*ChatMessageDto)(nil),     // 48: api.contract.ChatMessageDto
+	(*PlayError)(nil),          // 49: api.contract.PlayError
+	(*PlayEvent)(nil),          // 50: api.contract.PlayEvent
+	(*RoomFilterDto)(nil),      // 51: api.contract.RoomFilterDto
+	(*StartGameDto)(nil),       // 52: api.contract.StartGameDto
+	(*CreateSimulDto)(nil),     // 53: api.contract.CreateSimulDto
+	(*SimulBoardDto)(nil),      // 54: api.contract.SimulBoardDto
+	(*GetSimulDto)(nil),        // 55: api.contract.GetSimulDto
+	(*SimulList)(nil),          // 56: api.contract.SimulList
+	(*SimulBoardList)(nil),     // 57: api.contract.SimulBoardList
+	(*PlayerList)(nil),         // 58: api.contract.PlayerList
+	(*RoomList)(nil),           // 59: api.contract.RoomList
+	(*BoardList)(nil),          // 60: api.contract.BoardList
+	(*GameList)(nil),           // 61: api.contract.GameList
+	(*emptypb.Empty)(nil),      // 62: google.protobuf.Empty
 }
 var file_contract_proto_depIdxs = []int32{
-	3,  // 0: api.contract.PlayerList.players:type_name -> api.contract.GetPlayerDto
-	6,  // 1: api.contract.RoomList.rooms:type_name -> api.contract.GetRoomDto
-	9,  // 2: api.contract.BoardList.boards:type_name -> api.contract.GetBoardDto
-	10, // 3: api.contract.GameList.games:type_name -> api.contract.GetGameDto
-	0,  // 4: api.contract.PlayerService.GetPlayer:input_type -> api.contract.RequestEntity
-	15, // 5: api.contract.PlayerService.GetAllPlayers:input_type -> google.protobuf.Empty
-	1,  // 6: api.contract.PlayerService.CreatePlayer:input_type -> api.contract.CreatePlayerDto
-	2,  // 7: api.contract.PlayerService.UpdatePlayer:input_type -> api.contract.UpdatePlayerDto
-	0,  // 8: api.contract.PlayerService.DeletePlayer:input_type -> api.contract.RequestEntity
-	0,  // 9: api.contract.RoomService.GetRoom:input_type -> api.contract.RequestEntity
-	15, // 10: api.contract.RoomService.GetAllRooms:input_type -> google.protobuf.Empty
-	4,  // 11: api.contract.RoomService.CreateRoom:input_type -> api.contract.CreateRoomDto
-	5,  // 12: api.contract.RoomService.UpdateRoom:input_type -> api.contract.UpdateRoomDto
-	0,  // 13: api.contract.RoomService.DeleteRoom:input_type -> api.contract.RequestEntity
-	0,  // 14: api.contract.BoardService.GetBoard:input_type -> api.contract.RequestEntity
-	15, // 15: api.contract.BoardService.GetAllBoards:input_type -> google.protobuf.Empty
-	7,  // 16: api.contract.BoardService.CreateBoard:input_type -> api.contract.CreateBoardDto
-	8,  // 17: api.contract.BoardService.UpdateBoard:input_type -> api.contract.UpdateBoardDto
-	0,  // 18: api.contract.BoardService.DeleteBoard:input_type -> api.contract.RequestEntity
-	0,  // 19: api.contract.GameService.GetGame:input_type -> api.contract.RequestEntity
-	15, // 20: api.contract.GameService.GetAllGames:input_type -> google.protobuf.Empty
-	15, // 21: api.contract.GameService.CreateGame:input_type -> google.protobuf.Empty
-	0,  // 22: api.contract.GameService.DeleteGame:input_type -> api.contract.RequestEntity
-	3,  // 23: api.contract.PlayerService.GetPlayer:output_type -> api.contract.GetPlayerDto
-	11, // 24: api.contract.PlayerService.GetAllPlayers:output_type -> api.contract.PlayerList
-	3,  // 25: api.contract.PlayerService.CreatePlayer:output_type -> api.contract.GetPlayerDto
-	3,  // 26: api.contract.PlayerService.UpdatePlayer:output_type -> api.contract.GetPlayerDto
-	15, // 27: api.contract.PlayerService.DeletePlayer:output_type -> google.protobuf.Empty
-	6,  // 28: api.contract.RoomService.GetRoom:output_type -> api.contract.GetRoomDto
-	12, // 29: api.contract.RoomService.GetAllRooms:output_type -> api.contract.RoomList
-	6,  // 30: api.contract.RoomService.CreateRoom:output_type -> api.contract.GetRoomDto
-	6,  // 31: api.contract.RoomService.UpdateRoom:output_type -> api.contract.GetRoomDto
-	15, // 32: api.contract.RoomService.DeleteRoom:output_type -> google.protobuf.Empty
-	9,  // 33: api.contract.BoardService.GetBoard:output_type -> api.contract.GetBoardDto
-	13, // 34: api.contract.BoardService.GetAllBoards:output_type -> api.contract.BoardList
-	9,  // 35: api.contract.BoardService.CreateBoard:output_type -> api.contract.GetBoardDto
-	9,  // 36: api.contract.BoardService.UpdateBoard:output_type -> api.contract.GetBoardDto
-	15, // 37: api.contract.BoardService.DeleteBoard:output_type -> google.protobuf.Empty
-	10, // 38: api.contract.GameService.GetGame:output_type -> api.contract.GetGameDto
-	14, // 39: api.contract.GameService.GetAllGames:output_type -> api.contract.GameList
-	10, // 40: api.contract.GameService.CreateGame:output_type -> api.contract.GetGameDto
-	15, // 41: api.contract.GameService.DeleteGame:output_type -> google.protobuf.Empty
-	23, // [23:42] is the sub-list for method output_type
-	4,  // [4:23] is the sub-list for method input_type
-	4,  // [4:4] is the sub-list for extension type_name
-	4,  // [4:4] is the sub-list for extension extendee
-	0,  // [0:4] is the sub-list for field type_name
+	3,   // 0: api.contract.TokenDto.player:type_name -> api.contract.GetPlayerDto
+	15,  // 1: api.contract.SessionList.sessions:type_name -> api.contract.SessionDto
+	19,  // 2: api.contract.UserList.users:type_name -> api.contract.UserDto
+	24,  // 3: api.contract.CreateRoomDto.settings:type_name -> api.contract.RoomSettingsDto
+	23,  // 4: api.contract.RoomSettingsDto.time_control:type_name -> api.contract.TimeControlDto
+	3,   // 5: api.contract.TeamDto.players:type_name -> api.contract.GetPlayerDto
+	24,  // 6: api.contract.UpdateRoomDto.settings:type_name -> api.contract.RoomSettingsDto
+	32,  // 7: api.contract.GetNigiriDto.log:type_name -> api.contract.NigiriEventDto
+	33,  // 8: api.contract.NigiriList.nigiri:type_name -> api.contract.GetNigiriDto
+	3,   // 9: api.contract.GetRoomDto.players:type_name -> api.contract.GetPlayerDto
+	39,  // 10: api.contract.GetRoomDto.game:type_name -> api.contract.GetGameDto
+	33,  // 11: api.contract.GetRoomDto.nigiri:type_name -> api.contract.GetNigiriDto
+	24,  // 12: api.contract.GetRoomDto.settings:type_name -> api.contract.RoomSettingsDto
+	25,  // 13: api.contract.GetRoomDto.teams:type_name -> api.contract.TeamDto
+	40,  // 14: api.contract.GetGameDto.clock:type_name -> api.contract.ClockDto
+	39,  // 15: api.contract.GameEvent.game:type_name -> api.contract.GetGameDto
+	41,  // 16: api.contract.GameEvent.move:type_name -> api.contract.GameMoveDto
+	40,  // 17: api.contract.GameEvent.clock:type_name -> api.contract.ClockDto
+	42,  // 18: api.contract.GameEvent.result:type_name -> api.contract.GameResultDto
+	0,   // 19: api.contract.PlayCommand.sit:type_name -> api.contract.RequestEntity
+	45,  // 20: api.contract.PlayCommand.move:type_name -> api.contract.PointDto
+	62,  // 21: api.contract.PlayCommand.pass:type_name -> google.protobuf.Empty
+	62,  // 22: api.contract.PlayCommand.resign:type_name -> google.protobuf.Empty
+	62,  // 23: api.contract.PlayCommand.takeback:type_name -> google.protobuf.Empty
+	62,  // 24: api.contract.PlayCommand.accept_score:type_name -> google.protobuf.Empty
+	62,  // 25: api.contract.PlayCommand.resume_play:type_name -> google.protobuf.Empty
+	47,  // 26: api.contract.PlayCommand.chat:type_name -> api.contract.PostChatMessageDto
+	62,  // 27: api.contract.PlayCommand.accept_takeback:type_name -> google.protobuf.Empty
+	62,  // 28: api.contract.PlayCommand.decline_takeback:type_name -> google.protobuf.Empty
+	35,  // 29: api.contract.PlayEvent.room:type_name -> api.contract.GetRoomDto
+	43,  // 30: api.contract.PlayEvent.game:type_name -> api.contract.GameEvent
+	49,  // 31: api.contract.PlayEvent.error:type_name -> api.contract.PlayError
+	48,  // 32: api.contract.PlayEvent.chat:type_name -> api.contract.ChatMessageDto
+	24,  // 33: api.contract.CreateSimulDto.settings:type_name -> api.contract.RoomSettingsDto
+	39,  // 34: api.contract.SimulBoardDto.game:type_name -> api.contract.GetGameDto
+	24,  // 35: api.contract.GetSimulDto.settings:type_name -> api.contract.RoomSettingsDto
+	3,   // 36: api.contract.GetSimulDto.opponents:type_name -> api.contract.GetPlayerDto
+	54,  // 37: api.contract.GetSimulDto.boards:type_name -> api.contract.SimulBoardDto
+	55,  // 38: api.contract.SimulList.simuls:type_name -> api.contract.GetSimulDto
+	54,  // 39: api.contract.SimulBoardList.boards:type_name -> api.contract.SimulBoardDto
+	3,   // 40: api.contract.PlayerList.players:type_name -> api.contract.GetPlayerDto
+	35,  // 41: api.contract.RoomList.rooms:type_name -> api.contract.GetRoomDto
+	38,  // 42: api.contract.BoardList.boards:type_name -> api.contract.GetBoardDto
+	39,  // 43: api.contract.GameList.games:type_name -> api.contract.GetGameDto
+	4,   // 44: api.contract.AuthService.Register:input_type -> api.contract.CredentialsDto
+	4,   // 45: api.contract.AuthService.Login:input_type -> api.contract.CredentialsDto
+	14,  // 46: api.contract.AuthService.Refresh:input_type -> api.contract.RefreshTokenDto
+	62,  // 47: api.contract.AuthService.Logout:input_type -> google.protobuf.Empty
+	62,  // 48: api.contract.AuthService.LogoutEverywhere:input_type -> google.protobuf.Empty
+	62,  // 49: api.contract.AuthService.ListSessions:input_type -> google.protobuf.Empty
+	17,  // 50: api.contract.AuthService.RevokeSession:input_type -> api.contract.RevokeSessionDto
+	6,   // 51: api.contract.AuthService.VerifyTwoFactor:input_type -> api.contract.TwoFactorLoginDto
+	62,  // 52: api.contract.AuthService.SetupTwoFactor:input_type -> google.protobuf.Empty
+	8,   // 53: api.contract.AuthService.EnableTwoFactor:input_type -> api.contract.TwoFactorCodeDto
+	8,   // 54: api.contract.AuthService.DisableTwoFactor:input_type -> api.contract.TwoFactorCodeDto
+	8,   // 55: api.contract.AuthService.RegenerateRecoveryCodes:input_type -> api.contract.TwoFactorCodeDto
+	62,  // 56: api.contract.AuthService.GetEmail:input_type -> google.protobuf.Empty
+	10,  // 57: api.contract.AuthService.SetEmail:input_type -> api.contract.EmailDto
+	62,  // 58: api.contract.AuthService.ResendVerificationEmail:input_type -> google.protobuf.Empty
+	12,  // 59: api.contract.AuthService.VerifyEmail:input_type -> api.contract.VerifyEmailDto
+	10,  // 60: api.contract.AuthService.RequestPasswordReset:input_type -> api.contract.EmailDto
+	13,  // 61: api.contract.AuthService.ResetPassword:input_type -> api.contract.ResetPasswordDto
+	0,   // 62: api.contract.PlayerService.GetPlayer:input_type -> api.contract.RequestEntity
+	62,  // 63: api.contract.PlayerService.GetAllPlayers:input_type -> google.protobuf.Empty
+	1,   // 64: api.contract.PlayerService.CreatePlayer:input_type -> api.contract.CreatePlayerDto
+	2,   // 65: api.contract.PlayerService.UpdatePlayer:input_type -> api.contract.UpdatePlayerDto
+	0,   // 66: api.contract.PlayerService.DeletePlayer:input_type -> api.contract.RequestEntity
+	0,   // 67: api.contract.RoomService.GetRoom:input_type -> api.contract.RequestEntity
+	51,  // 68: api.contract.RoomService.GetAllRooms:input_type -> api.contract.RoomFilterDto
+	22,  // 69: api.contract.RoomService.CreateRoom:input_type -> api.contract.CreateRoomDto
+	27,  // 70: api.contract.RoomService.UpdateRoom:input_type -> api.contract.UpdateRoomDto
+	0,   // 71: api.contract.RoomService.DeleteRoom:input_type -> api.contract.RequestEntity
+	0,   // 72: api.contract.RoomService.JoinRoom:input_type -> api.contract.RequestEntity
+	26,  // 73: api.contract.RoomService.JoinRoomByCode:input_type -> api.contract.JoinRoomByCodeDto
+	0,   // 74: api.contract.RoomService.LeaveRoom:input_type -> api.contract.RequestEntity
+	0,   // 75: api.contract.RoomService.Spectate:input_type -> api.contract.RequestEntity
+	0,   // 76: api.contract.RoomService.StopSpectating:input_type -> api.contract.RequestEntity
+	52,  // 77: api.contract.RoomService.StartGame:input_type -> api.contract.StartGameDto
+	44,  // 78: api.contract.RoomService.PlayMove:input_type -> api.contract.MoveDto
+	0,   // 79: api.contract.RoomService.Pass:input_type -> api.contract.RequestEntity
+	0,   // 80: api.contract.RoomService.Resign:input_type -> api.contract.RequestEntity
+	0,   // 81: api.contract.RoomService.AcceptScore:input_type -> api.contract.RequestEntity
+	0,   // 82: api.contract.RoomService.ResumePlay:input_type -> api.contract.RequestEntity
+	0,   // 83: api.contract.RoomService.Takeback:input_type -> api.contract.RequestEntity
+	0,   // 84: api.contract.RoomService.AcceptTakeback:input_type -> api.contract.RequestEntity
+	0,   // 85: api.contract.RoomService.DeclineTakeback:input_type -> api.contract.RequestEntity
+	28,  // 86: api.contract.RoomService.CommitNigiri:input_type -> api.contract.NigiriCommitDto
+	29,  // 87: api.contract.RoomService.GuessNigiri:input_type -> api.contract.NigiriGuessDto
+	30,  // 88: api.contract.RoomService.RevealNigiri:input_type -> api.contract.NigiriRevealDto
+	31,  // 89: api.contract.RoomService.ClaimNigiri:input_type -> api.contract.NigiriClaimDto
+	0,   // 90: api.contract.RoomService.GetNigiriAudit:input_type -> api.contract.RequestEntity
+	46,  // 91: api.contract.RoomService.PlaySession:input_type -> api.contract.PlayCommand
+	0,   // 92: api.contract.SimulService.GetSimul:input_type -> api.contract.RequestEntity
+	62,  // 93: api.contract.SimulService.GetAllSimuls:input_type -> google.protobuf.Empty
+	53,  // 94: api.contract.SimulService.CreateSimul:input_type -> api.contract.CreateSimulDto
+	0,   // 95: api.contract.SimulService.JoinSimul:input_type -> api.contract.RequestEntity
+	0,   // 96: api.contract.SimulService.LeaveSimul:input_type -> api.contract.RequestEntity
+	0,   // 97: api.contract.SimulService.StartSimul:input_type -> api.contract.RequestEntity
+	0,   // 98: api.contract.SimulService.GetSimulQueue:input_type -> api.contract.RequestEntity
+	0,   // 99: api.contract.BoardService.GetBoard:input_type -> api.contract.RequestEntity
+	62,  // 100: api.contract.BoardService.GetAllBoards:input_type -> google.protobuf.Empty
+	36,  // 101: api.contract.BoardService.CreateBoard:input_type -> api.contract.CreateBoardDto
+	37,  // 102: api.contract.BoardService.UpdateBoard:input_type -> api.contract.UpdateBoardDto
+	0,   // 103: api.contract.BoardService.DeleteBoard:input_type -> api.contract.RequestEntity
+	0,   // 104: api.contract.GameService.GetGame:input_type -> api.contract.RequestEntity
+	62,  // 105: api.contract.GameService.GetAllGames:input_type -> google.protobuf.Empty
+	62,  // 106: api.contract.GameService.CreateGame:input_type -> google.protobuf.Empty
+	0,   // 107: api.contract.GameService.DeleteGame:input_type -> api.contract.RequestEntity
+	0,   // 108: api.contract.GameService.WatchGame:input_type -> api.contract.RequestEntity
+	18,  // 109: api.contract.AdminService.AdjudicateGame:input_type -> api.contract.AdjudicateGameDto
+	62,  // 110: api.contract.AdminService.ListUsers:input_type -> google.protobuf.Empty
+	21,  // 111: api.contract.AdminService.SetUserRole:input_type -> api.contract.SetUserRoleDto
+	0,   // 112: api.contract.AdminService.DeleteUser:input_type -> api.contract.RequestEntity
+	5,   // 113: api.contract.AuthService.Register:output_type -> api.contract.TokenDto
+	5,   // 114: api.contract.AuthService.Login:output_type -> api.contract.TokenDto
+	5,   // 115: api.contract.AuthService.Refresh:output_type -> api.contract.TokenDto
+	62,  // 116: api.contract.AuthService.Logout:output_type -> google.protobuf.Empty
+	62,  // 117: api.contract.AuthService.LogoutEverywhere:output_type -> google.protobuf.Empty
+	16,  // 118: api.contract.AuthService.ListSessions:output_type -> api.contract.SessionList
+	62,  // 119: api.contract.AuthService.RevokeSession:output_type -> google.protobuf.Empty
+	5,   // 120: api.contract.AuthService.VerifyTwoFactor:output_type -> api.contract.TokenDto
+	7,   // 121: api.contract.AuthService.SetupTwoFactor:output_type -> api.contract.TwoFactorSetupDto
+	9,   // 122: api.contract.AuthService.EnableTwoFactor:output_type -> api.contract.RecoveryCodesDto
+	62,  // 123: api.contract.AuthService.DisableTwoFactor:output_type -> google.protobuf.Empty
+	9,   // 124: api.contract.AuthService.RegenerateRecoveryCodes:output_type -> api.contract.RecoveryCodesDto
+	11,  // 125: api.contract.AuthService.GetEmail:output_type -> api.contract.AccountEmailDto
+	11,  // 126: api.contract.AuthService.SetEmail:output_type -> api.contract.AccountEmailDto
+	62,  // 127: api.contract.AuthService.ResendVerificationEmail:output_type -> google.protobuf.Empty
+	11,  // 128: api.contract.AuthService.VerifyEmail:output_type -> api.contract.AccountEmailDto
+	62,  // 129: api.contract.AuthService.RequestPasswordReset:output_type -> google.protobuf.Empty
+	62,  // 130: api.contract.AuthService.ResetPassword:output_type -> google.protobuf.Empty
+	3,   // 131: api.contract.PlayerService.GetPlayer:output_type -> api.contract.GetPlayerDto
+	58,  // 132: api.contract.PlayerService.GetAllPlayers:output_type -> api.contract.PlayerList
+	3,   // 133: api.contract.PlayerService.CreatePlayer:output_type -> api.contract.GetPlayerDto
+	3,   // 134: api.contract.PlayerService.UpdatePlayer:output_type -> api.contract.GetPlayerDto
+	62,  // 135: api.contract.PlayerService.DeletePlayer:output_type -> google.protobuf.Empty
+	35,  // 136: api.contract.RoomService.GetRoom:output_type -> api.contract.GetRoomDto
+	59,  // 137: api.contract.RoomService.GetAllRooms:output_type -> api.contract.RoomList
+	35,  // 138: api.contract.RoomService.CreateRoom:output_type -> api.contract.GetRoomDto
+	35,  // 139: api.contract.RoomService.UpdateRoom:output_type -> api.contract.GetRoomDto
+	62,  // 140: api.contract.RoomService.DeleteRoom:output_type -> google.protobuf.Empty
+	35,  // 141: api.contract.RoomService.JoinRoom:output_type -> api.contract.GetRoomDto
+	35,  // 142: api.contract.RoomService.JoinRoomByCode:output_type -> api.contract.GetRoomDto
+	35,  // 143: api.contract.RoomService.LeaveRoom:output_type -> api.contract.GetRoomDto
+	35,  // 144: api.contract.RoomService.Spectate:output_type -> api.contract.GetRoomDto
+	35,  // 145: api.contract.RoomService.StopSpectating:output_type -> api.contract.GetRoomDto
+	35,  // 146: api.contract.RoomService.StartGame:output_type -> api.contract.GetRoomDto
+	35,  // 147: api.contract.RoomService.PlayMove:output_type -> api.contract.GetRoomDto
+	35,  // 148: api.contract.RoomService.Pass:output_type -> api.contract.GetRoomDto
+	35,  // 149: api.contract.RoomService.Resign:output_type -> api.contract.GetRoomDto
+	35,  // 150: api.contract.RoomService.AcceptScore:output_type -> api.contract.GetRoomDto
+	35,  // 151: api.contract.RoomService.ResumePlay:output_type -> api.contract.GetRoomDto
+	35,  // 152: api.contract.RoomService.Takeback:output_type -> api.contract.GetRoomDto
+	35,  // 153: api.contract.RoomService.AcceptTakeback:output_type -> api.contract.GetRoomDto
+	35,  // 154: api.contract.RoomService.DeclineTakeback:output_type -> api.contract.GetRoomDto
+	35,  // 155: api.contract.RoomService.CommitNigiri:output_type -> api.contract.GetRoomDto
+	35,  // 156: api.contract.RoomService.GuessNigiri:output_type -> api.contract.GetRoomDto
+	35,  // 157: api.contract.RoomService.RevealNigiri:output_type -> api.contract.GetRoomDto
+	35,  // 158: api.contract.RoomService.ClaimNigiri:output_type -> api.contract.GetRoomDto
+	34,  // 159: api.contract.RoomService.GetNigiriAudit:output_type -> api.contract.NigiriList
+	50,  // 160: api.contract.RoomService.PlaySession:output_type -> api.contract.PlayEvent
+	55,  // 161: api.contract.SimulService.GetSimul:output_type -> api.contract.GetSimulDto
+	56,  // 162: api.contract.SimulService.GetAllSimuls:output_type -> api.contract.SimulList
+	55,  // 163: api.contract.SimulService.CreateSimul:output_type -> api.contract.GetSimulDto
+	55,  // 164: api.contract.SimulService.JoinSimul:output_type -> api.contract.GetSimulDto
+	55,  // 165: api.contract.SimulService.LeaveSimul:output_type -> api.contract.GetSimulDto
+	55,  // 166: api.contract.SimulService.StartSimul:output_type -> api.contract.GetSimulDto
+	57,  // 167: api.contract.SimulService.GetSimulQueue:output_type -> api.contract.SimulBoardList
+	38,  // 168: api.contract.BoardService.GetBoard:output_type -> api.contract.GetBoardDto
+	60,  // 169: api.contract.BoardService.GetAllBoards:output_type -> api.contract.BoardList
+	38,  // 170: api.contract.BoardService.CreateBoard:output_type -> api.contract.GetBoardDto
+	38,  // 171: api.contract.BoardService.UpdateBoard:output_type -> api.contract.GetBoardDto
+	62,  // 172: api.contract.BoardService.DeleteBoard:output_type -> google.protobuf.Empty
+	39,  // 173: api.contract.GameService.GetGame:output_type -> api.contract.GetGameDto
+	61,  // 174: api.contract.GameService.GetAllGames:output_type -> api.contract.GameList
+	39,  // 175: api.contract.GameService.CreateGame:output_type -> api.contract.GetGameDto
+	62,  // 176: api.contract.GameService.DeleteGame:output_type -> google.protobuf.Empty
+	43,  // 177: api.contract.GameService.WatchGame:output_type -> api.contract.GameEvent
+	35,  // 178: api.contract.AdminService.AdjudicateGame:output_type -> api.contract.GetRoomDto
+	20,  // 179: api.contract.AdminService.ListUsers:output_type -> api.contract.UserList
+	19,  // 180: api.contract.AdminService.SetUserRole:output_type -> api.contract.UserDto
+	62,  // 181: api.contract.AdminService.DeleteUser:output_type -> google.protobuf.Empty
+	113, // [113:182] is the sub-list for method output_type
+	44,  // [44:113] is the sub-list for method input_type
+	44,  // [44:44] is the sub-list for extension type_name
+	44,  // [44:44] is the sub-list for extension extendee
+	0,   // [0:44] is the sub-list for field type_name
 }
 
 func init() { file_contract_proto_init() }
@@ -889,15 +4907,43 @@ func file_contract_proto_init() {
 	if File_contract_proto != nil {
 		return
 	}
+	file_contract_proto_msgTypes[24].OneofWrappers = []any{}
+	file_contract_proto_msgTypes[43].OneofWrappers = []any{
+		(*GameEvent_Game)(nil),
+		(*GameEvent_Move)(nil),
+		(*GameEvent_MoveCount)(nil),
+		(*GameEvent_Clock)(nil),
+		(*GameEvent_Result)(nil),
+		(*GameEvent_PlayerId)(nil),
+	}
+	file_contract_proto_msgTypes[46].OneofWrappers = []any{
+		(*PlayCommand_Sit)(nil),
+		(*PlayCommand_Move)(nil),
+		(*PlayCommand_Pass)(nil),
+		(*PlayCommand_Resign)(nil),
+		(*PlayCommand_Takeback)(nil),
+		(*PlayCommand_AcceptScore)(nil),
+		(*PlayCommand_ResumePlay)(nil),
+		(*PlayCommand_Chat)(nil),
+		(*PlayCommand_AcceptTakeback)(nil),
+		(*PlayCommand_DeclineTakeback)(nil),
+	}
+	file_contract_proto_msgTypes[50].OneofWrappers = []any{
+		(*PlayEvent_Room)(nil),
+		(*PlayEvent_Game)(nil),
+		(*PlayEvent_Error)(nil),
+		(*PlayEvent_Chat)(nil),
+		(*PlayEvent_DeletedChatMessageId)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_contract_proto_rawDesc), len(file_contract_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   62,
 			NumExtensions: 0,
-			NumServices:   4,
+			NumServices:   7,
 		},
 		GoTypes:           file_contract_proto_goTypes,
 		DependencyIndexes: file_contract_proto_depIdxs,
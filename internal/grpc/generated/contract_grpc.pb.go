@@ -20,515 +20,2440 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	PlayerService_GetPlayer_FullMethodName     = "/api.contract.PlayerService/GetPlayer"
-	PlayerService_GetAllPlayers_FullMethodName = "/api.contract.PlayerService/GetAllPlayers"
-	PlayerService_CreatePlayer_FullMethodName  = "/api.contract.PlayerService/CreatePlayer"
-	PlayerService_UpdatePlayer_FullMethodName  = "/api.contract.PlayerService/UpdatePlayer"
-	PlayerService_DeletePlayer_FullMethodName  = "/api.contract.PlayerService/DeletePlayer"
+	AuthService_Register_FullMethodName                = "/api.contract.AuthService/Register"
+	AuthService_Login_FullMethodName                   = "/api.contract.AuthService/Login"
+	AuthService_Refresh_FullMethodName                 = "/api.contract.AuthService/Refresh"
+	AuthService_Logout_FullMethodName                  = "/api.contract.AuthService/Logout"
+	AuthService_LogoutEverywhere_FullMethodName        = "/api.contract.AuthService/LogoutEverywhere"
+	AuthService_ListSessions_FullMethodName            = "/api.contract.AuthService/ListSessions"
+	AuthService_RevokeSession_FullMethodName           = "/api.contract.AuthService/RevokeSession"
+	AuthService_VerifyTwoFactor_FullMethodName         = "/api.contract.AuthService/VerifyTwoFactor"
+	AuthService_SetupTwoFactor_FullMethodName          = "/api.contract.AuthService/SetupTwoFactor"
+	AuthService_EnableTwoFactor_FullMethodName         = "/api.contract.AuthService/EnableTwoFactor"
+	AuthService_DisableTwoFactor_FullMethodName        = "/api.contract.AuthService/DisableTwoFactor"
+	AuthService_RegenerateRecoveryCodes_FullMethodName = "/api.contract.AuthService/RegenerateRecoveryCodes"
+	AuthService_GetEmail_FullMethodName                = "/api.contract.AuthService/GetEmail"
+	AuthService_SetEmail_FullMethodName                = "/api.contract.AuthService/SetEmail"
+	AuthService_ResendVerificationEmail_FullMethodName = "/api.contract.AuthService/ResendVerificationEmail"
+	AuthService_VerifyEmail_FullMethodName             = "/api.contract.AuthService/VerifyEmail"
+	AuthService_RequestPasswordReset_FullMethodName    = "/api.contract.AuthService/RequestPasswordReset"
+	AuthService_ResetPassword_FullMethodName           = "/api.contract.AuthService/ResetPassword"
 )
 
-// PlayerServiceClient is the client API for PlayerService service.
+// AuthServiceClient is the client API for AuthService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
-// Player service
-type PlayerServiceClient interface {
-	GetPlayer(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetPlayerDto, error)
-	GetAllPlayers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PlayerList, error)
-	CreatePlayer(ctx context.Context, in *CreatePlayerDto, opts ...grpc.CallOption) (*GetPlayerDto, error)
-	UpdatePlayer(ctx context.Context, in *UpdatePlayerDto, opts ...grpc.CallOption) (*GetPlayerDto, error)
-	DeletePlayer(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*emptypb.Empty, error)
+// Auth service
+type AuthServiceClient interface {
+	// Creates a player named after the username and returns an access token for it.
+	Register(ctx context.Context, in *CredentialsDto, opts ...grpc.CallOption) (*TokenDto, error)
+	// Returns an access token to send as "authorization: Bearer <token>" metadata.
+	Login(ctx context.Context, in *CredentialsDto, opts ...grpc.CallOption) (*TokenDto, error)
+	// Exchanges a refresh token for new tokens. Using a refresh token twice
+	// revokes its session.
+	Refresh(ctx context.Context, in *RefreshTokenDto, opts ...grpc.CallOption) (*TokenDto, error)
+	// Revokes the session of the bearer token.
+	Logout(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Revokes every session of the player identified by the bearer token.
+	LogoutEverywhere(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Lists the active sessions of the player, the most recently used first.
+	ListSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SessionList, error)
+	RevokeSession(ctx context.Context, in *RevokeSessionDto, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Completes a login that returned a two-factor token. A login is dropped
+	// after 5 wrong codes, and after 10 wrong codes in a row over all logins
+	// the account's codes are refused with RESOURCE_EXHAUSTED for a while.
+	VerifyTwoFactor(ctx context.Context, in *TwoFactorLoginDto, opts ...grpc.CallOption) (*TokenDto, error)
+	// Returns a new TOTP secret, enabled by EnableTwoFactor with a first code.
+	SetupTwoFactor(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TwoFactorSetupDto, error)
+	EnableTwoFactor(ctx context.Context, in *TwoFactorCodeDto, opts ...grpc.CallOption) (*RecoveryCodesDto, error)
+	DisableTwoFactor(ctx context.Context, in *TwoFactorCodeDto, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RegenerateRecoveryCodes(ctx context.Context, in *TwoFactorCodeDto, opts ...grpc.CallOption) (*RecoveryCodesDto, error)
+	GetEmail(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AccountEmailDto, error)
+	// Sets the email address of the account and sends a link to verify it, at
+	// most once a minute.
+	SetEmail(ctx context.Context, in *EmailDto, opts ...grpc.CallOption) (*AccountEmailDto, error)
+	ResendVerificationEmail(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	VerifyEmail(ctx context.Context, in *VerifyEmailDto, opts ...grpc.CallOption) (*AccountEmailDto, error)
+	// Sends a password reset token to the verified email address of an
+	// account. The response is the same whether or not such an account exists.
+	RequestPasswordReset(ctx context.Context, in *EmailDto, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Sets a new password and revokes every session of the player.
+	ResetPassword(ctx context.Context, in *ResetPasswordDto, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type authServiceClient struct {
+	cc grpc.ClientConnInterface
 }
 
-type playerServiceClient struct {
-	cc grpc.ClientConnInterface
+func NewAuthServiceClient(cc grpc.ClientConnInterface) AuthServiceClient {
+	return &authServiceClient{cc}
 }
 
-func NewPlayerServiceClient(cc grpc.ClientConnInterface) PlayerServiceClient {
-	return &playerServiceClient{cc}
+func (c *authServiceClient) Register(ctx context.Context, in *CredentialsDto, opts ...grpc.CallOption) (*TokenDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenDto)
+	err := c.cc.Invoke(ctx, AuthService_Register_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *playerServiceClient) GetPlayer(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetPlayerDto, error) {
+func (c *authServiceClient) Login(ctx context.Context, in *CredentialsDto, opts ...grpc.CallOption) (*TokenDto, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetPlayerDto)
-	err := c.cc.Invoke(ctx, PlayerService_GetPlayer_FullMethodName, in, out, cOpts...)
+	out := new(TokenDto)
+	err := c.cc.Invoke(ctx, AuthService_Login_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *playerServiceClient) GetAllPlayers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PlayerList, error) {
+func (c *authServiceClient) Refresh(ctx context.Context, in *RefreshTokenDto, opts ...grpc.CallOption) (*TokenDto, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(PlayerList)
-	err := c.cc.Invoke(ctx, PlayerService_GetAllPlayers_FullMethodName, in, out, cOpts...)
+	out := new(TokenDto)
+	err := c.cc.Invoke(ctx, AuthService_Refresh_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *playerServiceClient) CreatePlayer(ctx context.Context, in *CreatePlayerDto, opts ...grpc.CallOption) (*GetPlayerDto, error) {
+func (c *authServiceClient) Logout(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetPlayerDto)
-	err := c.cc.Invoke(ctx, PlayerService_CreatePlayer_FullMethodName, in, out, cOpts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_Logout_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *playerServiceClient) UpdatePlayer(ctx context.Context, in *UpdatePlayerDto, opts ...grpc.CallOption) (*GetPlayerDto, error) {
+func (c *authServiceClient) LogoutEverywhere(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetPlayerDto)
-	err := c.cc.Invoke(ctx, PlayerService_UpdatePlayer_FullMethodName, in, out, cOpts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_LogoutEverywhere_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *playerServiceClient) DeletePlayer(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *authServiceClient) ListSessions(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SessionList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SessionList)
+	err := c.cc.Invoke(ctx, AuthService_ListSessions_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RevokeSession(ctx context.Context, in *RevokeSessionDto, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, PlayerService_DeletePlayer_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, AuthService_RevokeSession_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// PlayerServiceServer is the server API for PlayerService service.
-// All implementations must embed UnimplementedPlayerServiceServer
-// for forward compatibility.
-//
-// Player service
-type PlayerServiceServer interface {
-	GetPlayer(context.Context, *RequestEntity) (*GetPlayerDto, error)
-	GetAllPlayers(context.Context, *emptypb.Empty) (*PlayerList, error)
-	CreatePlayer(context.Context, *CreatePlayerDto) (*GetPlayerDto, error)
-	UpdatePlayer(context.Context, *UpdatePlayerDto) (*GetPlayerDto, error)
-	DeletePlayer(context.Context, *RequestEntity) (*emptypb.Empty, error)
-	mustEmbedUnimplementedPlayerServiceServer()
+func (c *authServiceClient) VerifyTwoFactor(ctx context.Context, in *TwoFactorLoginDto, opts ...grpc.CallOption) (*TokenDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TokenDto)
+	err := c.cc.Invoke(ctx, AuthService_VerifyTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-// UnimplementedPlayerServiceServer must be embedded to have
+func (c *authServiceClient) SetupTwoFactor(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*TwoFactorSetupDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(TwoFactorSetupDto)
+	err := c.cc.Invoke(ctx, AuthService_SetupTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) EnableTwoFactor(ctx context.Context, in *TwoFactorCodeDto, opts ...grpc.CallOption) (*RecoveryCodesDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecoveryCodesDto)
+	err := c.cc.Invoke(ctx, AuthService_EnableTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) DisableTwoFactor(ctx context.Context, in *TwoFactorCodeDto, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_DisableTwoFactor_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RegenerateRecoveryCodes(ctx context.Context, in *TwoFactorCodeDto, opts ...grpc.CallOption) (*RecoveryCodesDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RecoveryCodesDto)
+	err := c.cc.Invoke(ctx, AuthService_RegenerateRecoveryCodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) GetEmail(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*AccountEmailDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountEmailDto)
+	err := c.cc.Invoke(ctx, AuthService_GetEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) SetEmail(ctx context.Context, in *EmailDto, opts ...grpc.CallOption) (*AccountEmailDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountEmailDto)
+	err := c.cc.Invoke(ctx, AuthService_SetEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ResendVerificationEmail(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_ResendVerificationEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) VerifyEmail(ctx context.Context, in *VerifyEmailDto, opts ...grpc.CallOption) (*AccountEmailDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AccountEmailDto)
+	err := c.cc.Invoke(ctx, AuthService_VerifyEmail_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) RequestPasswordReset(ctx context.Context, in *EmailDto, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_RequestPasswordReset_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authServiceClient) ResetPassword(ctx context.Context, in *ResetPasswordDto, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AuthService_ResetPassword_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AuthServiceServer is the server API for AuthService service.
+// All implementations must embed UnimplementedAuthServiceServer
+// for forward compatibility.
+//
+// Auth service
+type AuthServiceServer interface {
+	// Creates a player named after the username and returns an access token for it.
+	Register(context.Context, *CredentialsDto) (*TokenDto, error)
+	// Returns an access token to send as "authorization: Bearer <token>" metadata.
+	Login(context.Context, *CredentialsDto) (*TokenDto, error)
+	// Exchanges a refresh token for new tokens. Using a refresh token twice
+	// revokes its session.
+	Refresh(context.Context, *RefreshTokenDto) (*TokenDto, error)
+	// Revokes the session of the bearer token.
+	Logout(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Revokes every session of the player identified by the bearer token.
+	LogoutEverywhere(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	// Lists the active sessions of the player, the most recently used first.
+	ListSessions(context.Context, *emptypb.Empty) (*SessionList, error)
+	RevokeSession(context.Context, *RevokeSessionDto) (*emptypb.Empty, error)
+	// Completes a login that returned a two-factor token. A login is dropped
+	// after 5 wrong codes, and after 10 wrong codes in a row over all logins
+	// the account's codes are refused with RESOURCE_EXHAUSTED for a while.
+	VerifyTwoFactor(context.Context, *TwoFactorLoginDto) (*TokenDto, error)
+	// Returns a new TOTP secret, enabled by EnableTwoFactor with a first code.
+	SetupTwoFactor(context.Context, *emptypb.Empty) (*TwoFactorSetupDto, error)
+	EnableTwoFactor(context.Context, *TwoFactorCodeDto) (*RecoveryCodesDto, error)
+	DisableTwoFactor(context.Context, *TwoFactorCodeDto) (*emptypb.Empty, error)
+	RegenerateRecoveryCodes(context.Context, *TwoFactorCodeDto) (*RecoveryCodesDto, error)
+	GetEmail(context.Context, *emptypb.Empty) (*AccountEmailDto, error)
+	// Sets the email address of the account and sends a link to verify it, at
+	// most once a minute.
+	SetEmail(context.Context, *EmailDto) (*AccountEmailDto, error)
+	ResendVerificationEmail(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
+	VerifyEmail(context.Context, *VerifyEmailDto) (*AccountEmailDto, error)
+	// Sends a password reset token to the verified email address of an
+	// account. The response is the same whether or not such an account exists.
+	RequestPasswordReset(context.Context, *EmailDto) (*emptypb.Empty, error)
+	// Sets a new password and revokes every session of the player.
+	ResetPassword(context.Context, *ResetPasswordDto) (*emptypb.Empty, error)
+	mustEmbedUnimplementedAuthServiceServer()
+}
+
+// UnimplementedAuthServiceServer must be embedded to have
 // forward compatible implementations.
 //
 // NOTE: this should be embedded by value instead of pointer to avoid a nil
 // pointer dereference when methods are called.
-type UnimplementedPlayerServiceServer struct{}
+type UnimplementedAuthServiceServer struct{}
 
-func (UnimplementedPlayerServiceServer) GetPlayer(context.Context, *RequestEntity) (*GetPlayerDto, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPlayer not implemented")
+func (UnimplementedAuthServiceServer) Register(context.Context, *CredentialsDto) (*TokenDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Register not implemented")
 }
-func (UnimplementedPlayerServiceServer) GetAllPlayers(context.Context, *emptypb.Empty) (*PlayerList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllPlayers not implemented")
+func (UnimplementedAuthServiceServer) Login(context.Context, *CredentialsDto) (*TokenDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
 }
-func (UnimplementedPlayerServiceServer) CreatePlayer(context.Context, *CreatePlayerDto) (*GetPlayerDto, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreatePlayer not implemented")
+func (UnimplementedAuthServiceServer) Refresh(context.Context, *RefreshTokenDto) (*TokenDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refresh not implemented")
 }
-func (UnimplementedPlayerServiceServer) UpdatePlayer(context.Context, *UpdatePlayerDto) (*GetPlayerDto, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdatePlayer not implemented")
+func (UnimplementedAuthServiceServer) Logout(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Logout not implemented")
 }
-func (UnimplementedPlayerServiceServer) DeletePlayer(context.Context, *RequestEntity) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeletePlayer not implemented")
+func (UnimplementedAuthServiceServer) LogoutEverywhere(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LogoutEverywhere not implemented")
 }
-func (UnimplementedPlayerServiceServer) mustEmbedUnimplementedPlayerServiceServer() {}
-func (UnimplementedPlayerServiceServer) testEmbeddedByValue()                       {}
+func (UnimplementedAuthServiceServer) ListSessions(context.Context, *emptypb.Empty) (*SessionList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSessions not implemented")
+}
+func (UnimplementedAuthServiceServer) RevokeSession(context.Context, *RevokeSessionDto) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeSession not implemented")
+}
+func (UnimplementedAuthServiceServer) VerifyTwoFactor(context.Context, *TwoFactorLoginDto) (*TokenDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyTwoFactor not implemented")
+}
+func (UnimplementedAuthServiceServer) SetupTwoFactor(context.Context, *emptypb.Empty) (*TwoFactorSetupDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetupTwoFactor not implemented")
+}
+func (UnimplementedAuthServiceServer) EnableTwoFactor(context.Context, *TwoFactorCodeDto) (*RecoveryCodesDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EnableTwoFactor not implemented")
+}
+func (UnimplementedAuthServiceServer) DisableTwoFactor(context.Context, *TwoFactorCodeDto) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisableTwoFactor not implemented")
+}
+func (UnimplementedAuthServiceServer) RegenerateRecoveryCodes(context.Context, *TwoFactorCodeDto) (*RecoveryCodesDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RegenerateRecoveryCodes not implemented")
+}
+func (UnimplementedAuthServiceServer) GetEmail(context.Context, *emptypb.Empty) (*AccountEmailDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetEmail not implemented")
+}
+func (UnimplementedAuthServiceServer) SetEmail(context.Context, *EmailDto) (*AccountEmailDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetEmail not implemented")
+}
+func (UnimplementedAuthServiceServer) ResendVerificationEmail(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResendVerificationEmail not implemented")
+}
+func (UnimplementedAuthServiceServer) VerifyEmail(context.Context, *VerifyEmailDto) (*AccountEmailDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyEmail not implemented")
+}
+func (UnimplementedAuthServiceServer) RequestPasswordReset(context.Context, *EmailDto) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RequestPasswordReset not implemented")
+}
+func (UnimplementedAuthServiceServer) ResetPassword(context.Context, *ResetPasswordDto) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResetPassword not implemented")
+}
+func (UnimplementedAuthServiceServer) mustEmbedUnimplementedAuthServiceServer() {}
+func (UnimplementedAuthServiceServer) testEmbeddedByValue()                     {}
 
-// UnsafePlayerServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to PlayerServiceServer will
+// UnsafeAuthServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AuthServiceServer will
 // result in compilation errors.
-type UnsafePlayerServiceServer interface {
-	mustEmbedUnimplementedPlayerServiceServer()
+type UnsafeAuthServiceServer interface {
+	mustEmbedUnimplementedAuthServiceServer()
 }
 
-func RegisterPlayerServiceServer(s grpc.ServiceRegistrar, srv PlayerServiceServer) {
-	// If the following call pancis, it indicates UnimplementedPlayerServiceServer was
+func RegisterAuthServiceServer(s grpc.ServiceRegistrar, srv AuthServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAuthServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&PlayerService_ServiceDesc, srv)
+	s.RegisterService(&AuthService_ServiceDesc, srv)
 }
 
-func _PlayerService_GetPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestEntity)
+func _AuthService_Register_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CredentialsDto)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlayerServiceServer).GetPlayer(ctx, in)
+		return srv.(AuthServiceServer).Register(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PlayerService_GetPlayer_FullMethodName,
+		FullMethod: AuthService_Register_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlayerServiceServer).GetPlayer(ctx, req.(*RequestEntity))
+		return srv.(AuthServiceServer).Register(ctx, req.(*CredentialsDto))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PlayerService_GetAllPlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _AuthService_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CredentialsDto)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlayerServiceServer).GetAllPlayers(ctx, in)
+		return srv.(AuthServiceServer).Login(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PlayerService_GetAllPlayers_FullMethodName,
+		FullMethod: AuthService_Login_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlayerServiceServer).GetAllPlayers(ctx, req.(*emptypb.Empty))
+		return srv.(AuthServiceServer).Login(ctx, req.(*CredentialsDto))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PlayerService_CreatePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreatePlayerDto)
+func _AuthService_Refresh_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefreshTokenDto)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlayerServiceServer).CreatePlayer(ctx, in)
+		return srv.(AuthServiceServer).Refresh(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PlayerService_CreatePlayer_FullMethodName,
+		FullMethod: AuthService_Refresh_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlayerServiceServer).CreatePlayer(ctx, req.(*CreatePlayerDto))
+		return srv.(AuthServiceServer).Refresh(ctx, req.(*RefreshTokenDto))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PlayerService_UpdatePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdatePlayerDto)
+func _AuthService_Logout_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlayerServiceServer).UpdatePlayer(ctx, in)
+		return srv.(AuthServiceServer).Logout(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PlayerService_UpdatePlayer_FullMethodName,
+		FullMethod: AuthService_Logout_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlayerServiceServer).UpdatePlayer(ctx, req.(*UpdatePlayerDto))
+		return srv.(AuthServiceServer).Logout(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _PlayerService_DeletePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RequestEntity)
+func _AuthService_LogoutEverywhere_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PlayerServiceServer).DeletePlayer(ctx, in)
+		return srv.(AuthServiceServer).LogoutEverywhere(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PlayerService_DeletePlayer_FullMethodName,
+		FullMethod: AuthService_LogoutEverywhere_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PlayerServiceServer).DeletePlayer(ctx, req.(*RequestEntity))
+		return srv.(AuthServiceServer).LogoutEverywhere(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// PlayerService_ServiceDesc is the grpc.ServiceDesc for PlayerService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var PlayerService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "api.contract.PlayerService",
-	HandlerType: (*PlayerServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetPlayer",
-			Handler:    _PlayerService_GetPlayer_Handler,
-		},
-		{
-			MethodName: "GetAllPlayers",
-			Handler:    _PlayerService_GetAllPlayers_Handler,
-		},
-		{
-			MethodName: "CreatePlayer",
-			Handler:    _PlayerService_CreatePlayer_Handler,
-		},
-		{
-			MethodName: "UpdatePlayer",
-			Handler:    _PlayerService_UpdatePlayer_Handler,
-		},
-		{
-			MethodName: "DeletePlayer",
-			Handler:    _PlayerService_DeletePlayer_Handler,
-		},
-	},
-	Streams:  []grpc.StreamDesc{},
-	Metadata: "contract.proto",
+func _AuthService_ListSessions_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ListSessions(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ListSessions_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ListSessions(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
 }
 
-const (
-	RoomService_GetRoom_FullMethodName     = "/api.contract.RoomService/GetRoom"
-	RoomService_GetAllRooms_FullMethodName = "/api.contract.RoomService/GetAllRooms"
-	RoomService_CreateRoom_FullMethodName  = "/api.contract.RoomService/CreateRoom"
-	RoomService_UpdateRoom_FullMethodName  = "/api.contract.RoomService/UpdateRoom"
-	RoomService_DeleteRoom_FullMethodName  = "/api.contract.RoomService/DeleteRoom"
-)
+func _AuthService_RevokeSession_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeSessionDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RevokeSession(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RevokeSession_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RevokeSession(ctx, req.(*RevokeSessionDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
 
-// RoomServiceClient is the client API for RoomService service.
+func _AuthService_VerifyTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwoFactorLoginDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).VerifyTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_VerifyTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).VerifyTwoFactor(ctx, req.(*TwoFactorLoginDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SetupTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SetupTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SetupTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SetupTwoFactor(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_EnableTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwoFactorCodeDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).EnableTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_EnableTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).EnableTwoFactor(ctx, req.(*TwoFactorCodeDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_DisableTwoFactor_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwoFactorCodeDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).DisableTwoFactor(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_DisableTwoFactor_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).DisableTwoFactor(ctx, req.(*TwoFactorCodeDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RegenerateRecoveryCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TwoFactorCodeDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RegenerateRecoveryCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RegenerateRecoveryCodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RegenerateRecoveryCodes(ctx, req.(*TwoFactorCodeDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_GetEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).GetEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_GetEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).GetEmail(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_SetEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmailDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).SetEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_SetEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).SetEmail(ctx, req.(*EmailDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ResendVerificationEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ResendVerificationEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ResendVerificationEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ResendVerificationEmail(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_VerifyEmail_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyEmailDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).VerifyEmail(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_VerifyEmail_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).VerifyEmail(ctx, req.(*VerifyEmailDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_RequestPasswordReset_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EmailDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).RequestPasswordReset(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_RequestPasswordReset_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).RequestPasswordReset(ctx, req.(*EmailDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthService_ResetPassword_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetPasswordDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthServiceServer).ResetPassword(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthService_ResetPassword_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthServiceServer).ResetPassword(ctx, req.(*ResetPasswordDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AuthService_ServiceDesc is the grpc.ServiceDesc for AuthService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AuthService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.contract.AuthService",
+	HandlerType: (*AuthServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Register",
+			Handler:    _AuthService_Register_Handler,
+		},
+		{
+			MethodName: "Login",
+			Handler:    _AuthService_Login_Handler,
+		},
+		{
+			MethodName: "Refresh",
+			Handler:    _AuthService_Refresh_Handler,
+		},
+		{
+			MethodName: "Logout",
+			Handler:    _AuthService_Logout_Handler,
+		},
+		{
+			MethodName: "LogoutEverywhere",
+			Handler:    _AuthService_LogoutEverywhere_Handler,
+		},
+		{
+			MethodName: "ListSessions",
+			Handler:    _AuthService_ListSessions_Handler,
+		},
+		{
+			MethodName: "RevokeSession",
+			Handler:    _AuthService_RevokeSession_Handler,
+		},
+		{
+			MethodName: "VerifyTwoFactor",
+			Handler:    _AuthService_VerifyTwoFactor_Handler,
+		},
+		{
+			MethodName: "SetupTwoFactor",
+			Handler:    _AuthService_SetupTwoFactor_Handler,
+		},
+		{
+			MethodName: "EnableTwoFactor",
+			Handler:    _AuthService_EnableTwoFactor_Handler,
+		},
+		{
+			MethodName: "DisableTwoFactor",
+			Handler:    _AuthService_DisableTwoFactor_Handler,
+		},
+		{
+			MethodName: "RegenerateRecoveryCodes",
+			Handler:    _AuthService_RegenerateRecoveryCodes_Handler,
+		},
+		{
+			MethodName: "GetEmail",
+			Handler:    _AuthService_GetEmail_Handler,
+		},
+		{
+			MethodName: "SetEmail",
+			Handler:    _AuthService_SetEmail_Handler,
+		},
+		{
+			MethodName: "ResendVerificationEmail",
+			Handler:    _AuthService_ResendVerificationEmail_Handler,
+		},
+		{
+			MethodName: "VerifyEmail",
+			Handler:    _AuthService_VerifyEmail_Handler,
+		},
+		{
+			MethodName: "RequestPasswordReset",
+			Handler:    _AuthService_RequestPasswordReset_Handler,
+		},
+		{
+			MethodName: "ResetPassword",
+			Handler:    _AuthService_ResetPassword_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "contract.proto",
+}
+
+const (
+	PlayerService_GetPlayer_FullMethodName     = "/api.contract.PlayerService/GetPlayer"
+	PlayerService_GetAllPlayers_FullMethodName = "/api.contract.PlayerService/GetAllPlayers"
+	PlayerService_CreatePlayer_FullMethodName  = "/api.contract.PlayerService/CreatePlayer"
+	PlayerService_UpdatePlayer_FullMethodName  = "/api.contract.PlayerService/UpdatePlayer"
+	PlayerService_DeletePlayer_FullMethodName  = "/api.contract.PlayerService/DeletePlayer"
+)
+
+// PlayerServiceClient is the client API for PlayerService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 //
-// Room service
-type RoomServiceClient interface {
-	GetRoom(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error)
-	GetAllRooms(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RoomList, error)
-	CreateRoom(ctx context.Context, in *CreateRoomDto, opts ...grpc.CallOption) (*GetRoomDto, error)
-	UpdateRoom(ctx context.Context, in *UpdateRoomDto, opts ...grpc.CallOption) (*GetRoomDto, error)
-	DeleteRoom(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*emptypb.Empty, error)
+// Player service
+type PlayerServiceClient interface {
+	GetPlayer(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetPlayerDto, error)
+	GetAllPlayers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PlayerList, error)
+	CreatePlayer(ctx context.Context, in *CreatePlayerDto, opts ...grpc.CallOption) (*GetPlayerDto, error)
+	UpdatePlayer(ctx context.Context, in *UpdatePlayerDto, opts ...grpc.CallOption) (*GetPlayerDto, error)
+	DeletePlayer(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*emptypb.Empty, error)
 }
 
-type roomServiceClient struct {
+type playerServiceClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewRoomServiceClient(cc grpc.ClientConnInterface) RoomServiceClient {
-	return &roomServiceClient{cc}
+func NewPlayerServiceClient(cc grpc.ClientConnInterface) PlayerServiceClient {
+	return &playerServiceClient{cc}
 }
 
-func (c *roomServiceClient) GetRoom(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error) {
+func (c *playerServiceClient) GetPlayer(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetPlayerDto, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetRoomDto)
-	err := c.cc.Invoke(ctx, RoomService_GetRoom_FullMethodName, in, out, cOpts...)
+	out := new(GetPlayerDto)
+	err := c.cc.Invoke(ctx, PlayerService_GetPlayer_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *roomServiceClient) GetAllRooms(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*RoomList, error) {
+func (c *playerServiceClient) GetAllPlayers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*PlayerList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(RoomList)
-	err := c.cc.Invoke(ctx, RoomService_GetAllRooms_FullMethodName, in, out, cOpts...)
+	out := new(PlayerList)
+	err := c.cc.Invoke(ctx, PlayerService_GetAllPlayers_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *roomServiceClient) CreateRoom(ctx context.Context, in *CreateRoomDto, opts ...grpc.CallOption) (*GetRoomDto, error) {
+func (c *playerServiceClient) CreatePlayer(ctx context.Context, in *CreatePlayerDto, opts ...grpc.CallOption) (*GetPlayerDto, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetRoomDto)
-	err := c.cc.Invoke(ctx, RoomService_CreateRoom_FullMethodName, in, out, cOpts...)
+	out := new(GetPlayerDto)
+	err := c.cc.Invoke(ctx, PlayerService_CreatePlayer_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *roomServiceClient) UpdateRoom(ctx context.Context, in *UpdateRoomDto, opts ...grpc.CallOption) (*GetRoomDto, error) {
+func (c *playerServiceClient) UpdatePlayer(ctx context.Context, in *UpdatePlayerDto, opts ...grpc.CallOption) (*GetPlayerDto, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(GetRoomDto)
-	err := c.cc.Invoke(ctx, RoomService_UpdateRoom_FullMethodName, in, out, cOpts...)
+	out := new(GetPlayerDto)
+	err := c.cc.Invoke(ctx, PlayerService_UpdatePlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *playerServiceClient) DeletePlayer(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, PlayerService_DeletePlayer_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PlayerServiceServer is the server API for PlayerService service.
+// All implementations must embed UnimplementedPlayerServiceServer
+// for forward compatibility.
+//
+// Player service
+type PlayerServiceServer interface {
+	GetPlayer(context.Context, *RequestEntity) (*GetPlayerDto, error)
+	GetAllPlayers(context.Context, *emptypb.Empty) (*PlayerList, error)
+	CreatePlayer(context.Context, *CreatePlayerDto) (*GetPlayerDto, error)
+	UpdatePlayer(context.Context, *UpdatePlayerDto) (*GetPlayerDto, error)
+	DeletePlayer(context.Context, *RequestEntity) (*emptypb.Empty, error)
+	mustEmbedUnimplementedPlayerServiceServer()
+}
+
+// UnimplementedPlayerServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPlayerServiceServer struct{}
+
+func (UnimplementedPlayerServiceServer) GetPlayer(context.Context, *RequestEntity) (*GetPlayerDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPlayer not implemented")
+}
+func (UnimplementedPlayerServiceServer) GetAllPlayers(context.Context, *emptypb.Empty) (*PlayerList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllPlayers not implemented")
+}
+func (UnimplementedPlayerServiceServer) CreatePlayer(context.Context, *CreatePlayerDto) (*GetPlayerDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreatePlayer not implemented")
+}
+func (UnimplementedPlayerServiceServer) UpdatePlayer(context.Context, *UpdatePlayerDto) (*GetPlayerDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdatePlayer not implemented")
+}
+func (UnimplementedPlayerServiceServer) DeletePlayer(context.Context, *RequestEntity) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeletePlayer not implemented")
+}
+func (UnimplementedPlayerServiceServer) mustEmbedUnimplementedPlayerServiceServer() {}
+func (UnimplementedPlayerServiceServer) testEmbeddedByValue()                       {}
+
+// UnsafePlayerServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PlayerServiceServer will
+// result in compilation errors.
+type UnsafePlayerServiceServer interface {
+	mustEmbedUnimplementedPlayerServiceServer()
+}
+
+func RegisterPlayerServiceServer(s grpc.ServiceRegistrar, srv PlayerServiceServer) {
+	// If the following call pancis, it indicates UnimplementedPlayerServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PlayerService_ServiceDesc, srv)
+}
+
+func _PlayerService_GetPlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServiceServer).GetPlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerService_GetPlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServiceServer).GetPlayer(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerService_GetAllPlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServiceServer).GetAllPlayers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerService_GetAllPlayers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServiceServer).GetAllPlayers(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerService_CreatePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePlayerDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServiceServer).CreatePlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerService_CreatePlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServiceServer).CreatePlayer(ctx, req.(*CreatePlayerDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerService_UpdatePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdatePlayerDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServiceServer).UpdatePlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerService_UpdatePlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServiceServer).UpdatePlayer(ctx, req.(*UpdatePlayerDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PlayerService_DeletePlayer_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PlayerServiceServer).DeletePlayer(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PlayerService_DeletePlayer_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PlayerServiceServer).DeletePlayer(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PlayerService_ServiceDesc is the grpc.ServiceDesc for PlayerService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PlayerService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.contract.PlayerService",
+	HandlerType: (*PlayerServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPlayer",
+			Handler:    _PlayerService_GetPlayer_Handler,
+		},
+		{
+			MethodName: "GetAllPlayers",
+			Handler:    _PlayerService_GetAllPlayers_Handler,
+		},
+		{
+			MethodName: "CreatePlayer",
+			Handler:    _PlayerService_CreatePlayer_Handler,
+		},
+		{
+			MethodName: "UpdatePlayer",
+			Handler:    _PlayerService_UpdatePlayer_Handler,
+		},
+		{
+			MethodName: "DeletePlayer",
+			Handler:    _PlayerService_DeletePlayer_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "contract.proto",
+}
+
+const (
+	RoomService_GetRoom_FullMethodName         = "/api.contract.RoomService/GetRoom"
+	RoomService_GetAllRooms_FullMethodName     = "/api.contract.RoomService/GetAllRooms"
+	RoomService_CreateRoom_FullMethodName      = "/api.contract.RoomService/CreateRoom"
+	RoomService_UpdateRoom_FullMethodName      = "/api.contract.RoomService/UpdateRoom"
+	RoomService_DeleteRoom_FullMethodName      = "/api.contract.RoomService/DeleteRoom"
+	RoomService_JoinRoom_FullMethodName        = "/api.contract.RoomService/JoinRoom"
+	RoomService_JoinRoomByCode_FullMethodName  = "/api.contract.RoomService/JoinRoomByCode"
+	RoomService_LeaveRoom_FullMethodName       = "/api.contract.RoomService/LeaveRoom"
+	RoomService_Spectate_FullMethodName        = "/api.contract.RoomService/Spectate"
+	RoomService_StopSpectating_FullMethodName  = "/api.contract.RoomService/StopSpectating"
+	RoomService_StartGame_FullMethodName       = "/api.contract.RoomService/StartGame"
+	RoomService_PlayMove_FullMethodName        = "/api.contract.RoomService/PlayMove"
+	RoomService_Pass_FullMethodName            = "/api.contract.RoomService/Pass"
+	RoomService_Resign_FullMethodName          = "/api.contract.RoomService/Resign"
+	RoomService_AcceptScore_FullMethodName     = "/api.contract.RoomService/AcceptScore"
+	RoomService_ResumePlay_FullMethodName      = "/api.contract.RoomService/ResumePlay"
+	RoomService_Takeback_FullMethodName        = "/api.contract.RoomService/Takeback"
+	RoomService_AcceptTakeback_FullMethodName  = "/api.contract.RoomService/AcceptTakeback"
+	RoomService_DeclineTakeback_FullMethodName = "/api.contract.RoomService/DeclineTakeback"
+	RoomService_CommitNigiri_FullMethodName    = "/api.contract.RoomService/CommitNigiri"
+	RoomService_GuessNigiri_FullMethodName     = "/api.contract.RoomService/GuessNigiri"
+	RoomService_RevealNigiri_FullMethodName    = "/api.contract.RoomService/RevealNigiri"
+	RoomService_ClaimNigiri_FullMethodName     = "/api.contract.RoomService/ClaimNigiri"
+	RoomService_GetNigiriAudit_FullMethodName  = "/api.contract.RoomService/GetNigiriAudit"
+	RoomService_PlaySession_FullMethodName     = "/api.contract.RoomService/PlaySession"
+)
+
+// RoomServiceClient is the client API for RoomService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Room service
+type RoomServiceClient interface {
+	// Games are shown with the spectator delay unless the bearer token belongs to a seated player.
+	GetRoom(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error)
+	GetAllRooms(ctx context.Context, in *RoomFilterDto, opts ...grpc.CallOption) (*RoomList, error)
+	CreateRoom(ctx context.Context, in *CreateRoomDto, opts ...grpc.CallOption) (*GetRoomDto, error)
+	UpdateRoom(ctx context.Context, in *UpdateRoomDto, opts ...grpc.CallOption) (*GetRoomDto, error)
+	DeleteRoom(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Seats the player identified by the bearer token in the room. Only the
+	// owner can join by ID alone, the other players join with JoinRoomByCode.
+	JoinRoom(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error)
+	// Seats the player identified by the bearer token in the room the invite code belongs to.
+	JoinRoomByCode(ctx context.Context, in *JoinRoomByCodeDto, opts ...grpc.CallOption) (*GetRoomDto, error)
+	// Frees the seat of the player identified by the bearer token.
+	LeaveRoom(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error)
+	// Adds the player identified by the bearer token to the spectators of the room.
+	Spectate(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error)
+	StopSpectating(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error)
+	// Starts a game between the two players seated in the room.
+	StartGame(ctx context.Context, in *StartGameDto, opts ...grpc.CallOption) (*GetRoomDto, error)
+	// Places a stone for the player identified by the bearer token.
+	PlayMove(ctx context.Context, in *MoveDto, opts ...grpc.CallOption) (*GetRoomDto, error)
+	Pass(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error)
+	Resign(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error)
+	// Agrees with the count, the game finishes once both players agree.
+	AcceptScore(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error)
+	// Disputes the count and returns the game to play.
+	ResumePlay(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error)
+	// Asks the opponent to undo the last move of the player when the room
+	// allows takebacks.
+	Takeback(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error)
+	// Undoes the move the opponent asked to take back and restores the clock
+	// to where it stood before that move.
+	AcceptTakeback(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error)
+	DeclineTakeback(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error)
+	// Publishes the stone holder's nigiri commitment.
+	CommitNigiri(ctx context.Context, in *NigiriCommitDto, opts ...grpc.CallOption) (*GetRoomDto, error)
+	// Records the opponent's odd or even guess.
+	GuessNigiri(ctx context.Context, in *NigiriGuessDto, opts ...grpc.CallOption) (*GetRoomDto, error)
+	// Reveals and verifies the committed stones, assigning colors.
+	RevealNigiri(ctx context.Context, in *NigiriRevealDto, opts ...grpc.CallOption) (*GetRoomDto, error)
+	// Picks the color of the guesser when the holder did not reveal in time.
+	ClaimNigiri(ctx context.Context, in *NigiriClaimDto, opts ...grpc.CallOption) (*GetRoomDto, error)
+	// Returns every nigiri held in the room, oldest first.
+	GetNigiriAudit(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*NigiriList, error)
+	// Plays in a room over a single stream for the player identified by the
+	// bearer token: sit first, then send moves and receive the opponent's moves
+	// and clock updates. Refused commands are answered with a PlayError.
+	PlaySession(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PlayCommand, PlayEvent], error)
+}
+
+type roomServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRoomServiceClient(cc grpc.ClientConnInterface) RoomServiceClient {
+	return &roomServiceClient{cc}
+}
+
+func (c *roomServiceClient) GetRoom(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_GetRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) GetAllRooms(ctx context.Context, in *RoomFilterDto, opts ...grpc.CallOption) (*RoomList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RoomList)
+	err := c.cc.Invoke(ctx, RoomService_GetAllRooms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) CreateRoom(ctx context.Context, in *CreateRoomDto, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_CreateRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) UpdateRoom(ctx context.Context, in *UpdateRoomDto, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_UpdateRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) DeleteRoom(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoomService_DeleteRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) JoinRoom(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_JoinRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) JoinRoomByCode(ctx context.Context, in *JoinRoomByCodeDto, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_JoinRoomByCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) LeaveRoom(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_LeaveRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) Spectate(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_Spectate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) StopSpectating(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_StopSpectating_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) StartGame(ctx context.Context, in *StartGameDto, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_StartGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) PlayMove(ctx context.Context, in *MoveDto, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_PlayMove_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) Pass(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_Pass_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) Resign(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_Resign_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) AcceptScore(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_AcceptScore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) ResumePlay(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_ResumePlay_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) Takeback(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_Takeback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) AcceptTakeback(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_AcceptTakeback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) DeclineTakeback(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_DeclineTakeback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) CommitNigiri(ctx context.Context, in *NigiriCommitDto, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_CommitNigiri_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) GuessNigiri(ctx context.Context, in *NigiriGuessDto, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_GuessNigiri_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) RevealNigiri(ctx context.Context, in *NigiriRevealDto, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_RevealNigiri_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) ClaimNigiri(ctx context.Context, in *NigiriClaimDto, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, RoomService_ClaimNigiri_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) GetNigiriAudit(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*NigiriList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(NigiriList)
+	err := c.cc.Invoke(ctx, RoomService_GetNigiriAudit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomServiceClient) PlaySession(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[PlayCommand, PlayEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoomService_ServiceDesc.Streams[0], RoomService_PlaySession_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[PlayCommand, PlayEvent]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomService_PlaySessionClient = grpc.BidiStreamingClient[PlayCommand, PlayEvent]
+
+// RoomServiceServer is the server API for RoomService service.
+// All implementations must embed UnimplementedRoomServiceServer
+// for forward compatibility.
+//
+// Room service
+type RoomServiceServer interface {
+	// Games are shown with the spectator delay unless the bearer token belongs to a seated player.
+	GetRoom(context.Context, *RequestEntity) (*GetRoomDto, error)
+	GetAllRooms(context.Context, *RoomFilterDto) (*RoomList, error)
+	CreateRoom(context.Context, *CreateRoomDto) (*GetRoomDto, error)
+	UpdateRoom(context.Context, *UpdateRoomDto) (*GetRoomDto, error)
+	DeleteRoom(context.Context, *RequestEntity) (*emptypb.Empty, error)
+	// Seats the player identified by the bearer token in the room. Only the
+	// owner can join by ID alone, the other players join with JoinRoomByCode.
+	JoinRoom(context.Context, *RequestEntity) (*GetRoomDto, error)
+	// Seats the player identified by the bearer token in the room the invite code belongs to.
+	JoinRoomByCode(context.Context, *JoinRoomByCodeDto) (*GetRoomDto, error)
+	// Frees the seat of the player identified by the bearer token.
+	LeaveRoom(context.Context, *RequestEntity) (*GetRoomDto, error)
+	// Adds the player identified by the bearer token to the spectators of the room.
+	Spectate(context.Context, *RequestEntity) (*GetRoomDto, error)
+	StopSpectating(context.Context, *RequestEntity) (*GetRoomDto, error)
+	// Starts a game between the two players seated in the room.
+	StartGame(context.Context, *StartGameDto) (*GetRoomDto, error)
+	// Places a stone for the player identified by the bearer token.
+	PlayMove(context.Context, *MoveDto) (*GetRoomDto, error)
+	Pass(context.Context, *RequestEntity) (*GetRoomDto, error)
+	Resign(context.Context, *RequestEntity) (*GetRoomDto, error)
+	// Agrees with the count, the game finishes once both players agree.
+	AcceptScore(context.Context, *RequestEntity) (*GetRoomDto, error)
+	// Disputes the count and returns the game to play.
+	ResumePlay(context.Context, *RequestEntity) (*GetRoomDto, error)
+	// Asks the opponent to undo the last move of the player when the room
+	// allows takebacks.
+	Takeback(context.Context, *RequestEntity) (*GetRoomDto, error)
+	// Undoes the move the opponent asked to take back and restores the clock
+	// to where it stood before that move.
+	AcceptTakeback(context.Context, *RequestEntity) (*GetRoomDto, error)
+	DeclineTakeback(context.Context, *RequestEntity) (*GetRoomDto, error)
+	// Publishes the stone holder's nigiri commitment.
+	CommitNigiri(context.Context, *NigiriCommitDto) (*GetRoomDto, error)
+	// Records the opponent's odd or even guess.
+	GuessNigiri(context.Context, *NigiriGuessDto) (*GetRoomDto, error)
+	// Reveals and verifies the committed stones, assigning colors.
+	RevealNigiri(context.Context, *NigiriRevealDto) (*GetRoomDto, error)
+	// Picks the color of the guesser when the holder did not reveal in time.
+	ClaimNigiri(context.Context, *NigiriClaimDto) (*GetRoomDto, error)
+	// Returns every nigiri held in the room, oldest first.
+	GetNigiriAudit(context.Context, *RequestEntity) (*NigiriList, error)
+	// Plays in a room over a single stream for the player identified by the
+	// bearer token: sit first, then send moves and receive the opponent's moves
+	// and clock updates. Refused commands are answered with a PlayError.
+	PlaySession(grpc.BidiStreamingServer[PlayCommand, PlayEvent]) error
+	mustEmbedUnimplementedRoomServiceServer()
+}
+
+// UnimplementedRoomServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRoomServiceServer struct{}
+
+func (UnimplementedRoomServiceServer) GetRoom(context.Context, *RequestEntity) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoom not implemented")
+}
+func (UnimplementedRoomServiceServer) GetAllRooms(context.Context, *RoomFilterDto) (*RoomList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllRooms not implemented")
+}
+func (UnimplementedRoomServiceServer) CreateRoom(context.Context, *CreateRoomDto) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoom not implemented")
+}
+func (UnimplementedRoomServiceServer) UpdateRoom(context.Context, *UpdateRoomDto) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoom not implemented")
+}
+func (UnimplementedRoomServiceServer) DeleteRoom(context.Context, *RequestEntity) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoom not implemented")
+}
+func (UnimplementedRoomServiceServer) JoinRoom(context.Context, *RequestEntity) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinRoom not implemented")
+}
+func (UnimplementedRoomServiceServer) JoinRoomByCode(context.Context, *JoinRoomByCodeDto) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinRoomByCode not implemented")
+}
+func (UnimplementedRoomServiceServer) LeaveRoom(context.Context, *RequestEntity) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveRoom not implemented")
+}
+func (UnimplementedRoomServiceServer) Spectate(context.Context, *RequestEntity) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Spectate not implemented")
+}
+func (UnimplementedRoomServiceServer) StopSpectating(context.Context, *RequestEntity) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StopSpectating not implemented")
+}
+func (UnimplementedRoomServiceServer) StartGame(context.Context, *StartGameDto) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartGame not implemented")
+}
+func (UnimplementedRoomServiceServer) PlayMove(context.Context, *MoveDto) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PlayMove not implemented")
+}
+func (UnimplementedRoomServiceServer) Pass(context.Context, *RequestEntity) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Pass not implemented")
+}
+func (UnimplementedRoomServiceServer) Resign(context.Context, *RequestEntity) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Resign not implemented")
+}
+func (UnimplementedRoomServiceServer) AcceptScore(context.Context, *RequestEntity) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptScore not implemented")
+}
+func (UnimplementedRoomServiceServer) ResumePlay(context.Context, *RequestEntity) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumePlay not implemented")
+}
+func (UnimplementedRoomServiceServer) Takeback(context.Context, *RequestEntity) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Takeback not implemented")
+}
+func (UnimplementedRoomServiceServer) AcceptTakeback(context.Context, *RequestEntity) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AcceptTakeback not implemented")
+}
+func (UnimplementedRoomServiceServer) DeclineTakeback(context.Context, *RequestEntity) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeclineTakeback not implemented")
+}
+func (UnimplementedRoomServiceServer) CommitNigiri(context.Context, *NigiriCommitDto) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CommitNigiri not implemented")
+}
+func (UnimplementedRoomServiceServer) GuessNigiri(context.Context, *NigiriGuessDto) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GuessNigiri not implemented")
+}
+func (UnimplementedRoomServiceServer) RevealNigiri(context.Context, *NigiriRevealDto) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevealNigiri not implemented")
+}
+func (UnimplementedRoomServiceServer) ClaimNigiri(context.Context, *NigiriClaimDto) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimNigiri not implemented")
+}
+func (UnimplementedRoomServiceServer) GetNigiriAudit(context.Context, *RequestEntity) (*NigiriList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNigiriAudit not implemented")
+}
+func (UnimplementedRoomServiceServer) PlaySession(grpc.BidiStreamingServer[PlayCommand, PlayEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method PlaySession not implemented")
+}
+func (UnimplementedRoomServiceServer) mustEmbedUnimplementedRoomServiceServer() {}
+func (UnimplementedRoomServiceServer) testEmbeddedByValue()                     {}
+
+// UnsafeRoomServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RoomServiceServer will
+// result in compilation errors.
+type UnsafeRoomServiceServer interface {
+	mustEmbedUnimplementedRoomServiceServer()
+}
+
+func RegisterRoomServiceServer(s grpc.ServiceRegistrar, srv RoomServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRoomServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RoomService_ServiceDesc, srv)
+}
+
+func _RoomService_GetRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).GetRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_GetRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).GetRoom(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_GetAllRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomFilterDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).GetAllRooms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_GetAllRooms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).GetAllRooms(ctx, req.(*RoomFilterDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoomDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).CreateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_CreateRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).CreateRoom(ctx, req.(*CreateRoomDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_UpdateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoomDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).UpdateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_UpdateRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).UpdateRoom(ctx, req.(*UpdateRoomDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_DeleteRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).DeleteRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_DeleteRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).DeleteRoom(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_JoinRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).JoinRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_JoinRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).JoinRoom(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_JoinRoomByCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRoomByCodeDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).JoinRoomByCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_JoinRoomByCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).JoinRoomByCode(ctx, req.(*JoinRoomByCodeDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_LeaveRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).LeaveRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_LeaveRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).LeaveRoom(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_Spectate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).Spectate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_Spectate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).Spectate(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_StopSpectating_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).StopSpectating(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_StopSpectating_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).StopSpectating(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_StartGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartGameDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).StartGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_StartGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).StartGame(ctx, req.(*StartGameDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_PlayMove_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).PlayMove(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_PlayMove_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).PlayMove(ctx, req.(*MoveDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_Pass_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).Pass(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_Pass_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).Pass(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_Resign_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).Resign(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_Resign_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).Resign(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_AcceptScore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).AcceptScore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_AcceptScore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).AcceptScore(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_ResumePlay_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).ResumePlay(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_ResumePlay_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).ResumePlay(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_Takeback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).Takeback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_Takeback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).Takeback(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_AcceptTakeback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).AcceptTakeback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_AcceptTakeback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).AcceptTakeback(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_DeclineTakeback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).DeclineTakeback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_DeclineTakeback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).DeclineTakeback(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_CommitNigiri_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NigiriCommitDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).CommitNigiri(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_CommitNigiri_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).CommitNigiri(ctx, req.(*NigiriCommitDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_GuessNigiri_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NigiriGuessDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).GuessNigiri(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_GuessNigiri_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).GuessNigiri(ctx, req.(*NigiriGuessDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_RevealNigiri_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NigiriRevealDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).RevealNigiri(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_RevealNigiri_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).RevealNigiri(ctx, req.(*NigiriRevealDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_ClaimNigiri_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NigiriClaimDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).ClaimNigiri(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_ClaimNigiri_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).ClaimNigiri(ctx, req.(*NigiriClaimDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_GetNigiriAudit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomServiceServer).GetNigiriAudit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomService_GetNigiriAudit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomServiceServer).GetNigiriAudit(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomService_PlaySession_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RoomServiceServer).PlaySession(&grpc.GenericServerStream[PlayCommand, PlayEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomService_PlaySessionServer = grpc.BidiStreamingServer[PlayCommand, PlayEvent]
+
+// RoomService_ServiceDesc is the grpc.ServiceDesc for RoomService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RoomService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.contract.RoomService",
+	HandlerType: (*RoomServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetRoom",
+			Handler:    _RoomService_GetRoom_Handler,
+		},
+		{
+			MethodName: "GetAllRooms",
+			Handler:    _RoomService_GetAllRooms_Handler,
+		},
+		{
+			MethodName: "CreateRoom",
+			Handler:    _RoomService_CreateRoom_Handler,
+		},
+		{
+			MethodName: "UpdateRoom",
+			Handler:    _RoomService_UpdateRoom_Handler,
+		},
+		{
+			MethodName: "DeleteRoom",
+			Handler:    _RoomService_DeleteRoom_Handler,
+		},
+		{
+			MethodName: "JoinRoom",
+			Handler:    _RoomService_JoinRoom_Handler,
+		},
+		{
+			MethodName: "JoinRoomByCode",
+			Handler:    _RoomService_JoinRoomByCode_Handler,
+		},
+		{
+			MethodName: "LeaveRoom",
+			Handler:    _RoomService_LeaveRoom_Handler,
+		},
+		{
+			MethodName: "Spectate",
+			Handler:    _RoomService_Spectate_Handler,
+		},
+		{
+			MethodName: "StopSpectating",
+			Handler:    _RoomService_StopSpectating_Handler,
+		},
+		{
+			MethodName: "StartGame",
+			Handler:    _RoomService_StartGame_Handler,
+		},
+		{
+			MethodName: "PlayMove",
+			Handler:    _RoomService_PlayMove_Handler,
+		},
+		{
+			MethodName: "Pass",
+			Handler:    _RoomService_Pass_Handler,
+		},
+		{
+			MethodName: "Resign",
+			Handler:    _RoomService_Resign_Handler,
+		},
+		{
+			MethodName: "AcceptScore",
+			Handler:    _RoomService_AcceptScore_Handler,
+		},
+		{
+			MethodName: "ResumePlay",
+			Handler:    _RoomService_ResumePlay_Handler,
+		},
+		{
+			MethodName: "Takeback",
+			Handler:    _RoomService_Takeback_Handler,
+		},
+		{
+			MethodName: "AcceptTakeback",
+			Handler:    _RoomService_AcceptTakeback_Handler,
+		},
+		{
+			MethodName: "DeclineTakeback",
+			Handler:    _RoomService_DeclineTakeback_Handler,
+		},
+		{
+			MethodName: "CommitNigiri",
+			Handler:    _RoomService_CommitNigiri_Handler,
+		},
+		{
+			MethodName: "GuessNigiri",
+			Handler:    _RoomService_GuessNigiri_Handler,
+		},
+		{
+			MethodName: "RevealNigiri",
+			Handler:    _RoomService_RevealNigiri_Handler,
+		},
+		{
+			MethodName: "ClaimNigiri",
+			Handler:    _RoomService_ClaimNigiri_Handler,
+		},
+		{
+			MethodName: "GetNigiriAudit",
+			Handler:    _RoomService_GetNigiriAudit_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "PlaySession",
+			Handler:       _RoomService_PlaySession_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "contract.proto",
+}
+
+const (
+	SimulService_GetSimul_FullMethodName      = "/api.contract.SimulService/GetSimul"
+	SimulService_GetAllSimuls_FullMethodName  = "/api.contract.SimulService/GetAllSimuls"
+	SimulService_CreateSimul_FullMethodName   = "/api.contract.SimulService/CreateSimul"
+	SimulService_JoinSimul_FullMethodName     = "/api.contract.SimulService/JoinSimul"
+	SimulService_LeaveSimul_FullMethodName    = "/api.contract.SimulService/LeaveSimul"
+	SimulService_StartSimul_FullMethodName    = "/api.contract.SimulService/StartSimul"
+	SimulService_GetSimulQueue_FullMethodName = "/api.contract.SimulService/GetSimulQueue"
+)
+
+// SimulServiceClient is the client API for SimulService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Simul service
+type SimulServiceClient interface {
+	// Returns a simul with the game of every board.
+	GetSimul(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetSimulDto, error)
+	GetAllSimuls(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SimulList, error)
+	// Creates a simul hosted by the player identified by the bearer token.
+	CreateSimul(ctx context.Context, in *CreateSimulDto, opts ...grpc.CallOption) (*GetSimulDto, error)
+	JoinSimul(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetSimulDto, error)
+	LeaveSimul(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetSimulDto, error)
+	// Opens a room with a started game for every opponent.
+	StartSimul(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetSimulDto, error)
+	// Returns the boards waiting for the host, the one waiting longest first.
+	GetSimulQueue(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*SimulBoardList, error)
+}
+
+type simulServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSimulServiceClient(cc grpc.ClientConnInterface) SimulServiceClient {
+	return &simulServiceClient{cc}
+}
+
+func (c *simulServiceClient) GetSimul(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetSimulDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSimulDto)
+	err := c.cc.Invoke(ctx, SimulService_GetSimul_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulServiceClient) GetAllSimuls(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*SimulList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SimulList)
+	err := c.cc.Invoke(ctx, SimulService_GetAllSimuls_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulServiceClient) CreateSimul(ctx context.Context, in *CreateSimulDto, opts ...grpc.CallOption) (*GetSimulDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSimulDto)
+	err := c.cc.Invoke(ctx, SimulService_CreateSimul_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulServiceClient) JoinSimul(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetSimulDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSimulDto)
+	err := c.cc.Invoke(ctx, SimulService_JoinSimul_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulServiceClient) LeaveSimul(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetSimulDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSimulDto)
+	err := c.cc.Invoke(ctx, SimulService_LeaveSimul_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *simulServiceClient) StartSimul(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetSimulDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetSimulDto)
+	err := c.cc.Invoke(ctx, SimulService_StartSimul_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *roomServiceClient) DeleteRoom(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *simulServiceClient) GetSimulQueue(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*SimulBoardList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, RoomService_DeleteRoom_FullMethodName, in, out, cOpts...)
+	out := new(SimulBoardList)
+	err := c.cc.Invoke(ctx, SimulService_GetSimulQueue_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// RoomServiceServer is the server API for RoomService service.
-// All implementations must embed UnimplementedRoomServiceServer
+// SimulServiceServer is the server API for SimulService service.
+// All implementations must embed UnimplementedSimulServiceServer
 // for forward compatibility.
 //
-// Room service
-type RoomServiceServer interface {
-	GetRoom(context.Context, *RequestEntity) (*GetRoomDto, error)
-	GetAllRooms(context.Context, *emptypb.Empty) (*RoomList, error)
-	CreateRoom(context.Context, *CreateRoomDto) (*GetRoomDto, error)
-	UpdateRoom(context.Context, *UpdateRoomDto) (*GetRoomDto, error)
-	DeleteRoom(context.Context, *RequestEntity) (*emptypb.Empty, error)
-	mustEmbedUnimplementedRoomServiceServer()
-}
-
-// UnimplementedRoomServiceServer must be embedded to have
+// Simul service
+type SimulServiceServer interface {
+	// Returns a simul with the game of every board.
+	GetSimul(context.Context, *RequestEntity) (*GetSimulDto, error)
+	GetAllSimuls(context.Context, *emptypb.Empty) (*SimulList, error)
+	// Creates a simul hosted by the player identified by the bearer token.
+	CreateSimul(context.Context, *CreateSimulDto) (*GetSimulDto, error)
+	JoinSimul(context.Context, *RequestEntity) (*GetSimulDto, error)
+	LeaveSimul(context.Context, *RequestEntity) (*GetSimulDto, error)
+	// Opens a room with a started game for every opponent.
+	StartSimul(context.Context, *RequestEntity) (*GetSimulDto, error)
+	// Returns the boards waiting for the host, the one waiting longest first.
+	GetSimulQueue(context.Context, *RequestEntity) (*SimulBoardList, error)
+	mustEmbedUnimplementedSimulServiceServer()
+}
+
+// UnimplementedSimulServiceServer must be embedded to have
 // forward compatible implementations.
 //
 // NOTE: this should be embedded by value instead of pointer to avoid a nil
 // pointer dereference when methods are called.
-type UnimplementedRoomServiceServer struct{}
+type UnimplementedSimulServiceServer struct{}
 
-func (UnimplementedRoomServiceServer) GetRoom(context.Context, *RequestEntity) (*GetRoomDto, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRoom not implemented")
+func (UnimplementedSimulServiceServer) GetSimul(context.Context, *RequestEntity) (*GetSimulDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSimul not implemented")
 }
-func (UnimplementedRoomServiceServer) GetAllRooms(context.Context, *emptypb.Empty) (*RoomList, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAllRooms not implemented")
+func (UnimplementedSimulServiceServer) GetAllSimuls(context.Context, *emptypb.Empty) (*SimulList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAllSimuls not implemented")
 }
-func (UnimplementedRoomServiceServer) CreateRoom(context.Context, *CreateRoomDto) (*GetRoomDto, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateRoom not implemented")
+func (UnimplementedSimulServiceServer) CreateSimul(context.Context, *CreateSimulDto) (*GetSimulDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSimul not implemented")
 }
-func (UnimplementedRoomServiceServer) UpdateRoom(context.Context, *UpdateRoomDto) (*GetRoomDto, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoom not implemented")
+func (UnimplementedSimulServiceServer) JoinSimul(context.Context, *RequestEntity) (*GetSimulDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinSimul not implemented")
 }
-func (UnimplementedRoomServiceServer) DeleteRoom(context.Context, *RequestEntity) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoom not implemented")
+func (UnimplementedSimulServiceServer) LeaveSimul(context.Context, *RequestEntity) (*GetSimulDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveSimul not implemented")
 }
-func (UnimplementedRoomServiceServer) mustEmbedUnimplementedRoomServiceServer() {}
-func (UnimplementedRoomServiceServer) testEmbeddedByValue()                     {}
+func (UnimplementedSimulServiceServer) StartSimul(context.Context, *RequestEntity) (*GetSimulDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method StartSimul not implemented")
+}
+func (UnimplementedSimulServiceServer) GetSimulQueue(context.Context, *RequestEntity) (*SimulBoardList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetSimulQueue not implemented")
+}
+func (UnimplementedSimulServiceServer) mustEmbedUnimplementedSimulServiceServer() {}
+func (UnimplementedSimulServiceServer) testEmbeddedByValue()                      {}
 
-// UnsafeRoomServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to RoomServiceServer will
+// UnsafeSimulServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SimulServiceServer will
 // result in compilation errors.
-type UnsafeRoomServiceServer interface {
-	mustEmbedUnimplementedRoomServiceServer()
+type UnsafeSimulServiceServer interface {
+	mustEmbedUnimplementedSimulServiceServer()
 }
 
-func RegisterRoomServiceServer(s grpc.ServiceRegistrar, srv RoomServiceServer) {
-	// If the following call pancis, it indicates UnimplementedRoomServiceServer was
+func RegisterSimulServiceServer(s grpc.ServiceRegistrar, srv SimulServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSimulServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&RoomService_ServiceDesc, srv)
+	s.RegisterService(&SimulService_ServiceDesc, srv)
 }
 
-func _RoomService_GetRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _SimulService_GetSimul_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RequestEntity)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoomServiceServer).GetRoom(ctx, in)
+		return srv.(SimulServiceServer).GetSimul(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: RoomService_GetRoom_FullMethodName,
+		FullMethod: SimulService_GetSimul_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomServiceServer).GetRoom(ctx, req.(*RequestEntity))
+		return srv.(SimulServiceServer).GetSimul(ctx, req.(*RequestEntity))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RoomService_GetAllRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _SimulService_GetAllSimuls_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoomServiceServer).GetAllRooms(ctx, in)
+		return srv.(SimulServiceServer).GetAllSimuls(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: RoomService_GetAllRooms_FullMethodName,
+		FullMethod: SimulService_GetAllSimuls_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomServiceServer).GetAllRooms(ctx, req.(*emptypb.Empty))
+		return srv.(SimulServiceServer).GetAllSimuls(ctx, req.(*emptypb.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RoomService_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRoomDto)
+func _SimulService_CreateSimul_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSimulDto)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoomServiceServer).CreateRoom(ctx, in)
+		return srv.(SimulServiceServer).CreateSimul(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: RoomService_CreateRoom_FullMethodName,
+		FullMethod: SimulService_CreateSimul_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomServiceServer).CreateRoom(ctx, req.(*CreateRoomDto))
+		return srv.(SimulServiceServer).CreateSimul(ctx, req.(*CreateSimulDto))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RoomService_UpdateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(UpdateRoomDto)
+func _SimulService_JoinSimul_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoomServiceServer).UpdateRoom(ctx, in)
+		return srv.(SimulServiceServer).JoinSimul(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: RoomService_UpdateRoom_FullMethodName,
+		FullMethod: SimulService_JoinSimul_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomServiceServer).UpdateRoom(ctx, req.(*UpdateRoomDto))
+		return srv.(SimulServiceServer).JoinSimul(ctx, req.(*RequestEntity))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RoomService_DeleteRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _SimulService_LeaveSimul_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RequestEntity)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoomServiceServer).DeleteRoom(ctx, in)
+		return srv.(SimulServiceServer).LeaveSimul(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: RoomService_DeleteRoom_FullMethodName,
+		FullMethod: SimulService_LeaveSimul_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomServiceServer).DeleteRoom(ctx, req.(*RequestEntity))
+		return srv.(SimulServiceServer).LeaveSimul(ctx, req.(*RequestEntity))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// RoomService_ServiceDesc is the grpc.ServiceDesc for RoomService service.
+func _SimulService_StartSimul_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulServiceServer).StartSimul(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulService_StartSimul_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulServiceServer).StartSimul(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SimulService_GetSimulQueue_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SimulServiceServer).GetSimulQueue(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SimulService_GetSimulQueue_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SimulServiceServer).GetSimulQueue(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// SimulService_ServiceDesc is the grpc.ServiceDesc for SimulService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var RoomService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "api.contract.RoomService",
-	HandlerType: (*RoomServiceServer)(nil),
+var SimulService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.contract.SimulService",
+	HandlerType: (*SimulServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "GetRoom",
-			Handler:    _RoomService_GetRoom_Handler,
+			MethodName: "GetSimul",
+			Handler:    _SimulService_GetSimul_Handler,
 		},
 		{
-			MethodName: "GetAllRooms",
-			Handler:    _RoomService_GetAllRooms_Handler,
+			MethodName: "GetAllSimuls",
+			Handler:    _SimulService_GetAllSimuls_Handler,
 		},
 		{
-			MethodName: "CreateRoom",
-			Handler:    _RoomService_CreateRoom_Handler,
+			MethodName: "CreateSimul",
+			Handler:    _SimulService_CreateSimul_Handler,
 		},
 		{
-			MethodName: "UpdateRoom",
-			Handler:    _RoomService_UpdateRoom_Handler,
+			MethodName: "JoinSimul",
+			Handler:    _SimulService_JoinSimul_Handler,
 		},
 		{
-			MethodName: "DeleteRoom",
-			Handler:    _RoomService_DeleteRoom_Handler,
+			MethodName: "LeaveSimul",
+			Handler:    _SimulService_LeaveSimul_Handler,
+		},
+		{
+			MethodName: "StartSimul",
+			Handler:    _SimulService_StartSimul_Handler,
+		},
+		{
+			MethodName: "GetSimulQueue",
+			Handler:    _SimulService_GetSimulQueue_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
@@ -798,6 +2723,7 @@ const (
 	GameService_GetAllGames_FullMethodName = "/api.contract.GameService/GetAllGames"
 	GameService_CreateGame_FullMethodName  = "/api.contract.GameService/CreateGame"
 	GameService_DeleteGame_FullMethodName  = "/api.contract.GameService/DeleteGame"
+	GameService_WatchGame_FullMethodName   = "/api.contract.GameService/WatchGame"
 )
 
 // GameServiceClient is the client API for GameService service.
@@ -806,10 +2732,17 @@ const (
 //
 // Game service
 type GameServiceClient interface {
+	// Games in progress are shown with the spectator delay of their room
+	// unless the bearer token belongs to a seated player.
 	GetGame(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*GetGameDto, error)
 	GetAllGames(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GameList, error)
 	CreateGame(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*GetGameDto, error)
+	// Players can delete the games they played once they are over, admins any
+	// game.
 	DeleteGame(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	// Streams a game.snapshot followed by every change to the game until it
+	// ends. Spectators of rooms with a spectator delay see the delayed game.
+	WatchGame(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameEvent], error)
 }
 
 type gameServiceClient struct {
@@ -860,16 +2793,42 @@ func (c *gameServiceClient) DeleteGame(ctx context.Context, in *RequestEntity, o
 	return out, nil
 }
 
+func (c *gameServiceClient) WatchGame(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GameEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &GameService_ServiceDesc.Streams[0], GameService_WatchGame_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RequestEntity, GameEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameService_WatchGameClient = grpc.ServerStreamingClient[GameEvent]
+
 // GameServiceServer is the server API for GameService service.
 // All implementations must embed UnimplementedGameServiceServer
 // for forward compatibility.
 //
 // Game service
 type GameServiceServer interface {
+	// Games in progress are shown with the spectator delay of their room
+	// unless the bearer token belongs to a seated player.
 	GetGame(context.Context, *RequestEntity) (*GetGameDto, error)
 	GetAllGames(context.Context, *emptypb.Empty) (*GameList, error)
 	CreateGame(context.Context, *emptypb.Empty) (*GetGameDto, error)
+	// Players can delete the games they played once they are over, admins any
+	// game.
 	DeleteGame(context.Context, *RequestEntity) (*emptypb.Empty, error)
+	// Streams a game.snapshot followed by every change to the game until it
+	// ends. Spectators of rooms with a spectator delay see the delayed game.
+	WatchGame(*RequestEntity, grpc.ServerStreamingServer[GameEvent]) error
 	mustEmbedUnimplementedGameServiceServer()
 }
 
@@ -892,6 +2851,9 @@ func (UnimplementedGameServiceServer) CreateGame(context.Context, *emptypb.Empty
 func (UnimplementedGameServiceServer) DeleteGame(context.Context, *RequestEntity) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteGame not implemented")
 }
+func (UnimplementedGameServiceServer) WatchGame(*RequestEntity, grpc.ServerStreamingServer[GameEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchGame not implemented")
+}
 func (UnimplementedGameServiceServer) mustEmbedUnimplementedGameServiceServer() {}
 func (UnimplementedGameServiceServer) testEmbeddedByValue()                     {}
 
@@ -985,6 +2947,17 @@ func _GameService_DeleteGame_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _GameService_WatchGame_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RequestEntity)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GameServiceServer).WatchGame(m, &grpc.GenericServerStream[RequestEntity, GameEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type GameService_WatchGameServer = grpc.ServerStreamingServer[GameEvent]
+
 // GameService_ServiceDesc is the grpc.ServiceDesc for GameService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1009,6 +2982,240 @@ var GameService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _GameService_DeleteGame_Handler,
 		},
 	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchGame",
+			Handler:       _GameService_WatchGame_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "contract.proto",
+}
+
+const (
+	AdminService_AdjudicateGame_FullMethodName = "/api.contract.AdminService/AdjudicateGame"
+	AdminService_ListUsers_FullMethodName      = "/api.contract.AdminService/ListUsers"
+	AdminService_SetUserRole_FullMethodName    = "/api.contract.AdminService/SetUserRole"
+	AdminService_DeleteUser_FullMethodName     = "/api.contract.AdminService/DeleteUser"
+)
+
+// AdminServiceClient is the client API for AdminService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Admin service, every call requires the admin role.
+type AdminServiceClient interface {
+	// Ends a game in progress with the decided result.
+	AdjudicateGame(ctx context.Context, in *AdjudicateGameDto, opts ...grpc.CallOption) (*GetRoomDto, error)
+	ListUsers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UserList, error)
+	// Changes the role of an account and revokes its sessions so that the role
+	// applies at once.
+	SetUserRole(ctx context.Context, in *SetUserRoleDto, opts ...grpc.CallOption) (*UserDto, error)
+	// Deletes the player with their account and sessions.
+	DeleteUser(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+type adminServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewAdminServiceClient(cc grpc.ClientConnInterface) AdminServiceClient {
+	return &adminServiceClient{cc}
+}
+
+func (c *adminServiceClient) AdjudicateGame(ctx context.Context, in *AdjudicateGameDto, opts ...grpc.CallOption) (*GetRoomDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetRoomDto)
+	err := c.cc.Invoke(ctx, AdminService_AdjudicateGame_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) ListUsers(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*UserList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserList)
+	err := c.cc.Invoke(ctx, AdminService_ListUsers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) SetUserRole(ctx context.Context, in *SetUserRoleDto, opts ...grpc.CallOption) (*UserDto, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserDto)
+	err := c.cc.Invoke(ctx, AdminService_SetUserRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *adminServiceClient) DeleteUser(ctx context.Context, in *RequestEntity, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, AdminService_DeleteUser_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// AdminServiceServer is the server API for AdminService service.
+// All implementations must embed UnimplementedAdminServiceServer
+// for forward compatibility.
+//
+// Admin service, every call requires the admin role.
+type AdminServiceServer interface {
+	// Ends a game in progress with the decided result.
+	AdjudicateGame(context.Context, *AdjudicateGameDto) (*GetRoomDto, error)
+	ListUsers(context.Context, *emptypb.Empty) (*UserList, error)
+	// Changes the role of an account and revokes its sessions so that the role
+	// applies at once.
+	SetUserRole(context.Context, *SetUserRoleDto) (*UserDto, error)
+	// Deletes the player with their account and sessions.
+	DeleteUser(context.Context, *RequestEntity) (*emptypb.Empty, error)
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+// UnimplementedAdminServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedAdminServiceServer struct{}
+
+func (UnimplementedAdminServiceServer) AdjudicateGame(context.Context, *AdjudicateGameDto) (*GetRoomDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AdjudicateGame not implemented")
+}
+func (UnimplementedAdminServiceServer) ListUsers(context.Context, *emptypb.Empty) (*UserList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListUsers not implemented")
+}
+func (UnimplementedAdminServiceServer) SetUserRole(context.Context, *SetUserRoleDto) (*UserDto, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetUserRole not implemented")
+}
+func (UnimplementedAdminServiceServer) DeleteUser(context.Context, *RequestEntity) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteUser not implemented")
+}
+func (UnimplementedAdminServiceServer) mustEmbedUnimplementedAdminServiceServer() {}
+func (UnimplementedAdminServiceServer) testEmbeddedByValue()                      {}
+
+// UnsafeAdminServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to AdminServiceServer will
+// result in compilation errors.
+type UnsafeAdminServiceServer interface {
+	mustEmbedUnimplementedAdminServiceServer()
+}
+
+func RegisterAdminServiceServer(s grpc.ServiceRegistrar, srv AdminServiceServer) {
+	// If the following call pancis, it indicates UnimplementedAdminServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&AdminService_ServiceDesc, srv)
+}
+
+func _AdminService_AdjudicateGame_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjudicateGameDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).AdjudicateGame(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_AdjudicateGame_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).AdjudicateGame(ctx, req.(*AdjudicateGameDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_ListUsers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(emptypb.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).ListUsers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_ListUsers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).ListUsers(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_SetUserRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetUserRoleDto)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).SetUserRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_SetUserRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).SetUserRole(ctx, req.(*SetUserRoleDto))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AdminService_DeleteUser_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RequestEntity)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AdminServiceServer).DeleteUser(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AdminService_DeleteUser_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AdminServiceServer).DeleteUser(ctx, req.(*RequestEntity))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// AdminService_ServiceDesc is the grpc.ServiceDesc for AdminService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var AdminService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.contract.AdminService",
+	HandlerType: (*AdminServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "AdjudicateGame",
+			Handler:    _AdminService_AdjudicateGame_Handler,
+		},
+		{
+			MethodName: "ListUsers",
+			Handler:    _AdminService_ListUsers_Handler,
+		},
+		{
+			MethodName: "SetUserRole",
+			Handler:    _AdminService_SetUserRole_Handler,
+		},
+		{
+			MethodName: "DeleteUser",
+			Handler:    _AdminService_DeleteUser_Handler,
+		},
+	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "contract.proto",
 }
@@ -0,0 +1,85 @@
+package services
+
+import (
+	"context"
+
+	"github.com/moLIart/go-course/internal/grpc/generated"
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/model/game"
+	"github.com/moLIart/go-course/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type AdminService struct {
+	generated.UnimplementedAdminServiceServer
+}
+
+func (s *AdminService) AdjudicateGame(ctx context.Context, req *generated.AdjudicateGameDto) (*generated.GetRoomDto, error) {
+	winner, ok := game.ParseColor(req.Winner)
+	if !ok && req.Winner != "draw" {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid winner, expected black, white or draw")
+	}
+
+	r, err := repository.AdjudicateGame(int(req.GameId), winner)
+	if err == nil && r == nil {
+		return nil, status.Errorf(codes.NotFound, "game not found")
+	}
+	return roomUpdateResult(ctx, r, err)
+}
+
+func (s *AdminService) ListUsers(ctx context.Context, _ *emptypb.Empty) (*generated.UserList, error) {
+	accounts, err := repository.GetAccounts()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	users := make([]*generated.UserDto, len(accounts))
+	for i, acc := range accounts {
+		users[i] = newUserDto(acc)
+	}
+	return &generated.UserList{Users: users}, nil
+}
+
+func (s *AdminService) SetUserRole(ctx context.Context, req *generated.SetUserRoleDto) (*generated.UserDto, error) {
+	role, err := account.ParseRole(req.Role)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	if viewerIDFromContext(ctx) == int(req.PlayerId) {
+		return nil, status.Errorf(codes.InvalidArgument, "admins cannot change their own role")
+	}
+
+	acc, err := repository.SetAccountRole(int(req.PlayerId), role)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	if acc == nil {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	return newUserDto(acc), nil
+}
+
+func (s *AdminService) DeleteUser(ctx context.Context, req *generated.RequestEntity) (*emptypb.Empty, error) {
+	ok, err := repository.DeleteAccount(int(req.Id))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	if !ok {
+		return nil, status.Errorf(codes.NotFound, "user not found")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func newUserDto(acc *account.Account) *generated.UserDto {
+	return &generated.UserDto{
+		PlayerId:        int32(acc.PlayerID),
+		Username:        acc.Username,
+		Role:            string(acc.GetRole()),
+		CreatedAtUnixMs: acc.CreatedAt.UnixMilli(),
+	}
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+
+	"github.com/moLIart/go-course/internal/grpc/generated"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// MethodAccess mirrors the authorization of the HTTP routes for the
+// interceptors of middlewares: reads are public and open to read-only API
+// keys, sessions are managed with tokens only, the admin service requires the
+// admin role and every other method a valid token or an API key with the play
+// scope. Ownership of the resources is checked by the methods themselves.
+var MethodAccess = map[string]middlewares.Access{
+	generated.AuthService_Register_FullMethodName: {Public: true},
+	generated.AuthService_Login_FullMethodName:    {Public: true},
+	generated.AuthService_Refresh_FullMethodName:  {Public: true},
+
+	generated.AuthService_VerifyTwoFactor_FullMethodName:      {Public: true},
+	generated.AuthService_VerifyEmail_FullMethodName:          {Public: true},
+	generated.AuthService_RequestPasswordReset_FullMethodName: {Public: true},
+	generated.AuthService_ResetPassword_FullMethodName:        {Public: true},
+
+	generated.AuthService_Logout_FullMethodName:           {TokenOnly: true},
+	generated.AuthService_LogoutEverywhere_FullMethodName: {TokenOnly: true},
+	generated.AuthService_ListSessions_FullMethodName:     {TokenOnly: true},
+	generated.AuthService_RevokeSession_FullMethodName:    {TokenOnly: true},
+
+	generated.AuthService_SetupTwoFactor_FullMethodName:          {TokenOnly: true},
+	generated.AuthService_EnableTwoFactor_FullMethodName:         {TokenOnly: true},
+	generated.AuthService_DisableTwoFactor_FullMethodName:        {TokenOnly: true},
+	generated.AuthService_RegenerateRecoveryCodes_FullMethodName: {TokenOnly: true},
+
+	generated.AuthService_GetEmail_FullMethodName:                {TokenOnly: true},
+	generated.AuthService_SetEmail_FullMethodName:                {TokenOnly: true},
+	generated.AuthService_ResendVerificationEmail_FullMethodName: {TokenOnly: true},
+
+	generated.PlayerService_GetPlayer_FullMethodName:     {Public: true, Scope: account.ScopeRead},
+	generated.PlayerService_GetAllPlayers_FullMethodName: {Public: true, Scope: account.ScopeRead},
+
+	generated.RoomService_GetRoom_FullMethodName:        {Public: true, Scope: account.ScopeRead},
+	generated.RoomService_GetAllRooms_FullMethodName:    {Public: true, Scope: account.ScopeRead},
+	generated.RoomService_GetNigiriAudit_FullMethodName: {Public: true, Scope: account.ScopeRead},
+
+	generated.SimulService_GetSimul_FullMethodName:      {Public: true, Scope: account.ScopeRead},
+	generated.SimulService_GetAllSimuls_FullMethodName:  {Public: true, Scope: account.ScopeRead},
+	generated.SimulService_GetSimulQueue_FullMethodName: {Scope: account.ScopeRead},
+
+	generated.BoardService_GetBoard_FullMethodName:     {Public: true, Scope: account.ScopeRead},
+	generated.BoardService_GetAllBoards_FullMethodName: {Public: true, Scope: account.ScopeRead},
+
+	generated.GameService_GetGame_FullMethodName:     {Public: true, Scope: account.ScopeRead},
+	generated.GameService_GetAllGames_FullMethodName: {Public: true, Scope: account.ScopeRead},
+	generated.GameService_WatchGame_FullMethodName:   {Public: true, Scope: account.ScopeRead},
+
+	generated.AdminService_AdjudicateGame_FullMethodName: {Role: account.RoleAdmin},
+	generated.AdminService_ListUsers_FullMethodName:      {Role: account.RoleAdmin},
+	generated.AdminService_SetUserRole_FullMethodName:    {Role: account.RoleAdmin},
+	generated.AdminService_DeleteUser_FullMethodName:     {Role: account.RoleAdmin},
+}
+
+// principalFromContext returns the caller authenticated by the interceptors.
+func principalFromContext(ctx context.Context) (middlewares.Principal, error) {
+	principal, ok := middlewares.PrincipalFromContext(ctx)
+	if !ok {
+		return middlewares.Principal{}, status.Errorf(codes.Unauthenticated, "missing or invalid authorization metadata")
+	}
+	return principal, nil
+}
+
+// authorizeOwner checks that the caller may change what belongs to ownerID.
+func authorizeOwner(ctx context.Context, ownerID int, refusal string) error {
+	principal, err := principalFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	if !principal.CanActFor(ownerID) {
+		return status.Errorf(codes.PermissionDenied, "%s", refusal)
+	}
+	return nil
+}
+
+// playerIDFromContext returns the player ID of the caller.
+func playerIDFromContext(ctx context.Context) (int, error) {
+	id, _, err := sessionFromContext(ctx)
+	return id, err
+}
+
+// sessionFromContext returns the player ID of the caller and the session
+// their token was issued for.
+func sessionFromContext(ctx context.Context) (int, string, error) {
+	principal, err := principalFromContext(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+
+	if principal.PlayerID == 0 {
+		return 0, "", status.Errorf(codes.Unauthenticated, "token does not identify a player")
+	}
+	return principal.PlayerID, principal.SessionID, nil
+}
+
+// viewerIDFromContext returns the player ID of the caller, or 0 for
+// anonymous calls.
+func viewerIDFromContext(ctx context.Context) int {
+	id, _ := playerIDFromContext(ctx)
+	return id
+}
+
+func playerFromContext(ctx context.Context) (*room.Player, error) {
+	id, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	player, err := repository.GetPlayerByID(id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	if player == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "player not found")
+	}
+	return player, nil
+}
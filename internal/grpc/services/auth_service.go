@@ -0,0 +1,426 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"net"
+	"time"
+
+	"github.com/moLIart/go-course/internal/grpc/generated"
+	"github.com/moLIart/go-course/internal/mailer"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type AuthService struct {
+	generated.UnimplementedAuthServiceServer
+}
+
+func (s *AuthService) Register(ctx context.Context, req *generated.CredentialsDto) (*generated.TokenDto, error) {
+	player, err := repository.Register(req.Username, req.Password)
+	switch {
+	case errors.Is(err, account.ErrInvalidUsername), errors.Is(err, account.ErrInvalidPassword):
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, repository.ErrUsernameTaken):
+		return nil, status.Errorf(codes.AlreadyExists, "%v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	return startSession(ctx, player)
+}
+
+func (s *AuthService) Login(ctx context.Context, req *generated.CredentialsDto) (*generated.TokenDto, error) {
+	player, err := repository.Login(req.Username, req.Password)
+	switch {
+	case errors.Is(err, account.ErrInvalidCredentials):
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	acc, err := repository.GetAccountByPlayerID(player.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	if acc != nil && acc.TwoFactorEnabled() {
+		challenge, err := repository.CreateTwoFactorChallenge(player.ID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+		}
+		return &generated.TokenDto{
+			TwoFactorToken: challenge.Token,
+			ExpiresIn:      int32(account.TwoFactorChallengeTTL.Seconds()),
+		}, nil
+	}
+	return startSession(ctx, player)
+}
+
+func (s *AuthService) VerifyTwoFactor(ctx context.Context, req *generated.TwoFactorLoginDto) (*generated.TokenDto, error) {
+	player, err := repository.CompleteTwoFactorChallenge(req.TwoFactorToken, req.Code)
+	switch {
+	case errors.Is(err, account.ErrInvalidTwoFactorCode), errors.Is(err, account.ErrInvalidTwoFactorToken),
+		errors.Is(err, account.ErrInvalidCredentials):
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	case errors.Is(err, account.ErrTwoFactorLocked):
+		return nil, status.Errorf(codes.ResourceExhausted, "%v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	return startSession(ctx, player)
+}
+
+func (s *AuthService) SetupTwoFactor(ctx context.Context, _ *emptypb.Empty) (*generated.TwoFactorSetupDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	twoFactor, acc, err := repository.SetupTwoFactor(playerID)
+	switch {
+	case errors.Is(err, account.ErrTwoFactorEnabled):
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, account.ErrInvalidCredentials):
+		return nil, status.Errorf(codes.Unauthenticated, "account not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	return &generated.TwoFactorSetupDto{
+		Secret:          twoFactor.Secret,
+		ProvisioningUri: twoFactor.ProvisioningURI(acc.Username),
+	}, nil
+}
+
+func (s *AuthService) EnableTwoFactor(ctx context.Context, req *generated.TwoFactorCodeDto) (*generated.RecoveryCodesDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := repository.EnableTwoFactor(playerID, req.Code)
+	if err != nil {
+		return nil, twoFactorError(err)
+	}
+	return &generated.RecoveryCodesDto{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *AuthService) DisableTwoFactor(ctx context.Context, req *generated.TwoFactorCodeDto) (*emptypb.Empty, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repository.DisableTwoFactor(playerID, req.Code); err != nil {
+		return nil, twoFactorError(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthService) RegenerateRecoveryCodes(ctx context.Context, req *generated.TwoFactorCodeDto) (*generated.RecoveryCodesDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, err := repository.RegenerateRecoveryCodes(playerID, req.Code)
+	if err != nil {
+		return nil, twoFactorError(err)
+	}
+	return &generated.RecoveryCodesDto{RecoveryCodes: recoveryCodes}, nil
+}
+
+func (s *AuthService) GetEmail(ctx context.Context, _ *emptypb.Empty) (*generated.AccountEmailDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := repository.GetAccountByPlayerID(playerID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	if acc == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "account not found")
+	}
+	return &generated.AccountEmailDto{Email: acc.Email, Verified: acc.EmailVerified}, nil
+}
+
+func (s *AuthService) SetEmail(ctx context.Context, req *generated.EmailDto) (*generated.AccountEmailDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := repository.SetAccountEmail(playerID, req.Email)
+	switch {
+	case errors.Is(err, account.ErrInvalidEmail):
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, repository.ErrEmailTaken):
+		return nil, status.Errorf(codes.AlreadyExists, "%v", err)
+	case errors.Is(err, account.ErrInvalidCredentials):
+		return nil, status.Errorf(codes.Unauthenticated, "account not found")
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	if !acc.EmailVerified {
+		// The player can ask for another email if this one was throttled.
+		if _, err := sendVerificationEmail(acc); err != nil {
+			log.Printf("Failed to send verification email to player %d: %v", playerID, err)
+		}
+	}
+	return &generated.AccountEmailDto{Email: acc.Email, Verified: acc.EmailVerified}, nil
+}
+
+func (s *AuthService) ResendVerificationEmail(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := repository.GetAccountByPlayerID(playerID)
+	switch {
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	case acc == nil:
+		return nil, status.Errorf(codes.Unauthenticated, "account not found")
+	case acc.Email == "":
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", account.ErrNoEmail)
+	case acc.EmailVerified:
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", account.ErrEmailVerified)
+	}
+
+	sent, err := sendVerificationEmail(acc)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	if !sent {
+		return nil, status.Errorf(codes.ResourceExhausted, "an email was sent less than a minute ago")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthService) VerifyEmail(ctx context.Context, req *generated.VerifyEmailDto) (*generated.AccountEmailDto, error) {
+	playerID, email, err := middlewares.ParseActionToken(middlewares.PurposeEmailVerification, req.Token)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	}
+
+	verified, err := repository.VerifyAccountEmail(playerID, email)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	if !verified {
+		return nil, status.Errorf(codes.Unauthenticated, "%v", account.ErrInvalidActionToken)
+	}
+	return &generated.AccountEmailDto{Email: email, Verified: true}, nil
+}
+
+func (s *AuthService) RequestPasswordReset(ctx context.Context, req *generated.EmailDto) (*emptypb.Empty, error) {
+	acc, err := repository.GetAccountByEmail(req.Email)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	if acc != nil && acc.EmailVerified && !acc.Bot {
+		if _, err := sendPasswordResetEmail(acc); err != nil {
+			log.Printf("Failed to send password reset email to player %d: %v", acc.PlayerID, err)
+		}
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthService) ResetPassword(ctx context.Context, req *generated.ResetPasswordDto) (*emptypb.Empty, error) {
+	playerID, fingerprint, err := middlewares.ParseActionToken(middlewares.PurposePasswordReset, req.Token)
+	if err == nil {
+		err = repository.ResetPassword(playerID, fingerprint, req.Password)
+	}
+	switch {
+	case errors.Is(err, account.ErrInvalidActionToken):
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	case errors.Is(err, account.ErrInvalidPassword):
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthService) Refresh(ctx context.Context, req *generated.RefreshTokenDto) (*generated.TokenDto, error) {
+	session, refreshToken, err := repository.RefreshSession(req.RefreshToken)
+	switch {
+	case errors.Is(err, account.ErrInvalidRefreshToken):
+		return nil, status.Errorf(codes.Unauthenticated, "%v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	player, err := repository.GetPlayerByID(session.PlayerID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	if player == nil {
+		return nil, status.Errorf(codes.Unauthenticated, "player not found")
+	}
+	return newTokenDto(player, session, refreshToken)
+}
+
+func (s *AuthService) Logout(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	playerID, sessionID, err := sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := repository.RevokeSession(playerID, sessionID); err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthService) LogoutEverywhere(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := repository.RevokePlayerSessions(playerID); err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+func (s *AuthService) ListSessions(ctx context.Context, _ *emptypb.Empty) (*generated.SessionList, error) {
+	playerID, currentID, err := sessionFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions, err := repository.GetPlayerSessions(playerID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	sessionDtos := make([]*generated.SessionDto, len(sessions))
+	for i, s := range sessions {
+		sessionDtos[i] = &generated.SessionDto{
+			Id:               s.ID,
+			UserAgent:        s.UserAgent,
+			Ip:               s.IP,
+			CreatedAtUnixMs:  s.CreatedAt.UnixMilli(),
+			LastUsedAtUnixMs: s.LastUsedAt.UnixMilli(),
+			Current:          s.ID == currentID,
+		}
+	}
+	return &generated.SessionList{Sessions: sessionDtos}, nil
+}
+
+func (s *AuthService) RevokeSession(ctx context.Context, req *generated.RevokeSessionDto) (*emptypb.Empty, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := repository.RevokeSession(playerID, req.Id)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	if !revoked {
+		return nil, status.Errorf(codes.NotFound, "session not found")
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// sendVerificationEmail sends a link to verify the address of the account,
+// unless one was sent less than account.MailInterval ago. It reports whether
+// the email was sent.
+func sendVerificationEmail(acc *account.Account) (bool, error) {
+	if ok, err := repository.ReserveMail(middlewares.PurposeEmailVerification, acc.PlayerID); !ok || err != nil {
+		return false, err
+	}
+	token, err := middlewares.IssueActionToken(middlewares.PurposeEmailVerification, acc.PlayerID, acc.Email, account.EmailVerificationTTL)
+	if err != nil {
+		return false, err
+	}
+	mailer.SendAsync(mailer.VerificationEmail(acc.Email, acc.Username, token))
+	return true, nil
+}
+
+// sendPasswordResetEmail sends a token to reset the password of the account,
+// unless one was sent less than account.MailInterval ago.
+func sendPasswordResetEmail(acc *account.Account) (bool, error) {
+	if ok, err := repository.ReserveMail(middlewares.PurposePasswordReset, acc.PlayerID); !ok || err != nil {
+		return false, err
+	}
+	token, err := middlewares.IssueActionToken(middlewares.PurposePasswordReset, acc.PlayerID, acc.PasswordFingerprint(), account.PasswordResetTTL)
+	if err != nil {
+		return false, err
+	}
+	mailer.SendAsync(mailer.PasswordResetEmail(acc.Email, acc.Username, token))
+	return true, nil
+}
+
+func twoFactorError(err error) error {
+	switch {
+	case errors.Is(err, account.ErrInvalidTwoFactorCode):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, account.ErrTwoFactorEnabled), errors.Is(err, account.ErrTwoFactorNotEnabled),
+		errors.Is(err, account.ErrTwoFactorNotSetUp):
+		return status.Errorf(codes.FailedPrecondition, "%v", err)
+	case errors.Is(err, account.ErrTwoFactorLocked):
+		return status.Errorf(codes.ResourceExhausted, "%v", err)
+	}
+	return status.Errorf(codes.Internal, "internal error: %v", err)
+}
+
+// startSession logs the player in on the client making the call.
+func startSession(ctx context.Context, player *room.Player) (*generated.TokenDto, error) {
+	var userAgent, ip string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("user-agent"); len(values) > 0 {
+			userAgent = values[0]
+		}
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		ip = p.Addr.String()
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+	}
+
+	session, refreshToken, err := repository.CreateSession(player.ID, userAgent, ip)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	return newTokenDto(player, session, refreshToken)
+}
+
+func newTokenDto(player *room.Player, session *account.Session, refreshToken string) (*generated.TokenDto, error) {
+	role, err := repository.GetPlayerRole(player.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	token, expiresAt, err := middlewares.IssueAccessToken(player.ID, session.ID, role)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	return &generated.TokenDto{
+		AccessToken:  token,
+		TokenType:    "Bearer",
+		ExpiresIn:    int32(time.Until(expiresAt).Seconds()),
+		RefreshToken: refreshToken,
+		SessionId:    session.ID,
+		Player:       &generated.GetPlayerDto{Id: int32(player.ID), Name: player.Name},
+	}, nil
+}
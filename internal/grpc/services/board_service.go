@@ -48,6 +48,7 @@ func (s *BoardService) GetAllBoards(ctx context.Context, _ *emptypb.Empty) (*gen
 
 func (s *BoardService) CreateBoard(ctx context.Context, req *generated.CreateBoardDto) (*generated.GetBoardDto, error) {
 	board := game.NewBoard(int(req.Size))
+	board.OwnerID = viewerIDFromContext(ctx)
 	repository.AddEntity(board)
 	return &generated.GetBoardDto{
 		Id:   int32(board.ID),
@@ -56,6 +57,10 @@ func (s *BoardService) CreateBoard(ctx context.Context, req *generated.CreateBoa
 }
 
 func (s *BoardService) UpdateBoard(ctx context.Context, req *generated.UpdateBoardDto) (*generated.GetBoardDto, error) {
+	if err := authorizeBoardOwner(ctx, int(req.Id)); err != nil {
+		return nil, err
+	}
+
 	ok, err := repository.UpdateBoardByID(int(req.Id), int(req.Size))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
@@ -77,6 +82,10 @@ func (s *BoardService) UpdateBoard(ctx context.Context, req *generated.UpdateBoa
 }
 
 func (s *BoardService) DeleteBoard(ctx context.Context, req *generated.RequestEntity) (*emptypb.Empty, error) {
+	if err := authorizeBoardOwner(ctx, int(req.Id)); err != nil {
+		return nil, err
+	}
+
 	ok, err := repository.DeleteBoardByID(int(req.Id))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
@@ -87,3 +96,17 @@ func (s *BoardService) DeleteBoard(ctx context.Context, req *generated.RequestEn
 	}
 	return &emptypb.Empty{}, nil
 }
+
+// authorizeBoardOwner checks that the board exists and that the caller
+// created it or is an admin.
+func authorizeBoardOwner(ctx context.Context, id int) error {
+	board, err := repository.GetBoardByID(id)
+	if err != nil {
+		return status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	if board == nil {
+		return status.Errorf(codes.NotFound, "board not found")
+	}
+	return authorizeOwner(ctx, board.OwnerID, "only the board owner can change the board")
+}
@@ -2,15 +2,22 @@ package services
 
 import (
 	"context"
+	"time"
 
+	"github.com/moLIart/go-course/internal/events"
 	"github.com/moLIart/go-course/internal/grpc/generated"
+	"github.com/moLIart/go-course/internal/model/account"
 	"github.com/moLIart/go-course/internal/model/game"
+	"github.com/moLIart/go-course/internal/realtime"
 	"github.com/moLIart/go-course/internal/repository"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/emptypb"
 )
 
+// gameSnapshot is the type of the first message of WatchGame.
+const gameSnapshot = "game.snapshot"
+
 type GameService struct {
 	generated.UnimplementedGameServiceServer
 }
@@ -24,9 +31,12 @@ func (s *GameService) GetGame(ctx context.Context, req *generated.RequestEntity)
 	if g == nil {
 		return nil, status.Errorf(codes.NotFound, "game not found")
 	}
-	return &generated.GetGameDto{
-		Id: int32(g.ID),
-	}, nil
+
+	view, err := repository.ViewGame(g, viewerIDFromContext(ctx))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	return newGameDto(view), nil
 }
 
 func (s *GameService) GetAllGames(ctx context.Context, _ *emptypb.Empty) (*generated.GameList, error) {
@@ -35,11 +45,14 @@ func (s *GameService) GetAllGames(ctx context.Context, _ *emptypb.Empty) (*gener
 		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
 	}
 
+	viewerID := viewerIDFromContext(ctx)
 	gameDtos := make([]*generated.GetGameDto, len(games))
 	for i, g := range games {
-		gameDtos[i] = &generated.GetGameDto{
-			Id: int32(g.ID),
+		view, err := repository.ViewGame(g, viewerID)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "internal error: %v", err)
 		}
+		gameDtos[i] = newGameDto(view)
 	}
 	return &generated.GameList{Games: gameDtos}, nil
 }
@@ -47,12 +60,14 @@ func (s *GameService) GetAllGames(ctx context.Context, _ *emptypb.Empty) (*gener
 func (s *GameService) CreateGame(ctx context.Context, _ *emptypb.Empty) (*generated.GetGameDto, error) {
 	g := game.NewGame()
 	repository.AddEntity(g)
-	return &generated.GetGameDto{
-		Id: int32(g.ID),
-	}, nil
+	return newGameDto(g), nil
 }
 
 func (s *GameService) DeleteGame(ctx context.Context, req *generated.RequestEntity) (*emptypb.Empty, error) {
+	if err := authorizeGameDeletion(ctx, int(req.Id)); err != nil {
+		return nil, err
+	}
+
 	ok, err := repository.DeleteGameByID(int(req.Id))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
@@ -63,3 +78,186 @@ func (s *GameService) DeleteGame(ctx context.Context, req *generated.RequestEnti
 	}
 	return &emptypb.Empty{}, nil
 }
+
+// authorizeGameDeletion checks that the game exists and that the caller
+// played it and it is over, or is an admin.
+func authorizeGameDeletion(ctx context.Context, id int) error {
+	g, err := repository.GetGameByID(id)
+	if err != nil {
+		return status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	if g == nil {
+		return status.Errorf(codes.NotFound, "game not found")
+	}
+
+	principal, err := principalFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !principal.HasRole(account.RoleAdmin) && (!g.IsOver() || g.GetColor(principal.PlayerID) == game.Empty) {
+		return status.Errorf(codes.PermissionDenied, "only the players of a finished game can delete it")
+	}
+	return nil
+}
+
+func (s *GameService) WatchGame(req *generated.RequestEntity, stream generated.GameService_WatchGameServer) error {
+	ctx := stream.Context()
+	gameID := int(req.Id)
+	viewerID := viewerIDFromContext(ctx)
+
+	r, err := repository.GetRoomByGameID(gameID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	if r == nil {
+		// Games outside rooms never change.
+		return sendStoredGame(stream, gameID)
+	}
+
+	watched, stop := realtime.WatchRoom(r.ID, viewerID)
+	defer stop()
+
+	// Reload the room once subscribed so that no change is missed.
+	r, err = repository.GetRoomByID(r.ID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	if r == nil || r.Game == nil || r.Game.ID != gameID {
+		return sendStoredGame(stream, gameID)
+	}
+
+	g := r.ViewFor(viewerID).Game
+	if err := stream.Send(newGameSnapshotEvent(r.ID, g)); err != nil {
+		return err
+	}
+	if g.IsOver() {
+		return nil
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case e, ok := <-watched:
+			if !ok {
+				return status.Errorf(codes.Unavailable, "stream fell behind the game's events, reconnect to catch up")
+			}
+			if e.GameID != gameID {
+				continue
+			}
+			msg := newGameEvent(e)
+			if msg == nil {
+				continue
+			}
+			if err := stream.Send(msg); err != nil {
+				return err
+			}
+			if e.Type == events.GameOver {
+				return nil
+			}
+		}
+	}
+}
+
+func sendStoredGame(stream generated.GameService_WatchGameServer, gameID int) error {
+	g, err := repository.GetGameByID(gameID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	if g == nil {
+		return status.Errorf(codes.NotFound, "game not found")
+	}
+	return stream.Send(newGameSnapshotEvent(0, g))
+}
+
+func newGameSnapshotEvent(roomID int, g *game.Game) *generated.GameEvent {
+	return &generated.GameEvent{
+		Type:       gameSnapshot,
+		GameId:     int32(g.ID),
+		RoomId:     int32(roomID),
+		TimeUnixMs: time.Now().UnixMilli(),
+		Payload:    &generated.GameEvent_Game{Game: newGameDto(g)},
+	}
+}
+
+// newGameEvent converts a game event, returning nil for room events.
+func newGameEvent(e events.Event) *generated.GameEvent {
+	msg := &generated.GameEvent{
+		Type:       e.Type,
+		GameId:     int32(e.GameID),
+		RoomId:     int32(e.RoomID),
+		TimeUnixMs: e.Time.UnixMilli(),
+	}
+
+	switch data := e.Data.(type) {
+	case events.Move:
+		msg.Payload = &generated.GameEvent_Move{Move: &generated.GameMoveDto{
+			Number:   int32(data.Number),
+			PlayerId: int32(data.PlayerID),
+			Color:    data.Color,
+			Pass:     data.Pass,
+			X:        int32(data.X),
+			Y:        int32(data.Y),
+		}}
+	case events.Takeback:
+		msg.Payload = &generated.GameEvent_MoveCount{MoveCount: int32(data.MoveCount)}
+	case events.Player:
+		// Joins and leaves carry players too but are room events.
+		if e.Type != events.TakebackAsked && e.Type != events.TakebackDeclined {
+			return nil
+		}
+		msg.Payload = &generated.GameEvent_PlayerId{PlayerId: int32(data.PlayerID)}
+	case events.Clock:
+		msg.Payload = &generated.GameEvent_Clock{Clock: &generated.ClockDto{
+			BlackRemainingMs: data.BlackRemainingMs,
+			WhiteRemainingMs: data.WhiteRemainingMs,
+			BlackPeriods:     int32(data.BlackPeriods),
+			WhitePeriods:     int32(data.WhitePeriods),
+			CurrentTurn:      data.CurrentTurn,
+		}}
+	case events.Result:
+		msg.Payload = &generated.GameEvent_Result{Result: &generated.GameResultDto{
+			Status: data.Status,
+			Result: data.Result,
+		}}
+	default:
+		return nil
+	}
+	return msg
+}
+
+func newGameDto(g *game.Game) *generated.GetGameDto {
+	gameDto := &generated.GetGameDto{
+		Id:                  int32(g.ID),
+		BlackId:             int32(g.BlackID),
+		WhiteId:             int32(g.WhiteID),
+		BlackPartnerId:      int32(g.BlackPartnerID),
+		WhitePartnerId:      int32(g.WhitePartnerID),
+		CurrentPlayerId:     int32(g.GetCurrentPlayerID()),
+		CurrentTurn:         g.GetCurrentTurn().String(),
+		Status:              g.GetStatus().String(),
+		Result:              g.Result,
+		MoveCount:           int32(len(g.Moves)),
+		BlackCaptures:       int32(g.BlackCaptures),
+		WhiteCaptures:       int32(g.WhiteCaptures),
+		Board:               g.GetBoard().Rows(),
+		Ruleset:             string(g.Ruleset),
+		Komi:                g.Komi,
+		Handicap:            int32(g.Handicap),
+		TakebackRequestedBy: int32(g.PendingTakeback()),
+	}
+	if g.Clock != nil {
+		now := time.Now()
+		blackRemaining, blackPeriods := g.RemainingTime(game.Black, now)
+		whiteRemaining, whitePeriods := g.RemainingTime(game.White, now)
+		gameDto.Clock = &generated.ClockDto{
+			BlackRemainingMs: blackRemaining.Milliseconds(),
+			WhiteRemainingMs: whiteRemaining.Milliseconds(),
+			BlackPeriods:     int32(blackPeriods),
+			WhitePeriods:     int32(whitePeriods),
+		}
+	}
+	return gameDto
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"context"
+
+	"github.com/moLIart/go-course/internal/grpc/generated"
+	"github.com/moLIart/go-course/internal/model/game"
+	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *RoomService) CommitNigiri(ctx context.Context, req *generated.NigiriCommitDto) (*generated.GetRoomDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := repository.CommitNigiri(int(req.RoomId), playerID, req.Commitment)
+	return roomUpdateResult(ctx, r, err)
+}
+
+func (s *RoomService) GuessNigiri(ctx context.Context, req *generated.NigiriGuessDto) (*generated.GetRoomDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := repository.GuessNigiri(int(req.RoomId), playerID, req.Guess)
+	return roomUpdateResult(ctx, r, err)
+}
+
+func (s *RoomService) RevealNigiri(ctx context.Context, req *generated.NigiriRevealDto) (*generated.GetRoomDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := repository.RevealNigiri(int(req.RoomId), playerID, int(req.Stones), req.Nonce)
+	return roomUpdateResult(ctx, r, err)
+}
+
+func (s *RoomService) ClaimNigiri(ctx context.Context, req *generated.NigiriClaimDto) (*generated.GetRoomDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	color, _ := game.ParseColor(req.Color)
+	r, err := repository.ClaimNigiri(int(req.RoomId), playerID, color)
+	return roomUpdateResult(ctx, r, err)
+}
+
+func (s *RoomService) GetNigiriAudit(ctx context.Context, req *generated.RequestEntity) (*generated.NigiriList, error) {
+	r, err := repository.GetRoomByID(int(req.Id))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	if r == nil {
+		return nil, status.Errorf(codes.NotFound, "room not found")
+	}
+
+	nigiriDtos := make([]*generated.GetNigiriDto, 0, len(r.NigiriHistory)+1)
+	for _, n := range r.NigiriHistory {
+		nigiriDtos = append(nigiriDtos, newNigiriDto(n))
+	}
+	if r.Nigiri != nil {
+		nigiriDtos = append(nigiriDtos, newNigiriDto(r.Nigiri))
+	}
+	return &generated.NigiriList{Nigiri: nigiriDtos}, nil
+}
+
+func newNigiriDto(n *room.Nigiri) *generated.GetNigiriDto {
+	log := make([]*generated.NigiriEventDto, len(n.Log))
+	for i, e := range n.Log {
+		log[i] = &generated.NigiriEventDto{
+			At:       e.At.Unix(),
+			PlayerId: int32(e.PlayerID),
+			Action:   e.Action,
+			Detail:   e.Detail,
+		}
+	}
+	nigiriDto := &generated.GetNigiriDto{
+		HolderId:   int32(n.HolderID),
+		GuesserId:  int32(n.GuesserID),
+		Commitment: n.Commitment,
+		Guess:      n.Guess,
+		Stones:     int32(n.Stones),
+		Nonce:      n.Nonce,
+		BlackId:    int32(n.BlackID),
+		GameId:     int32(n.GameID),
+		Log:        log,
+	}
+	if deadline := n.RevealDeadline(); !deadline.IsZero() && !n.IsRevealed() {
+		nigiriDto.RevealDeadline = deadline.Unix()
+	}
+	return nigiriDto
+}
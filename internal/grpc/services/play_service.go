@@ -0,0 +1,59 @@
+package services
+
+import (
+	"context"
+
+	"github.com/moLIart/go-course/internal/grpc/generated"
+	"github.com/moLIart/go-course/internal/model/game"
+	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+func (s *RoomService) PlayMove(ctx context.Context, req *generated.MoveDto) (*generated.GetRoomDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := repository.PlayMove(int(req.RoomId), playerID, game.Point{X: int(req.X), Y: int(req.Y)})
+	return roomUpdateResult(ctx, r, err)
+}
+
+func (s *RoomService) Pass(ctx context.Context, req *generated.RequestEntity) (*generated.GetRoomDto, error) {
+	return gameAction(ctx, req, repository.Pass)
+}
+
+func (s *RoomService) Resign(ctx context.Context, req *generated.RequestEntity) (*generated.GetRoomDto, error) {
+	return gameAction(ctx, req, repository.Resign)
+}
+
+func (s *RoomService) AcceptScore(ctx context.Context, req *generated.RequestEntity) (*generated.GetRoomDto, error) {
+	return gameAction(ctx, req, repository.AcceptScore)
+}
+
+func (s *RoomService) ResumePlay(ctx context.Context, req *generated.RequestEntity) (*generated.GetRoomDto, error) {
+	return gameAction(ctx, req, repository.ResumePlay)
+}
+
+func (s *RoomService) Takeback(ctx context.Context, req *generated.RequestEntity) (*generated.GetRoomDto, error) {
+	return gameAction(ctx, req, repository.Takeback)
+}
+
+func (s *RoomService) AcceptTakeback(ctx context.Context, req *generated.RequestEntity) (*generated.GetRoomDto, error) {
+	return gameAction(ctx, req, repository.AcceptTakeback)
+}
+
+func (s *RoomService) DeclineTakeback(ctx context.Context, req *generated.RequestEntity) (*generated.GetRoomDto, error) {
+	return gameAction(ctx, req, repository.DeclineTakeback)
+}
+
+// gameAction runs a game action for the player identified by the bearer token.
+func gameAction(ctx context.Context, req *generated.RequestEntity, action func(id int, playerID int) (*room.Room, error)) (*generated.GetRoomDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := action(int(req.Id), playerID)
+	return roomUpdateResult(ctx, r, err)
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+
+	"github.com/moLIart/go-course/internal/events"
+	"github.com/moLIart/go-course/internal/grpc/generated"
+	"github.com/moLIart/go-course/internal/model/chat"
+	"github.com/moLIart/go-course/internal/model/game"
+	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/realtime"
+	"github.com/moLIart/go-course/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (s *RoomService) PlaySession(stream generated.RoomService_PlaySessionServer) error {
+	ctx := stream.Context()
+	player, err := playerFromContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	session := &playSession{stream: stream, player: player, stop: func() {}}
+	defer func() { session.stop() }()
+
+	commands, recvErr := receiveCommands(ctx, stream)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case err := <-recvErr:
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		case cmd := <-commands:
+			if err := session.run(cmd); err != nil {
+				return err
+			}
+		case e, ok := <-session.watched:
+			if !ok {
+				return status.Errorf(codes.Unavailable, "session fell behind the room's events, reconnect to catch up")
+			}
+			if err := session.forward(e); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// receiveCommands reads the commands of a stream until it fails, so that
+// they can be waited for together with room events.
+func receiveCommands(ctx context.Context, stream generated.RoomService_PlaySessionServer) (<-chan *generated.PlayCommand, <-chan error) {
+	commands := make(chan *generated.PlayCommand)
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			cmd, err := stream.Recv()
+			if err != nil {
+				recvErr <- err
+				return
+			}
+			select {
+			case commands <- cmd:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return commands, recvErr
+}
+
+// playSession is the state of a PlaySession stream. Only the goroutine
+// running PlaySession uses it.
+type playSession struct {
+	stream  generated.RoomService_PlaySessionServer
+	player  *room.Player
+	roomID  int
+	watched <-chan events.Event
+	stop    func()
+}
+
+// run executes a command, answering refused ones with a PlayError. Only
+// failures to send end the session.
+func (p *playSession) run(cmd *generated.PlayCommand) error {
+	var err error
+	switch c := cmd.Command.(type) {
+	case *generated.PlayCommand_Sit:
+		var roomDto *generated.GetRoomDto
+		if roomDto, err = p.sit(int(c.Sit.Id)); err == nil {
+			return p.send(&generated.PlayEvent{Event: &generated.PlayEvent_Room{Room: roomDto}})
+		}
+	case nil:
+		err = status.Errorf(codes.InvalidArgument, "missing command")
+	default:
+		err = p.act(cmd)
+	}
+
+	if err == nil {
+		return nil
+	}
+	return p.send(&generated.PlayEvent{Event: &generated.PlayEvent_Error{Error: newPlayError(cmd.Seq, err)}})
+}
+
+// sit seats the player in the room, or keeps their seat, and starts
+// forwarding the room's events.
+func (p *playSession) sit(roomID int) (*generated.GetRoomDto, error) {
+	watched, stop := realtime.WatchRoom(roomID, p.player.ID)
+
+	r, err := repository.JoinRoom(roomID, "", p.player)
+	if errors.Is(err, room.ErrAlreadyInRoom) {
+		r, err = repository.GetRoomByID(roomID)
+	}
+	roomDto, err := roomUpdateResult(p.stream.Context(), r, err)
+	if err != nil {
+		stop()
+		return nil, err
+	}
+
+	p.stop()
+	p.roomID, p.watched, p.stop = roomID, watched, stop
+	return roomDto, nil
+}
+
+// act runs a game command in the room the session sits in. Its effects reach
+// the client as room events.
+func (p *playSession) act(cmd *generated.PlayCommand) error {
+	if p.roomID == 0 {
+		return status.Errorf(codes.FailedPrecondition, "sit in a room first")
+	}
+
+	var r *room.Room
+	var err error
+	switch c := cmd.Command.(type) {
+	case *generated.PlayCommand_Move:
+		r, err = repository.PlayMove(p.roomID, p.player.ID, game.Point{X: int(c.Move.X), Y: int(c.Move.Y)})
+	case *generated.PlayCommand_Pass:
+		r, err = repository.Pass(p.roomID, p.player.ID)
+	case *generated.PlayCommand_Resign:
+		r, err = repository.Resign(p.roomID, p.player.ID)
+	case *generated.PlayCommand_Takeback:
+		r, err = repository.Takeback(p.roomID, p.player.ID)
+	case *generated.PlayCommand_AcceptTakeback:
+		r, err = repository.AcceptTakeback(p.roomID, p.player.ID)
+	case *generated.PlayCommand_DeclineTakeback:
+		r, err = repository.DeclineTakeback(p.roomID, p.player.ID)
+	case *generated.PlayCommand_AcceptScore:
+		r, err = repository.AcceptScore(p.roomID, p.player.ID)
+	case *generated.PlayCommand_ResumePlay:
+		r, err = repository.ResumePlay(p.roomID, p.player.ID)
+	case *generated.PlayCommand_Chat:
+		return p.chat(c.Chat)
+	}
+
+	_, err = roomUpdateResult(p.stream.Context(), r, err)
+	return err
+}
+
+func (p *playSession) chat(req *generated.PostChatMessageDto) error {
+	msg, err := repository.PostChatMessage(p.roomID, p.player, chat.Channel(req.Channel), req.Text)
+	switch {
+	case errors.Is(err, chat.ErrInvalidChannel), errors.Is(err, chat.ErrEmptyMessage), errors.Is(err, chat.ErrMessageTooLong):
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, room.ErrNotInChatChannel), errors.Is(err, room.ErrMuted):
+		return status.Errorf(codes.PermissionDenied, "%v", err)
+	case errors.Is(err, repository.ErrChatRateLimited):
+		return status.Errorf(codes.ResourceExhausted, "%v", err)
+	case err != nil:
+		return status.Errorf(codes.Internal, "internal error: %v", err)
+	case msg == nil:
+		return status.Errorf(codes.NotFound, "room not found")
+	}
+	return nil
+}
+
+// forward sends game and chat events as they are and the updated room after
+// room events.
+func (p *playSession) forward(e events.Event) error {
+	switch data := e.Data.(type) {
+	case events.Chat:
+		return p.send(&generated.PlayEvent{Event: &generated.PlayEvent_Chat{Chat: &generated.ChatMessageDto{
+			Id:         int32(data.MessageID),
+			Channel:    data.Channel,
+			PlayerId:   int32(data.PlayerID),
+			PlayerName: data.PlayerName,
+			Text:       data.Text,
+			TimeUnixMs: e.Time.UnixMilli(),
+		}}})
+	case events.ChatDeletion:
+		return p.send(&generated.PlayEvent{Event: &generated.PlayEvent_DeletedChatMessageId{DeletedChatMessageId: int32(data.MessageID)}})
+	}
+
+	if !strings.HasPrefix(e.Type, "room.") {
+		if msg := newGameEvent(e); msg != nil {
+			return p.send(&generated.PlayEvent{Event: &generated.PlayEvent_Game{Game: msg}})
+		}
+		return nil
+	}
+
+	r, err := repository.GetRoomByID(p.roomID)
+	if err != nil {
+		return status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	if r == nil {
+		return status.Errorf(codes.NotFound, "room not found")
+	}
+	roomDto := newRoomDto(r.ViewFor(p.player.ID), p.player.ID)
+	return p.send(&generated.PlayEvent{Event: &generated.PlayEvent_Room{Room: roomDto}})
+}
+
+func (p *playSession) send(e *generated.PlayEvent) error {
+	return p.stream.Send(e)
+}
+
+func newPlayError(seq int32, err error) *generated.PlayError {
+	st, _ := status.FromError(err)
+	return &generated.PlayError{
+		Seq:     seq,
+		Code:    st.Code().String(),
+		Message: st.Message(),
+	}
+}
@@ -56,6 +56,10 @@ func (s *PlayerService) CreatePlayer(ctx context.Context, req *generated.CreateP
 }
 
 func (s *PlayerService) UpdatePlayer(ctx context.Context, req *generated.UpdatePlayerDto) (*generated.GetPlayerDto, error) {
+	if err := authorizeOwner(ctx, int(req.Id), "players can only change themselves"); err != nil {
+		return nil, err
+	}
+
 	ok, err := repository.UpdatePlayerByID(int(req.Id), req.Name)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
@@ -76,7 +80,11 @@ func (s *PlayerService) UpdatePlayer(ctx context.Context, req *generated.UpdateP
 }
 
 func (s *PlayerService) DeletePlayer(ctx context.Context, req *generated.RequestEntity) (*emptypb.Empty, error) {
-	ok, err := repository.DeletePlayerByID(int(req.Id))
+	if err := authorizeOwner(ctx, int(req.Id), "players can only change themselves"); err != nil {
+		return nil, err
+	}
+
+	ok, err := repository.DeleteAccount(int(req.Id))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
 	}
@@ -2,8 +2,11 @@ package services
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"github.com/moLIart/go-course/internal/grpc/generated"
+	"github.com/moLIart/go-course/internal/model/game"
 	"github.com/moLIart/go-course/internal/model/room"
 	"github.com/moLIart/go-course/internal/repository"
 	"google.golang.org/grpc/codes"
@@ -24,45 +27,86 @@ func (s *RoomService) GetRoom(ctx context.Context, req *generated.RequestEntity)
 	if r == nil {
 		return nil, status.Errorf(codes.NotFound, "room not found")
 	}
-	return &generated.GetRoomDto{
-		Id:   int32(r.ID),
-		Code: r.Code,
-	}, nil
+	viewerID := viewerIDFromContext(ctx)
+	return newRoomDto(r.ViewFor(viewerID), viewerID), nil
 }
 
-func (s *RoomService) GetAllRooms(ctx context.Context, _ *emptypb.Empty) (*generated.RoomList, error) {
-	rooms, err := repository.GetRooms()
+func (s *RoomService) GetAllRooms(ctx context.Context, req *generated.RoomFilterDto) (*generated.RoomList, error) {
+	states := make([]room.State, len(req.States))
+	for i, s := range req.States {
+		states[i] = room.State(s)
+		if !states[i].IsValid() {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid state %q", s)
+		}
+	}
+
+	rooms, err := repository.GetRooms(states...)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
 	}
 
+	viewerID := viewerIDFromContext(ctx)
 	roomDtos := make([]*generated.GetRoomDto, len(rooms))
 	for i, r := range rooms {
-		roomDtos[i] = &generated.GetRoomDto{
-			Id:   int32(r.ID),
-			Code: r.Code,
-		}
+		roomDtos[i] = newRoomDto(r.ViewFor(viewerID), viewerID)
 	}
 	return &generated.RoomList{Rooms: roomDtos}, nil
 }
 
 func (s *RoomService) CreateRoom(ctx context.Context, req *generated.CreateRoomDto) (*generated.GetRoomDto, error) {
-	r := room.NewRoom(req.Code)
-	repository.AddEntity(r)
-	return &generated.GetRoomDto{
-		Id:   int32(r.ID),
-		Code: r.Code,
-	}, nil
+	if req.CodeTtl < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "code_ttl must not be negative")
+	}
+
+	settings := room.DefaultSettings()
+	if req.Settings != nil {
+		settings = applyRoomSettings(settings, req.Settings)
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	r := room.NewRoom("")
+	r.Settings = settings
+	r.OwnerID, _ = playerIDFromContext(ctx)
+	r.SetCodeTTL(time.Duration(req.CodeTtl) * time.Second)
+	if err := repository.CreateRoom(r); err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	return newRoomDto(r, r.OwnerID), nil
 }
 
 func (s *RoomService) UpdateRoom(ctx context.Context, req *generated.UpdateRoomDto) (*generated.GetRoomDto, error) {
-	ok, err := repository.UpdateRoomByID(int(req.Id), req.Code)
-	if err != nil {
-		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	if req.Code == "" && req.Settings == nil {
+		return nil, status.Errorf(codes.InvalidArgument, "nothing to update")
 	}
 
-	if !ok {
-		return nil, status.Errorf(codes.NotFound, "room not found")
+	if err := authorizeRoomOwner(ctx, int(req.Id)); err != nil {
+		return nil, err
+	}
+
+	if req.Settings != nil {
+		r, err := repository.UpdateRoomSettings(int(req.Id), func(s room.Settings) room.Settings {
+			return applyRoomSettings(s, req.Settings)
+		})
+		if _, err := roomUpdateResult(ctx, r, err); err != nil {
+			return nil, err
+		}
+	}
+
+	if req.Code != "" {
+		ok, err := repository.UpdateRoomByID(int(req.Id), room.NormalizeCode(req.Code))
+		if errors.Is(err, repository.ErrCodeTaken) {
+			return nil, status.Errorf(codes.AlreadyExists, "%v", err)
+		}
+
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+		}
+
+		if !ok {
+			return nil, status.Errorf(codes.NotFound, "room not found")
+		}
 	}
 
 	r, err := repository.GetRoomByID(int(req.Id))
@@ -70,13 +114,14 @@ func (s *RoomService) UpdateRoom(ctx context.Context, req *generated.UpdateRoomD
 		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
 	}
 
-	return &generated.GetRoomDto{
-		Id:   int32(r.ID),
-		Code: r.Code,
-	}, nil
+	return newRoomDto(r, viewerIDFromContext(ctx)), nil
 }
 
 func (s *RoomService) DeleteRoom(ctx context.Context, req *generated.RequestEntity) (*emptypb.Empty, error) {
+	if err := authorizeRoomOwner(ctx, int(req.Id)); err != nil {
+		return nil, err
+	}
+
 	ok, err := repository.DeleteRoomByID(int(req.Id))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
@@ -87,3 +132,260 @@ func (s *RoomService) DeleteRoom(ctx context.Context, req *generated.RequestEnti
 	}
 	return &emptypb.Empty{}, nil
 }
+
+func (s *RoomService) JoinRoom(ctx context.Context, req *generated.RequestEntity) (*generated.GetRoomDto, error) {
+	player, err := playerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := repository.JoinRoom(int(req.Id), "", player)
+	return roomUpdateResult(ctx, r, err)
+}
+
+func (s *RoomService) JoinRoomByCode(ctx context.Context, req *generated.JoinRoomByCodeDto) (*generated.GetRoomDto, error) {
+	player, err := playerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := repository.JoinRoomByCode(room.NormalizeCode(req.Code), player)
+	return roomUpdateResult(ctx, r, err)
+}
+
+func (s *RoomService) LeaveRoom(ctx context.Context, req *generated.RequestEntity) (*generated.GetRoomDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := repository.LeaveRoom(int(req.Id), playerID)
+	return roomUpdateResult(ctx, r, err)
+}
+
+func (s *RoomService) StartGame(ctx context.Context, req *generated.StartGameDto) (*generated.GetRoomDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	assignment := room.ColorAssignment(req.ColorAssignment)
+	if !assignment.IsValid() {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid color assignment")
+	}
+
+	color, _ := game.ParseColor(req.Color)
+	r, err := repository.StartRoomGame(int(req.RoomId), playerID, assignment, color)
+	return roomUpdateResult(ctx, r, err)
+}
+
+// authorizeRoomOwner checks that the room exists and that the caller is its
+// owner or an admin.
+func authorizeRoomOwner(ctx context.Context, id int) error {
+	r, err := repository.GetRoomByID(id)
+	if err != nil {
+		return status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	if r == nil {
+		return status.Errorf(codes.NotFound, "room not found")
+	}
+	return authorizeOwner(ctx, r.OwnerID, "only the room owner can change the room")
+}
+
+// roomUpdateResult converts the outcome of a room modification into a reply
+// with the status code matching the reason it was refused.
+func roomUpdateResult(ctx context.Context, r *room.Room, err error) (*generated.GetRoomDto, error) {
+	switch {
+	case isAnyError(err, invalidRoomArgumentErrors):
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	case errors.Is(err, room.ErrNotSeated), errors.Is(err, room.ErrNotModerator), errors.Is(err, room.ErrColorChoiceForbidden), errors.Is(err, room.ErrCodeRequired):
+		return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+	case errors.Is(err, room.ErrCodeExpired), isAnyError(err, roomPreconditionErrors):
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	case r == nil:
+		return nil, status.Errorf(codes.NotFound, "room not found")
+	}
+	return newRoomDto(r, viewerIDFromContext(ctx)), nil
+}
+
+// invalidRoomArgumentErrors are room modification errors caused by invalid input.
+var invalidRoomArgumentErrors = []error{
+	room.ErrInvalidColorAssignment,
+	room.ErrInvalidCommitment,
+	room.ErrInvalidGuess,
+	room.ErrInvalidStones,
+	room.ErrRevealMismatch,
+	room.ErrInvalidRuleset,
+	room.ErrInvalidBoardSize,
+	room.ErrInvalidKomi,
+	room.ErrInvalidHandicap,
+	room.ErrInvalidTimeControl,
+	room.ErrRatedTakebacks,
+	room.ErrInvalidSpectators,
+	game.ErrOutOfBoard,
+}
+
+// roomPreconditionErrors are room modification errors caused by the current
+// state of the room.
+var roomPreconditionErrors = []error{
+	room.ErrNigiriInProgress,
+	room.ErrNoNigiri,
+	room.ErrNigiriOutOfTurn,
+	room.ErrRevealExpired,
+	room.ErrRevealPending,
+	room.ErrNigiriRequired,
+	room.ErrRoomFull,
+	room.ErrAlreadyInRoom,
+	room.ErrNotInRoom,
+	room.ErrRoomNotFull,
+	room.ErrGameInProgress,
+	room.ErrInvalidTransition,
+	room.ErrRoomAbandoned,
+	room.ErrNoGame,
+	game.ErrGameOver,
+	game.ErrNotYourTurn,
+	game.ErrOccupied,
+	game.ErrSuicide,
+	game.ErrKo,
+	game.ErrScoring,
+	game.ErrNotScoring,
+	game.ErrTimeout,
+	game.ErrNothingToTakeBack,
+	game.ErrTakebackPending,
+	game.ErrNoTakebackRequest,
+	room.ErrTakebacksDisabled,
+	room.ErrSettingsLocked,
+	room.ErrSeatsTaken,
+	room.ErrSpectatorsNotAllowed,
+	room.ErrSpectatorsFull,
+	room.ErrAlreadySpectating,
+	room.ErrNotSpectating,
+	repository.ErrConcurrentUpdate,
+}
+
+func isAnyError(err error, targets []error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// newRoomDto converts the room for a viewer. Only the owner is shown the
+// invite code, the others joined with it or have to be given it.
+func newRoomDto(r *room.Room, viewerID int) *generated.GetRoomDto {
+	players := make([]*generated.GetPlayerDto, 0, len(r.Players))
+	for _, player := range r.Players {
+		if player != nil {
+			players = append(players, &generated.GetPlayerDto{Id: int32(player.ID), Name: player.Name})
+		}
+	}
+	roomDto := &generated.GetRoomDto{
+		Id:             int32(r.ID),
+		Players:        players,
+		OwnerId:        int32(r.OwnerID),
+		State:          string(r.GetState()),
+		Settings:       newRoomSettingsDto(r.GetSettings()),
+		SpectatorCount: int32(len(r.Spectators)),
+	}
+	if viewerID != 0 && viewerID == r.OwnerID {
+		roomDto.Code = r.Code
+		if r.CodeExpiresAt != nil {
+			roomDto.CodeExpiresAt = r.CodeExpiresAt.Unix()
+		}
+	}
+	if r.GetSettings().PairGo {
+		for team := range 2 {
+			teamDto := &generated.TeamDto{}
+			for _, player := range r.GetTeam(team) {
+				if player != nil {
+					teamDto.Players = append(teamDto.Players, &generated.GetPlayerDto{Id: int32(player.ID), Name: player.Name})
+				}
+			}
+			roomDto.Teams = append(roomDto.Teams, teamDto)
+		}
+	}
+	if r.Game != nil {
+		roomDto.Game = newGameDto(r.Game)
+	}
+	if r.Nigiri != nil {
+		roomDto.Nigiri = newNigiriDto(r.Nigiri)
+	}
+	return roomDto
+}
+
+// applyRoomSettings overrides s with the fields present in the request.
+func applyRoomSettings(s room.Settings, req *generated.RoomSettingsDto) room.Settings {
+	if req.Ruleset != nil {
+		s.Ruleset = game.Ruleset(req.GetRuleset())
+	}
+	if req.BoardSize != nil {
+		s.BoardSize = int(req.GetBoardSize())
+	}
+	if req.Komi != nil {
+		s.Komi = req.GetKomi()
+	}
+	if req.Handicap != nil {
+		s.Handicap = int(req.GetHandicap())
+	}
+	if req.PairGo != nil {
+		s.PairGo = req.GetPairGo()
+	}
+	if tc := req.TimeControl; tc != nil {
+		s.TimeControl = game.TimeControl{
+			Type:       tc.Type,
+			MainTime:   int(tc.MainTime),
+			Increment:  int(tc.Increment),
+			Periods:    int(tc.Periods),
+			PeriodTime: int(tc.PeriodTime),
+		}
+	}
+	if req.Rated != nil {
+		s.Rated = req.GetRated()
+	}
+	if req.TakebacksAllowed != nil {
+		s.TakebacksAllowed = req.GetTakebacksAllowed()
+	}
+	if req.SpectatorsAllowed != nil {
+		s.SpectatorsAllowed = req.GetSpectatorsAllowed()
+	}
+	if req.MaxSpectators != nil {
+		s.MaxSpectators = int(req.GetMaxSpectators())
+	}
+	if req.SpectatorDelay != nil {
+		s.SpectatorDelay = int(req.GetSpectatorDelay())
+	}
+	return s
+}
+
+func newRoomSettingsDto(s room.Settings) *generated.RoomSettingsDto {
+	ruleset := string(s.Ruleset)
+	boardSize := int32(s.BoardSize)
+	handicap := int32(s.Handicap)
+	pairGo := s.PairGo
+	maxSpectators := int32(s.MaxSpectators)
+	spectatorDelay := int32(s.SpectatorDelay)
+	return &generated.RoomSettingsDto{
+		Ruleset:   &ruleset,
+		BoardSize: &boardSize,
+		Komi:      &s.Komi,
+		Handicap:  &handicap,
+		TimeControl: &generated.TimeControlDto{
+			Type:       s.TimeControl.Type,
+			MainTime:   int32(s.TimeControl.MainTime),
+			Increment:  int32(s.TimeControl.Increment),
+			Periods:    int32(s.TimeControl.Periods),
+			PeriodTime: int32(s.TimeControl.PeriodTime),
+		},
+		PairGo:            &pairGo,
+		Rated:             &s.Rated,
+		TakebacksAllowed:  &s.TakebacksAllowed,
+		SpectatorsAllowed: &s.SpectatorsAllowed,
+		MaxSpectators:     &maxSpectators,
+		SpectatorDelay:    &spectatorDelay,
+	}
+}
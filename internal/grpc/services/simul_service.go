@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/moLIart/go-course/internal/grpc/generated"
+	"github.com/moLIart/go-course/internal/model/game"
+	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/model/simul"
+	"github.com/moLIart/go-course/internal/repository"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type SimulService struct {
+	generated.UnimplementedSimulServiceServer
+}
+
+func (s *SimulService) GetSimul(ctx context.Context, req *generated.RequestEntity) (*generated.GetSimulDto, error) {
+	sim, err := repository.GetSimulByID(int(req.Id))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	if sim == nil {
+		return nil, status.Errorf(codes.NotFound, "simul not found")
+	}
+
+	rooms, err := repository.GetSimulRooms(sim.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	viewerID := viewerIDFromContext(ctx)
+	for i, r := range rooms {
+		rooms[i] = r.ViewFor(viewerID)
+	}
+	return newSimulDto(sim, rooms), nil
+}
+
+func (s *SimulService) GetAllSimuls(ctx context.Context, req *emptypb.Empty) (*generated.SimulList, error) {
+	simuls, err := repository.GetSimuls()
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	simulDtos := make([]*generated.GetSimulDto, len(simuls))
+	for i, sim := range simuls {
+		simulDtos[i] = newSimulDto(sim, nil)
+	}
+	return &generated.SimulList{Simuls: simulDtos}, nil
+}
+
+func (s *SimulService) CreateSimul(ctx context.Context, req *generated.CreateSimulDto) (*generated.GetSimulDto, error) {
+	hostID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	hostColor := game.White
+	if req.HostColor != "" {
+		hostColor, _ = game.ParseColor(req.HostColor)
+	}
+	settings := room.DefaultSettings()
+	if req.Settings != nil {
+		settings = applyRoomSettings(settings, req.Settings)
+	}
+
+	sim := simul.NewSimul(req.Name, hostID, int(req.MaxOpponents), hostColor, settings)
+	if err := sim.Validate(); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+
+	if err := repository.AddEntity(sim); err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+	return newSimulDto(sim, nil), nil
+}
+
+func (s *SimulService) JoinSimul(ctx context.Context, req *generated.RequestEntity) (*generated.GetSimulDto, error) {
+	player, err := playerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sim, err := repository.JoinSimul(int(req.Id), player)
+	return simulUpdateResult(sim, err)
+}
+
+func (s *SimulService) LeaveSimul(ctx context.Context, req *generated.RequestEntity) (*generated.GetSimulDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sim, err := repository.LeaveSimul(int(req.Id), playerID)
+	return simulUpdateResult(sim, err)
+}
+
+func (s *SimulService) StartSimul(ctx context.Context, req *generated.RequestEntity) (*generated.GetSimulDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sim, err := repository.StartSimul(int(req.Id), playerID)
+	return simulUpdateResult(sim, err)
+}
+
+func (s *SimulService) GetSimulQueue(ctx context.Context, req *generated.RequestEntity) (*generated.SimulBoardList, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sim, err := repository.GetSimulByID(int(req.Id))
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	if sim == nil {
+		return nil, status.Errorf(codes.NotFound, "simul not found")
+	}
+
+	if sim.HostID != playerID {
+		return nil, status.Errorf(codes.PermissionDenied, "%v", simul.ErrNotHost)
+	}
+
+	rooms, err := repository.GetSimulRooms(sim.ID)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	}
+
+	queue := sim.Queue(rooms)
+	boardDtos := make([]*generated.SimulBoardDto, len(queue))
+	for i, r := range queue {
+		boardDtos[i] = newSimulBoardDto(sim, r)
+	}
+	return &generated.SimulBoardList{Boards: boardDtos}, nil
+}
+
+// simulUpdateResult converts the outcome of a simul modification into a
+// reply with the status code matching the reason it was refused.
+func simulUpdateResult(sim *simul.Simul, err error) (*generated.GetSimulDto, error) {
+	switch {
+	case errors.Is(err, simul.ErrNotHost), errors.Is(err, repository.ErrHostNotFound):
+		return nil, status.Errorf(codes.PermissionDenied, "%v", err)
+	case isAnyError(err, simulPreconditionErrors):
+		return nil, status.Errorf(codes.FailedPrecondition, "%v", err)
+	case err != nil:
+		return nil, status.Errorf(codes.Internal, "internal error: %v", err)
+	case sim == nil:
+		return nil, status.Errorf(codes.NotFound, "simul not found")
+	}
+	return newSimulDto(sim, nil), nil
+}
+
+// simulPreconditionErrors are simul modification errors caused by the
+// current state of the simul.
+var simulPreconditionErrors = []error{
+	simul.ErrSimulFull,
+	simul.ErrSimulStarted,
+	simul.ErrHostCannotJoin,
+	simul.ErrAlreadyJoined,
+	simul.ErrNotJoined,
+	simul.ErrNoOpponents,
+	repository.ErrConcurrentUpdate,
+}
+
+// newSimulDto builds the overview of a simul. Boards only carry their game
+// when the rooms of the boards are given.
+func newSimulDto(sim *simul.Simul, rooms []*room.Room) *generated.GetSimulDto {
+	opponents := make([]*generated.GetPlayerDto, len(sim.Opponents))
+	for i, opponent := range sim.Opponents {
+		opponents[i] = &generated.GetPlayerDto{Id: int32(opponent.ID), Name: opponent.Name}
+	}
+
+	simulDto := &generated.GetSimulDto{
+		Id:           int32(sim.ID),
+		Name:         sim.Name,
+		HostId:       int32(sim.HostID),
+		HostColor:    sim.HostColor.String(),
+		MaxOpponents: int32(sim.MaxOpponents),
+		State:        string(sim.GetState(rooms)),
+		Settings:     newRoomSettingsDto(sim.Settings),
+		Opponents:    opponents,
+	}
+
+	roomsByID := make(map[int]*room.Room, len(rooms))
+	for _, r := range rooms {
+		roomsByID[r.ID] = r
+	}
+	for _, board := range sim.Boards {
+		r, ok := roomsByID[board.RoomID]
+		if !ok {
+			simulDto.Boards = append(simulDto.Boards, &generated.SimulBoardDto{RoomId: int32(board.RoomID), OpponentId: int32(board.OpponentID)})
+			continue
+		}
+
+		simulDto.Boards = append(simulDto.Boards, newSimulBoardDto(sim, r))
+		if r.Game == nil || !r.Game.IsOver() {
+			continue
+		}
+		switch hostColor := r.Game.GetColor(sim.HostID); {
+		case r.Game.Status == game.Draw:
+			simulDto.Draws++
+		case (r.Game.Status == game.BlackWon) == (hostColor == game.Black):
+			simulDto.HostWins++
+		default:
+			simulDto.HostLosses++
+		}
+	}
+	return simulDto
+}
+
+func newSimulBoardDto(sim *simul.Simul, r *room.Room) *generated.SimulBoardDto {
+	boardDto := &generated.SimulBoardDto{RoomId: int32(r.ID)}
+	for _, board := range sim.Boards {
+		if board.RoomID == r.ID {
+			boardDto.OpponentId = int32(board.OpponentID)
+		}
+	}
+	if r.Game != nil {
+		boardDto.Game = newGameDto(r.Game)
+		boardDto.HostToMove = !r.Game.IsOver() && r.Game.GetCurrentPlayerID() == sim.HostID
+	}
+	return boardDto
+}
@@ -0,0 +1,28 @@
+package services
+
+import (
+	"context"
+
+	"github.com/moLIart/go-course/internal/grpc/generated"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+func (s *RoomService) Spectate(ctx context.Context, req *generated.RequestEntity) (*generated.GetRoomDto, error) {
+	player, err := playerFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := repository.Spectate(int(req.Id), player)
+	return roomUpdateResult(ctx, r.ViewFor(player.ID), err)
+}
+
+func (s *RoomService) StopSpectating(ctx context.Context, req *generated.RequestEntity) (*generated.GetRoomDto, error) {
+	playerID, err := playerIDFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := repository.StopSpectating(int(req.Id), playerID)
+	return roomUpdateResult(ctx, r.ViewFor(playerID), err)
+}
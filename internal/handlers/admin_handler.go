@@ -0,0 +1,188 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/dto"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/model/game"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+// AdjudicateGameHandler ends a game in progress with the result decided by an admin.
+//
+//	@Summary		Adjudicate game (Requires admin role)
+//	@Description	Ends a game in progress with a win for black or white, or a draw.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Game ID"
+//	@Param			result	body		dto.AdjudicateGameDto	true	"Decided result"
+//	@Success		200		{object}	dto.GetRoomDto
+//	@Failure		400		{string}	string	"Invalid id parameter or request body"
+//	@Failure		401		{string}	string	"Missing or invalid Authorization header"
+//	@Failure		403		{string}	string	"Requires the admin role"
+//	@Failure		404		{string}	string	"Game not found"
+//	@Failure		409		{string}	string	"Game is not in progress"
+//	@Security		BearerAuth
+//	@Router			/admin/games/{id}/adjudicate [post]
+func AdjudicateGameHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	var adjudicateDto dto.AdjudicateGameDto
+	if err := json.NewDecoder(r.Body).Decode(&adjudicateDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	winner, ok := game.ParseColor(adjudicateDto.Winner)
+	if !ok && adjudicateDto.Winner != "draw" {
+		http.Error(w, "Invalid winner, expected black, white or draw", http.StatusBadRequest)
+		return
+	}
+
+	room, err := repository.AdjudicateGame(id, winner)
+	if err == nil && room == nil {
+		http.Error(w, "Game not found", http.StatusNotFound)
+		return
+	}
+	writeRoomUpdate(w, r, room, err)
+}
+
+// GetUsersHandler lists the accounts with their roles.
+//
+//	@Summary		Get all users (Requires admin role)
+//	@Description	Returns the accounts of every registered player with their role.
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{array}		dto.UserDto
+//	@Failure		401	{string}	string	"Missing or invalid Authorization header"
+//	@Failure		403	{string}	string	"Requires the admin role"
+//	@Security		BearerAuth
+//	@Router			/admin/users [get]
+func GetUsersHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	accounts, err := repository.GetAccounts()
+	if err != nil {
+		http.Error(w, "Failed to retrieve users", http.StatusInternalServerError)
+		return
+	}
+
+	userDtos := make([]dto.UserDto, len(accounts))
+	for i, acc := range accounts {
+		userDtos[i] = newUserDto(acc)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(userDtos); err != nil {
+		http.Error(w, "Failed to encode users", http.StatusInternalServerError)
+	}
+}
+
+// SetUserRoleHandler changes the role of a player's account.
+//
+//	@Summary		Set user role (Requires admin role)
+//	@Description	Gives the account another role and logs the player out everywhere so that the role applies at once. Admins cannot change their own role.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"Player ID"
+//	@Param			role	body		dto.SetUserRoleDto	true	"New role"
+//	@Success		200		{object}	dto.UserDto
+//	@Failure		400		{string}	string	"Invalid id parameter, request body or role"
+//	@Failure		401		{string}	string	"Missing or invalid Authorization header"
+//	@Failure		403		{string}	string	"Requires the admin role"
+//	@Failure		404		{string}	string	"User not found"
+//	@Security		BearerAuth
+//	@Router			/admin/users/{id}/role [put]
+func SetUserRoleHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	var roleDto dto.SetUserRoleDto
+	if err := json.NewDecoder(r.Body).Decode(&roleDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	role, err := account.ParseRole(roleDto.Role)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if playerID, _ := middlewares.PlayerIDFromContext(r.Context()); playerID == id {
+		http.Error(w, "Admins cannot change their own role", http.StatusBadRequest)
+		return
+	}
+
+	acc, err := repository.SetAccountRole(id, role)
+	if err != nil {
+		http.Error(w, "Failed to update user", http.StatusInternalServerError)
+		return
+	}
+
+	if acc == nil {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newUserDto(acc)); err != nil {
+		http.Error(w, "Failed to encode user", http.StatusInternalServerError)
+	}
+}
+
+// DeleteUserHandler deletes a player with their account and sessions.
+//
+//	@Summary		Delete user (Requires admin role)
+//	@Description	Deletes the player, their account and their sessions.
+//	@Tags			admin
+//	@Param			id	path		int		true	"Player ID"
+//	@Success		204	{string}	string	"No Content"
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Missing or invalid Authorization header"
+//	@Failure		403	{string}	string	"Requires the admin role"
+//	@Failure		404	{string}	string	"User not found"
+//	@Security		BearerAuth
+//	@Router			/admin/users/{id} [delete]
+func DeleteUserHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	ok, err := repository.DeleteAccount(id)
+	if err != nil {
+		http.Error(w, "Failed to delete user", http.StatusInternalServerError)
+		return
+	}
+
+	if !ok {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func newUserDto(acc *account.Account) dto.UserDto {
+	return dto.UserDto{
+		PlayerID:  acc.PlayerID,
+		Username:  acc.Username,
+		Role:      string(acc.GetRole()),
+		CreatedAt: acc.CreatedAt,
+	}
+}
@@ -0,0 +1,320 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/dto"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+// RegisterHandler creates a player account and logs it in.
+//
+//	@Summary		Register
+//	@Description	Creates a player named after the username and returns an access token for it. Usernames are unique regardless of case.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			credentials	body		dto.CredentialsDto	true	"Username and password"
+//	@Success		201			{object}	dto.TokenDto
+//	@Failure		400			{string}	string	"Invalid username or password"
+//	@Failure		409			{string}	string	"Username is already taken"
+//	@Router			/auth/register [post]
+func RegisterHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var credentials dto.CredentialsDto
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	player, err := repository.Register(credentials.Username, credentials.Password)
+	switch {
+	case errors.Is(err, account.ErrInvalidUsername), errors.Is(err, account.ErrInvalidPassword):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, repository.ErrUsernameTaken):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, "Failed to register", http.StatusInternalServerError)
+		return
+	}
+
+	startSession(w, r, player, http.StatusCreated)
+}
+
+// LoginHandler exchanges credentials for an access token.
+//
+//	@Summary		Log in
+//	@Description	Returns an access token carrying the player ID in its sub claim, to be sent as "Authorization: Bearer <token>". Accounts with two-factor authentication get a two-factor token instead, to send with their code to /auth/login/2fa.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			credentials	body		dto.CredentialsDto	true	"Username and password"
+//	@Success		200			{object}	dto.TokenDto
+//	@Success		202			{object}	dto.TwoFactorChallengeDto
+//	@Failure		400			{string}	string	"Invalid request body"
+//	@Failure		401			{string}	string	"Invalid username or password"
+//	@Router			/auth/login [post]
+func LoginHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var credentials dto.CredentialsDto
+	if err := json.NewDecoder(r.Body).Decode(&credentials); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	player, err := repository.Login(credentials.Username, credentials.Password)
+	switch {
+	case errors.Is(err, account.ErrInvalidCredentials):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case err != nil:
+		http.Error(w, "Failed to log in", http.StatusInternalServerError)
+		return
+	}
+
+	completeLogin(w, r, player)
+}
+
+// RefreshHandler exchanges a refresh token for new tokens.
+//
+//	@Summary		Refresh tokens
+//	@Description	Returns a new access token and a new refresh token for the session. Each refresh token can only be used once; using one again revokes its session.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			refresh	body		dto.RefreshTokenDto	true	"Refresh token"
+//	@Success		200		{object}	dto.TokenDto
+//	@Failure		400		{string}	string	"Invalid request body"
+//	@Failure		401		{string}	string	"Invalid refresh token"
+//	@Router			/auth/refresh [post]
+func RefreshHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var refreshDto dto.RefreshTokenDto
+	if err := json.NewDecoder(r.Body).Decode(&refreshDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	session, refreshToken, err := repository.RefreshSession(refreshDto.RefreshToken)
+	switch {
+	case errors.Is(err, account.ErrInvalidRefreshToken):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case err != nil:
+		http.Error(w, "Failed to refresh session", http.StatusInternalServerError)
+		return
+	}
+
+	player, err := repository.GetPlayerByID(session.PlayerID)
+	if err != nil {
+		http.Error(w, "Failed to retrieve player", http.StatusInternalServerError)
+		return
+	}
+
+	if player == nil {
+		http.Error(w, "Player not found", http.StatusUnauthorized)
+		return
+	}
+
+	writeTokens(w, player, session, refreshToken, http.StatusOK)
+}
+
+// LogoutHandler revokes the session of the request token.
+//
+//	@Summary		Log out (Requires authorization)
+//	@Description	Revokes the session of the token. Its access and refresh tokens are rejected from now on.
+//	@Tags			auth
+//	@Success		204	{string}	string	"No Content"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Security		BearerAuth
+//	@Router			/auth/logout [post]
+func LogoutHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	sessionID, _ := middlewares.SessionIDFromContext(r.Context())
+	if _, err := repository.RevokeSession(playerID, sessionID); err != nil {
+		http.Error(w, "Failed to revoke session", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// LogoutEverywhereHandler revokes every session of the player.
+//
+//	@Summary		Log out everywhere (Requires authorization)
+//	@Description	Revokes every session of the player identified by the token, including the current one.
+//	@Tags			auth
+//	@Success		204	{string}	string	"No Content"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Security		BearerAuth
+//	@Router			/auth/logout-all [post]
+func LogoutEverywhereHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	if err := repository.RevokePlayerSessions(playerID); err != nil {
+		http.Error(w, "Failed to revoke sessions", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSessionsHandler lists the active sessions of the player.
+//
+//	@Summary		List sessions (Requires authorization)
+//	@Description	Returns the active sessions of the player identified by the token, the most recently used first.
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{array}		dto.SessionDto
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Security		BearerAuth
+//	@Router			/auth/sessions [get]
+func GetSessionsHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	sessions, err := repository.GetPlayerSessions(playerID)
+	if err != nil {
+		http.Error(w, "Failed to retrieve sessions", http.StatusInternalServerError)
+		return
+	}
+
+	currentID, _ := middlewares.SessionIDFromContext(r.Context())
+	sessionDtos := make([]dto.SessionDto, len(sessions))
+	for i, s := range sessions {
+		sessionDtos[i] = dto.SessionDto{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastUsedAt: s.LastUsedAt,
+			Current:    s.ID == currentID,
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(sessionDtos); err != nil {
+		http.Error(w, "Failed to encode sessions", http.StatusInternalServerError)
+	}
+}
+
+// RevokeSessionHandler revokes one session of the player.
+//
+//	@Summary		Revoke session (Requires authorization)
+//	@Description	Revokes a session of the player identified by the token, logging out the device it belongs to.
+//	@Tags			auth
+//	@Param			id	path		string	true	"Session ID"
+//	@Success		204	{string}	string	"No Content"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		404	{string}	string	"Session not found"
+//	@Security		BearerAuth
+//	@Router			/auth/sessions/{id} [delete]
+func RevokeSessionHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	revoked, err := repository.RevokeSession(playerID, ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Failed to revoke session", http.StatusInternalServerError)
+		return
+	}
+
+	if !revoked {
+		http.Error(w, "Session not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// completeLogin logs in the player whose credentials were checked, unless
+// their account also needs a two-factor code.
+func completeLogin(w http.ResponseWriter, r *http.Request, player *room.Player) {
+	acc, err := repository.GetAccountByPlayerID(player.ID)
+	if err != nil {
+		http.Error(w, "Failed to retrieve account", http.StatusInternalServerError)
+		return
+	}
+
+	if acc == nil || !acc.TwoFactorEnabled() {
+		startSession(w, r, player, http.StatusOK)
+		return
+	}
+
+	challenge, err := repository.CreateTwoFactorChallenge(player.ID)
+	if err != nil {
+		http.Error(w, "Failed to start login", http.StatusInternalServerError)
+		return
+	}
+
+	challengeDto := dto.TwoFactorChallengeDto{
+		TwoFactorToken: challenge.Token,
+		ExpiresIn:      int(account.TwoFactorChallengeTTL.Seconds()),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	if err := json.NewEncoder(w).Encode(challengeDto); err != nil {
+		http.Error(w, "Failed to encode two-factor token", http.StatusInternalServerError)
+	}
+}
+
+// startSession logs the player in on the device making the request.
+func startSession(w http.ResponseWriter, r *http.Request, player *room.Player, status int) {
+	session, refreshToken, err := repository.CreateSession(player.ID, r.UserAgent(), middlewares.ClientIP(r))
+	if err != nil {
+		http.Error(w, "Failed to start session", http.StatusInternalServerError)
+		return
+	}
+
+	writeTokens(w, player, session, refreshToken, status)
+}
+
+func writeTokens(w http.ResponseWriter, player *room.Player, session *account.Session, refreshToken string, status int) {
+	role, err := repository.GetPlayerRole(player.ID)
+	if err != nil {
+		http.Error(w, "Failed to retrieve account", http.StatusInternalServerError)
+		return
+	}
+
+	token, expiresAt, err := middlewares.IssueAccessToken(player.ID, session.ID, role)
+	if err != nil {
+		http.Error(w, "Failed to issue token", http.StatusInternalServerError)
+		return
+	}
+
+	tokenDto := dto.TokenDto{
+		AccessToken:  token,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(time.Until(expiresAt).Seconds()),
+		RefreshToken: refreshToken,
+		SessionID:    session.ID,
+		Player:       dto.GetPlayerDto{ID: player.ID, Name: player.Name},
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(tokenDto); err != nil {
+		http.Error(w, "Failed to encode token", http.StatusInternalServerError)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/julienschmidt/httprouter"
 
 	"github.com/moLIart/go-course/internal/dto"
+	"github.com/moLIart/go-course/internal/middlewares"
 	"github.com/moLIart/go-course/internal/model/game"
 	"github.com/moLIart/go-course/internal/repository"
 )
@@ -23,6 +24,7 @@ import (
 //	@Success		201				{object}	dto.GetBoardDto
 //	@Failure		400				{string}	string	"Invalid request body"
 //	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/boards [post]
 func CreateBoardHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var boardDto dto.CreateBoardDto
@@ -32,6 +34,7 @@ func CreateBoardHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 	}
 
 	board := game.NewBoard(boardDto.Size)
+	board.OwnerID, _ = middlewares.PlayerIDFromContext(r.Context())
 	repository.AddEntity(board)
 	w.WriteHeader(http.StatusCreated)
 }
@@ -98,13 +101,15 @@ func GetBoardByIDHandler(w http.ResponseWriter, r *http.Request, ps httprouter.P
 // DeleteBoardHandler deletes a board by its ID.
 //
 //	@Summary		Delete board by ID (Requires authorization)
-//	@Description	Deletes a board by its ID.
+//	@Description	Deletes a board by its ID. Only the player who created the board or an admin can delete it.
 //	@Tags			boards
 //	@Param			id				path		int		true	"Board ID"
 //	@Success		200				{string}	string	"OK"
 //	@Failure		400				{string}	string	"Invalid id parameter"
+//	@Failure		403				{string}	string	"Only the board owner can change the board"
 //	@Failure		404				{string}	string	"Board not found"
 //	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/boards/{id} [delete]
 func DeleteBoardHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	id, err := strconv.Atoi(ps.ByName("id"))
@@ -113,6 +118,10 @@ func DeleteBoardHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 		return
 	}
 
+	if !authorizeBoardOwner(w, r, id) {
+		return
+	}
+
 	ok, err := repository.DeleteBoardByID(id)
 	if err != nil {
 		http.Error(w, "Failed to delete board", http.StatusInternalServerError)
@@ -130,15 +139,17 @@ func DeleteBoardHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 // UpdateBoardHandler updates a board's size by its ID.
 //
 //	@Summary		Update board by ID (Requires authorization)
-//	@Description	Updates the size of a board by its ID.
+//	@Description	Updates the size of a board by its ID. Only the player who created the board or an admin can update it.
 //	@Tags			boards
 //	@Accept			json
 //	@Param			id				path		int					true	"Board ID"
 //	@Param			board			body		dto.UpdateBoardDto	true	"Updated board size"
 //	@Success		200				{string}	string				"OK"
 //	@Failure		400				{string}	string				"Invalid id parameter or request body"
+//	@Failure		403				{string}	string				"Only the board owner can change the board"
 //	@Failure		404				{string}	string				"Board not found"
 //	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/boards/{id} [put]
 func UpdateBoardHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	id, err := strconv.Atoi(ps.ByName("id"))
@@ -153,6 +164,10 @@ func UpdateBoardHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 		return
 	}
 
+	if !authorizeBoardOwner(w, r, id) {
+		return
+	}
+
 	ok, err := repository.UpdateBoardByID(id, boardDto.Size)
 	if err != nil {
 		http.Error(w, "Failed to update board", http.StatusInternalServerError)
@@ -166,3 +181,19 @@ func UpdateBoardHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 
 	w.WriteHeader(http.StatusOK)
 }
+
+// authorizeBoardOwner checks that the board exists and that the principal of
+// the request created it or is an admin.
+func authorizeBoardOwner(w http.ResponseWriter, r *http.Request, id int) bool {
+	board, err := repository.GetBoardByID(id)
+	if err != nil {
+		http.Error(w, "Failed to retrieve board", http.StatusInternalServerError)
+		return false
+	}
+
+	if board == nil {
+		http.Error(w, "Board not found", http.StatusNotFound)
+		return false
+	}
+	return authorizeOwner(w, r, board.OwnerID, "Only the board owner can change the board")
+}
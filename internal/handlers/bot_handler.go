@@ -0,0 +1,265 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/dto"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+// CreateBotHandler creates a bot account managed by the player.
+//
+//	@Summary		Create a bot (Requires authorization)
+//	@Description	Creates a player named after the username with a bot account managed by the caller. Bots have no password and authenticate with the API keys of their owner.
+//	@Tags			bots
+//	@Accept			json
+//	@Produce		json
+//	@Param			bot	body		dto.CreateBotDto	true	"Bot username"
+//	@Success		201	{object}	dto.BotDto
+//	@Failure		400	{string}	string	"Invalid request body or username"
+//	@Failure		401	{string}	string	"Missing or invalid Authorization header"
+//	@Failure		403	{string}	string	"API keys cannot be used here"
+//	@Failure		409	{string}	string	"Username is already taken"
+//	@Security		BearerAuth
+//	@Router			/bots [post]
+func CreateBotHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var botDto dto.CreateBotDto
+	if err := json.NewDecoder(r.Body).Decode(&botDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	ownerID, _ := middlewares.PlayerIDFromContext(r.Context())
+	bot, err := repository.CreateBot(botDto.Username, ownerID)
+	switch {
+	case errors.Is(err, account.ErrInvalidUsername):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, repository.ErrUsernameTaken):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, "Failed to create bot", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newBotDto(bot)); err != nil {
+		http.Error(w, "Failed to encode bot", http.StatusInternalServerError)
+	}
+}
+
+// GetBotsHandler lists the bots of the player.
+//
+//	@Summary		Get my bots (Requires authorization)
+//	@Description	Returns the bots managed by the caller.
+//	@Tags			bots
+//	@Produce		json
+//	@Success		200	{array}		dto.BotDto
+//	@Failure		401	{string}	string	"Missing or invalid Authorization header"
+//	@Failure		403	{string}	string	"API keys cannot be used here"
+//	@Security		BearerAuth
+//	@Router			/bots [get]
+func GetBotsHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	ownerID, _ := middlewares.PlayerIDFromContext(r.Context())
+	bots, err := repository.GetBots(ownerID)
+	if err != nil {
+		http.Error(w, "Failed to retrieve bots", http.StatusInternalServerError)
+		return
+	}
+
+	botDtos := make([]dto.BotDto, len(bots))
+	for i, bot := range bots {
+		botDtos[i] = newBotDto(bot)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(botDtos); err != nil {
+		http.Error(w, "Failed to encode bots", http.StatusInternalServerError)
+	}
+}
+
+// CreateAPIKeyHandler creates an API key for a bot.
+//
+//	@Summary		Create a bot API key (Requires authorization)
+//	@Description	Creates a key the bot sends in the X-API-Key header. The key is only returned once, only its hash is stored. Keys with the play scope may do everything the bot could, read-only keys only read.
+//	@Tags			bots
+//	@Accept			json
+//	@Produce		json
+//	@Param			id	path		int						true	"Bot player ID"
+//	@Param			key	body		dto.CreateAPIKeyDto		true	"Key name and scopes"
+//	@Success		201	{object}	dto.NewAPIKeyDto
+//	@Failure		400	{string}	string	"Invalid id parameter, request body, name or scopes"
+//	@Failure		401	{string}	string	"Missing or invalid Authorization header"
+//	@Failure		403	{string}	string	"Only the bot owner can manage its keys"
+//	@Failure		404	{string}	string	"Bot not found"
+//	@Security		BearerAuth
+//	@Router			/bots/{id}/keys [post]
+func CreateAPIKeyHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	var keyDto dto.CreateAPIKeyDto
+	if err := json.NewDecoder(r.Body).Decode(&keyDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	scopes, err := account.ParseScopes(keyDto.Scopes)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !authorizeBotOwner(w, r, id) {
+		return
+	}
+
+	k, key, err := repository.CreateAPIKey(id, keyDto.Name, scopes)
+	switch {
+	case errors.Is(err, account.ErrInvalidAPIKeyName):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, "Failed to create API key", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(dto.NewAPIKeyDto{APIKeyDto: newAPIKeyDto(k), Key: key}); err != nil {
+		http.Error(w, "Failed to encode API key", http.StatusInternalServerError)
+	}
+}
+
+// GetAPIKeysHandler lists the API keys of a bot.
+//
+//	@Summary		Get bot API keys (Requires authorization)
+//	@Description	Returns the keys of the bot, revoked ones included, the newest first. The keys themselves are never returned again.
+//	@Tags			bots
+//	@Produce		json
+//	@Param			id	path		int	true	"Bot player ID"
+//	@Success		200	{array}		dto.APIKeyDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Missing or invalid Authorization header"
+//	@Failure		403	{string}	string	"Only the bot owner can manage its keys"
+//	@Failure		404	{string}	string	"Bot not found"
+//	@Security		BearerAuth
+//	@Router			/bots/{id}/keys [get]
+func GetAPIKeysHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	if !authorizeBotOwner(w, r, id) {
+		return
+	}
+
+	keys, err := repository.GetAPIKeys(id)
+	if err != nil {
+		http.Error(w, "Failed to retrieve API keys", http.StatusInternalServerError)
+		return
+	}
+
+	keyDtos := make([]dto.APIKeyDto, len(keys))
+	for i, k := range keys {
+		keyDtos[i] = newAPIKeyDto(k)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(keyDtos); err != nil {
+		http.Error(w, "Failed to encode API keys", http.StatusInternalServerError)
+	}
+}
+
+// RevokeAPIKeyHandler revokes an API key of a bot.
+//
+//	@Summary		Revoke a bot API key (Requires authorization)
+//	@Description	Revokes the key at once. Revoked keys are kept with the time they were revoked.
+//	@Tags			bots
+//	@Param			id		path		int		true	"Bot player ID"
+//	@Param			keyId	path		string	true	"API key ID"
+//	@Success		204		{string}	string	"No Content"
+//	@Failure		400		{string}	string	"Invalid id parameter"
+//	@Failure		401		{string}	string	"Missing or invalid Authorization header"
+//	@Failure		403		{string}	string	"Only the bot owner can manage its keys"
+//	@Failure		404		{string}	string	"Bot or API key not found"
+//	@Security		BearerAuth
+//	@Router			/bots/{id}/keys/{keyId} [delete]
+func RevokeAPIKeyHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	if !authorizeBotOwner(w, r, id) {
+		return
+	}
+
+	ok, err := repository.RevokeAPIKey(id, ps.ByName("keyId"))
+	if err != nil {
+		http.Error(w, "Failed to revoke API key", http.StatusInternalServerError)
+		return
+	}
+
+	if !ok {
+		http.Error(w, "API key not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authorizeBotOwner checks that the bot exists and that the principal of the
+// request is its owner or an admin.
+func authorizeBotOwner(w http.ResponseWriter, r *http.Request, id int) bool {
+	bot, err := repository.GetBot(id)
+	if err != nil {
+		http.Error(w, "Failed to retrieve bot", http.StatusInternalServerError)
+		return false
+	}
+
+	if bot == nil {
+		http.Error(w, "Bot not found", http.StatusNotFound)
+		return false
+	}
+	return authorizeOwner(w, r, bot.OwnerID, "Only the bot owner can manage its keys")
+}
+
+func newBotDto(bot *account.Account) dto.BotDto {
+	return dto.BotDto{
+		PlayerID:  bot.PlayerID,
+		Username:  bot.Username,
+		CreatedAt: bot.CreatedAt,
+	}
+}
+
+func newAPIKeyDto(k *account.APIKey) dto.APIKeyDto {
+	scopes := make([]string, len(k.Scopes))
+	for i, scope := range k.Scopes {
+		scopes[i] = string(scope)
+	}
+
+	return dto.APIKeyDto{
+		ID:         k.ID,
+		Name:       k.Name,
+		Scopes:     scopes,
+		CreatedAt:  k.CreatedAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
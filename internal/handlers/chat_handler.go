@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/dto"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/model/chat"
+	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+const (
+	defaultChatPageSize = 50
+	maxChatPageSize     = 100
+)
+
+// GetChatMessagesHandler returns a page of the chat history of a room.
+//
+//	@Summary		Get chat history
+//	@Description	Returns the messages of a chat channel, oldest first. Pass the returned before value to get older messages. Players of a game in progress cannot read the spectators channel.
+//	@Tags			chat
+//	@Produce		json
+//	@Param			id		path		int		true	"Room ID"
+//	@Param			channel	query		string	false	"players (default) or spectators"
+//	@Param			before	query		int		false	"Only return messages older than this message ID"
+//	@Param			limit	query		int		false	"Number of messages, 50 by default and at most 100"
+//	@Success		200		{object}	dto.ChatPageDto
+//	@Failure		400		{string}	string	"Invalid parameters"
+//	@Failure		403		{string}	string	"The viewer cannot read this channel"
+//	@Failure		404		{string}	string	"Room not found"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/chat [get]
+func GetChatMessagesHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	query := r.URL.Query()
+	channel := chat.Players
+	if c := query.Get("channel"); c != "" {
+		channel = chat.Channel(c)
+	}
+	if !channel.IsValid() {
+		http.Error(w, chat.ErrInvalidChannel.Error(), http.StatusBadRequest)
+		return
+	}
+
+	before, err := optionalIntParam(query.Get("before"), 0)
+	if err != nil || before < 0 {
+		http.Error(w, "Invalid before parameter", http.StatusBadRequest)
+		return
+	}
+	limit, err := optionalIntParam(query.Get("limit"), defaultChatPageSize)
+	if err != nil || limit < 1 || limit > maxChatPageSize {
+		http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+		return
+	}
+
+	room, err := repository.GetRoomByID(id)
+	if err != nil {
+		http.Error(w, "Failed to retrieve room", http.StatusInternalServerError)
+		return
+	}
+
+	if room == nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	viewerID, _ := middlewares.PlayerIDFromContext(r.Context())
+	if !room.CanReadChat(viewerID, channel) {
+		http.Error(w, "The viewer cannot read this channel", http.StatusForbidden)
+		return
+	}
+
+	messages, err := repository.GetChatMessages(id, channel, before, limit)
+	if err != nil {
+		http.Error(w, "Failed to retrieve chat messages", http.StatusInternalServerError)
+		return
+	}
+
+	page := dto.ChatPageDto{Messages: make([]dto.ChatMessageDto, len(messages))}
+	for i, msg := range messages {
+		page.Messages[i] = newChatMessageDto(msg)
+	}
+	if len(messages) == limit {
+		page.Before = messages[0].ID
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(page); err != nil {
+		http.Error(w, "Failed to encode chat messages", http.StatusInternalServerError)
+	}
+}
+
+// PostChatMessageHandler writes a message in the chat of a room.
+//
+//	@Summary		Write chat message (Requires authorization)
+//	@Description	Seated players write in the players channel and spectators in the spectators channel. A player may write 5 messages every 10 seconds in a room.
+//	@Tags			chat
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Room ID"
+//	@Param			message	body		dto.PostChatMessageDto	true	"Message"
+//	@Success		201		{object}	dto.ChatMessageDto
+//	@Failure		400		{string}	string	"Invalid request body or message"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		403		{string}	string	"Player cannot write in this channel or is muted"
+//	@Failure		404		{string}	string	"Room not found"
+//	@Failure		429		{string}	string	"Too many messages"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/chat [post]
+func PostChatMessageHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	var messageDto dto.PostChatMessageDto
+	if err := json.NewDecoder(r.Body).Decode(&messageDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	player, ok := authenticatedPlayer(w, r)
+	if !ok {
+		return
+	}
+
+	msg, err := repository.PostChatMessage(id, player, chat.Channel(messageDto.Channel), messageDto.Text)
+	switch {
+	case errors.Is(err, chat.ErrInvalidChannel), errors.Is(err, chat.ErrEmptyMessage), errors.Is(err, chat.ErrMessageTooLong):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, room.ErrNotInChatChannel), errors.Is(err, room.ErrMuted):
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	case errors.Is(err, repository.ErrChatRateLimited):
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	case err != nil:
+		http.Error(w, "Failed to post chat message", http.StatusInternalServerError)
+		return
+	case msg == nil:
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newChatMessageDto(msg)); err != nil {
+		http.Error(w, "Failed to encode chat message", http.StatusInternalServerError)
+	}
+}
+
+// DeleteChatMessageHandler removes a message from the chat of a room.
+//
+//	@Summary		Delete chat message (Requires authorization)
+//	@Description	Removes a message from the chat history. Only the room owner or a moderator can delete messages.
+//	@Tags			chat
+//	@Param			id			path		int		true	"Room ID"
+//	@Param			messageId	path		int		true	"Message ID"
+//	@Success		200			{string}	string	"OK"
+//	@Failure		400			{string}	string	"Invalid id parameter"
+//	@Failure		403			{string}	string	"Only the room owner or a moderator can moderate the chat"
+//	@Failure		404			{string}	string	"Room or message not found"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/chat/{messageId} [delete]
+func DeleteChatMessageHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	messageID, err := strconv.Atoi(ps.ByName("messageId"))
+	if err != nil {
+		http.Error(w, "Invalid messageId parameter", http.StatusBadRequest)
+		return
+	}
+
+	principal, ok := middlewares.PrincipalFromContext(r.Context())
+	if !ok || principal.PlayerID == 0 {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	deleted, err := repository.DeleteChatMessage(id, messageID, principal.PlayerID, principal.Role)
+	switch {
+	case errors.Is(err, room.ErrNotModerator):
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	case err != nil:
+		http.Error(w, "Failed to delete chat message", http.StatusInternalServerError)
+		return
+	case !deleted:
+		http.Error(w, "Room or message not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// MuteChatPlayerHandler stops a player from writing in the chat of a room.
+//
+//	@Summary		Mute player (Requires authorization)
+//	@Description	Stops a player from writing in either chat channel of the room. Only the room owner or a moderator can mute players.
+//	@Tags			chat
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Room ID"
+//	@Param			player	body		dto.MuteChatPlayerDto	true	"Player to mute"
+//	@Success		200		{object}	dto.GetRoomDto
+//	@Failure		400		{string}	string	"Invalid request body"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		403		{string}	string	"Only the room owner or a moderator can moderate the chat"
+//	@Failure		404		{string}	string	"Room not found"
+//	@Failure		409		{string}	string	"Player is already muted"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/chat/mute [post]
+func MuteChatPlayerHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	moderateChat(w, r, ps, repository.MuteChatPlayer)
+}
+
+// UnmuteChatPlayerHandler lets a muted player write in the chat of a room again.
+//
+//	@Summary		Unmute player (Requires authorization)
+//	@Description	Lets a muted player write in the chat of the room again. Only the room owner or a moderator can unmute players.
+//	@Tags			chat
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Room ID"
+//	@Param			player	body		dto.MuteChatPlayerDto	true	"Player to unmute"
+//	@Success		200		{object}	dto.GetRoomDto
+//	@Failure		400		{string}	string	"Invalid request body"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		403		{string}	string	"Only the room owner or a moderator can moderate the chat"
+//	@Failure		404		{string}	string	"Room not found"
+//	@Failure		409		{string}	string	"Player is not muted"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/chat/unmute [post]
+func UnmuteChatPlayerHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	moderateChat(w, r, ps, repository.UnmuteChatPlayer)
+}
+
+func moderateChat(w http.ResponseWriter, r *http.Request, ps httprouter.Params, action func(id int, moderatorID int, role account.Role, playerID int) (*room.Room, error)) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	var muteDto dto.MuteChatPlayerDto
+	if err := json.NewDecoder(r.Body).Decode(&muteDto); err != nil || muteDto.PlayerID == 0 {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	principal, ok := middlewares.PrincipalFromContext(r.Context())
+	if !ok || principal.PlayerID == 0 {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	room, err := action(id, principal.PlayerID, principal.Role, muteDto.PlayerID)
+	writeRoomUpdate(w, r, room, err)
+}
+
+func newChatMessageDto(msg *chat.Message) dto.ChatMessageDto {
+	return dto.ChatMessageDto{
+		ID:         msg.ID,
+		Channel:    string(msg.Channel),
+		PlayerID:   msg.PlayerID,
+		PlayerName: msg.PlayerName,
+		Text:       msg.Text,
+		CreatedAt:  msg.CreatedAt,
+	}
+}
+
+func optionalIntParam(value string, fallback int) (int, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	return strconv.Atoi(value)
+}
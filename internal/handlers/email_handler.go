@@ -0,0 +1,287 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/dto"
+	"github.com/moLIart/go-course/internal/mailer"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+// GetEmailHandler returns the email address of the player's account.
+//
+//	@Summary		Get email address (Requires authorization)
+//	@Description	Returns the email address of the player's account and whether it was verified. Passwords can only be reset by email once it was.
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{object}	dto.AccountEmailDto
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		403	{string}	string	"API keys cannot be used here"
+//	@Security		BearerAuth
+//	@Router			/auth/email [get]
+func GetEmailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	acc, err := repository.GetAccountByPlayerID(playerID)
+	if err != nil {
+		http.Error(w, "Failed to get account", http.StatusInternalServerError)
+		return
+	}
+	if acc == nil {
+		http.Error(w, "Account not found", http.StatusUnauthorized)
+		return
+	}
+
+	writeAccountEmail(w, acc, http.StatusOK)
+}
+
+// SetEmailHandler changes the email address of the player's account.
+//
+//	@Summary		Set email address (Requires authorization)
+//	@Description	Sets the email address of the player's account and sends a link to verify it, at most once a minute. Addresses are unique regardless of case.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			email	body		dto.EmailDto	true	"Email address"
+//	@Success		200		{object}	dto.AccountEmailDto
+//	@Failure		400		{string}	string	"Invalid request body or email address"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		403		{string}	string	"API keys cannot be used here"
+//	@Failure		409		{string}	string	"Email address is already used by another account"
+//	@Security		BearerAuth
+//	@Router			/auth/email [put]
+func SetEmailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	var emailDto dto.EmailDto
+	if err := json.NewDecoder(r.Body).Decode(&emailDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	acc, err := repository.SetAccountEmail(playerID, emailDto.Email)
+	switch {
+	case errors.Is(err, account.ErrInvalidEmail):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case errors.Is(err, repository.ErrEmailTaken):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case errors.Is(err, account.ErrInvalidCredentials):
+		http.Error(w, "Account not found", http.StatusUnauthorized)
+		return
+	case err != nil:
+		http.Error(w, "Failed to set email address", http.StatusInternalServerError)
+		return
+	}
+
+	if !acc.EmailVerified {
+		// The player can ask for another email if this one was throttled.
+		if _, err := sendVerificationEmail(acc); err != nil {
+			log.Printf("Failed to send verification email to player %d: %v", playerID, err)
+		}
+	}
+	writeAccountEmail(w, acc, http.StatusOK)
+}
+
+// ResendVerificationEmailHandler sends another link to verify the email address.
+//
+//	@Summary		Resend verification email (Requires authorization)
+//	@Description	Sends another link to verify the email address of the player's account, at most once a minute.
+//	@Tags			auth
+//	@Success		202	{string}	string	"Accepted"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		403	{string}	string	"API keys cannot be used here"
+//	@Failure		409	{string}	string	"Account has no email address, or it is already verified"
+//	@Failure		429	{string}	string	"An email was sent less than a minute ago"
+//	@Security		BearerAuth
+//	@Router			/auth/email/verification [post]
+func ResendVerificationEmailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	acc, err := repository.GetAccountByPlayerID(playerID)
+	if err != nil {
+		http.Error(w, "Failed to get account", http.StatusInternalServerError)
+		return
+	}
+	switch {
+	case acc == nil:
+		http.Error(w, "Account not found", http.StatusUnauthorized)
+		return
+	case acc.Email == "":
+		http.Error(w, account.ErrNoEmail.Error(), http.StatusConflict)
+		return
+	case acc.EmailVerified:
+		http.Error(w, account.ErrEmailVerified.Error(), http.StatusConflict)
+		return
+	}
+
+	sent, err := sendVerificationEmail(acc)
+	if err != nil {
+		http.Error(w, "Failed to send verification email", http.StatusInternalServerError)
+		return
+	}
+	if !sent {
+		http.Error(w, "An email was sent less than a minute ago", http.StatusTooManyRequests)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// VerifyEmailHandler verifies an email address with the token of its link.
+//
+//	@Summary		Verify email address
+//	@Description	Marks the email address as verified. This is the link sent by email, so it works without authorization for 24 hours, as long as the address did not change.
+//	@Tags			auth
+//	@Produce		json
+//	@Param			token	query		string	true	"Verification token"
+//	@Success		200		{object}	dto.AccountEmailDto
+//	@Failure		401		{string}	string	"Invalid or expired token"
+//	@Router			/auth/email/verify [get]
+func VerifyEmailHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, email, err := middlewares.ParseActionToken(middlewares.PurposeEmailVerification, r.URL.Query().Get("token"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	verified, err := repository.VerifyAccountEmail(playerID, email)
+	if err != nil {
+		http.Error(w, "Failed to verify email address", http.StatusInternalServerError)
+		return
+	}
+	if !verified {
+		http.Error(w, account.ErrInvalidActionToken.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(dto.AccountEmailDto{Email: email, Verified: true}); err != nil {
+		http.Error(w, "Failed to encode email address", http.StatusInternalServerError)
+	}
+}
+
+// ForgotPasswordHandler sends a password reset token by email.
+//
+//	@Summary		Request a password reset
+//	@Description	Sends a token to reset the password to the verified email address of an account, at most once a minute. The response is the same whether or not such an account exists.
+//	@Tags			auth
+//	@Accept			json
+//	@Param			email	body		dto.EmailDto	true	"Email address"
+//	@Success		202		{string}	string	"Accepted"
+//	@Failure		400		{string}	string	"Invalid request body"
+//	@Router			/auth/password/forgot [post]
+func ForgotPasswordHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var emailDto dto.EmailDto
+	if err := json.NewDecoder(r.Body).Decode(&emailDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	acc, err := repository.GetAccountByEmail(emailDto.Email)
+	if err != nil {
+		http.Error(w, "Failed to request a password reset", http.StatusInternalServerError)
+		return
+	}
+	if acc != nil && acc.EmailVerified && !acc.Bot {
+		if _, err := sendPasswordResetEmail(acc); err != nil {
+			log.Printf("Failed to send password reset email to player %d: %v", acc.PlayerID, err)
+		}
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// ResetPasswordHandler sets a new password with a password reset token.
+//
+//	@Summary		Reset password
+//	@Description	Sets a new password with the token sent by /auth/password/forgot and logs the player out everywhere. A token works for an hour and only until the password changed.
+//	@Tags			auth
+//	@Accept			json
+//	@Param			reset	body		dto.ResetPasswordDto	true	"Token and new password"
+//	@Success		204		{string}	string	"No Content"
+//	@Failure		400		{string}	string	"Invalid request body or password"
+//	@Failure		401		{string}	string	"Invalid or expired token"
+//	@Router			/auth/password/reset [post]
+func ResetPasswordHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var resetDto dto.ResetPasswordDto
+	if err := json.NewDecoder(r.Body).Decode(&resetDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	playerID, fingerprint, err := middlewares.ParseActionToken(middlewares.PurposePasswordReset, resetDto.Token)
+	if err == nil {
+		err = repository.ResetPassword(playerID, fingerprint, resetDto.Password)
+	}
+	switch {
+	case errors.Is(err, account.ErrInvalidActionToken):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case errors.Is(err, account.ErrInvalidPassword):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, "Failed to reset password", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func writeAccountEmail(w http.ResponseWriter, acc *account.Account, status int) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(dto.AccountEmailDto{Email: acc.Email, Verified: acc.EmailVerified}); err != nil {
+		http.Error(w, "Failed to encode email address", http.StatusInternalServerError)
+	}
+}
+
+// sendVerificationEmail sends a link to verify the address of the account,
+// unless one was sent less than account.MailInterval ago. It reports whether
+// the email was sent.
+func sendVerificationEmail(acc *account.Account) (bool, error) {
+	if ok, err := repository.ReserveMail(middlewares.PurposeEmailVerification, acc.PlayerID); !ok || err != nil {
+		return false, err
+	}
+	token, err := middlewares.IssueActionToken(middlewares.PurposeEmailVerification, acc.PlayerID, acc.Email, account.EmailVerificationTTL)
+	if err != nil {
+		return false, err
+	}
+	mailer.SendAsync(mailer.VerificationEmail(acc.Email, acc.Username, token))
+	return true, nil
+}
+
+// sendPasswordResetEmail sends a token to reset the password of the account,
+// unless one was sent less than account.MailInterval ago.
+func sendPasswordResetEmail(acc *account.Account) (bool, error) {
+	if ok, err := repository.ReserveMail(middlewares.PurposePasswordReset, acc.PlayerID); !ok || err != nil {
+		return false, err
+	}
+	token, err := middlewares.IssueActionToken(middlewares.PurposePasswordReset, acc.PlayerID, acc.PasswordFingerprint(), account.PasswordResetTTL)
+	if err != nil {
+		return false, err
+	}
+	mailer.SendAsync(mailer.PasswordResetEmail(acc.Email, acc.Username, token))
+	return true, nil
+}
@@ -4,9 +4,12 @@ import (
 	"encoding/json"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 	"github.com/moLIart/go-course/internal/dto"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/account"
 	"github.com/moLIart/go-course/internal/model/game"
 	"github.com/moLIart/go-course/internal/repository"
 )
@@ -18,6 +21,7 @@ import (
 //	@Tags			games
 //	@Success		201				{object}	dto.GetGameDto
 //	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/games [post]
 func CreateGameHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	game := game.NewGame()
@@ -28,11 +32,13 @@ func CreateGameHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 // GetGamesHandler retrieves all games.
 //
 //	@Summary		Get all games
-//	@Description	Returns a list of all games.
+//	@Description	Returns a list of all games. Games in progress are shown with the spectator delay of their room unless the token belongs to a seated player.
 //	@Tags			games
 //	@Produce		json
 //	@Success		200	{array}		dto.GetGameDto
 //	@Failure		500	{string}	string	"Failed to encode games"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/games [get]
 func GetGamesHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	games, err := repository.GetGames()
@@ -41,9 +47,15 @@ func GetGamesHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Param
 		return
 	}
 
+	viewerID, _ := middlewares.PlayerIDFromContext(r.Context())
 	gameDtos := make([]dto.GetGameDto, len(games))
 	for i, game := range games {
-		gameDtos[i] = dto.GetGameDto{ID: game.ID}
+		view, err := repository.ViewGame(game, viewerID)
+		if err != nil {
+			http.Error(w, "Failed to retrieve games", http.StatusInternalServerError)
+			return
+		}
+		gameDtos[i] = newGameDto(view)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -55,13 +67,15 @@ func GetGamesHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Param
 // GetGameByIDHandler retrieves a game by its ID.
 //
 //	@Summary		Get game by ID
-//	@Description	Returns a game by its ID.
+//	@Description	Returns a game by its ID. A game in progress is shown with the spectator delay of its room unless the token belongs to a seated player.
 //	@Tags			games
 //	@Produce		json
 //	@Param			id	query		int	true	"Game ID"
 //	@Success		200	{object}	dto.GetGameDto
 //	@Failure		400	{string}	string	"Invalid id parameter"
 //	@Failure		404	{string}	string	"Game not found"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/games/{id} [get]
 func GetGameByIDHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	id, err := strconv.Atoi(r.URL.Query().Get("id"))
@@ -76,7 +90,14 @@ func GetGameByIDHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 		return
 	}
 
-	gameDto := dto.GetGameDto{ID: game.ID}
+	viewerID, _ := middlewares.PlayerIDFromContext(r.Context())
+	view, err := repository.ViewGame(game, viewerID)
+	if err != nil {
+		http.Error(w, "Failed to retrieve game", http.StatusInternalServerError)
+		return
+	}
+
+	gameDto := newGameDto(view)
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(gameDto); err != nil {
 		http.Error(w, "Failed to encode game", http.StatusInternalServerError)
@@ -86,13 +107,15 @@ func GetGameByIDHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 // DeleteGameHandler deletes a game by its ID.
 //
 //	@Summary		Delete game by ID (Requires authorization)
-//	@Description	Deletes a game by its ID.
+//	@Description	Deletes a game by its ID. Players can delete the games they played once they are over, admins any game.
 //	@Tags			games
 //	@Param			id				query		int		true	"Game ID"
 //	@Success		200				{string}	string	"OK"
 //	@Failure		400				{string}	string	"Invalid id parameter"
+//	@Failure		403				{string}	string	"Not a player of the finished game nor an admin"
 //	@Failure		404				{string}	string	"Game not found"
 //	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/games/{id} [delete]
 func DeleteGameHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	id, err := strconv.Atoi(r.URL.Query().Get("id"))
@@ -100,6 +123,9 @@ func DeleteGameHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
 		return
 	}
+	if !authorizeGameDeletion(w, r, id) {
+		return
+	}
 
 	ok, err := repository.DeleteGameByID(id)
 	if err != nil {
@@ -114,3 +140,63 @@ func DeleteGameHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 
 	w.WriteHeader(http.StatusOK)
 }
+
+// authorizeGameDeletion checks that the game exists and that the principal
+// of the request played it and it is over, or is an admin.
+func authorizeGameDeletion(w http.ResponseWriter, r *http.Request, id int) bool {
+	g, err := repository.GetGameByID(id)
+	if err != nil {
+		http.Error(w, "Failed to retrieve game", http.StatusInternalServerError)
+		return false
+	}
+
+	if g == nil {
+		http.Error(w, "Game not found", http.StatusNotFound)
+		return false
+	}
+
+	principal, ok := middlewares.PrincipalFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing or invalid Authorization header", http.StatusUnauthorized)
+		return false
+	}
+	if !principal.HasRole(account.RoleAdmin) && (!g.IsOver() || g.GetColor(principal.PlayerID) == game.Empty) {
+		http.Error(w, "Only the players of a finished game can delete it", http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+func newGameDto(g *game.Game) dto.GetGameDto {
+	gameDto := dto.GetGameDto{
+		ID:                  g.ID,
+		BlackID:             g.BlackID,
+		WhiteID:             g.WhiteID,
+		BlackPartnerID:      g.BlackPartnerID,
+		WhitePartnerID:      g.WhitePartnerID,
+		CurrentPlayerID:     g.GetCurrentPlayerID(),
+		CurrentTurn:         g.GetCurrentTurn().String(),
+		Status:              g.GetStatus().String(),
+		Result:              g.Result,
+		MoveCount:           len(g.Moves),
+		BlackCaptures:       g.BlackCaptures,
+		WhiteCaptures:       g.WhiteCaptures,
+		Board:               g.GetBoard().Rows(),
+		Ruleset:             string(g.Ruleset),
+		Komi:                g.Komi,
+		Handicap:            g.Handicap,
+		TakebackRequestedBy: g.PendingTakeback(),
+	}
+	if g.Clock != nil {
+		now := time.Now()
+		blackRemaining, blackPeriods := g.RemainingTime(game.Black, now)
+		whiteRemaining, whitePeriods := g.RemainingTime(game.White, now)
+		gameDto.Clock = &dto.GetClockDto{
+			BlackRemainingMs: blackRemaining.Milliseconds(),
+			WhiteRemainingMs: whiteRemaining.Milliseconds(),
+			BlackPeriods:     blackPeriods,
+			WhitePeriods:     whitePeriods,
+		}
+	}
+	return gameDto
+}
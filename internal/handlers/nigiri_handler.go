@@ -0,0 +1,241 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/dto"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/game"
+	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+// CommitNigiriHandler starts a commit-reveal nigiri in a room.
+//
+//	@Summary		Commit nigiri stones (Requires authorization)
+//	@Description	The player holding the stones publishes SHA-256("<stones>:<nonce>") as a hex string. The opponent then guesses odd or even.
+//	@Tags			nigiri
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Room ID"
+//	@Param			commit	body		dto.NigiriCommitDto		true	"Commitment"
+//	@Success		200		{object}	dto.GetRoomDto
+//	@Failure		400		{string}	string	"Invalid id parameter, request body or commitment"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		404		{string}	string	"Room not found"
+//	@Failure		409		{string}	string	"Nigiri cannot be started now"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/nigiri/commit [post]
+func CommitNigiriHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	var commitDto dto.NigiriCommitDto
+	if err := json.NewDecoder(r.Body).Decode(&commitDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	room, err := repository.CommitNigiri(id, playerID, commitDto.Commitment)
+	writeRoomUpdate(w, r, room, err)
+}
+
+// GuessNigiriHandler records the odd or even guess of a nigiri.
+//
+//	@Summary		Guess nigiri parity (Requires authorization)
+//	@Description	The opponent of the stone holder guesses whether the committed stone count is odd or even.
+//	@Tags			nigiri
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"Room ID"
+//	@Param			guess	body		dto.NigiriGuessDto	true	"Guess"
+//	@Success		200		{object}	dto.GetRoomDto
+//	@Failure		400		{string}	string	"Invalid id parameter, request body or guess"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		404		{string}	string	"Room not found"
+//	@Failure		409		{string}	string	"No nigiri is waiting for this player's guess"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/nigiri/guess [post]
+func GuessNigiriHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	var guessDto dto.NigiriGuessDto
+	if err := json.NewDecoder(r.Body).Decode(&guessDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	room, err := repository.GuessNigiri(id, playerID, guessDto.Guess)
+	writeRoomUpdate(w, r, room, err)
+}
+
+// RevealNigiriHandler reveals the committed stones and assigns colors.
+//
+//	@Summary		Reveal nigiri stones (Requires authorization)
+//	@Description	The stone holder reveals the stone count and nonce. The server verifies them against the commitment and gives black to the guesser if the guess was right.
+//	@Tags			nigiri
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int						true	"Room ID"
+//	@Param			reveal	body		dto.NigiriRevealDto		true	"Stones and nonce"
+//	@Success		200		{object}	dto.GetRoomDto
+//	@Failure		400		{string}	string	"Invalid id parameter or request body, or reveal does not match the commitment"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		404		{string}	string	"Room not found"
+//	@Failure		409		{string}	string	"No nigiri is waiting for this player's reveal"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/nigiri/reveal [post]
+func RevealNigiriHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	var revealDto dto.NigiriRevealDto
+	if err := json.NewDecoder(r.Body).Decode(&revealDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	room, err := repository.RevealNigiri(id, playerID, revealDto.Stones, revealDto.Nonce)
+	writeRoomUpdate(w, r, room, err)
+}
+
+// ClaimNigiriHandler lets the guesser pick colors when the holder did not reveal in time.
+//
+//	@Summary		Claim an unrevealed nigiri (Requires authorization)
+//	@Description	Once the stone holder let 2 minutes pass after the guess without revealing, the guesser picks the color they play.
+//	@Tags			nigiri
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"Room ID"
+//	@Param			claim	body		dto.NigiriClaimDto	true	"Color of the guesser"
+//	@Success		200		{object}	dto.GetRoomDto
+//	@Failure		400		{string}	string	"Invalid id parameter, request body or color"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		404		{string}	string	"Room not found"
+//	@Failure		409		{string}	string	"No nigiri waits for a reveal by the opponent, or the holder can still reveal"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/nigiri/claim [post]
+func ClaimNigiriHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	var claimDto dto.NigiriClaimDto
+	if err := json.NewDecoder(r.Body).Decode(&claimDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	color, _ := game.ParseColor(claimDto.Color)
+	room, err := repository.ClaimNigiri(id, playerID, color)
+	writeRoomUpdate(w, r, room, err)
+}
+
+// GetNigiriAuditHandler returns every nigiri held in a room.
+//
+//	@Summary		Get nigiri audit log
+//	@Description	Returns the past nigiri of a room followed by the current one, with every step of each exchange.
+//	@Tags			nigiri
+//	@Produce		json
+//	@Param			id	path		int	true	"Room ID"
+//	@Success		200	{array}		dto.GetNigiriDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		404	{string}	string	"Room not found"
+//	@Router			/rooms/{id}/nigiri [get]
+func GetNigiriAuditHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	room, err := repository.GetRoomByID(id)
+	if err != nil {
+		http.Error(w, "Failed to retrieve room", http.StatusInternalServerError)
+		return
+	}
+
+	if room == nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return
+	}
+
+	nigiriDtos := make([]dto.GetNigiriDto, 0, len(room.NigiriHistory)+1)
+	for _, n := range room.NigiriHistory {
+		nigiriDtos = append(nigiriDtos, newNigiriDto(n))
+	}
+	if room.Nigiri != nil {
+		nigiriDtos = append(nigiriDtos, newNigiriDto(room.Nigiri))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(nigiriDtos); err != nil {
+		http.Error(w, "Failed to encode nigiri", http.StatusInternalServerError)
+	}
+}
+
+func newNigiriDto(n *room.Nigiri) dto.GetNigiriDto {
+	log := make([]dto.NigiriEventDto, len(n.Log))
+	for i, e := range n.Log {
+		log[i] = dto.NigiriEventDto{At: e.At, PlayerID: e.PlayerID, Action: e.Action, Detail: e.Detail}
+	}
+	nigiriDto := dto.GetNigiriDto{
+		HolderID:   n.HolderID,
+		GuesserID:  n.GuesserID,
+		Commitment: n.Commitment,
+		Guess:      n.Guess,
+		Stones:     n.Stones,
+		Nonce:      n.Nonce,
+		BlackID:    n.BlackID,
+		GameID:     n.GameID,
+		Log:        log,
+	}
+	if deadline := n.RevealDeadline(); !deadline.IsZero() && !n.IsRevealed() {
+		nigiriDto.RevealDeadline = &deadline
+	}
+	return nigiriDto
+}
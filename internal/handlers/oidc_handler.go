@@ -0,0 +1,336 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/dto"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/oidc"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+// oidcStateCookie binds a login with a provider to the browser that started
+// it, so that nobody can make a player complete a login they started.
+const oidcStateCookie = "oidc_state"
+
+// GetOIDCProvidersHandler lists the providers players may log in with.
+//
+//	@Summary		Get identity providers
+//	@Description	Returns the OpenID Connect providers players may log in with.
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{array}	dto.OIDCProviderDto
+//	@Router			/auth/oidc [get]
+func GetOIDCProvidersHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	names := oidc.ProviderNames()
+	providerDtos := make([]dto.OIDCProviderDto, len(names))
+	for i, name := range names {
+		providerDtos[i] = dto.OIDCProviderDto{Name: name, LoginURL: "/auth/oidc/" + name + "/login"}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(providerDtos); err != nil {
+		http.Error(w, "Failed to encode providers", http.StatusInternalServerError)
+	}
+}
+
+// OIDCLoginHandler sends the browser of the player to log in with a provider.
+//
+//	@Summary		Log in with an identity provider
+//	@Description	Redirects to the provider, which sends the player back to the callback route. Players logging in for the first time get an account without password.
+//	@Tags			auth
+//	@Param			provider	path		string	true	"Provider name"
+//	@Param			login_hint	query		string	false	"Account to suggest to the provider"
+//	@Success		302			{string}	string	"Redirect to the provider"
+//	@Failure		404			{string}	string	"Unknown identity provider"
+//	@Failure		502			{string}	string	"Failed to reach the identity provider"
+//	@Router			/auth/oidc/{provider}/login [get]
+func OIDCLoginHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	authURL, ok := startOIDCLogin(w, r, ps.ByName("provider"), 0)
+	if !ok {
+		return
+	}
+
+	http.Redirect(w, r, authURL, http.StatusFound)
+}
+
+// OIDCLinkHandler starts linking an identity at a provider to the player.
+//
+//	@Summary		Link an identity (Requires authorization)
+//	@Description	Returns where to send the browser of the player to log in with the provider. Coming back to the callback route links the identity to the player instead of logging in.
+//	@Tags			auth
+//	@Produce		json
+//	@Param			provider	path		string	true	"Provider name"
+//	@Success		200			{object}	dto.AuthorizationURLDto
+//	@Failure		401			{string}	string	"Token does not identify a player"
+//	@Failure		403			{string}	string	"API keys cannot be used here"
+//	@Failure		404			{string}	string	"Unknown identity provider"
+//	@Failure		502			{string}	string	"Failed to reach the identity provider"
+//	@Security		BearerAuth
+//	@Router			/auth/oidc/{provider}/link [post]
+func OIDCLinkHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	authURL, ok := startOIDCLogin(w, r, ps.ByName("provider"), playerID)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(dto.AuthorizationURLDto{AuthorizationURL: authURL}); err != nil {
+		http.Error(w, "Failed to encode authorization URL", http.StatusInternalServerError)
+	}
+}
+
+// OIDCCallbackHandler completes a login with a provider.
+//
+//	@Summary		Identity provider callback
+//	@Description	Exchanges the code the provider sent the player back with and verifies the ID token. Logins return tokens, or a two-factor token, like the password login. Links return the linked identity.
+//	@Tags			auth
+//	@Produce		json
+//	@Param			provider	path		string	true	"Provider name"
+//	@Param			code		query		string	true	"Authorization code"
+//	@Param			state		query		string	true	"State of the login"
+//	@Success		200			{object}	dto.TokenDto
+//	@Success		202			{object}	dto.TwoFactorChallengeDto
+//	@Failure		400			{string}	string	"Login expired or was started elsewhere"
+//	@Failure		401			{string}	string	"Login was refused by the identity provider or the ID token is invalid"
+//	@Failure		404			{string}	string	"Unknown identity provider"
+//	@Failure		409			{string}	string	"Identity is already linked to another player"
+//	@Failure		502			{string}	string	"Failed to reach the identity provider"
+//	@Router			/auth/oidc/{provider}/callback [get]
+func OIDCCallbackHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	provider, err := oidc.GetProvider(ps.ByName("provider"))
+	if err != nil {
+		http.Error(w, "Unknown identity provider", http.StatusNotFound)
+		return
+	}
+
+	query := r.URL.Query()
+	state := query.Get("state")
+	cookie, err := r.Cookie(oidcStateCookie)
+	if err != nil || cookie.Value != state {
+		http.Error(w, account.ErrInvalidOIDCState.Error(), http.StatusBadRequest)
+		return
+	}
+	clearOIDCStateCookie(w, r)
+
+	login, err := repository.TakeOIDCLogin(state)
+	switch {
+	case errors.Is(err, account.ErrInvalidOIDCState):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		http.Error(w, "Failed to retrieve login", http.StatusInternalServerError)
+		return
+	case login.Provider != provider.Name():
+		http.Error(w, account.ErrInvalidOIDCState.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if refusal := query.Get("error"); refusal != "" {
+		http.Error(w, "Login was refused by the identity provider: "+refusal, http.StatusUnauthorized)
+		return
+	}
+
+	claims, err := provider.Exchange(r.Context(), query.Get("code"), login.Verifier, login.Nonce)
+	switch {
+	case errors.Is(err, oidc.ErrCodeRefused), errors.Is(err, oidc.ErrInvalidIDToken):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case err != nil:
+		http.Error(w, "Failed to reach the identity provider", http.StatusBadGateway)
+		return
+	}
+
+	email := ""
+	if claims.EmailVerified {
+		email = claims.Email
+	}
+	identity, err := account.NewIdentity(provider.Name(), claims.Issuer, claims.Subject, email)
+	if err != nil {
+		http.Error(w, "Failed to link identity", http.StatusInternalServerError)
+		return
+	}
+
+	if login.LinkTo != 0 {
+		linkIdentity(w, login.LinkTo, identity)
+		return
+	}
+
+	player, err := repository.LoginWithIdentity(identity, claims.PreferredUsername, claims.Email, claims.Name)
+	switch {
+	case errors.Is(err, account.ErrInvalidCredentials):
+		http.Error(w, "Player not found", http.StatusUnauthorized)
+		return
+	case err != nil:
+		http.Error(w, "Failed to log in", http.StatusInternalServerError)
+		return
+	}
+
+	completeLogin(w, r, player)
+}
+
+// GetIdentitiesHandler lists the identities linked to the player.
+//
+//	@Summary		List linked identities (Requires authorization)
+//	@Description	Returns the identities at providers the player can log in with, the oldest first.
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{array}		dto.IdentityDto
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		403	{string}	string	"API keys cannot be used here"
+//	@Security		BearerAuth
+//	@Router			/auth/identities [get]
+func GetIdentitiesHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	identities, err := repository.GetPlayerIdentities(playerID)
+	if err != nil {
+		http.Error(w, "Failed to retrieve identities", http.StatusInternalServerError)
+		return
+	}
+
+	identityDtos := make([]dto.IdentityDto, len(identities))
+	for i, identity := range identities {
+		identityDtos[i] = newIdentityDto(identity)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(identityDtos); err != nil {
+		http.Error(w, "Failed to encode identities", http.StatusInternalServerError)
+	}
+}
+
+// UnlinkIdentityHandler unlinks an identity from the player.
+//
+//	@Summary		Unlink identity (Requires authorization)
+//	@Description	Stops the identity from logging in as the player. Accounts without password keep at least one identity.
+//	@Tags			auth
+//	@Param			id	path		string	true	"Identity ID"
+//	@Success		204	{string}	string	"No Content"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		403	{string}	string	"API keys cannot be used here"
+//	@Failure		404	{string}	string	"Identity not found"
+//	@Failure		409	{string}	string	"Cannot unlink the only way to log in"
+//	@Security		BearerAuth
+//	@Router			/auth/identities/{id} [delete]
+func UnlinkIdentityHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	unlinked, err := repository.UnlinkIdentity(playerID, ps.ByName("id"))
+	switch {
+	case errors.Is(err, account.ErrLastIdentity):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, "Failed to unlink identity", http.StatusInternalServerError)
+		return
+	}
+
+	if !unlinked {
+		http.Error(w, "Identity not found", http.StatusNotFound)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// startOIDCLogin saves a login with the provider for the browser making the
+// request and returns where to send it.
+func startOIDCLogin(w http.ResponseWriter, r *http.Request, providerName string, linkTo int) (string, bool) {
+	provider, err := oidc.GetProvider(providerName)
+	if err != nil {
+		http.Error(w, "Unknown identity provider", http.StatusNotFound)
+		return "", false
+	}
+
+	login, err := account.NewOIDCLogin(provider.Name(), linkTo)
+	if err != nil {
+		http.Error(w, "Failed to start login", http.StatusInternalServerError)
+		return "", false
+	}
+
+	authURL, err := provider.AuthCodeURL(r.Context(), login.State, login.Nonce, login.Verifier, r.URL.Query().Get("login_hint"))
+	if err != nil {
+		http.Error(w, "Failed to reach the identity provider", http.StatusBadGateway)
+		return "", false
+	}
+
+	if err := repository.SaveOIDCLogin(login); err != nil {
+		http.Error(w, "Failed to start login", http.StatusInternalServerError)
+		return "", false
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Value:    login.State,
+		Path:     "/auth/oidc/",
+		MaxAge:   int(account.OIDCLoginTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   isHTTPS(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+	return authURL, true
+}
+
+func clearOIDCStateCookie(w http.ResponseWriter, r *http.Request) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcStateCookie,
+		Path:     "/auth/oidc/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   isHTTPS(r),
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func linkIdentity(w http.ResponseWriter, playerID int, identity *account.Identity) {
+	linked, err := repository.LinkIdentity(playerID, identity)
+	switch {
+	case errors.Is(err, account.ErrIdentityLinked):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, "Failed to link identity", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newIdentityDto(linked)); err != nil {
+		http.Error(w, "Failed to encode identity", http.StatusInternalServerError)
+	}
+}
+
+// isHTTPS reports whether the client reached us over HTTPS, directly or
+// through the load balancer.
+func isHTTPS(r *http.Request) bool {
+	return r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https"
+}
+
+func newIdentityDto(identity *account.Identity) dto.IdentityDto {
+	return dto.IdentityDto{
+		ID:        identity.ID,
+		Provider:  identity.Provider,
+		Subject:   identity.Subject,
+		Email:     identity.Email,
+		CreatedAt: identity.CreatedAt,
+	}
+}
@@ -0,0 +1,215 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/dto"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/game"
+	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+// PlayMoveHandler places a stone in the game of a room.
+//
+//	@Summary		Play a move (Requires authorization)
+//	@Description	Places a stone for the player identified by the token. X is the column and Y the row, both starting at 0.
+//	@Tags			play
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int			true	"Room ID"
+//	@Param			move	body		dto.MoveDto	true	"Move"
+//	@Success		200		{object}	dto.GetRoomDto
+//	@Failure		400		{string}	string	"Invalid id parameter, request body or point"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		403		{string}	string	"Player is not seated in the game"
+//	@Failure		404		{string}	string	"Room not found"
+//	@Failure		409		{string}	string	"Move is not allowed now"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/move [post]
+func PlayMoveHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	var moveDto dto.MoveDto
+	if err := json.NewDecoder(r.Body).Decode(&moveDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	room, err := repository.PlayMove(id, playerID, game.Point{X: moveDto.X, Y: moveDto.Y})
+	writeRoomUpdate(w, r, room, err)
+}
+
+// PassHandler passes the turn in the game of a room.
+//
+//	@Summary		Pass (Requires authorization)
+//	@Description	Passes the turn of the player identified by the token. Two consecutive passes move the room to scoring.
+//	@Tags			play
+//	@Produce		json
+//	@Param			id	path		int	true	"Room ID"
+//	@Success		200	{object}	dto.GetRoomDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		403	{string}	string	"Player is not seated in the game"
+//	@Failure		404	{string}	string	"Room not found"
+//	@Failure		409	{string}	string	"Pass is not allowed now"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/pass [post]
+func PassHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	handleGameAction(w, r, ps, repository.Pass)
+}
+
+// ResignHandler resigns the game of a room.
+//
+//	@Summary		Resign (Requires authorization)
+//	@Description	Resigns the game for the player identified by the token.
+//	@Tags			play
+//	@Produce		json
+//	@Param			id	path		int	true	"Room ID"
+//	@Success		200	{object}	dto.GetRoomDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		403	{string}	string	"Player is not seated in the game"
+//	@Failure		404	{string}	string	"Room not found"
+//	@Failure		409	{string}	string	"No game is being played"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/resign [post]
+func ResignHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	handleGameAction(w, r, ps, repository.Resign)
+}
+
+// AcceptScoreHandler agrees with the count of a game being scored.
+//
+//	@Summary		Accept the score (Requires authorization)
+//	@Description	Agrees with the area count of the current position. The game finishes once both players agree.
+//	@Tags			play
+//	@Produce		json
+//	@Param			id	path		int	true	"Room ID"
+//	@Success		200	{object}	dto.GetRoomDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		403	{string}	string	"Player is not seated in the game"
+//	@Failure		404	{string}	string	"Room not found"
+//	@Failure		409	{string}	string	"Game is not being scored"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/score/accept [post]
+func AcceptScoreHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	handleGameAction(w, r, ps, repository.AcceptScore)
+}
+
+// ResumePlayHandler returns a game being scored to play.
+//
+//	@Summary		Resume play (Requires authorization)
+//	@Description	Disputes the count and resumes play so dead stones can be settled on the board.
+//	@Tags			play
+//	@Produce		json
+//	@Param			id	path		int	true	"Room ID"
+//	@Success		200	{object}	dto.GetRoomDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		403	{string}	string	"Player is not seated in the game"
+//	@Failure		404	{string}	string	"Room not found"
+//	@Failure		409	{string}	string	"Game is not being scored"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/score/resume [post]
+func ResumePlayHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	handleGameAction(w, r, ps, repository.ResumePlay)
+}
+
+// TakebackHandler asks the opponent to undo the last move of the player.
+//
+//	@Summary		Request a takeback (Requires authorization)
+//	@Description	Asks the opponent to undo the last move when it was played by the player identified by the token and the room allows takebacks. The move is only undone once the opponent accepts, and the request lapses when they move instead.
+//	@Tags			play
+//	@Produce		json
+//	@Param			id	path		int	true	"Room ID"
+//	@Success		200	{object}	dto.GetRoomDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		403	{string}	string	"Player is not seated in the game"
+//	@Failure		404	{string}	string	"Room not found"
+//	@Failure		409	{string}	string	"Takebacks are not allowed, the last move is the opponent's or it was already requested"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/takeback [post]
+func TakebackHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	handleGameAction(w, r, ps, repository.Takeback)
+}
+
+// AcceptTakebackHandler undoes the move the opponent asked to take back.
+//
+//	@Summary		Accept a takeback (Requires authorization)
+//	@Description	Undoes the move the opponent asked to take back, gives them the turn again and puts both clocks back to where they stood before that move.
+//	@Tags			play
+//	@Produce		json
+//	@Param			id	path		int	true	"Room ID"
+//	@Success		200	{object}	dto.GetRoomDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		403	{string}	string	"Player is not seated in the game"
+//	@Failure		404	{string}	string	"Room not found"
+//	@Failure		409	{string}	string	"Takebacks are not allowed or the opponent did not ask for one"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/takeback/accept [post]
+func AcceptTakebackHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	handleGameAction(w, r, ps, repository.AcceptTakeback)
+}
+
+// DeclineTakebackHandler refuses the takeback the opponent asked for.
+//
+//	@Summary		Decline a takeback (Requires authorization)
+//	@Description	Refuses the takeback the opponent asked for, leaving the move on the board.
+//	@Tags			play
+//	@Produce		json
+//	@Param			id	path		int	true	"Room ID"
+//	@Success		200	{object}	dto.GetRoomDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		403	{string}	string	"Player is not seated in the game"
+//	@Failure		404	{string}	string	"Room not found"
+//	@Failure		409	{string}	string	"The opponent did not ask for a takeback"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/takeback/decline [post]
+func DeclineTakebackHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	handleGameAction(w, r, ps, repository.DeclineTakeback)
+}
+
+// handleGameAction runs a game action without request body for the player
+// identified by the token.
+func handleGameAction(w http.ResponseWriter, r *http.Request, ps httprouter.Params, action func(id int, playerID int) (*room.Room, error)) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	room, err := action(id, playerID)
+	writeRoomUpdate(w, r, room, err)
+}
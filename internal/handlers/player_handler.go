@@ -22,6 +22,7 @@ import (
 //	@Success		201		{object}	dto.GetPlayerDto
 //	@Failure		400		{string}	string	"Invalid request body"
 //	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/players [post]
 func CreatePlayerHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	var playerDto dto.CreatePlayerDto
@@ -101,13 +102,15 @@ func GetPlayerByIDHandler(w http.ResponseWriter, r *http.Request, ps httprouter.
 // DeletePlayerByIDHandler deletes a player by their ID.
 //
 //	@Summary		Delete player by ID (Requires authorization)
-//	@Description	Deletes a player by their ID.
+//	@Description	Deletes a player by their ID along with their account, sessions and API keys. Players can only delete themselves, unless they are admins.
 //	@Tags			players
 //	@Param			id	path		int		true	"Player ID"
 //	@Success		200	{string}	string	"OK"
 //	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		403	{string}	string	"Players can only change themselves"
 //	@Failure		404	{string}	string	"Player not found"
 //	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/players/{id} [delete]
 func DeletePlayerByIDHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	id, err := strconv.Atoi(ps.ByName("id"))
@@ -116,7 +119,11 @@ func DeletePlayerByIDHandler(w http.ResponseWriter, r *http.Request, ps httprout
 		return
 	}
 
-	ok, err := repository.DeletePlayerByID(id)
+	if !authorizeOwner(w, r, id, "Players can only change themselves") {
+		return
+	}
+
+	ok, err := repository.DeleteAccount(id)
 	if err != nil {
 		http.Error(w, "Failed to delete player", http.StatusInternalServerError)
 		return
@@ -133,15 +140,17 @@ func DeletePlayerByIDHandler(w http.ResponseWriter, r *http.Request, ps httprout
 // UpdatePlayerHandler updates a player's name by their ID.
 //
 //	@Summary		Update player by ID (Requires authorization)
-//	@Description	Updates the name of a player by their ID.
+//	@Description	Updates the name of a player by their ID. Players can only update themselves, unless they are admins.
 //	@Tags			players
 //	@Accept			json
 //	@Param			id		path		int					true	"Player ID"
 //	@Param			player	body		dto.UpdatePlayerDto	true	"Updated player name"
 //	@Success		200		{string}	string				"OK"
 //	@Failure		400		{string}	string				"Invalid id parameter or request body"
+//	@Failure		403		{string}	string				"Players can only change themselves"
 //	@Failure		404		{string}	string				"Player not found"
 //	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/players/{id} [put]
 func UpdatePlayerHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	id, err := strconv.Atoi(ps.ByName("id"))
@@ -156,6 +165,10 @@ func UpdatePlayerHandler(w http.ResponseWriter, r *http.Request, ps httprouter.P
 		return
 	}
 
+	if !authorizeOwner(w, r, id, "Players can only change themselves") {
+		return
+	}
+
 	ok, err := repository.UpdatePlayerByID(id, playerDto.Name)
 	if err != nil {
 		http.Error(w, "Failed to update player", http.StatusInternalServerError)
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/websocket"
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/realtime"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+var upgrader = websocket.Upgrader{
+	ReadBufferSize:  1024,
+	WriteBufferSize: 1024,
+}
+
+// RoomEventsHandler streams the events of a room over WebSocket.
+//
+//	@Summary		Watch a room over WebSocket
+//	@Description	Upgrades to a WebSocket that first sends a room.snapshot message, then pushes room.* and game.* events (joins and leaves, moves, takebacks, clock ticks and game over) as JSON.
+//	@Description	Seated players may send {"type":"move","x":3,"y":3}, {"type":"pass"} or {"type":"resign"}, and seated players and spectators {"type":"chat","channel":"players","text":"hi"}; refused commands are answered with a command.error message. Chat arrives as chat.* events.
+//	@Description	Browsers that cannot set the Authorization header may pass the token in the access_token query parameter. Anonymous viewers and bots whose API key lacks the play scope only receive events.
+//	@Tags			rooms
+//	@Param			id				path		int		true	"Room ID"
+//	@Param			access_token	query		string	false	"JWT, when the Authorization header cannot be set"
+//	@Success		101				{string}	string	"Switching Protocols"
+//	@Failure		400				{string}	string	"Invalid id parameter"
+//	@Failure		401				{string}	string	"Invalid token"
+//	@Failure		404				{string}	string	"Room not found"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/ws [get]
+func RoomEventsHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	principal, ok := streamViewer(w, r, id)
+	if !ok {
+		return
+	}
+
+	// Upgrade replies with an HTTP error itself when it fails.
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return
+	}
+
+	realtime.ServeRoom(conn, id, principal, roomSnapshot(id, principal.PlayerID))
+}
+
+// RoomEventStreamHandler streams the events of a room as Server-Sent Events.
+//
+//	@Summary		Watch a room with Server-Sent Events
+//	@Description	Streams the same messages as the WebSocket endpoint, named after their type. Events kept in the room's event log carry an increasing id.
+//	@Description	A client reconnecting with the Last-Event-ID header receives the events it missed instead of a new room.snapshot. It gets a new snapshot when the bounded log no longer goes back to that event, and spectators kept behind by a spectator delay always do.
+//	@Tags			rooms
+//	@Produce		text/event-stream
+//	@Param			id				path		int		true	"Room ID"
+//	@Param			Last-Event-ID	header		string	false	"ID of the last event received"
+//	@Param			access_token	query		string	false	"JWT, when the Authorization header cannot be set"
+//	@Success		200				{string}	string	"Event stream"
+//	@Failure		400				{string}	string	"Invalid id parameter"
+//	@Failure		401				{string}	string	"Invalid token"
+//	@Failure		404				{string}	string	"Room not found"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/events [get]
+func RoomEventStreamHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	principal, ok := streamViewer(w, r, id)
+	if !ok {
+		return
+	}
+
+	realtime.ServeRoomEvents(w, r, id, principal.PlayerID, r.Header.Get("Last-Event-ID"), roomSnapshot(id, principal.PlayerID))
+}
+
+// streamViewer checks that the room exists and returns the caller watching
+// it, whose PlayerID is 0 for anonymous viewers. WebSocket and EventSource
+// clients in browsers cannot set headers, so the token may also come from the
+// access_token query parameter.
+func streamViewer(w http.ResponseWriter, r *http.Request, roomID int) (middlewares.Principal, bool) {
+	principal, _ := middlewares.PrincipalFromContext(r.Context())
+	if token := r.URL.Query().Get("access_token"); token != "" && principal.PlayerID == 0 {
+		var err error
+		if principal, err = middlewares.PrincipalFromToken(token); err != nil || principal.PlayerID == 0 {
+			http.Error(w, "Invalid token", http.StatusUnauthorized)
+			return middlewares.Principal{}, false
+		}
+	}
+
+	room, err := repository.GetRoomByID(roomID)
+	if err != nil {
+		http.Error(w, "Failed to retrieve room", http.StatusInternalServerError)
+		return middlewares.Principal{}, false
+	}
+
+	if room == nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return middlewares.Principal{}, false
+	}
+	return principal, true
+}
+
+// roomSnapshot builds the room as the viewer sees it for the first message
+// of a stream.
+func roomSnapshot(roomID, viewerID int) func() (any, error) {
+	return func() (any, error) {
+		room, err := repository.GetRoomByID(roomID)
+		if err != nil || room == nil {
+			return nil, err
+		}
+		return newRoomDto(room.ViewFor(viewerID), viewerID), nil
+	}
+}
@@ -2,12 +2,18 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/julienschmidt/httprouter"
 
 	"github.com/moLIart/go-course/internal/dto"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/game"
 	"github.com/moLIart/go-course/internal/model/room"
 	"github.com/moLIart/go-course/internal/repository"
 )
@@ -15,14 +21,15 @@ import (
 // CreateRoomHandler creates a new room.
 //
 //	@Summary		Create a new room (Requires authorization)
-//	@Description	Creates a new room with the given code and adds it to the repository.
+//	@Description	Creates a new room with a server generated invite code and adds it to the repository.
 //	@Tags			rooms
 //	@Accept			json
 //	@Produce		json
-//	@Param			room			body		dto.CreateRoomDto	true	"Room code"
+//	@Param			room			body		dto.CreateRoomDto	true	"Invite code lifetime and room settings"
 //	@Success		201				{object}	dto.GetRoomDto
-//	@Failure		400				{string}	string	"Invalid request body"
+//	@Failure		400				{string}	string	"Invalid request body or settings"
 //	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/rooms [post]
 func CreateRoomHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 
@@ -32,30 +39,72 @@ func CreateRoomHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 		return
 	}
 
-	room := room.NewRoom(roomDto.Code)
-	repository.AddEntity(room)
+	if roomDto.CodeTTL < 0 {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	settings := room.DefaultSettings()
+	if roomDto.Settings != nil {
+		settings = applyRoomSettings(settings, *roomDto.Settings)
+	}
+	if err := settings.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	room := room.NewRoom("")
+	room.Settings = settings
+	room.OwnerID, _ = middlewares.PlayerIDFromContext(r.Context())
+	room.SetCodeTTL(time.Duration(roomDto.CodeTTL) * time.Second)
+	if err := repository.CreateRoom(room); err != nil {
+		http.Error(w, "Failed to create room", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newRoomDto(room, room.OwnerID)); err != nil {
+		http.Error(w, "Failed to encode room", http.StatusInternalServerError)
+	}
 }
 
 // GetRoomsHandler retrieves all rooms.
 //
 //	@Summary		Get all rooms
-//	@Description	Returns a list of all rooms.
+//	@Description	Returns a list of all rooms, optionally only those in the given comma separated states. Games are shown with the spectator delay unless the token belongs to a seated player.
 //	@Tags			rooms
 //	@Produce		json
-//	@Success		200	{array}		dto.GetRoomDto
-//	@Failure		500	{string}	string	"Failed to encode rooms"
+//	@Param			state	query		string	false	"States to list: open, ready, playing, scoring, finished, abandoned"
+//	@Success		200		{array}		dto.GetRoomDto
+//	@Failure		400		{string}	string	"Invalid state parameter"
+//	@Failure		500		{string}	string	"Failed to encode rooms"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/rooms [get]
 func GetRoomsHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
-	rooms, err := repository.GetRooms()
+	var states []room.State
+	if param := r.URL.Query().Get("state"); param != "" {
+		for _, s := range strings.Split(param, ",") {
+			state := room.State(strings.TrimSpace(s))
+			if !state.IsValid() {
+				http.Error(w, "Invalid state parameter", http.StatusBadRequest)
+				return
+			}
+			states = append(states, state)
+		}
+	}
+
+	rooms, err := repository.GetRooms(states...)
 	if err != nil {
 		http.Error(w, "Failed to retrieve rooms", http.StatusInternalServerError)
 		return
 	}
 
+	viewerID, _ := middlewares.PlayerIDFromContext(r.Context())
 	roomDtos := make([]dto.GetRoomDto, len(rooms))
 	for i, room := range rooms {
-		roomDtos[i] = dto.GetRoomDto{ID: room.ID, Code: room.Code}
+		roomDtos[i] = newRoomDto(room.ViewFor(viewerID), viewerID)
 	}
 
 	w.Header().Set("Content-Type", "application/json")
@@ -67,13 +116,15 @@ func GetRoomsHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Param
 // GetRoomByIDHandler retrieves a room by its ID.
 //
 //	@Summary		Get room by ID
-//	@Description	Returns a room by its ID.
+//	@Description	Returns a room by its ID. The game is shown with the spectator delay unless the token belongs to a seated player.
 //	@Tags			rooms
 //	@Produce		json
 //	@Param			id	path		int	true	"Room ID"
 //	@Success		200	{object}	dto.GetRoomDto
 //	@Failure		400	{string}	string	"Invalid id parameter"
 //	@Failure		404	{string}	string	"Room not found"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/rooms/{id} [get]
 func GetRoomByIDHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	id, err := strconv.Atoi(ps.ByName("id"))
@@ -93,7 +144,8 @@ func GetRoomByIDHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 		return
 	}
 
-	roomDto := dto.GetRoomDto{ID: room.ID, Code: room.Code}
+	viewerID, _ := middlewares.PlayerIDFromContext(r.Context())
+	roomDto := newRoomDto(room.ViewFor(viewerID), viewerID)
 	w.Header().Set("Content-Type", "application/json")
 	if err := json.NewEncoder(w).Encode(roomDto); err != nil {
 		http.Error(w, "Failed to encode room", http.StatusInternalServerError)
@@ -103,13 +155,15 @@ func GetRoomByIDHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Pa
 // DeleteRoomHandler deletes a room by its ID.
 //
 //	@Summary		Delete room by ID (Requires authorization)
-//	@Description	Deletes a room by its ID.
+//	@Description	Deletes a room by its ID. Only the room owner or an admin can delete it.
 //	@Tags			rooms
 //	@Param			id				path		int		true	"Room ID"
 //	@Success		200				{string}	string	"OK"
 //	@Failure		400				{string}	string	"Invalid id parameter"
+//	@Failure		403				{string}	string	"Only the room owner can change the room"
 //	@Failure		404				{string}	string	"Room not found"
 //	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/rooms/{id} [delete]
 func DeleteRoomHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	id, err := strconv.Atoi(ps.ByName("id"))
@@ -118,6 +172,10 @@ func DeleteRoomHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 		return
 	}
 
+	if !authorizeRoomOwner(w, r, id) {
+		return
+	}
+
 	ok, err := repository.DeleteRoomByID(id)
 	if err != nil {
 		http.Error(w, "Failed to delete room", http.StatusInternalServerError)
@@ -132,18 +190,21 @@ func DeleteRoomHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 	w.WriteHeader(http.StatusOK)
 }
 
-// UpdateRoomHandler updates a room's code by its ID.
+// UpdateRoomHandler updates a room's code and settings by its ID.
 //
 //	@Summary		Update room by ID (Requires authorization)
-//	@Description	Updates the code of a room by its ID.
+//	@Description	Updates the code and settings of a room by its ID. Only the room owner or an admin can update it, and settings cannot change while a game is in progress.
 //	@Tags			rooms
 //	@Accept			json
 //	@Param			id				path		int					true	"Room ID"
-//	@Param			room			body		dto.UpdateRoomDto	true	"Updated room code"
+//	@Param			room			body		dto.UpdateRoomDto	true	"Updated room code and settings"
 //	@Success		200				{string}	string				"OK"
-//	@Failure		400				{string}	string				"Invalid id parameter or request body"
+//	@Failure		400				{string}	string				"Invalid id parameter, request body or settings"
+//	@Failure		403				{string}	string				"Only the room owner can change the room"
 //	@Failure		404				{string}	string				"Room not found"
+//	@Failure		409				{string}	string				"Invite code is already used by another room or a game is in progress"
 //	@Security		BearerAuth
+//	@Security		APIKeyAuth
 //	@Router			/rooms/{id} [put]
 func UpdateRoomHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 	id, err := strconv.Atoi(ps.ByName("id"))
@@ -158,16 +219,460 @@ func UpdateRoomHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 		return
 	}
 
-	ok, err := repository.UpdateRoomByID(id, roomDto.Code)
+	if roomDto.Code == "" && roomDto.Settings == nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	if !authorizeRoomOwner(w, r, id) {
+		return
+	}
+
+	if roomDto.Settings != nil {
+		updated, err := repository.UpdateRoomSettings(id, func(s room.Settings) room.Settings {
+			return applyRoomSettings(s, *roomDto.Settings)
+		})
+		switch {
+		case isAnyError(err, badRoomRequestErrors):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		case isAnyError(err, roomConflictErrors):
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		case err != nil:
+			http.Error(w, "Failed to update room", http.StatusInternalServerError)
+			return
+		case updated == nil:
+			http.Error(w, "Room not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	if roomDto.Code != "" {
+		ok, err := repository.UpdateRoomByID(id, room.NormalizeCode(roomDto.Code))
+		if errors.Is(err, repository.ErrCodeTaken) {
+			http.Error(w, err.Error(), http.StatusConflict)
+			return
+		}
+
+		if err != nil {
+			http.Error(w, "Failed to update room", http.StatusInternalServerError)
+			return
+		}
+
+		if !ok {
+			http.Error(w, "Room not found", http.StatusNotFound)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// JoinRoomHandler seats the authenticated player in a room.
+//
+//	@Summary		Join room by ID (Requires authorization)
+//	@Description	Seats the player identified by the token in the room. Anyone but the owner sends the room's current invite code in the body.
+//	@Tags			rooms
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"Room ID"
+//	@Param			join	body		dto.JoinRoomDto		false	"Invite code"
+//	@Success		200		{object}	dto.GetRoomDto
+//	@Failure		400		{string}	string	"Invalid id parameter or request body"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		403		{string}	string	"Invite code is missing"
+//	@Failure		404		{string}	string	"Room not found"
+//	@Failure		409		{string}	string	"Room is full or player is already in the room"
+//	@Failure		410		{string}	string	"Invite code has expired"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/join [post]
+func JoinRoomHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	// The body is optional, the owner joins by ID alone.
+	var joinDto dto.JoinRoomDto
+	if err := json.NewDecoder(r.Body).Decode(&joinDto); err != nil && !errors.Is(err, io.EOF) {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	player, ok := authenticatedPlayer(w, r)
+	if !ok {
+		return
+	}
+
+	rm, err := repository.JoinRoom(id, room.NormalizeCode(joinDto.Code), player)
+	writeRoomUpdate(w, r, rm, err)
+}
+
+// JoinRoomByCodeHandler seats the authenticated player in the room the invite code belongs to.
+//
+//	@Summary		Join room by invite code (Requires authorization)
+//	@Description	Seats the player identified by the token in the room shared through the invite code.
+//	@Tags			rooms
+//	@Produce		json
+//	@Param			code	path		string	true	"Invite code"
+//	@Success		200		{object}	dto.GetRoomDto
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		404		{string}	string	"Room not found"
+//	@Failure		409		{string}	string	"Room is full or player is already in the room"
+//	@Failure		410		{string}	string	"Invite code has expired"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/join/{code} [post]
+func JoinRoomByCodeHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	player, ok := authenticatedPlayer(w, r)
+	if !ok {
+		return
+	}
+
+	rm, err := repository.JoinRoomByCode(room.NormalizeCode(ps.ByName("code")), player)
+	writeRoomUpdate(w, r, rm, err)
+}
+
+// LeaveRoomHandler frees the seat of the authenticated player.
+//
+//	@Summary		Leave room by ID (Requires authorization)
+//	@Description	Removes the player identified by the token from the room.
+//	@Tags			rooms
+//	@Produce		json
+//	@Param			id	path		int	true	"Room ID"
+//	@Success		200	{object}	dto.GetRoomDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		404	{string}	string	"Room not found"
+//	@Failure		409	{string}	string	"Player is not in the room"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/leave [post]
+func LeaveRoomHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
 	if err != nil {
-		http.Error(w, "Failed to update room", http.StatusInternalServerError)
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	room, err := repository.LeaveRoom(id, playerID)
+	writeRoomUpdate(w, r, room, err)
+}
+
+// StartGameHandler starts a game between the two players seated in a room.
+//
+//	@Summary		Start a game in a room (Requires authorization)
+//	@Description	Creates the game of a full room. Colors are picked by the owner of an unrated room ("choice"), swapped from the previous game ("alternate") or drawn by the revealed nigiri of the room ("nigiri"), which the first game of "alternate" also uses. Unrated rooms without a revealed nigiri draw at random, rated rooms need one.
+//	@Tags			rooms
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		int					true	"Room ID"
+//	@Param			start	body		dto.StartGameDto	true	"Color assignment"
+//	@Success		200		{object}	dto.GetRoomDto
+//	@Failure		400		{string}	string	"Invalid id parameter or request body"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		403		{string}	string	"Only the owner of an unrated room can choose colors"
+//	@Failure		404		{string}	string	"Room not found"
+//	@Failure		409		{string}	string	"Room is not full, a game is in progress or the player is not in the room"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/start [post]
+func StartGameHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
 		return
 	}
 
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
 	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	var startDto dto.StartGameDto
+	if err := json.NewDecoder(r.Body).Decode(&startDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	assignment := room.ColorAssignment(startDto.ColorAssignment)
+	if !assignment.IsValid() {
+		http.Error(w, "Invalid color assignment", http.StatusBadRequest)
+		return
+	}
+
+	color, _ := game.ParseColor(startDto.Color)
+	room, err := repository.StartRoomGame(id, playerID, assignment, color)
+	writeRoomUpdate(w, r, room, err)
+}
+
+// authenticatedPlayer loads the player identified by the request token and
+// writes an error response if there is none.
+func authenticatedPlayer(w http.ResponseWriter, r *http.Request) (*room.Player, bool) {
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	player, err := repository.GetPlayerByID(playerID)
+	if err != nil {
+		http.Error(w, "Failed to retrieve player", http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if player == nil {
+		http.Error(w, "Player not found", http.StatusUnauthorized)
+		return nil, false
+	}
+	return player, true
+}
+
+// authorizeOwner checks that the principal of the request may change what
+// belongs to ownerID and otherwise writes the refusal.
+func authorizeOwner(w http.ResponseWriter, r *http.Request, ownerID int, refusal string) bool {
+	principal, ok := middlewares.PrincipalFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Missing or invalid Authorization header", http.StatusUnauthorized)
+		return false
+	}
+
+	if !principal.CanActFor(ownerID) {
+		http.Error(w, refusal, http.StatusForbidden)
+		return false
+	}
+	return true
+}
+
+// authorizeRoomOwner checks that the room exists and that the principal of
+// the request is its owner or an admin.
+func authorizeRoomOwner(w http.ResponseWriter, r *http.Request, id int) bool {
+	rm, err := repository.GetRoomByID(id)
+	if err != nil {
+		http.Error(w, "Failed to retrieve room", http.StatusInternalServerError)
+		return false
+	}
+
+	if rm == nil {
+		http.Error(w, "Room not found", http.StatusNotFound)
+		return false
+	}
+	return authorizeOwner(w, r, rm.OwnerID, "Only the room owner can change the room")
+}
+
+// writeRoomUpdate answers a room modification with the updated room or the
+// status matching the reason it was refused.
+func writeRoomUpdate(w http.ResponseWriter, r *http.Request, rm *room.Room, err error) {
+	switch {
+	case errors.Is(err, room.ErrCodeExpired):
+		http.Error(w, err.Error(), http.StatusGone)
+		return
+	case errors.Is(err, room.ErrNotModerator), errors.Is(err, room.ErrNotSeated), errors.Is(err, room.ErrColorChoiceForbidden), errors.Is(err, room.ErrCodeRequired):
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	case isAnyError(err, badRoomRequestErrors):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case isAnyError(err, roomConflictErrors):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, "Failed to update room", http.StatusInternalServerError)
+		return
+	case rm == nil:
 		http.Error(w, "Room not found", http.StatusNotFound)
 		return
 	}
 
-	w.WriteHeader(http.StatusOK)
+	viewerID, _ := middlewares.PlayerIDFromContext(r.Context())
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newRoomDto(rm, viewerID)); err != nil {
+		http.Error(w, "Failed to encode room", http.StatusInternalServerError)
+	}
+}
+
+// badRoomRequestErrors are room modification errors caused by invalid input.
+var badRoomRequestErrors = []error{
+	room.ErrInvalidColorAssignment,
+	room.ErrInvalidCommitment,
+	room.ErrInvalidGuess,
+	room.ErrInvalidStones,
+	room.ErrRevealMismatch,
+	room.ErrInvalidRuleset,
+	room.ErrInvalidBoardSize,
+	room.ErrInvalidKomi,
+	room.ErrInvalidHandicap,
+	room.ErrInvalidTimeControl,
+	room.ErrRatedTakebacks,
+	room.ErrInvalidSpectators,
+	game.ErrOutOfBoard,
+}
+
+// roomConflictErrors are room modification errors caused by the current
+// state of the room.
+var roomConflictErrors = []error{
+	room.ErrNigiriInProgress,
+	room.ErrNoNigiri,
+	room.ErrNigiriOutOfTurn,
+	room.ErrRevealExpired,
+	room.ErrRevealPending,
+	room.ErrNigiriRequired,
+	room.ErrRoomFull,
+	room.ErrAlreadyInRoom,
+	room.ErrNotInRoom,
+	room.ErrRoomNotFull,
+	room.ErrGameInProgress,
+	room.ErrInvalidTransition,
+	room.ErrRoomAbandoned,
+	room.ErrNoGame,
+	game.ErrGameOver,
+	game.ErrNotYourTurn,
+	game.ErrOccupied,
+	game.ErrSuicide,
+	game.ErrKo,
+	game.ErrScoring,
+	game.ErrNotScoring,
+	game.ErrTimeout,
+	game.ErrNothingToTakeBack,
+	game.ErrTakebackPending,
+	game.ErrNoTakebackRequest,
+	room.ErrTakebacksDisabled,
+	room.ErrSettingsLocked,
+	room.ErrSeatsTaken,
+	room.ErrSpectatorsNotAllowed,
+	room.ErrSpectatorsFull,
+	room.ErrAlreadySpectating,
+	room.ErrNotSpectating,
+	room.ErrAlreadyMuted,
+	room.ErrNotMuted,
+	repository.ErrConcurrentUpdate,
+}
+
+func isAnyError(err error, targets []error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// newRoomDto converts the room for a viewer. Only the owner is shown the
+// invite code, the others joined with it or have to be given it.
+func newRoomDto(r *room.Room, viewerID int) dto.GetRoomDto {
+	players := make([]dto.GetPlayerDto, 0, len(r.Players))
+	for _, player := range r.Players {
+		if player != nil {
+			players = append(players, dto.GetPlayerDto{ID: player.ID, Name: player.Name})
+		}
+	}
+	roomDto := dto.GetRoomDto{
+		ID:             r.ID,
+		OwnerID:        r.OwnerID,
+		State:          string(r.GetState()),
+		Settings:       newRoomSettingsDto(r.GetSettings()),
+		Players:        players,
+		SpectatorCount: len(r.Spectators),
+		Muted:          r.Muted,
+	}
+	if viewerID != 0 && viewerID == r.OwnerID {
+		roomDto.Code = r.Code
+		roomDto.CodeExpiresAt = r.CodeExpiresAt
+	}
+	if r.GetSettings().PairGo {
+		for team := range 2 {
+			var teamDto []dto.GetPlayerDto
+			for _, player := range r.GetTeam(team) {
+				if player != nil {
+					teamDto = append(teamDto, dto.GetPlayerDto{ID: player.ID, Name: player.Name})
+				}
+			}
+			roomDto.Teams = append(roomDto.Teams, teamDto)
+		}
+	}
+	if r.Game != nil {
+		gameDto := newGameDto(r.Game)
+		roomDto.Game = &gameDto
+	}
+	if r.Nigiri != nil {
+		nigiriDto := newNigiriDto(r.Nigiri)
+		roomDto.Nigiri = &nigiriDto
+	}
+	return roomDto
+}
+
+// applyRoomSettings overrides s with the fields present in the request.
+func applyRoomSettings(s room.Settings, settingsDto dto.RoomSettingsDto) room.Settings {
+	if settingsDto.Ruleset != nil {
+		s.Ruleset = game.Ruleset(*settingsDto.Ruleset)
+	}
+	if settingsDto.BoardSize != nil {
+		s.BoardSize = *settingsDto.BoardSize
+	}
+	if settingsDto.Komi != nil {
+		s.Komi = *settingsDto.Komi
+	}
+	if settingsDto.Handicap != nil {
+		s.Handicap = *settingsDto.Handicap
+	}
+	if settingsDto.PairGo != nil {
+		s.PairGo = *settingsDto.PairGo
+	}
+	if tc := settingsDto.TimeControl; tc != nil {
+		s.TimeControl = game.TimeControl{
+			Type:       tc.Type,
+			MainTime:   tc.MainTime,
+			Increment:  tc.Increment,
+			Periods:    tc.Periods,
+			PeriodTime: tc.PeriodTime,
+		}
+	}
+	if settingsDto.Rated != nil {
+		s.Rated = *settingsDto.Rated
+	}
+	if settingsDto.TakebacksAllowed != nil {
+		s.TakebacksAllowed = *settingsDto.TakebacksAllowed
+	}
+	if settingsDto.SpectatorsAllowed != nil {
+		s.SpectatorsAllowed = *settingsDto.SpectatorsAllowed
+	}
+	if settingsDto.MaxSpectators != nil {
+		s.MaxSpectators = *settingsDto.MaxSpectators
+	}
+	if settingsDto.SpectatorDelay != nil {
+		s.SpectatorDelay = *settingsDto.SpectatorDelay
+	}
+	return s
+}
+
+func newRoomSettingsDto(s room.Settings) dto.GetRoomSettingsDto {
+	return dto.GetRoomSettingsDto{
+		Ruleset:   string(s.Ruleset),
+		BoardSize: s.BoardSize,
+		Komi:      s.Komi,
+		Handicap:  s.Handicap,
+		TimeControl: dto.TimeControlDto{
+			Type:       s.TimeControl.Type,
+			MainTime:   s.TimeControl.MainTime,
+			Increment:  s.TimeControl.Increment,
+			Periods:    s.TimeControl.Periods,
+			PeriodTime: s.TimeControl.PeriodTime,
+		},
+		PairGo:            s.PairGo,
+		Rated:             s.Rated,
+		TakebacksAllowed:  s.TakebacksAllowed,
+		SpectatorsAllowed: s.SpectatorsAllowed,
+		MaxSpectators:     s.MaxSpectators,
+		SpectatorDelay:    s.SpectatorDelay,
+	}
 }
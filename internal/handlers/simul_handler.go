@@ -0,0 +1,402 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/dto"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/game"
+	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/model/simul"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+// CreateSimulHandler creates a simul hosted by the authenticated player.
+//
+//	@Summary		Create a simul (Requires authorization)
+//	@Description	Creates a simultaneous exhibition hosted by the player identified by the token. Opponents join until the host starts it.
+//	@Tags			simuls
+//	@Accept			json
+//	@Produce		json
+//	@Param			simul	body		dto.CreateSimulDto	true	"Simul"
+//	@Success		201		{object}	dto.GetSimulDto
+//	@Failure		400		{string}	string	"Invalid request body or settings"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/simuls [post]
+func CreateSimulHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	hostID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	var simulDto dto.CreateSimulDto
+	if err := json.NewDecoder(r.Body).Decode(&simulDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	hostColor := game.White
+	if simulDto.HostColor != "" {
+		hostColor, _ = game.ParseColor(simulDto.HostColor)
+	}
+	settings := room.DefaultSettings()
+	if simulDto.Settings != nil {
+		settings = applyRoomSettings(settings, *simulDto.Settings)
+	}
+
+	s := simul.NewSimul(simulDto.Name, hostID, simulDto.MaxOpponents, hostColor, settings)
+	if err := s.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := repository.AddEntity(s); err != nil {
+		http.Error(w, "Failed to create simul", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newSimulDto(s, nil)); err != nil {
+		http.Error(w, "Failed to encode simul", http.StatusInternalServerError)
+	}
+}
+
+// GetSimulsHandler retrieves all simuls.
+//
+//	@Summary		Get all simuls
+//	@Description	Returns a list of all simuls without the games of their boards.
+//	@Tags			simuls
+//	@Produce		json
+//	@Success		200	{array}		dto.GetSimulDto
+//	@Failure		500	{string}	string	"Failed to encode simuls"
+//	@Router			/simuls [get]
+func GetSimulsHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	simuls, err := repository.GetSimuls()
+	if err != nil {
+		http.Error(w, "Failed to retrieve simuls", http.StatusInternalServerError)
+		return
+	}
+
+	simulDtos := make([]dto.GetSimulDto, len(simuls))
+	for i, s := range simuls {
+		simulDtos[i] = newSimulDto(s, nil)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(simulDtos); err != nil {
+		http.Error(w, "Failed to encode simuls", http.StatusInternalServerError)
+	}
+}
+
+// GetSimulByIDHandler returns the overview of a simul.
+//
+//	@Summary		Get simul overview
+//	@Description	Returns a simul with the game of every board and the results so far. Games are shown with the spectator delay unless the token belongs to the host or the opponent of the board.
+//	@Tags			simuls
+//	@Produce		json
+//	@Param			id	path		int	true	"Simul ID"
+//	@Success		200	{object}	dto.GetSimulDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		404	{string}	string	"Simul not found"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/simuls/{id} [get]
+func GetSimulByIDHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	s, err := repository.GetSimulByID(id)
+	if err != nil {
+		http.Error(w, "Failed to retrieve simul", http.StatusInternalServerError)
+		return
+	}
+
+	if s == nil {
+		http.Error(w, "Simul not found", http.StatusNotFound)
+		return
+	}
+
+	rooms, err := repository.GetSimulRooms(id)
+	if err != nil {
+		http.Error(w, "Failed to retrieve simul boards", http.StatusInternalServerError)
+		return
+	}
+
+	viewerID, _ := middlewares.PlayerIDFromContext(r.Context())
+	for i, room := range rooms {
+		rooms[i] = room.ViewFor(viewerID)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newSimulDto(s, rooms)); err != nil {
+		http.Error(w, "Failed to encode simul", http.StatusInternalServerError)
+	}
+}
+
+// GetSimulQueueHandler returns the boards waiting for the host.
+//
+//	@Summary		Get host queue (Requires authorization)
+//	@Description	Returns the boards where it is the host's turn, the one waiting longest first. Only the host can see the queue.
+//	@Tags			simuls
+//	@Produce		json
+//	@Param			id	path		int	true	"Simul ID"
+//	@Success		200	{array}		dto.SimulBoardDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		403	{string}	string	"Only the host can do this"
+//	@Failure		404	{string}	string	"Simul not found"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/simuls/{id}/queue [get]
+func GetSimulQueueHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	s, err := repository.GetSimulByID(id)
+	if err != nil {
+		http.Error(w, "Failed to retrieve simul", http.StatusInternalServerError)
+		return
+	}
+
+	if s == nil {
+		http.Error(w, "Simul not found", http.StatusNotFound)
+		return
+	}
+
+	if s.HostID != playerID {
+		http.Error(w, simul.ErrNotHost.Error(), http.StatusForbidden)
+		return
+	}
+
+	rooms, err := repository.GetSimulRooms(id)
+	if err != nil {
+		http.Error(w, "Failed to retrieve simul boards", http.StatusInternalServerError)
+		return
+	}
+
+	queue := s.Queue(rooms)
+	boardDtos := make([]dto.SimulBoardDto, len(queue))
+	for i, room := range queue {
+		boardDtos[i] = newSimulBoardDto(s, room)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(boardDtos); err != nil {
+		http.Error(w, "Failed to encode simul queue", http.StatusInternalServerError)
+	}
+}
+
+// JoinSimulHandler adds the authenticated player to the opponents of a simul.
+//
+//	@Summary		Join a simul (Requires authorization)
+//	@Description	Adds the player identified by the token to the opponents of an open simul.
+//	@Tags			simuls
+//	@Produce		json
+//	@Param			id	path		int	true	"Simul ID"
+//	@Success		200	{object}	dto.GetSimulDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		404	{string}	string	"Simul not found"
+//	@Failure		409	{string}	string	"Simul is full, started or the player already joined"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/simuls/{id}/join [post]
+func JoinSimulHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	player, ok := authenticatedPlayer(w, r)
+	if !ok {
+		return
+	}
+
+	s, err := repository.JoinSimul(id, player)
+	writeSimulUpdate(w, s, err)
+}
+
+// LeaveSimulHandler removes the authenticated player from the opponents of a simul.
+//
+//	@Summary		Leave a simul (Requires authorization)
+//	@Description	Removes the player identified by the token from the opponents of a simul that has not started.
+//	@Tags			simuls
+//	@Produce		json
+//	@Param			id	path		int	true	"Simul ID"
+//	@Success		200	{object}	dto.GetSimulDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		404	{string}	string	"Simul not found"
+//	@Failure		409	{string}	string	"Simul started or the player did not join it"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/simuls/{id}/leave [post]
+func LeaveSimulHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	s, err := repository.LeaveSimul(id, playerID)
+	writeSimulUpdate(w, s, err)
+}
+
+// StartSimulHandler starts every board of a simul.
+//
+//	@Summary		Start a simul (Requires authorization)
+//	@Description	Opens a room with a started game between the host and every opponent. Each board is then played through the room endpoints.
+//	@Tags			simuls
+//	@Produce		json
+//	@Param			id	path		int	true	"Simul ID"
+//	@Success		200	{object}	dto.GetSimulDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		403	{string}	string	"Only the host can do this"
+//	@Failure		404	{string}	string	"Simul not found"
+//	@Failure		409	{string}	string	"Simul already started or has no opponents"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/simuls/{id}/start [post]
+func StartSimulHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	s, err := repository.StartSimul(id, playerID)
+	writeSimulUpdate(w, s, err)
+}
+
+// writeSimulUpdate answers a simul modification with the updated simul or
+// the status matching the reason it was refused.
+func writeSimulUpdate(w http.ResponseWriter, s *simul.Simul, err error) {
+	switch {
+	case errors.Is(err, simul.ErrNotHost), errors.Is(err, repository.ErrHostNotFound):
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	case isAnyError(err, simulConflictErrors):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case err != nil:
+		http.Error(w, "Failed to update simul", http.StatusInternalServerError)
+		return
+	case s == nil:
+		http.Error(w, "Simul not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(newSimulDto(s, nil)); err != nil {
+		http.Error(w, "Failed to encode simul", http.StatusInternalServerError)
+	}
+}
+
+// simulConflictErrors are simul modification errors caused by the current
+// state of the simul.
+var simulConflictErrors = []error{
+	simul.ErrSimulFull,
+	simul.ErrSimulStarted,
+	simul.ErrHostCannotJoin,
+	simul.ErrAlreadyJoined,
+	simul.ErrNotJoined,
+	simul.ErrNoOpponents,
+	repository.ErrConcurrentUpdate,
+}
+
+// newSimulDto builds the overview of a simul. Boards only carry their game
+// when the rooms of the boards are given.
+func newSimulDto(s *simul.Simul, rooms []*room.Room) dto.GetSimulDto {
+	opponents := make([]dto.GetPlayerDto, len(s.Opponents))
+	for i, opponent := range s.Opponents {
+		opponents[i] = dto.GetPlayerDto{ID: opponent.ID, Name: opponent.Name}
+	}
+
+	simulDto := dto.GetSimulDto{
+		ID:           s.ID,
+		Name:         s.Name,
+		HostID:       s.HostID,
+		HostColor:    s.HostColor.String(),
+		MaxOpponents: s.MaxOpponents,
+		State:        string(s.GetState(rooms)),
+		Settings:     newRoomSettingsDto(s.Settings),
+		Opponents:    opponents,
+		Boards:       make([]dto.SimulBoardDto, 0, len(s.Boards)),
+	}
+
+	roomsByID := make(map[int]*room.Room, len(rooms))
+	for _, r := range rooms {
+		roomsByID[r.ID] = r
+	}
+	for _, board := range s.Boards {
+		r, ok := roomsByID[board.RoomID]
+		if !ok {
+			simulDto.Boards = append(simulDto.Boards, dto.SimulBoardDto{RoomID: board.RoomID, OpponentID: board.OpponentID})
+			continue
+		}
+
+		simulDto.Boards = append(simulDto.Boards, newSimulBoardDto(s, r))
+		if r.Game == nil || !r.Game.IsOver() {
+			continue
+		}
+		switch hostColor := r.Game.GetColor(s.HostID); {
+		case r.Game.Status == game.Draw:
+			simulDto.Draws++
+		case (r.Game.Status == game.BlackWon) == (hostColor == game.Black):
+			simulDto.HostWins++
+		default:
+			simulDto.HostLosses++
+		}
+	}
+	return simulDto
+}
+
+func newSimulBoardDto(s *simul.Simul, r *room.Room) dto.SimulBoardDto {
+	boardDto := dto.SimulBoardDto{RoomID: r.ID}
+	for _, board := range s.Boards {
+		if board.RoomID == r.ID {
+			boardDto.OpponentID = board.OpponentID
+		}
+	}
+	if r.Game != nil {
+		gameDto := newGameDto(r.Game)
+		boardDto.Game = &gameDto
+		boardDto.HostToMove = !r.Game.IsOver() && r.Game.GetCurrentPlayerID() == s.HostID
+	}
+	return boardDto
+}
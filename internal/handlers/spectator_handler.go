@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+// SpectateHandler adds the authenticated player to the spectators of a room.
+//
+//	@Summary		Spectate room (Requires authorization)
+//	@Description	Adds the player identified by the token to the spectators of the room. The game is shown with the room's spectator delay.
+//	@Tags			spectators
+//	@Produce		json
+//	@Param			id	path		int	true	"Room ID"
+//	@Success		200	{object}	dto.GetRoomDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		404	{string}	string	"Room not found"
+//	@Failure		409	{string}	string	"Room does not allow spectators, is full or the player is already in it"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/spectators/join [post]
+func SpectateHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	player, ok := authenticatedPlayer(w, r)
+	if !ok {
+		return
+	}
+
+	room, err := repository.Spectate(id, player)
+	writeRoomUpdate(w, r, room.ViewFor(player.ID), err)
+}
+
+// StopSpectatingHandler removes the authenticated player from the spectators of a room.
+//
+//	@Summary		Stop spectating room (Requires authorization)
+//	@Description	Removes the player identified by the token from the spectators of the room.
+//	@Tags			spectators
+//	@Produce		json
+//	@Param			id	path		int	true	"Room ID"
+//	@Success		200	{object}	dto.GetRoomDto
+//	@Failure		400	{string}	string	"Invalid id parameter"
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		404	{string}	string	"Room not found"
+//	@Failure		409	{string}	string	"Player is not spectating the room"
+//	@Security		BearerAuth
+//	@Security		APIKeyAuth
+//	@Router			/rooms/{id}/spectators/leave [post]
+func StopSpectatingHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		http.Error(w, "Invalid id parameter", http.StatusBadRequest)
+		return
+	}
+
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	room, err := repository.StopSpectating(id, playerID)
+	writeRoomUpdate(w, r, room.ViewFor(playerID), err)
+}
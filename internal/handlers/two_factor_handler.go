@@ -0,0 +1,220 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/dto"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+// TwoFactorLoginHandler completes a login with a two-factor code.
+//
+//	@Summary		Log in with a two-factor code
+//	@Description	Exchanges the two-factor token of a login and a code of the authenticator app, or a recovery code, for tokens. A login is dropped after 5 wrong codes.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			login	body		dto.TwoFactorLoginDto	true	"Two-factor token and code"
+//	@Success		200		{object}	dto.TokenDto
+//	@Failure		400		{string}	string	"Invalid request body"
+//	@Failure		401		{string}	string	"Invalid two-factor code, or the login expired"
+//	@Failure		429		{string}	string	"Too many wrong two-factor codes"
+//	@Router			/auth/login/2fa [post]
+func TwoFactorLoginHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	var loginDto dto.TwoFactorLoginDto
+	if err := json.NewDecoder(r.Body).Decode(&loginDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return
+	}
+
+	player, err := repository.CompleteTwoFactorChallenge(loginDto.TwoFactorToken, loginDto.Code)
+	switch {
+	case errors.Is(err, account.ErrInvalidTwoFactorCode), errors.Is(err, account.ErrInvalidTwoFactorToken),
+		errors.Is(err, account.ErrInvalidCredentials):
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	case errors.Is(err, account.ErrTwoFactorLocked):
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+		return
+	case err != nil:
+		http.Error(w, "Failed to log in", http.StatusInternalServerError)
+		return
+	}
+
+	startSession(w, r, player, http.StatusOK)
+}
+
+// SetupTwoFactorHandler starts enrolling the player in two-factor authentication.
+//
+//	@Summary		Set up two-factor authentication (Requires authorization)
+//	@Description	Returns a new TOTP secret with its provisioning URI, to show as a QR code to authenticator apps. Logins need codes once the enrollment is enabled with a first code.
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{object}	dto.TwoFactorSetupDto
+//	@Failure		401	{string}	string	"Token does not identify a player"
+//	@Failure		403	{string}	string	"API keys cannot be used here"
+//	@Failure		409	{string}	string	"Two-factor authentication is already enabled"
+//	@Security		BearerAuth
+//	@Router			/auth/2fa/setup [post]
+func SetupTwoFactorHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return
+	}
+
+	twoFactor, acc, err := repository.SetupTwoFactor(playerID)
+	switch {
+	case errors.Is(err, account.ErrTwoFactorEnabled):
+		http.Error(w, err.Error(), http.StatusConflict)
+		return
+	case errors.Is(err, account.ErrInvalidCredentials):
+		http.Error(w, "Account not found", http.StatusUnauthorized)
+		return
+	case err != nil:
+		http.Error(w, "Failed to set up two-factor authentication", http.StatusInternalServerError)
+		return
+	}
+
+	setupDto := dto.TwoFactorSetupDto{
+		Secret:          twoFactor.Secret,
+		ProvisioningURI: twoFactor.ProvisioningURI(acc.Username),
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(setupDto); err != nil {
+		http.Error(w, "Failed to encode two-factor setup", http.StatusInternalServerError)
+	}
+}
+
+// EnableTwoFactorHandler enables two-factor authentication with a first code.
+//
+//	@Summary		Enable two-factor authentication (Requires authorization)
+//	@Description	Checks a code of the authenticator app set up with /auth/2fa/setup and requires codes to log in from now on. Returns the recovery codes, which are only shown once.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	body		dto.TwoFactorCodeDto	true	"Code of the authenticator app"
+//	@Success		200		{object}	dto.RecoveryCodesDto
+//	@Failure		400		{string}	string	"Invalid request body or code"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		403		{string}	string	"API keys cannot be used here"
+//	@Failure		409		{string}	string	"Two-factor authentication is already enabled or was not set up"
+//	@Security		BearerAuth
+//	@Router			/auth/2fa/enable [post]
+func EnableTwoFactorHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, code, ok := twoFactorRequest(w, r)
+	if !ok {
+		return
+	}
+
+	codes, err := repository.EnableTwoFactor(playerID, code)
+	writeRecoveryCodes(w, codes, err)
+}
+
+// DisableTwoFactorHandler disables two-factor authentication.
+//
+//	@Summary		Disable two-factor authentication (Requires authorization)
+//	@Description	Checks a code of the authenticator app, or a recovery code, and stops requiring codes to log in.
+//	@Tags			auth
+//	@Accept			json
+//	@Param			code	body		dto.TwoFactorCodeDto	true	"Code or recovery code"
+//	@Success		204		{string}	string	"No Content"
+//	@Failure		400		{string}	string	"Invalid request body or code"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		403		{string}	string	"API keys cannot be used here"
+//	@Failure		409		{string}	string	"Two-factor authentication is not enabled"
+//	@Failure		429		{string}	string	"Too many wrong two-factor codes"
+//	@Security		BearerAuth
+//	@Router			/auth/2fa/disable [post]
+func DisableTwoFactorHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, code, ok := twoFactorRequest(w, r)
+	if !ok {
+		return
+	}
+
+	err := repository.DisableTwoFactor(playerID, code)
+	if !writeTwoFactorError(w, err) {
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodesHandler replaces the recovery codes of the player.
+//
+//	@Summary		Regenerate recovery codes (Requires authorization)
+//	@Description	Checks a code of the authenticator app, or a recovery code, and replaces every recovery code with new ones, which are only shown once.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			code	body		dto.TwoFactorCodeDto	true	"Code or recovery code"
+//	@Success		200		{object}	dto.RecoveryCodesDto
+//	@Failure		400		{string}	string	"Invalid request body or code"
+//	@Failure		401		{string}	string	"Token does not identify a player"
+//	@Failure		403		{string}	string	"API keys cannot be used here"
+//	@Failure		409		{string}	string	"Two-factor authentication is not enabled"
+//	@Failure		429		{string}	string	"Too many wrong two-factor codes"
+//	@Security		BearerAuth
+//	@Router			/auth/2fa/recovery-codes [post]
+func RegenerateRecoveryCodesHandler(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	playerID, code, ok := twoFactorRequest(w, r)
+	if !ok {
+		return
+	}
+
+	codes, err := repository.RegenerateRecoveryCodes(playerID, code)
+	writeRecoveryCodes(w, codes, err)
+}
+
+// twoFactorRequest reads the player and the code of a request changing
+// their two-factor authentication.
+func twoFactorRequest(w http.ResponseWriter, r *http.Request) (int, string, bool) {
+	playerID, ok := middlewares.PlayerIDFromContext(r.Context())
+	if !ok {
+		http.Error(w, "Token does not identify a player", http.StatusUnauthorized)
+		return 0, "", false
+	}
+
+	var codeDto dto.TwoFactorCodeDto
+	if err := json.NewDecoder(r.Body).Decode(&codeDto); err != nil {
+		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		return 0, "", false
+	}
+	return playerID, codeDto.Code, true
+}
+
+func writeRecoveryCodes(w http.ResponseWriter, codes []string, err error) {
+	if !writeTwoFactorError(w, err) {
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(dto.RecoveryCodesDto{RecoveryCodes: codes}); err != nil {
+		http.Error(w, "Failed to encode recovery codes", http.StatusInternalServerError)
+	}
+}
+
+// writeTwoFactorError writes the error of a change of two-factor
+// authentication and reports whether there was none.
+func writeTwoFactorError(w http.ResponseWriter, err error) bool {
+	switch {
+	case err == nil:
+		return true
+	case errors.Is(err, account.ErrInvalidTwoFactorCode):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, account.ErrTwoFactorEnabled), errors.Is(err, account.ErrTwoFactorNotEnabled),
+		errors.Is(err, account.ErrTwoFactorNotSetUp):
+		http.Error(w, err.Error(), http.StatusConflict)
+	case errors.Is(err, account.ErrTwoFactorLocked):
+		http.Error(w, err.Error(), http.StatusTooManyRequests)
+	default:
+		http.Error(w, "Failed to update two-factor authentication", http.StatusInternalServerError)
+	}
+	return false
+}
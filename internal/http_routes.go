@@ -4,10 +4,42 @@ import (
 	"github.com/julienschmidt/httprouter"
 	"github.com/moLIart/go-course/internal/handlers"
 	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/account"
 )
 
 func RegisterHTTPRoutes() *httprouter.Router {
 	router := httprouter.New()
+	router.POST("/auth/register", handlers.RegisterHandler)
+	router.POST("/auth/login", handlers.LoginHandler)
+	router.POST("/auth/login/2fa", handlers.TwoFactorLoginHandler)
+	router.POST("/auth/refresh", handlers.RefreshHandler)
+	router.POST("/auth/logout", tokenOnly(handlers.LogoutHandler))
+	router.POST("/auth/logout-all", tokenOnly(handlers.LogoutEverywhereHandler))
+	router.GET("/auth/sessions", tokenOnly(handlers.GetSessionsHandler))
+	router.DELETE("/auth/sessions/:id", tokenOnly(handlers.RevokeSessionHandler))
+	router.POST("/auth/2fa/setup", tokenOnly(handlers.SetupTwoFactorHandler))
+	router.POST("/auth/2fa/enable", tokenOnly(handlers.EnableTwoFactorHandler))
+	router.POST("/auth/2fa/disable", tokenOnly(handlers.DisableTwoFactorHandler))
+	router.POST("/auth/2fa/recovery-codes", tokenOnly(handlers.RegenerateRecoveryCodesHandler))
+	router.GET("/auth/email", tokenOnly(handlers.GetEmailHandler))
+	router.PUT("/auth/email", tokenOnly(handlers.SetEmailHandler))
+	router.POST("/auth/email/verification", tokenOnly(handlers.ResendVerificationEmailHandler))
+	router.GET("/auth/email/verify", handlers.VerifyEmailHandler)
+	router.POST("/auth/password/forgot", handlers.ForgotPasswordHandler)
+	router.POST("/auth/password/reset", handlers.ResetPasswordHandler)
+	router.GET("/auth/identities", tokenOnly(handlers.GetIdentitiesHandler))
+	router.DELETE("/auth/identities/:id", tokenOnly(handlers.UnlinkIdentityHandler))
+	router.GET("/auth/oidc", handlers.GetOIDCProvidersHandler)
+	router.GET("/auth/oidc/:provider/login", handlers.OIDCLoginHandler)
+	router.POST("/auth/oidc/:provider/link", tokenOnly(handlers.OIDCLinkHandler))
+	router.GET("/auth/oidc/:provider/callback", handlers.OIDCCallbackHandler)
+
+	router.POST("/bots", tokenOnly(handlers.CreateBotHandler))
+	router.GET("/bots", tokenOnly(handlers.GetBotsHandler))
+	router.POST("/bots/:id/keys", tokenOnly(handlers.CreateAPIKeyHandler))
+	router.GET("/bots/:id/keys", tokenOnly(handlers.GetAPIKeysHandler))
+	router.DELETE("/bots/:id/keys/:keyId", tokenOnly(handlers.RevokeAPIKeyHandler))
+
 	router.POST("/players", middlewares.JWTAuth(handlers.CreatePlayerHandler))
 	router.GET("/players", handlers.GetPlayersHandler)
 	router.GET("/players/:id", handlers.GetPlayerByIDHandler)
@@ -15,10 +47,46 @@ func RegisterHTTPRoutes() *httprouter.Router {
 	router.DELETE("/players/:id", middlewares.JWTAuth(handlers.DeletePlayerByIDHandler))
 
 	router.POST("/rooms", middlewares.JWTAuth(handlers.CreateRoomHandler))
-	router.GET("/rooms", handlers.GetRoomsHandler)
-	router.GET("/rooms/:id", handlers.GetRoomByIDHandler)
+	router.GET("/rooms", middlewares.OptionalJWTAuth(handlers.GetRoomsHandler))
+	router.GET("/rooms/:id", middlewares.OptionalJWTAuth(handlers.GetRoomByIDHandler))
 	router.PUT("/rooms/:id", middlewares.JWTAuth(handlers.UpdateRoomHandler))
 	router.DELETE("/rooms/:id", middlewares.JWTAuth(handlers.DeleteRoomHandler))
+	router.POST("/rooms/:id/join", middlewares.JWTAuth(handlers.JoinRoomHandler))
+	router.POST("/rooms/:id/leave", middlewares.JWTAuth(handlers.LeaveRoomHandler))
+	router.POST("/rooms/:id/spectators/join", middlewares.JWTAuth(handlers.SpectateHandler))
+	router.POST("/rooms/:id/spectators/leave", middlewares.JWTAuth(handlers.StopSpectatingHandler))
+	router.POST("/rooms/:id/start", middlewares.JWTAuth(handlers.StartGameHandler))
+	router.POST("/rooms/:id/move", middlewares.JWTAuth(handlers.PlayMoveHandler))
+	router.POST("/rooms/:id/pass", middlewares.JWTAuth(handlers.PassHandler))
+	router.POST("/rooms/:id/resign", middlewares.JWTAuth(handlers.ResignHandler))
+	router.POST("/rooms/:id/score/accept", middlewares.JWTAuth(handlers.AcceptScoreHandler))
+	router.POST("/rooms/:id/score/resume", middlewares.JWTAuth(handlers.ResumePlayHandler))
+	router.POST("/rooms/:id/takeback", middlewares.JWTAuth(handlers.TakebackHandler))
+	router.POST("/rooms/:id/takeback/accept", middlewares.JWTAuth(handlers.AcceptTakebackHandler))
+	router.POST("/rooms/:id/takeback/decline", middlewares.JWTAuth(handlers.DeclineTakebackHandler))
+	router.GET("/rooms/:id/events", middlewares.OptionalJWTAuth(handlers.RoomEventStreamHandler))
+	router.GET("/rooms/:id/ws", middlewares.OptionalJWTAuth(handlers.RoomEventsHandler))
+	router.GET("/rooms/:id/chat", middlewares.OptionalJWTAuth(handlers.GetChatMessagesHandler))
+	router.POST("/rooms/:id/chat", middlewares.JWTAuth(handlers.PostChatMessageHandler))
+	router.POST("/rooms/:id/chat/mute", middlewares.JWTAuth(handlers.MuteChatPlayerHandler))
+	router.POST("/rooms/:id/chat/unmute", middlewares.JWTAuth(handlers.UnmuteChatPlayerHandler))
+	router.DELETE("/rooms/:id/chat/:messageId", middlewares.JWTAuth(handlers.DeleteChatMessageHandler))
+	router.GET("/rooms/:id/nigiri", handlers.GetNigiriAuditHandler)
+	router.POST("/rooms/:id/nigiri/commit", middlewares.JWTAuth(handlers.CommitNigiriHandler))
+	router.POST("/rooms/:id/nigiri/guess", middlewares.JWTAuth(handlers.GuessNigiriHandler))
+	router.POST("/rooms/:id/nigiri/reveal", middlewares.JWTAuth(handlers.RevealNigiriHandler))
+	router.POST("/rooms/:id/nigiri/claim", middlewares.JWTAuth(handlers.ClaimNigiriHandler))
+	// httprouter cannot register /rooms/join/:code next to /rooms/:id/join,
+	// so invite links use their own short prefix.
+	router.POST("/join/:code", middlewares.JWTAuth(handlers.JoinRoomByCodeHandler))
+
+	router.POST("/simuls", middlewares.JWTAuth(handlers.CreateSimulHandler))
+	router.GET("/simuls", handlers.GetSimulsHandler)
+	router.GET("/simuls/:id", middlewares.OptionalJWTAuth(handlers.GetSimulByIDHandler))
+	router.GET("/simuls/:id/queue", middlewares.JWTAuth(handlers.GetSimulQueueHandler))
+	router.POST("/simuls/:id/join", middlewares.JWTAuth(handlers.JoinSimulHandler))
+	router.POST("/simuls/:id/leave", middlewares.JWTAuth(handlers.LeaveSimulHandler))
+	router.POST("/simuls/:id/start", middlewares.JWTAuth(handlers.StartSimulHandler))
 
 	router.POST("/boards", middlewares.JWTAuth(handlers.CreateBoardHandler))
 	router.GET("/boards", handlers.GetBoardsHandler)
@@ -27,11 +95,24 @@ func RegisterHTTPRoutes() *httprouter.Router {
 	router.DELETE("/boards/:id", middlewares.JWTAuth(handlers.DeleteBoardHandler))
 
 	router.POST("/games", middlewares.JWTAuth(handlers.CreateGameHandler))
-	router.GET("/games", handlers.GetGamesHandler)
-	router.GET("/games/:id", handlers.GetGameByIDHandler)
+	router.GET("/games", middlewares.OptionalJWTAuth(handlers.GetGamesHandler))
+	router.GET("/games/:id", middlewares.OptionalJWTAuth(handlers.GetGameByIDHandler))
 	router.DELETE("/games/:id", middlewares.JWTAuth(handlers.DeleteGameHandler))
 
+	router.POST("/admin/games/:id/adjudicate", adminOnly(handlers.AdjudicateGameHandler))
+	router.GET("/admin/users", adminOnly(handlers.GetUsersHandler))
+	router.PUT("/admin/users/:id/role", adminOnly(handlers.SetUserRoleHandler))
+	router.DELETE("/admin/users/:id", adminOnly(handlers.DeleteUserHandler))
+
 	router.Handler("GET", "/swagger/*any", handlers.SwaggerUIHandler())
 
 	return router
 }
+
+func adminOnly(handler httprouter.Handle) httprouter.Handle {
+	return middlewares.JWTAuth(middlewares.RequireRole(account.RoleAdmin, handler))
+}
+
+func tokenOnly(handler httprouter.Handle) httprouter.Handle {
+	return middlewares.JWTAuth(middlewares.TokenOnly(handler))
+}
@@ -0,0 +1,121 @@
+// Package jwks reads the RSA and Ed25519 public keys of JSON Web Key Sets.
+package jwks
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Key is a public key tokens may be signed with, for the one algorithm it can
+// be used with.
+type Key struct {
+	Alg string
+	Key any
+}
+
+// jsonWebKey holds the fields of RSA and Ed25519 public keys in a JWKS.
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg,omitempty"`
+	Use string `json:"use,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// Parse returns the signing keys of the set by key ID. Keys for encryption
+// are skipped, and RSA keys must have at least 2048 bits.
+func Parse(payload []byte) (map[string]Key, error) {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	if err := json.Unmarshal(payload, &set); err != nil {
+		return nil, err
+	}
+
+	parsed := map[string]Key{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		if jwk.Kid == "" {
+			return nil, errors.New("every key needs a kid")
+		}
+		key, err := jwk.key()
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", jwk.Kid, err)
+		}
+		parsed[jwk.Kid] = key
+	}
+	return parsed, nil
+}
+
+// Marshal returns the set holding the public key under the key ID, for
+// issuers publishing their own keys.
+func Marshal(kid string, key any) ([]byte, error) {
+	var jwk jsonWebKey
+	switch key := key.(type) {
+	case *rsa.PublicKey:
+		jwk = jsonWebKey{
+			Kty: "RSA",
+			Alg: jwt.SigningMethodRS256.Alg(),
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		jwk = jsonWebKey{
+			Kty: "OKP",
+			Alg: jwt.SigningMethodEdDSA.Alg(),
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", key)
+	}
+	jwk.Kid = kid
+	jwk.Use = "sig"
+
+	return json.Marshal(map[string][]jsonWebKey{"keys": {jwk}})
+}
+
+func (jwk jsonWebKey) key() (Key, error) {
+	switch {
+	case jwk.Kty == "RSA" && (jwk.Alg == "" || jwk.Alg == jwt.SigningMethodRS256.Alg()):
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return Key{}, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return Key{}, err
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() < 3 {
+			return Key{}, errors.New("invalid RSA exponent")
+		}
+		key := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}
+		if key.N.BitLen() < 2048 {
+			return Key{}, errors.New("RSA keys must have at least 2048 bits")
+		}
+		return Key{Alg: jwt.SigningMethodRS256.Alg(), Key: key}, nil
+	case jwk.Kty == "OKP" && jwk.Crv == "Ed25519" && (jwk.Alg == "" || jwk.Alg == jwt.SigningMethodEdDSA.Alg()):
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return Key{}, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return Key{}, errors.New("invalid Ed25519 key size")
+		}
+		return Key{Alg: jwt.SigningMethodEdDSA.Alg(), Key: ed25519.PublicKey(x)}, nil
+	}
+	return Key{}, fmt.Errorf("unsupported key type %s %s %s", jwk.Kty, jwk.Crv, jwk.Alg)
+}
@@ -0,0 +1,47 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// defaultFrom is the sender of messages written by FileMailer without one.
+const defaultFrom = "Go Course <no-reply@localhost>"
+
+// FileMailer is for local development. It writes each message to an .eml
+// file in Dir, which mail clients open, or to the log when Dir is empty. The
+// messages hold tokens, so the log must not be kept anywhere shared.
+type FileMailer struct {
+	Dir  string
+	From string
+}
+
+func (m FileMailer) Send(ctx context.Context, msg Message) error {
+	from := m.From
+	if from == "" {
+		from = defaultFrom
+	}
+	payload := format(from, msg)
+
+	if m.Dir == "" {
+		log.Printf("Email to %s:\n%s", msg.To, strings.ReplaceAll(string(payload), "\r\n", "\n"))
+		return nil
+	}
+
+	if err := os.MkdirAll(m.Dir, 0o700); err != nil {
+		return err
+	}
+	recipient := strings.Map(func(r rune) rune {
+		if r == '/' || r == '\\' || r == os.PathSeparator {
+			return '_'
+		}
+		return r
+	}, msg.To)
+	name := fmt.Sprintf("%s-%s-%s.eml", time.Now().UTC().Format("20060102T150405"), recipient, messageID()[:8])
+	return os.WriteFile(filepath.Join(m.Dir, name), payload, 0o600)
+}
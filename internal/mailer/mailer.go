@@ -0,0 +1,119 @@
+// Package mailer sends the emails of accounts through a pluggable Mailer.
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/mail"
+	"strings"
+	"sync"
+	"time"
+)
+
+// sendTimeout bounds how long sending an email in the background may take.
+const sendTimeout = 30 * time.Second
+
+// Message is a plain text email.
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// ErrNotConfigured is returned by Send until Configure is called.
+var ErrNotConfigured = errors.New("no mailer is configured")
+
+// Mailer delivers messages.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// unconfigured refuses messages rather than printing their tokens anywhere.
+type unconfigured struct{}
+
+func (unconfigured) Send(context.Context, Message) error {
+	return ErrNotConfigured
+}
+
+var (
+	mu      sync.RWMutex
+	current Mailer = unconfigured{}
+	baseURL        = "http://localhost:8080"
+)
+
+// Configure replaces the mailer and the URL the links in emails start with.
+// Until it is called, messages are refused with ErrNotConfigured.
+func Configure(m Mailer, publicURL string) {
+	mu.Lock()
+	defer mu.Unlock()
+	current = m
+	if publicURL != "" {
+		baseURL = strings.TrimSuffix(publicURL, "/")
+	}
+}
+
+// Send delivers the message with the configured mailer.
+func Send(ctx context.Context, msg Message) error {
+	mu.RLock()
+	m := current
+	mu.RUnlock()
+	return m.Send(ctx, msg)
+}
+
+// SendAsync delivers the message in the background and logs failures, so
+// that requests neither wait for the mail server nor reveal by their timing
+// whether an email was sent.
+func SendAsync(msg Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), sendTimeout)
+		defer cancel()
+		if err := Send(ctx, msg); err != nil {
+			log.Printf("Failed to send %q: %v", msg.Subject, err)
+		}
+	}()
+}
+
+func link(path string) string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return baseURL + path
+}
+
+// format renders the message as RFC 5322 text.
+func format(from string, msg Message) []byte {
+	var b bytes.Buffer
+	if addr, err := mail.ParseAddress(from); err == nil {
+		from = addr.String()
+	}
+	fmt.Fprintf(&b, "From: %s\r\n", from)
+	fmt.Fprintf(&b, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&b, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&b, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Message-ID: <%s@%s>\r\n", messageID(), domain(from))
+	b.WriteString("MIME-Version: 1.0\r\n")
+	b.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	b.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	b.WriteString(strings.ReplaceAll(strings.ReplaceAll(msg.Body, "\r\n", "\n"), "\n", "\r\n"))
+	return b.Bytes()
+}
+
+func messageID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func domain(from string) string {
+	if addr, err := mail.ParseAddress(from); err == nil {
+		if _, host, ok := strings.Cut(addr.Address, "@"); ok {
+			return host
+		}
+	}
+	return "localhost"
+}
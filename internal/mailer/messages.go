@@ -0,0 +1,53 @@
+package mailer
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/moLIart/go-course/internal/model/account"
+)
+
+// VerificationEmail asks the player to confirm that the address is theirs.
+func VerificationEmail(to, username, token string) Message {
+	return Message{
+		To:      to,
+		Subject: "Confirm your email address",
+		Body: fmt.Sprintf(`Hi %s,
+
+Confirm that this is your email address by opening this link:
+
+%s
+
+The link expires in %s. If you did not add this address to your account, you can ignore this email.
+`, username, link("/auth/email/verify?token="+url.QueryEscape(token)), duration(account.EmailVerificationTTL)),
+	}
+}
+
+// PasswordResetEmail sends the player the token to choose a new password with.
+func PasswordResetEmail(to, username, token string) Message {
+	return Message{
+		To:      to,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(`Hi %s,
+
+Someone asked to reset the password of your account. To choose a new one, send this token with your new password to %s:
+
+%s
+
+The token expires in %s and stops working once your password changed. If you did not ask for it, you can ignore this email.
+`, username, link("/auth/password/reset"), token, duration(account.PasswordResetTTL)),
+	}
+}
+
+// duration writes how long tokens are valid in whole hours or minutes.
+func duration(d time.Duration) string {
+	n, unit := int(d.Minutes()), "minute"
+	if d >= time.Hour && d%time.Hour == 0 {
+		n, unit = int(d.Hours()), "hour"
+	}
+	if n != 1 {
+		unit += "s"
+	}
+	return fmt.Sprintf("%d %s", n, unit)
+}
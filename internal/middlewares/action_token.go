@@ -0,0 +1,83 @@
+package middlewares
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/moLIart/go-course/internal/model/account"
+)
+
+// Purposes of action tokens. A token is only accepted for the purpose it was
+// issued for.
+const (
+	PurposeEmailVerification = "email_verification"
+	PurposePasswordReset     = "password_reset"
+)
+
+// actionClaims are the claims of tokens sent by email. Binding ties a token
+// to the state it was issued for, the address to verify or the password to
+// reset, so that it stops working once that state changed.
+type actionClaims struct {
+	jwt.RegisteredClaims
+	Purpose string `json:"purpose"`
+	Binding string `json:"bnd"`
+}
+
+// IssueActionToken signs a token allowing the player to do what the purpose
+// says until it expires. Action tokens are signed with the key of access
+// tokens but for another audience, so that neither is accepted as the other.
+func IssueActionToken(purpose string, playerID int, binding string, ttl time.Duration) (string, error) {
+	kid, key := signingKey()
+	if key == nil {
+		return "", ErrNoSigningKey
+	}
+	iss, aud := tokenClaims()
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, actionClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    iss,
+			Subject:   strconv.Itoa(playerID),
+			Audience:  jwt.ClaimStrings{actionAudience(aud, purpose)},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		Purpose: purpose,
+		Binding: binding,
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	return token.SignedString(key)
+}
+
+// ParseActionToken returns the player and the binding of a token issued for
+// the purpose, or account.ErrInvalidActionToken.
+func ParseActionToken(purpose, tokenString string) (int, string, error) {
+	iss, aud := tokenClaims()
+	token, err := jwt.ParseWithClaims(tokenString, &actionClaims{}, keyFunc,
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithIssuer(iss),
+		jwt.WithAudience(actionAudience(aud, purpose)),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil || !token.Valid {
+		return 0, "", account.ErrInvalidActionToken
+	}
+
+	claims := token.Claims.(*actionClaims)
+	playerID, err := strconv.Atoi(claims.Subject)
+	if err != nil || claims.Purpose != purpose {
+		return 0, "", account.ErrInvalidActionToken
+	}
+	return playerID, claims.Binding, nil
+}
+
+func actionAudience(aud, purpose string) string {
+	return aud + "/" + purpose
+}
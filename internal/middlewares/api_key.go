@@ -0,0 +1,47 @@
+package middlewares
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/model/account"
+)
+
+// APIKeyHeader is the header bots send their API key in. gRPC calls send it
+// in the "x-api-key" metadata.
+const APIKeyHeader = "X-API-Key"
+
+// PrincipalFromAPIKey validates the API key of a bot and returns the bot.
+// Bots never have more than the player role, whatever their account says.
+func PrincipalFromAPIKey(key string) (Principal, error) {
+	k, err := authenticateAPIKey(key)
+	if err != nil {
+		return Principal{}, err
+	}
+	return Principal{PlayerID: k.BotID, Role: account.RolePlayer, APIKeyID: k.ID, Scopes: k.Scopes}, nil
+}
+
+// TokenOnly refuses the requests of bots authenticated with an API key, for
+// managing sessions and keys. It wraps handlers already authenticated by
+// JWTAuth.
+func TokenOnly(handler httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if principal, ok := PrincipalFromContext(r.Context()); ok && principal.APIKeyID != "" {
+			http.Error(w, "API keys cannot be used here", http.StatusForbidden)
+			return
+		}
+
+		handler(w, r, ps)
+	}
+}
+
+// requestScope is the scope API keys need for the request: reading for safe
+// methods and playing for everything else.
+func requestScope(r *http.Request) account.Scope {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return account.ScopeRead
+	}
+	return account.ScopePlay
+}
@@ -0,0 +1,74 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+	"net/netip"
+	"strings"
+)
+
+// trustedProxies are the networks of the load balancers whose
+// X-Forwarded-For header is believed. It is set once at startup.
+var trustedProxies []netip.Prefix
+
+// ParseTrustedProxies reads addresses and networks written as
+// "10.0.0.1,10.1.0.0/16".
+func ParseTrustedProxies(s string) ([]netip.Prefix, error) {
+	var parsed []netip.Prefix
+	for _, entry := range strings.Split(s, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		if !strings.Contains(entry, "/") {
+			addr, err := netip.ParseAddr(entry)
+			if err != nil {
+				return nil, fmt.Errorf("invalid proxy address %q", entry)
+			}
+			parsed = append(parsed, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy network %q", entry)
+		}
+		parsed = append(parsed, prefix.Masked())
+	}
+	return parsed, nil
+}
+
+// SetTrustedProxies makes ClientIP believe the X-Forwarded-For header of
+// requests coming from these networks.
+func SetTrustedProxies(proxies []netip.Prefix) {
+	trustedProxies = proxies
+}
+
+// ClientIP returns the address of the client. X-Forwarded-For is only
+// believed as far as the hops that added to it are trusted proxies, so the
+// client is the last address before the first untrusted one.
+func ClientIP(r *http.Request) string {
+	remote, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	ip := remote.Addr().Unmap()
+	hops := strings.Split(strings.Join(r.Header.Values("X-Forwarded-For"), ","), ",")
+	for i := len(hops) - 1; i >= 0 && isTrustedProxy(ip); i-- {
+		hop, err := netip.ParseAddr(strings.TrimSpace(hops[i]))
+		if err != nil {
+			break
+		}
+		ip = hop.Unmap()
+	}
+	return ip.String()
+}
+
+func isTrustedProxy(ip netip.Addr) bool {
+	for _, proxy := range trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
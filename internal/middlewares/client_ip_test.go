@@ -0,0 +1,53 @@
+package middlewares
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	proxies, err := ParseTrustedProxies("10.0.0.1, 192.168.0.0/16")
+	if err != nil {
+		t.Fatalf("ParseTrustedProxies: %v", err)
+	}
+	SetTrustedProxies(proxies)
+	defer SetTrustedProxies(nil)
+
+	tests := []struct {
+		name      string
+		remote    string
+		forwarded []string
+		want      string
+	}{
+		{name: "direct client", remote: "203.0.113.7:5000", want: "203.0.113.7"},
+		{name: "direct client forging the header", remote: "203.0.113.7:5000", forwarded: []string{"198.51.100.1"}, want: "203.0.113.7"},
+		{name: "behind a trusted proxy", remote: "10.0.0.1:443", forwarded: []string{"198.51.100.1"}, want: "198.51.100.1"},
+		{name: "behind two trusted proxies", remote: "10.0.0.1:443", forwarded: []string{"198.51.100.1, 192.168.3.4"}, want: "198.51.100.1"},
+		{name: "client forging a hop", remote: "10.0.0.1:443", forwarded: []string{"1.2.3.4, 198.51.100.1"}, want: "198.51.100.1"},
+		{name: "header repeated", remote: "10.0.0.1:443", forwarded: []string{"1.2.3.4", "198.51.100.1"}, want: "198.51.100.1"},
+		{name: "only trusted hops", remote: "10.0.0.1:443", forwarded: []string{"192.168.3.4"}, want: "192.168.3.4"},
+		{name: "garbage hop", remote: "10.0.0.1:443", forwarded: []string{"not-an-ip"}, want: "10.0.0.1"},
+		{name: "untrusted address in a trusted network's neighborhood", remote: "10.0.0.2:443", forwarded: []string{"198.51.100.1"}, want: "10.0.0.2"},
+		{name: "ipv6 client", remote: "[2001:db8::1]:443", want: "2001:db8::1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("POST", "/auth/login", nil)
+			r.RemoteAddr = tt.remote
+			for _, v := range tt.forwarded {
+				r.Header.Add("X-Forwarded-For", v)
+			}
+			if got := ClientIP(r); got != tt.want {
+				t.Errorf("ClientIP() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTrustedProxiesRejectsGarbage(t *testing.T) {
+	for _, s := range []string{"10.0.0", "10.0.0.0/33", "proxy.local"} {
+		if _, err := ParseTrustedProxies(s); err == nil {
+			t.Errorf("ParseTrustedProxies(%q) accepted it", s)
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package middlewares
+
+import (
+	"cmp"
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/moLIart/go-course/internal/model/account"
+)
+
+// Access is what a gRPC method requires of its callers. The zero value
+// requires a valid token, or an API key with the play scope.
+type Access struct {
+	// Public methods are open to anonymous callers, like the routes wrapped in
+	// OptionalJWTAuth: callers sending a valid token are still identified.
+	Public bool
+	// Role is required on top of a valid token.
+	Role account.Role
+	// Scope is required of API keys, account.ScopePlay if empty.
+	Scope account.Scope
+	// TokenOnly methods refuse API keys, as the routes wrapped in TokenOnly.
+	TokenOnly bool
+}
+
+// UnaryAuth authenticates the calls of unary methods with the bearer token
+// sent in the "authorization" metadata or the API key sent in the "x-api-key"
+// metadata, as JWTAuth does for HTTP requests, and refuses them unless they
+// are granted the access of their method.
+// Methods missing from rules require a valid token.
+func UnaryAuth(rules map[string]Access) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticateCall(ctx, rules[info.FullMethod])
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamAuth is UnaryAuth for streaming methods.
+func StreamAuth(rules map[string]Access) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticateCall(ss.Context(), rules[info.FullMethod])
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authenticatedStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticateCall returns the context of the call carrying its principal.
+func authenticateCall(ctx context.Context, access Access) (context.Context, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	var principal Principal
+	var err error
+	if keys := md.Get(strings.ToLower(APIKeyHeader)); len(keys) > 0 {
+		principal, err = PrincipalFromAPIKey(keys[0])
+		if err != nil {
+			err = status.Errorf(codes.Unauthenticated, "invalid API key")
+		}
+	} else if values := md.Get("authorization"); len(values) > 0 && strings.HasPrefix(values[0], "Bearer ") {
+		principal, err = PrincipalFromToken(strings.TrimPrefix(values[0], "Bearer "))
+		if err != nil {
+			err = status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+		}
+	} else {
+		err = status.Errorf(codes.Unauthenticated, "missing or invalid authorization metadata")
+	}
+
+	scope := cmp.Or(access.Scope, account.ScopePlay)
+	switch {
+	case err == nil && access.TokenOnly && principal.APIKeyID != "":
+		err = status.Errorf(codes.PermissionDenied, "API keys cannot be used here")
+	case err == nil && !principal.HasScope(scope):
+		err = status.Errorf(codes.PermissionDenied, "API key lacks the %s scope", scope)
+	case err == nil && access.Role != "" && !principal.HasRole(access.Role):
+		err = status.Errorf(codes.PermissionDenied, "requires the %s role", access.Role)
+	}
+
+	if err != nil {
+		if access.Public {
+			return ctx, nil
+		}
+		return nil, err
+	}
+	return WithPrincipal(ctx, principal), nil
+}
+
+// authenticatedStream replaces the context of a stream with one carrying
+// the principal of the call.
+type authenticatedStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authenticatedStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,145 @@
+package middlewares
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/moLIart/go-course/internal/model/account"
+)
+
+var testRules = map[string]Access{
+	"/test/Public":    {Public: true, Scope: account.ScopeRead},
+	"/test/Admin":     {Role: account.RoleAdmin},
+	"/test/TokenOnly": {TokenOnly: true},
+}
+
+// setupAPIKeys makes "read-key" and "play-key" the keys of bot 9.
+func setupAPIKeys(t *testing.T) {
+	t.Helper()
+	prev := authenticateAPIKey
+	authenticateAPIKey = func(key string) (*account.APIKey, error) {
+		switch key {
+		case "read-key":
+			return &account.APIKey{ID: "read", BotID: 9, Scopes: []account.Scope{account.ScopeRead}}, nil
+		case "play-key":
+			return &account.APIKey{ID: "play", BotID: 9, Scopes: []account.Scope{account.ScopePlay}}, nil
+		}
+		return nil, account.ErrInvalidAPIKey
+	}
+	t.Cleanup(func() { authenticateAPIKey = prev })
+}
+
+func TestUnaryAuth(t *testing.T) {
+	setupJWT(t)
+	setupAPIKeys(t)
+
+	token := func(playerID int, sessionID string, role account.Role) string {
+		signed, _, err := IssueAccessToken(playerID, sessionID, role)
+		if err != nil {
+			t.Fatalf("IssueAccessToken: %v", err)
+		}
+		return signed
+	}
+	bearer := func(signed string) metadata.MD { return metadata.Pairs("authorization", "Bearer "+signed) }
+	apiKey := func(key string) metadata.MD { return metadata.Pairs("x-api-key", key) }
+
+	tests := []struct {
+		name       string
+		method     string
+		md         metadata.MD
+		wantCode   codes.Code
+		wantPlayer int
+	}{
+		{name: "anonymous public call", method: "/test/Public"},
+		{name: "anonymous call", method: "/test/Private", wantCode: codes.Unauthenticated},
+		{name: "anonymous admin call", method: "/test/Admin", wantCode: codes.Unauthenticated},
+		{name: "player on a public method", method: "/test/Public", md: bearer(token(3, "active", account.RolePlayer)), wantPlayer: 3},
+		{name: "invalid token on a public method", method: "/test/Public", md: bearer("garbage")},
+		{name: "invalid token", method: "/test/Private", md: bearer("garbage"), wantCode: codes.Unauthenticated},
+		{name: "revoked session", method: "/test/Private", md: bearer(token(3, "revoked", account.RolePlayer)), wantCode: codes.Unauthenticated},
+		{name: "player", method: "/test/Private", md: bearer(token(3, "active", account.RolePlayer)), wantPlayer: 3},
+		{name: "player on an admin method", method: "/test/Admin", md: bearer(token(3, "active", account.RolePlayer)), wantCode: codes.PermissionDenied},
+		{name: "moderator on an admin method", method: "/test/Admin", md: bearer(token(3, "active", account.RoleModerator)), wantCode: codes.PermissionDenied},
+		{name: "admin", method: "/test/Admin", md: bearer(token(1, "active", account.RoleAdmin)), wantPlayer: 1},
+		{name: "read key on a public method", method: "/test/Public", md: apiKey("read-key"), wantPlayer: 9},
+		{name: "read key", method: "/test/Private", md: apiKey("read-key"), wantCode: codes.PermissionDenied},
+		{name: "play key", method: "/test/Private", md: apiKey("play-key"), wantPlayer: 9},
+		{name: "play key on an admin method", method: "/test/Admin", md: apiKey("play-key"), wantCode: codes.PermissionDenied},
+		{name: "play key on a token only method", method: "/test/TokenOnly", md: apiKey("play-key"), wantCode: codes.PermissionDenied},
+		{name: "invalid key", method: "/test/Private", md: apiKey("stolen-key"), wantCode: codes.Unauthenticated},
+		{name: "player on a token only method", method: "/test/TokenOnly", md: bearer(token(3, "active", account.RolePlayer)), wantPlayer: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+			var got Principal
+			handler := func(ctx context.Context, req any) (any, error) {
+				got, _ = PrincipalFromContext(ctx)
+				return nil, nil
+			}
+			_, err := UnaryAuth(testRules)(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.method}, handler)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("UnaryAuth() = %v, want %v", err, tt.wantCode)
+			}
+			if got.PlayerID != tt.wantPlayer {
+				t.Errorf("handler called by player %d, want %d", got.PlayerID, tt.wantPlayer)
+			}
+		})
+	}
+}
+
+// testStream is a server stream with nothing but its context.
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testStream) Context() context.Context {
+	return s.ctx
+}
+
+func TestStreamAuth(t *testing.T) {
+	setupJWT(t)
+	setupAPIKeys(t)
+
+	signed, _, err := IssueAccessToken(3, "active", account.RolePlayer)
+	if err != nil {
+		t.Fatalf("IssueAccessToken: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		method     string
+		md         metadata.MD
+		wantCode   codes.Code
+		wantPlayer int
+	}{
+		{name: "anonymous public stream", method: "/test/Public"},
+		{name: "anonymous stream", method: "/test/Private", wantCode: codes.Unauthenticated},
+		{name: "player", method: "/test/Private", md: metadata.Pairs("authorization", "Bearer "+signed), wantPlayer: 3},
+		{name: "player on an admin stream", method: "/test/Admin", md: metadata.Pairs("authorization", "Bearer "+signed), wantCode: codes.PermissionDenied},
+		{name: "read key", method: "/test/Private", md: metadata.Pairs("x-api-key", "read-key"), wantCode: codes.PermissionDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stream := &testStream{ctx: metadata.NewIncomingContext(context.Background(), tt.md)}
+			var got Principal
+			handler := func(srv any, ss grpc.ServerStream) error {
+				got, _ = PrincipalFromContext(ss.Context())
+				return nil
+			}
+			err := StreamAuth(testRules)(nil, stream, &grpc.StreamServerInfo{FullMethod: tt.method}, handler)
+			if status.Code(err) != tt.wantCode {
+				t.Fatalf("StreamAuth() = %v, want %v", err, tt.wantCode)
+			}
+			if got.PlayerID != tt.wantPlayer {
+				t.Errorf("handler called by player %d, want %d", got.PlayerID, tt.wantPlayer)
+			}
+		})
+	}
+}
@@ -1,21 +1,198 @@
 package middlewares
 
 import (
+	"context"
+	"errors"
 	"net/http"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+type contextKey string
+
+var (
+	ErrNoPlayerIdentity = errors.New("token does not identify a player")
+	ErrSessionRevoked   = errors.New("session has been revoked")
 )
 
-var jwtSecret string
+// The repository lookups of authentication, replaced in tests.
+var (
+	authenticateAPIKey = repository.AuthenticateAPIKey
+	isSessionActive    = repository.IsSessionActive
+	getPlayerRole      = repository.GetPlayerRole
+)
+
+// accessClaims are the claims of access tokens. SessionID ties a token to
+// the session it was issued for, so that revoking the session rejects it.
+type accessClaims struct {
+	jwt.RegisteredClaims
+	SessionID string       `json:"sid"`
+	Role      account.Role `json:"role,omitempty"`
+}
+
+// AccessTokenTTL is how long access tokens are valid. Clients get new ones
+// with their refresh token.
+const AccessTokenTTL = 15 * time.Minute
+
+// clockSkew is how far the clocks of token issuers may be off when checking
+// "exp", "nbf" and "iat".
+const clockSkew = 30 * time.Second
+
+// validMethods are the only algorithms tokens may be signed with.
+var validMethods = []string{
+	jwt.SigningMethodHS256.Alg(),
+	jwt.SigningMethodRS256.Alg(),
+	jwt.SigningMethodEdDSA.Alg(),
+}
+
+// IssueAccessToken signs a token carrying the player ID in its "sub" claim
+// and the session in its "sid" claim, and returns it with its expiry. The
+// token names its signing key in the "kid" header and carries the role of
+// the player in its "role" claim.
+func IssueAccessToken(playerID int, sessionID string, role account.Role) (string, time.Time, error) {
+	kid, key := signingKey()
+	if key == nil {
+		return "", time.Time{}, ErrNoSigningKey
+	}
+	iss, aud := tokenClaims()
+
+	now := time.Now()
+	expiresAt := now.Add(AccessTokenTTL)
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    iss,
+			Subject:   strconv.Itoa(playerID),
+			Audience:  jwt.ClaimStrings{aud},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+		SessionID: sessionID,
+		Role:      role,
+	})
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
 
-func SetJwtSecret(secret string) {
-	jwtSecret = secret
+	signed, err := token.SignedString(key)
+	return signed, expiresAt, err
 }
 
+// parseToken validates the signature, algorithm, issuer, audience and
+// validity period of an access token and checks that its session is still
+// active. Tokens signed with our own secrets must belong to a session, while
+// tokens verified with a public key of the JWKS only do if they carry a "sid".
+// Their "role" claim is ignored, see principalOf.
+func parseToken(tokenString string) (*jwt.Token, error) {
+	iss, aud := tokenClaims()
+	token, err := jwt.ParseWithClaims(tokenString, &accessClaims{}, keyFunc,
+		jwt.WithValidMethods(validMethods),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithIssuer(iss),
+		jwt.WithAudience(aud),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, jwt.ErrTokenUnverifiable
+	}
+
+	claims := token.Claims.(*accessClaims)
+	if claims.SessionID == "" {
+		if token.Method.Alg() == jwt.SigningMethodHS256.Alg() {
+			return nil, ErrSessionRevoked
+		}
+		return token, nil
+	}
+	active, err := isSessionActive(claims.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		return nil, ErrSessionRevoked
+	}
+	return token, nil
+}
+
+// playerIDFromToken reads the player ID carried in the "sub" claim.
+func playerIDFromToken(token *jwt.Token) (int, error) {
+	subject, err := token.Claims.GetSubject()
+	if err != nil || subject == "" {
+		return 0, ErrNoPlayerIdentity
+	}
+
+	id, err := strconv.Atoi(subject)
+	if err != nil {
+		return 0, ErrNoPlayerIdentity
+	}
+	return id, nil
+}
+
+// principalOf returns the caller a validated token was issued for. Only
+// tokens signed with our own secrets are believed about the role of the
+// player, the role of players with tokens of the JWKS is looked up.
+func principalOf(token *jwt.Token) (Principal, error) {
+	claims := token.Claims.(*accessClaims)
+	id, _ := playerIDFromToken(token)
+	p := Principal{PlayerID: id, SessionID: claims.SessionID, Role: claims.Role}
+	if token.Method.Alg() == jwt.SigningMethodHS256.Alg() {
+		return p, nil
+	}
+
+	p.Role = ""
+	if id != 0 {
+		role, err := getPlayerRole(id)
+		if err != nil {
+			return Principal{}, err
+		}
+		p.Role = role
+	}
+	return p, nil
+}
+
+// SessionIDFromContext returns the session of the request authenticated by JWTAuth.
+func SessionIDFromContext(ctx context.Context) (string, bool) {
+	p, ok := PrincipalFromContext(ctx)
+	return p.SessionID, ok && p.SessionID != ""
+}
+
+// PlayerIDFromContext returns the player ID of the request authenticated by JWTAuth.
+func PlayerIDFromContext(ctx context.Context) (int, bool) {
+	p, ok := PrincipalFromContext(ctx)
+	return p.PlayerID, ok && p.PlayerID != 0
+}
+
+// JWTAuth authenticates requests with the bearer token of the Authorization
+// header, or the API key of a bot sent in the X-API-Key header. API keys only
+// allow reading unless they have the play scope.
 func JWTAuth(handler httprouter.Handle) httprouter.Handle {
 	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if key := r.Header.Get(APIKeyHeader); key != "" {
+			principal, err := PrincipalFromAPIKey(key)
+			if err != nil {
+				http.Error(w, "Invalid API key", http.StatusUnauthorized)
+				return
+			}
+
+			if scope := requestScope(r); !principal.HasScope(scope) {
+				http.Error(w, "API key lacks the "+string(scope)+" scope", http.StatusForbidden)
+				return
+			}
+
+			handler(w, r.WithContext(WithPrincipal(r.Context(), principal)), ps)
+			return
+		}
+
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" || !strings.HasPrefix(authHeader, "Bearer ") {
 			http.Error(w, "Missing or invalid Authorization header", http.StatusUnauthorized)
@@ -24,15 +201,30 @@ func JWTAuth(handler httprouter.Handle) httprouter.Handle {
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return []byte(jwtSecret), nil
-		})
-
-		if err != nil || !token.Valid {
+		principal, err := PrincipalFromToken(tokenString)
+		if err != nil {
 			http.Error(w, "Invalid token", http.StatusUnauthorized)
 			return
 		}
 
+		handler(w, r.WithContext(WithPrincipal(r.Context(), principal)), ps)
+	}
+}
+
+// OptionalJWTAuth identifies the player of requests carrying a valid token or
+// API key and lets every other request through anonymously.
+func OptionalJWTAuth(handler httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if key := r.Header.Get(APIKeyHeader); key != "" {
+			if principal, err := PrincipalFromAPIKey(key); err == nil && principal.HasScope(requestScope(r)) {
+				r = r.WithContext(WithPrincipal(r.Context(), principal))
+			}
+		} else if tokenString, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+			if principal, err := PrincipalFromToken(tokenString); err == nil {
+				r = r.WithContext(WithPrincipal(r.Context(), principal))
+			}
+		}
+
 		handler(w, r, ps)
 	}
 }
@@ -0,0 +1,184 @@
+package middlewares
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/moLIart/go-course/internal/jwks"
+	"github.com/moLIart/go-course/internal/model/account"
+)
+
+const (
+	testIssuer   = "test-issuer"
+	testAudience = "test-audience"
+)
+
+// setupJWT configures an HMAC key "k1" to sign with, a legacy secret for
+// tokens without a "kid" and an Ed25519 key "ext" published in a JWKS. Only
+// the "active" session exists and player 7 has the moderator role.
+func setupJWT(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	set, err := jwks.Marshal("ext", public)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	jwksFile := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(jwksFile, set, 0o600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	err = ConfigureJWT(JWTConfig{
+		Secret:       "legacy-secret",
+		Keys:         map[string]string{"k1": "secret-1"},
+		SigningKeyID: "k1",
+		Issuer:       testIssuer,
+		Audience:     testAudience,
+		JWKSFile:     jwksFile,
+	})
+	if err != nil {
+		t.Fatalf("ConfigureJWT: %v", err)
+	}
+
+	prevSession, prevRole := isSessionActive, getPlayerRole
+	isSessionActive = func(sessionID string) (bool, error) {
+		return sessionID == "active", nil
+	}
+	getPlayerRole = func(playerID int) (account.Role, error) {
+		if playerID == 7 {
+			return account.RoleModerator, nil
+		}
+		return account.RolePlayer, nil
+	}
+	t.Cleanup(func() {
+		isSessionActive, getPlayerRole = prevSession, prevRole
+		keysMu.Lock()
+		defer keysMu.Unlock()
+		keys, signingKID = map[string]jwks.Key{}, ""
+		issuer, audience = DefaultIssuer, DefaultAudience
+	})
+	return private
+}
+
+// testClaims are valid claims of player 7 in the active session.
+func testClaims() accessClaims {
+	now := time.Now()
+	return accessClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    testIssuer,
+			Subject:   strconv.Itoa(7),
+			Audience:  jwt.ClaimStrings{testAudience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+		SessionID: "active",
+	}
+}
+
+func signToken(t *testing.T, method jwt.SigningMethod, kid string, key any, claims accessClaims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(method, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	signed, err := token.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString: %v", err)
+	}
+	return signed
+}
+
+func TestParseToken(t *testing.T) {
+	private := setupJWT(t)
+	hmac := []byte("secret-1")
+
+	issued, _, err := IssueAccessToken(7, "active", account.RolePlayer)
+	if err != nil {
+		t.Fatalf("IssueAccessToken: %v", err)
+	}
+
+	with := func(change func(*accessClaims)) accessClaims {
+		claims := testClaims()
+		change(&claims)
+		return claims
+	}
+	tests := []struct {
+		name    string
+		token   string
+		wantErr error
+	}{
+		{name: "issued token", token: issued},
+		{name: "legacy secret without kid", token: signToken(t, jwt.SigningMethodHS256, "", []byte("legacy-secret"), testClaims())},
+		{name: "other issuer", token: signToken(t, jwt.SigningMethodHS256, "k1", hmac, with(func(c *accessClaims) { c.Issuer = "someone-else" })), wantErr: jwt.ErrTokenInvalidIssuer},
+		{name: "other audience", token: signToken(t, jwt.SigningMethodHS256, "k1", hmac, with(func(c *accessClaims) { c.Audience = jwt.ClaimStrings{"other-api"} })), wantErr: jwt.ErrTokenInvalidAudience},
+		{name: "expired", token: signToken(t, jwt.SigningMethodHS256, "k1", hmac, with(func(c *accessClaims) { c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-time.Hour)) })), wantErr: jwt.ErrTokenExpired},
+		{name: "without expiry", token: signToken(t, jwt.SigningMethodHS256, "k1", hmac, with(func(c *accessClaims) { c.ExpiresAt = nil })), wantErr: jwt.ErrTokenRequiredClaimMissing},
+		{name: "unknown kid", token: signToken(t, jwt.SigningMethodHS256, "k2", hmac, testClaims()), wantErr: ErrUnknownKey},
+		{name: "wrong secret", token: signToken(t, jwt.SigningMethodHS256, "k1", []byte("guessed"), testClaims()), wantErr: jwt.ErrTokenSignatureInvalid},
+		{name: "revoked session", token: signToken(t, jwt.SigningMethodHS256, "k1", hmac, with(func(c *accessClaims) { c.SessionID = "revoked" })), wantErr: ErrSessionRevoked},
+		{name: "HS256 without session", token: signToken(t, jwt.SigningMethodHS256, "k1", hmac, with(func(c *accessClaims) { c.SessionID = "" })), wantErr: ErrSessionRevoked},
+		{name: "JWKS key without session", token: signToken(t, jwt.SigningMethodEdDSA, "ext", private, with(func(c *accessClaims) { c.SessionID = "" }))},
+		{name: "JWKS key with revoked session", token: signToken(t, jwt.SigningMethodEdDSA, "ext", private, with(func(c *accessClaims) { c.SessionID = "revoked" })), wantErr: ErrSessionRevoked},
+		{name: "JWKS key under the kid of an HMAC key", token: signToken(t, jwt.SigningMethodEdDSA, "k1", private, testClaims()), wantErr: jwt.ErrTokenSignatureInvalid},
+		{name: "HMAC under the kid of a JWKS key", token: signToken(t, jwt.SigningMethodHS256, "ext", hmac, testClaims()), wantErr: jwt.ErrTokenSignatureInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseToken(tt.token)
+			if tt.wantErr == nil && err != nil {
+				t.Fatalf("parseToken() = %v, want a valid token", err)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("parseToken() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPrincipalOf(t *testing.T) {
+	private := setupJWT(t)
+
+	with := func(role account.Role, subject string) accessClaims {
+		claims := testClaims()
+		claims.Role = role
+		claims.Subject = subject
+		return claims
+	}
+	tests := []struct {
+		name   string
+		method jwt.SigningMethod
+		claims accessClaims
+		want   Principal
+	}{
+		{name: "issued role is believed", method: jwt.SigningMethodHS256, claims: with(account.RoleAdmin, "7"), want: Principal{PlayerID: 7, SessionID: "active", Role: account.RoleAdmin}},
+		{name: "JWKS role claim is looked up", method: jwt.SigningMethodEdDSA, claims: with(account.RoleAdmin, "7"), want: Principal{PlayerID: 7, SessionID: "active", Role: account.RoleModerator}},
+		{name: "JWKS token of another player", method: jwt.SigningMethodEdDSA, claims: with("", "8"), want: Principal{PlayerID: 8, SessionID: "active", Role: account.RolePlayer}},
+		{name: "JWKS token without player", method: jwt.SigningMethodEdDSA, claims: with(account.RoleAdmin, ""), want: Principal{SessionID: "active"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			kid, key := "k1", any([]byte("secret-1"))
+			if tt.method == jwt.SigningMethodEdDSA {
+				kid, key = "ext", private
+			}
+			got, err := PrincipalFromToken(signToken(t, tt.method, kid, key, tt.claims))
+			if err != nil {
+				t.Fatalf("PrincipalFromToken: %v", err)
+			}
+			if got.PlayerID != tt.want.PlayerID || got.SessionID != tt.want.SessionID || got.Role != tt.want.Role {
+				t.Errorf("PrincipalFromToken() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package middlewares
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/moLIart/go-course/internal/jwks"
+)
+
+// Default claims of issued tokens, used when the configuration leaves them out.
+const (
+	DefaultIssuer   = "go-course"
+	DefaultAudience = "go-course"
+)
+
+var (
+	ErrUnknownKey   = errors.New("token is signed with an unknown key")
+	ErrNoSigningKey = errors.New("no key to sign tokens with")
+)
+
+// JWTConfig configures how access tokens are signed and verified.
+type JWTConfig struct {
+	// Secret is the HMAC key of tokens without a "kid" header, as issued
+	// before keys had IDs.
+	Secret string
+	// Keys are HMAC secrets by key ID. Tokens are signed with SigningKeyID,
+	// or with Secret if it is empty, and verified with any of them, so a new
+	// key can be added before it is used and an old one removed once its
+	// tokens expired.
+	Keys         map[string]string
+	SigningKeyID string
+	Issuer       string
+	Audience     string
+	// JWKSFile is a JSON Web Key Set of RSA or Ed25519 public keys that RS256
+	// and EdDSA tokens of other issuers are verified with.
+	JWKSFile string
+}
+
+var (
+	keysMu     sync.RWMutex
+	keys       = map[string]jwks.Key{}
+	signingKID string
+	issuer     = DefaultIssuer
+	audience   = DefaultAudience
+)
+
+// ConfigureJWT replaces the keys and the claims access tokens are checked
+// against.
+func ConfigureJWT(cfg JWTConfig) error {
+	configured := map[string]jwks.Key{}
+	if cfg.Secret != "" {
+		configured[""] = jwks.Key{Alg: jwt.SigningMethodHS256.Alg(), Key: []byte(cfg.Secret)}
+	}
+	for kid, secret := range cfg.Keys {
+		configured[kid] = jwks.Key{Alg: jwt.SigningMethodHS256.Alg(), Key: []byte(secret)}
+	}
+	if key, ok := configured[cfg.SigningKeyID]; !ok || key.Alg != jwt.SigningMethodHS256.Alg() {
+		return fmt.Errorf("%w: %q", ErrNoSigningKey, cfg.SigningKeyID)
+	}
+
+	if cfg.JWKSFile != "" {
+		published, err := loadJWKS(cfg.JWKSFile)
+		if err != nil {
+			return err
+		}
+		for kid, key := range published {
+			if _, ok := configured[kid]; ok {
+				return fmt.Errorf("key ID %q is used twice", kid)
+			}
+			configured[kid] = key
+		}
+	}
+
+	keysMu.Lock()
+	defer keysMu.Unlock()
+	keys = configured
+	signingKID = cfg.SigningKeyID
+	issuer = cmp.Or(cfg.Issuer, DefaultIssuer)
+	audience = cmp.Or(cfg.Audience, DefaultAudience)
+	return nil
+}
+
+// ParseHMACKeys reads keys written as "kid1:secret1,kid2:secret2".
+func ParseHMACKeys(s string) (map[string]string, error) {
+	parsed := map[string]string{}
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		kid, secret, ok := strings.Cut(strings.TrimSpace(pair), ":")
+		if !ok || kid == "" || secret == "" {
+			return nil, fmt.Errorf("invalid key %q, expected kid:secret", pair)
+		}
+		parsed[kid] = secret
+	}
+	return parsed, nil
+}
+
+// signingKey returns the ID and the secret new tokens are signed with.
+func signingKey() (string, []byte) {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	key, _ := keys[signingKID].Key.([]byte)
+	return signingKID, key
+}
+
+func tokenClaims() (string, string) {
+	keysMu.RLock()
+	defer keysMu.RUnlock()
+	return issuer, audience
+}
+
+// keyFunc selects the key of a token by its "kid" header and refuses it if
+// the token claims another algorithm than the key is for.
+func keyFunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	keysMu.RLock()
+	key, ok := keys[kid]
+	keysMu.RUnlock()
+	if !ok {
+		return nil, ErrUnknownKey
+	}
+	if token.Method.Alg() != key.Alg {
+		return nil, jwt.ErrTokenSignatureInvalid
+	}
+	return key.Key, nil
+}
+
+func loadJWKS(path string) (map[string]jwks.Key, error) {
+	payload, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	loaded, err := jwks.Parse(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWKS %s: %w", path, err)
+	}
+	return loaded, nil
+}
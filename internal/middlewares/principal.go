@@ -0,0 +1,82 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+
+	"github.com/moLIart/go-course/internal/model/account"
+)
+
+const principalKey contextKey = "principal"
+
+// Principal is the caller authenticated by the token of a request.
+type Principal struct {
+	// PlayerID is 0 if the token does not identify a player.
+	PlayerID  int
+	SessionID string
+	Role      account.Role
+	// APIKeyID is set when a bot authenticated with an API key, which only
+	// allows what its Scopes allow.
+	APIKeyID string
+	Scopes   []account.Scope
+}
+
+// HasScope reports whether the principal may do what the scope allows.
+// Principals authenticated by a token may do everything.
+func (p Principal) HasScope(scope account.Scope) bool {
+	return p.APIKeyID == "" || account.HasScope(p.Scopes, scope)
+}
+
+// HasRole reports whether the principal has the powers of the role.
+func (p Principal) HasRole(role account.Role) bool {
+	return p.Role.Includes(role)
+}
+
+// CanActFor reports whether the principal may change what belongs to the
+// player: players only act for themselves, admins for everyone.
+func (p Principal) CanActFor(playerID int) bool {
+	return p.HasRole(account.RoleAdmin) || (p.PlayerID != 0 && p.PlayerID == playerID)
+}
+
+// WithPrincipal returns a copy of ctx carrying the principal.
+func WithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey, p)
+}
+
+// PrincipalFromContext returns the principal of a request authenticated by
+// JWTAuth or OptionalJWTAuth.
+func PrincipalFromContext(ctx context.Context) (Principal, bool) {
+	p, ok := ctx.Value(principalKey).(Principal)
+	return p, ok
+}
+
+// PrincipalFromToken validates the token and returns the caller it was
+// issued for.
+func PrincipalFromToken(tokenString string) (Principal, error) {
+	token, err := parseToken(tokenString)
+	if err != nil {
+		return Principal{}, err
+	}
+	return principalOf(token)
+}
+
+// RequireRole lets through the requests of principals having the role and
+// refuses the others. It wraps handlers already authenticated by JWTAuth.
+func RequireRole(role account.Role, handler httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		principal, ok := PrincipalFromContext(r.Context())
+		if !ok {
+			http.Error(w, "Missing or invalid Authorization header", http.StatusUnauthorized)
+			return
+		}
+
+		if !principal.HasRole(role) {
+			http.Error(w, "Requires the "+string(role)+" role", http.StatusForbidden)
+			return
+		}
+
+		handler(w, r, ps)
+	}
+}
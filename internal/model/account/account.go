@@ -0,0 +1,117 @@
+package account
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	MinPasswordLength = 8
+	// MaxPasswordLength is the number of bytes bcrypt hashes.
+	MaxPasswordLength = 72
+)
+
+var (
+	ErrInvalidUsername    = errors.New("username must be 3 to 32 letters, digits, dots, dashes or underscores")
+	ErrInvalidPassword    = errors.New("password must be 8 to 72 bytes long")
+	ErrInvalidCredentials = errors.New("invalid username or password")
+)
+
+var usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{3,32}$`)
+
+// dummyHash is compared against when a username is unknown, so that logins
+// take as long whether or not the account exists.
+var dummyHash, _ = bcrypt.GenerateFromPassword([]byte("not a password"), bcrypt.DefaultCost)
+
+// Account holds the credentials of a player.
+type Account struct {
+	PlayerID int `json:"player_id" bson:"_id"`
+	// Username is stored in lower case so that it is unique regardless of case.
+	Username     string `json:"username" bson:"username"`
+	PasswordHash []byte `json:"-" bson:"password_hash"`
+	// Role is empty for the accounts of players.
+	Role Role `json:"role,omitempty" bson:"role,omitempty"`
+	// TwoFactor is the TOTP enrollment of the account, if any.
+	TwoFactor *TwoFactor `json:"-" bson:"two_factor,omitempty"`
+	// Email is stored in lower case. Passwords can only be reset by email
+	// once the player proved the address is theirs.
+	Email         string `json:"email,omitempty" bson:"email,omitempty"`
+	EmailVerified bool   `json:"email_verified,omitempty" bson:"email_verified,omitempty"`
+	// Bot accounts have no password and authenticate with API keys. OwnerID
+	// is the player who created the bot and manages its keys.
+	Bot       bool      `json:"bot,omitempty" bson:"bot,omitempty"`
+	OwnerID   int       `json:"owner_id,omitempty" bson:"owner_id,omitempty"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+// GetRole returns the role of the account, RolePlayer unless another was given.
+func (a *Account) GetRole() Role {
+	if a.Role == "" {
+		return RolePlayer
+	}
+	return a.Role
+}
+
+// NormalizeUsername returns the form usernames are stored and looked up in.
+func NormalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
+
+// NewAccount validates the credentials and hashes the password. The player
+// ID is set once the player is stored.
+func NewAccount(username, password string) (*Account, error) {
+	username = strings.TrimSpace(username)
+	if !usernamePattern.MatchString(username) {
+		return nil, ErrInvalidUsername
+	}
+	hash, err := HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	return &Account{
+		Username:     NormalizeUsername(username),
+		PasswordHash: hash,
+		CreatedAt:    time.Now().UTC(),
+	}, nil
+}
+
+// NewBotAccount validates the username of a bot managed by the player ownerID.
+func NewBotAccount(username string, ownerID int) (*Account, error) {
+	username = strings.TrimSpace(username)
+	if !usernamePattern.MatchString(username) {
+		return nil, ErrInvalidUsername
+	}
+	return &Account{
+		Username:  NormalizeUsername(username),
+		Bot:       true,
+		OwnerID:   ownerID,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// HashPassword validates a new password and returns its bcrypt hash.
+func HashPassword(password string) ([]byte, error) {
+	if len(password) < MinPasswordLength || len(password) > MaxPasswordLength {
+		return nil, ErrInvalidPassword
+	}
+	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+}
+
+// CheckPassword returns ErrInvalidCredentials unless the password matches.
+// A nil account, or one without password such as bots, is checked against a
+// dummy hash to take as long.
+func (a *Account) CheckPassword(password string) error {
+	usable := a != nil && a.HasPassword()
+	hash := dummyHash
+	if usable {
+		hash = a.PasswordHash
+	}
+	if bcrypt.CompareHashAndPassword(hash, []byte(password)) != nil || !usable {
+		return ErrInvalidCredentials
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package account
+
+import (
+	"crypto/subtle"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MaxAPIKeyNameLength is the number of bytes of the names of API keys.
+const MaxAPIKeyNameLength = 64
+
+var (
+	ErrInvalidAPIKey     = errors.New("invalid API key")
+	ErrInvalidScopes     = errors.New("scopes must be read or play")
+	ErrInvalidAPIKeyName = errors.New("API key names must be 1 to 64 bytes long")
+)
+
+// Scope limits what an API key may do. Keys with the play scope may also read.
+type Scope string
+
+const (
+	// ScopeRead only allows reading rooms, games and the rest of the API.
+	ScopeRead Scope = "read"
+	// ScopePlay allows everything the bot could do with its own token.
+	ScopePlay Scope = "play"
+)
+
+// ParseScopes validates the names of the scopes of a new key.
+func ParseScopes(names []string) ([]Scope, error) {
+	if len(names) == 0 {
+		return nil, ErrInvalidScopes
+	}
+
+	scopes := make([]Scope, 0, len(names))
+	for _, name := range names {
+		scope := Scope(name)
+		if scope != ScopeRead && scope != ScopePlay {
+			return nil, ErrInvalidScopes
+		}
+		if !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes, nil
+}
+
+// APIKey authenticates a bot without a password. Only the SHA-256 of its
+// secret is stored, the key itself is shown once when it is created.
+type APIKey struct {
+	ID         string     `json:"id" bson:"_id"`
+	BotID      int        `json:"bot_id" bson:"bot_id"`
+	Name       string     `json:"name" bson:"name"`
+	Scopes     []Scope    `json:"scopes" bson:"scopes"`
+	SecretHash string     `json:"-" bson:"secret_hash"`
+	CreatedAt  time.Time  `json:"created_at" bson:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" bson:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" bson:"revoked_at,omitempty"`
+}
+
+// NewAPIKey creates a key of the bot and returns it with the key to hand to
+// the bot, made of the key ID and its secret.
+func NewAPIKey(botID int, name string, scopes []Scope) (*APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || len(name) > MaxAPIKeyNameLength {
+		return nil, "", ErrInvalidAPIKeyName
+	}
+
+	id, err := randomString(12)
+	if err != nil {
+		return nil, "", err
+	}
+	secret, err := randomString(32)
+	if err != nil {
+		return nil, "", err
+	}
+
+	k := &APIKey{
+		ID:         id,
+		BotID:      botID,
+		Name:       name,
+		Scopes:     scopes,
+		SecretHash: hashSecret(secret),
+		CreatedAt:  time.Now().UTC(),
+	}
+	return k, id + "." + secret, nil
+}
+
+// HasScope reports whether the key allows what the scope allows.
+func (k *APIKey) HasScope(scope Scope) bool {
+	return HasScope(k.Scopes, scope)
+}
+
+// HasScope reports whether scopes allow what scope allows.
+func HasScope(scopes []Scope, scope Scope) bool {
+	return slices.Contains(scopes, scope) || (scope == ScopeRead && slices.Contains(scopes, ScopePlay))
+}
+
+func (k *APIKey) IsRevoked() bool {
+	return k.RevokedAt != nil
+}
+
+// Check returns ErrInvalidAPIKey unless the key is this one and is not revoked.
+func (k *APIKey) Check(key string) error {
+	id, secret, err := ParseAPIKey(key)
+	if err != nil || id != k.ID || k.IsRevoked() {
+		return ErrInvalidAPIKey
+	}
+	if subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(k.SecretHash)) != 1 {
+		return ErrInvalidAPIKey
+	}
+	return nil
+}
+
+// ParseAPIKey splits a key into its ID and its secret.
+func ParseAPIKey(key string) (id, secret string, err error) {
+	id, secret, ok := strings.Cut(key, ".")
+	if !ok || id == "" || secret == "" {
+		return "", "", ErrInvalidAPIKey
+	}
+	return id, secret, nil
+}
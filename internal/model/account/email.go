@@ -0,0 +1,46 @@
+package account
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+const (
+	// MaxEmailLength is the longest address SMTP can deliver to.
+	MaxEmailLength = 254
+
+	EmailVerificationTTL = 24 * time.Hour
+	PasswordResetTTL     = time.Hour
+	// MailInterval is how often the same kind of email may be sent to a player.
+	MailInterval = time.Minute
+)
+
+var (
+	ErrInvalidEmail       = errors.New("invalid email address")
+	ErrInvalidActionToken = errors.New("invalid or expired token")
+	ErrNoEmail            = errors.New("account has no email address")
+	ErrEmailVerified      = errors.New("email address is already verified")
+)
+
+// NormalizeEmail validates a bare email address and returns the form it is
+// stored and looked up in.
+func NormalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email || len(email) > MaxEmailLength {
+		return "", ErrInvalidEmail
+	}
+	return strings.ToLower(email), nil
+}
+
+// PasswordFingerprint identifies the current password of the account without
+// revealing it. Password reset tokens carry it, so that they stop working
+// once the password changed.
+func (a *Account) PasswordFingerprint() string {
+	sum := sha256.Sum256(a.PasswordHash)
+	return hex.EncodeToString(sum[:8])
+}
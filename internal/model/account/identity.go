@@ -0,0 +1,124 @@
+package account
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// OIDCLoginTTL is how long players have to log in with their provider.
+const OIDCLoginTTL = 10 * time.Minute
+
+// fallbackUsername is given to players whose provider tells nothing usable
+// about them.
+const fallbackUsername = "player"
+
+var (
+	ErrInvalidOIDCState = errors.New("login expired or was started elsewhere, try again")
+	ErrIdentityLinked   = errors.New("identity is already linked to another player")
+	ErrLastIdentity     = errors.New("cannot unlink the only way to log in of an account without password")
+)
+
+// Identity links the account of a player at an OpenID provider to their
+// player. Issuer and Subject identify the account at the provider, Provider
+// is the name it is configured under.
+type Identity struct {
+	ID        string    `json:"id" bson:"_id"`
+	PlayerID  int       `json:"player_id" bson:"player_id"`
+	Provider  string    `json:"provider" bson:"provider"`
+	Issuer    string    `json:"issuer" bson:"issuer"`
+	Subject   string    `json:"subject" bson:"subject"`
+	Email     string    `json:"email,omitempty" bson:"email,omitempty"`
+	CreatedAt time.Time `json:"created_at" bson:"created_at"`
+}
+
+func NewIdentity(provider, issuer, subject, email string) (*Identity, error) {
+	id, err := randomString(12)
+	if err != nil {
+		return nil, err
+	}
+	return &Identity{
+		ID:        id,
+		Provider:  provider,
+		Issuer:    issuer,
+		Subject:   subject,
+		Email:     email,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// OIDCLogin is a login with a provider waiting for the player to come back
+// from it. The callback must bring back its State, and the ID token its Nonce,
+// while the Verifier proves that we asked for the code.
+type OIDCLogin struct {
+	State    string `json:"state"`
+	Provider string `json:"provider"`
+	Nonce    string `json:"nonce"`
+	Verifier string `json:"verifier"`
+	// LinkTo is the player linking the identity to their account, or 0 when
+	// the identity logs in.
+	LinkTo    int       `json:"link_to,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func NewOIDCLogin(provider string, linkTo int) (*OIDCLogin, error) {
+	var secrets [3]string
+	for i := range secrets {
+		s, err := randomString(32)
+		if err != nil {
+			return nil, err
+		}
+		secrets[i] = s
+	}
+	return &OIDCLogin{
+		State:     secrets[0],
+		Provider:  provider,
+		Nonce:     secrets[1],
+		Verifier:  secrets[2],
+		LinkTo:    linkTo,
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// NewExternalAccount creates the account of a player logging in with a
+// provider. It has no password until the player sets one.
+func NewExternalAccount(username string) (*Account, error) {
+	username = strings.TrimSpace(username)
+	if !usernamePattern.MatchString(username) {
+		return nil, ErrInvalidUsername
+	}
+	return &Account{
+		Username:  NormalizeUsername(username),
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+// ExternalUsername makes a valid username of the first of the names a
+// provider knows a player by that has enough usable characters. The local
+// part of email addresses is used.
+func ExternalUsername(names ...string) string {
+	for _, name := range names {
+		name, _, _ = strings.Cut(name, "@")
+		username := strings.Map(func(r rune) rune {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.', r == '-', r == '_':
+				return r
+			case r == ' ':
+				return '_'
+			}
+			return -1
+		}, strings.TrimSpace(name))
+		if len(username) > 32 {
+			username = username[:32]
+		}
+		if usernamePattern.MatchString(username) {
+			return username
+		}
+	}
+	return fallbackUsername
+}
+
+// HasPassword reports whether the player can log in with a password.
+func (a *Account) HasPassword() bool {
+	return !a.Bot && len(a.PasswordHash) > 0
+}
@@ -0,0 +1,40 @@
+package account
+
+import "errors"
+
+var ErrInvalidRole = errors.New("role must be player, moderator or admin")
+
+// Role grants administrative powers. Each role has the powers of the roles
+// below it: moderators moderate the chat of every room and admins also
+// manage users and adjudicate games.
+type Role string
+
+const (
+	RolePlayer    Role = "player"
+	RoleModerator Role = "moderator"
+	RoleAdmin     Role = "admin"
+)
+
+// ParseRole returns the role named s.
+func ParseRole(s string) (Role, error) {
+	switch role := Role(s); role {
+	case RolePlayer, RoleModerator, RoleAdmin:
+		return role, nil
+	}
+	return "", ErrInvalidRole
+}
+
+// Includes reports whether the role has the powers of other.
+func (r Role) Includes(other Role) bool {
+	return r.rank() >= other.rank()
+}
+
+func (r Role) rank() int {
+	switch r {
+	case RoleModerator:
+		return 1
+	case RoleAdmin:
+		return 2
+	}
+	return 0
+}
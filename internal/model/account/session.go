@@ -0,0 +1,99 @@
+package account
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+// SessionTTL is how long a session lasts after its refresh token was last used.
+const SessionTTL = 30 * 24 * time.Hour
+
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+// Session is a login of a player on a device. It lasts as long as its refresh
+// token keeps being used, and its access tokens are rejected once it is
+// revoked.
+type Session struct {
+	ID       string `json:"id"`
+	PlayerID int    `json:"player_id"`
+	// RefreshHash is the SHA-256 of the secret of the current refresh token.
+	// Refreshing replaces it, so each refresh token can be used once.
+	RefreshHash string    `json:"refresh_hash"`
+	UserAgent   string    `json:"user_agent"`
+	IP          string    `json:"ip"`
+	CreatedAt   time.Time `json:"created_at"`
+	LastUsedAt  time.Time `json:"last_used_at"`
+}
+
+// NewSession starts a session and returns it with its first refresh token.
+func NewSession(playerID int, userAgent, ip string) (*Session, string, error) {
+	id, err := randomString(16)
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now().UTC()
+	s := &Session{
+		ID:         id,
+		PlayerID:   playerID,
+		UserAgent:  userAgent,
+		IP:         ip,
+		CreatedAt:  now,
+		LastUsedAt: now,
+	}
+	refreshToken, err := s.Rotate()
+	if err != nil {
+		return nil, "", err
+	}
+	return s, refreshToken, nil
+}
+
+// Rotate replaces the refresh token of the session and returns the new one.
+func (s *Session) Rotate() (string, error) {
+	secret, err := randomString(32)
+	if err != nil {
+		return "", err
+	}
+	s.RefreshHash = hashSecret(secret)
+	s.LastUsedAt = time.Now().UTC()
+	return s.ID + "." + secret, nil
+}
+
+// CheckRefreshToken reports whether the token is the current refresh token
+// of the session.
+func (s *Session) CheckRefreshToken(refreshToken string) bool {
+	id, secret, err := ParseRefreshToken(refreshToken)
+	if err != nil || id != s.ID {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashSecret(secret)), []byte(s.RefreshHash)) == 1
+}
+
+// ParseRefreshToken splits a refresh token into the ID of its session and
+// its secret.
+func ParseRefreshToken(refreshToken string) (sessionID, secret string, err error) {
+	sessionID, secret, ok := strings.Cut(refreshToken, ".")
+	if !ok || sessionID == "" || secret == "" {
+		return "", "", ErrInvalidRefreshToken
+	}
+	return sessionID, secret, nil
+}
+
+func hashSecret(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+func randomString(size int) (string, error) {
+	b := make([]byte, size)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
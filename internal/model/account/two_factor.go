@@ -0,0 +1,219 @@
+package account
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// TOTPIssuer names the server in authenticator apps.
+	TOTPIssuer = "go-course"
+	TOTPDigits = 6
+	TOTPPeriod = 30 * time.Second
+	// totpSkew is how many periods codes may be early or late, for the
+	// clocks of phones and the time it takes to type the code.
+	totpSkew = 1
+
+	RecoveryCodeCount = 10
+
+	// TwoFactorChallengeTTL is how long players have to enter their code
+	// once their password was checked.
+	TwoFactorChallengeTTL = 5 * time.Minute
+	// MaxTwoFactorAttempts is how many codes may be tried per login.
+	MaxTwoFactorAttempts = 5
+	// MaxTwoFactorFailures is how many wrong codes in a row an account
+	// takes, over all its logins, before codes are locked out for
+	// TwoFactorLockout. Each further wrong code doubles the lockout up to
+	// MaxTwoFactorLockout.
+	MaxTwoFactorFailures = 10
+	TwoFactorLockout     = time.Minute
+	MaxTwoFactorLockout  = 24 * time.Hour
+)
+
+var (
+	ErrInvalidTwoFactorCode  = errors.New("invalid two-factor code")
+	ErrInvalidTwoFactorToken = errors.New("two-factor login expired or failed too often, log in again")
+	ErrTwoFactorEnabled      = errors.New("two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled   = errors.New("two-factor authentication is not enabled")
+	ErrTwoFactorNotSetUp     = errors.New("set up two-factor authentication first")
+	ErrTwoFactorLocked       = errors.New("too many wrong two-factor codes, try again later")
+)
+
+var base32NoPadding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactor is the TOTP enrollment of an account. It is pending until the
+// player proves with a first code that their authenticator app has the
+// secret, and only enabled enrollments are required to log in.
+type TwoFactor struct {
+	// Secret is the base32 key shared with the authenticator app.
+	Secret  string `bson:"secret"`
+	Enabled bool   `bson:"enabled"`
+	// RecoveryCodeHashes are the SHA-256 of the unused recovery codes, each
+	// of which can replace a code once.
+	RecoveryCodeHashes []string `bson:"recovery_code_hashes,omitempty"`
+	// LastStep is the period of the last accepted code, so that a code
+	// cannot be used twice.
+	LastStep int64 `bson:"last_step,omitempty"`
+	// Failures counts the wrong codes since the last accepted one, and
+	// LockedUntil is when codes are checked again after too many.
+	Failures    int        `bson:"failures,omitempty"`
+	LockedUntil *time.Time `bson:"locked_until,omitempty"`
+	CreatedAt   time.Time  `bson:"created_at"`
+	EnabledAt   *time.Time `bson:"enabled_at,omitempty"`
+}
+
+// TwoFactorChallenge is a login whose password was checked and that waits
+// for a code.
+type TwoFactorChallenge struct {
+	Token    string
+	PlayerID int
+}
+
+func NewTwoFactor() (*TwoFactor, error) {
+	secret := make([]byte, 20)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	return &TwoFactor{
+		Secret:    base32NoPadding.EncodeToString(secret),
+		CreatedAt: time.Now().UTC(),
+	}, nil
+}
+
+func NewTwoFactorChallenge(playerID int) (*TwoFactorChallenge, error) {
+	token, err := randomString(32)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorChallenge{Token: token, PlayerID: playerID}, nil
+}
+
+// TwoFactorEnabled reports whether logins need a code.
+func (a *Account) TwoFactorEnabled() bool {
+	return a.TwoFactor != nil && a.TwoFactor.Enabled
+}
+
+// ProvisioningURI returns the otpauth URI authenticator apps read from QR
+// codes to add the account.
+func (t *TwoFactor) ProvisioningURI(username string) string {
+	query := url.Values{
+		"secret":    {t.Secret},
+		"issuer":    {TOTPIssuer},
+		"algorithm": {"SHA1"},
+		"digits":    {fmt.Sprint(TOTPDigits)},
+		"period":    {fmt.Sprint(int(TOTPPeriod.Seconds()))},
+	}
+	label := url.PathEscape(TOTPIssuer + ":" + username)
+	return "otpauth://totp/" + label + "?" + query.Encode()
+}
+
+// VerifyCode returns the period of the code if it is valid at the time and
+// newer than the last accepted one.
+func (t *TwoFactor) VerifyCode(code string, now time.Time) (int64, bool) {
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != TOTPDigits {
+		return 0, false
+	}
+	secret, err := base32NoPadding.DecodeString(t.Secret)
+	if err != nil {
+		return 0, false
+	}
+
+	current := now.Unix() / int64(TOTPPeriod.Seconds())
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= t.LastStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(secret, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// Locked reports whether codes are locked out at the time after too many
+// wrong ones.
+func (t *TwoFactor) Locked(now time.Time) bool {
+	return t.LockedUntil != nil && now.Before(*t.LockedUntil)
+}
+
+// UseRecoveryCode removes the recovery code from the unused ones and returns
+// its hash, or false if it is not one of them.
+func (t *TwoFactor) UseRecoveryCode(code string) (string, bool) {
+	hash := HashRecoveryCode(code)
+	for i, h := range t.RecoveryCodeHashes {
+		if subtle.ConstantTimeCompare([]byte(h), []byte(hash)) == 1 {
+			t.RecoveryCodeHashes = slices.Delete(t.RecoveryCodeHashes, i, i+1)
+			return hash, true
+		}
+	}
+	return "", false
+}
+
+// LockoutAfter returns until when codes are locked out after the number of
+// wrong codes in a row, or nil if they are not.
+func LockoutAfter(failures int, now time.Time) *time.Time {
+	if failures < MaxTwoFactorFailures {
+		return nil
+	}
+	lockout := TwoFactorLockout
+	for range failures - MaxTwoFactorFailures {
+		if lockout *= 2; lockout >= MaxTwoFactorLockout {
+			lockout = MaxTwoFactorLockout
+			break
+		}
+	}
+	until := now.Add(lockout).UTC()
+	return &until
+}
+
+// NewRecoveryCodes returns codes to show the player once, with the hashes to
+// store.
+func NewRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, nil, err
+		}
+		code := strings.ToLower(base32NoPadding.EncodeToString(b))
+		codes[i] = code[:8] + "-" + code[8:16]
+		hashes[i] = HashRecoveryCode(codes[i])
+	}
+	return codes, hashes, nil
+}
+
+// HashRecoveryCode returns the stored form of a recovery code, whatever its
+// case and dashes.
+func HashRecoveryCode(code string) string {
+	code = strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	return hashSecret(code)
+}
+
+// totpCode computes the code of a period as in RFC 6238.
+func totpCode(secret []byte, step int64) string {
+	var counter [8]byte
+	binary.BigEndian.PutUint64(counter[:], uint64(step))
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter[:])
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	mod := uint32(1)
+	for range TOTPDigits {
+		mod *= 10
+	}
+	return fmt.Sprintf("%0*d", TOTPDigits, value%mod)
+}
@@ -0,0 +1,123 @@
+package account
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyCode(t *testing.T) {
+	twoFactor, err := NewTwoFactor()
+	if err != nil {
+		t.Fatalf("NewTwoFactor: %v", err)
+	}
+	secret, err := base32NoPadding.DecodeString(twoFactor.Secret)
+	if err != nil {
+		t.Fatalf("decoding the secret: %v", err)
+	}
+	now := time.Unix(1_700_000_000, 0)
+	current := now.Unix() / int64(TOTPPeriod.Seconds())
+
+	tests := []struct {
+		name     string
+		code     string
+		lastStep int64
+		wantOK   bool
+	}{
+		{name: "current period", code: totpCode(secret, current), wantOK: true},
+		{name: "previous period", code: totpCode(secret, current-1), wantOK: true},
+		{name: "next period", code: totpCode(secret, current+1), wantOK: true},
+		{name: "two periods late", code: totpCode(secret, current-2)},
+		{name: "two periods early", code: totpCode(secret, current+2)},
+		{name: "spaces are ignored", code: totpCode(secret, current)[:3] + " " + totpCode(secret, current)[3:], wantOK: true},
+		{name: "already used", code: totpCode(secret, current), lastStep: current},
+		{name: "older than the last used", code: totpCode(secret, current-1), lastStep: current - 1},
+		{name: "too short", code: totpCode(secret, current)[1:]},
+		{name: "recovery code", code: "abcdefgh-ijklmnop"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			twoFactor.LastStep = tt.lastStep
+			step, ok := twoFactor.VerifyCode(tt.code, now)
+			if ok != tt.wantOK {
+				t.Fatalf("VerifyCode(%q) = %v, want %v", tt.code, ok, tt.wantOK)
+			}
+			if ok && step <= tt.lastStep {
+				t.Errorf("VerifyCode() accepted period %d, not newer than %d", step, tt.lastStep)
+			}
+		})
+	}
+}
+
+func TestUseRecoveryCode(t *testing.T) {
+	codes, hashes, err := NewRecoveryCodes()
+	if err != nil {
+		t.Fatalf("NewRecoveryCodes: %v", err)
+	}
+	twoFactor := &TwoFactor{RecoveryCodeHashes: slices.Clone(hashes)}
+
+	hash, ok := twoFactor.UseRecoveryCode(strings.ToUpper(strings.ReplaceAll(codes[3], "-", "")))
+	if !ok {
+		t.Fatalf("UseRecoveryCode(%q) was refused", codes[3])
+	}
+	if hash != hashes[3] {
+		t.Errorf("UseRecoveryCode() = %q, want %q", hash, hashes[3])
+	}
+	if len(twoFactor.RecoveryCodeHashes) != RecoveryCodeCount-1 {
+		t.Errorf("%d recovery codes left, want %d", len(twoFactor.RecoveryCodeHashes), RecoveryCodeCount-1)
+	}
+
+	if _, ok := twoFactor.UseRecoveryCode(codes[3]); ok {
+		t.Errorf("a used recovery code was accepted again")
+	}
+	if _, ok := twoFactor.UseRecoveryCode("abcdefgh-ijklmnop"); ok {
+		t.Errorf("an unknown recovery code was accepted")
+	}
+	for i, code := range codes {
+		if i == 3 {
+			continue
+		}
+		if _, ok := twoFactor.UseRecoveryCode(code); !ok {
+			t.Errorf("UseRecoveryCode(%q) was refused", code)
+		}
+	}
+}
+
+func TestLockoutAfter(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		failures int
+		want     time.Duration
+	}{
+		{name: "no failures", failures: 0},
+		{name: "below the limit", failures: MaxTwoFactorFailures - 1},
+		{name: "at the limit", failures: MaxTwoFactorFailures, want: TwoFactorLockout},
+		{name: "one more", failures: MaxTwoFactorFailures + 1, want: 2 * TwoFactorLockout},
+		{name: "three more", failures: MaxTwoFactorFailures + 3, want: 8 * TwoFactorLockout},
+		{name: "capped", failures: MaxTwoFactorFailures + 100, want: MaxTwoFactorLockout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			until := LockoutAfter(tt.failures, now)
+			if tt.want == 0 {
+				if until != nil {
+					t.Fatalf("LockoutAfter(%d) = %v, want no lockout", tt.failures, until)
+				}
+				return
+			}
+			if until == nil || !until.Equal(now.Add(tt.want)) {
+				t.Fatalf("LockoutAfter(%d) = %v, want %v", tt.failures, until, now.Add(tt.want))
+			}
+
+			twoFactor := &TwoFactor{LockedUntil: until}
+			if !twoFactor.Locked(now.Add(tt.want - time.Second)) {
+				t.Errorf("not locked just before the lockout ends")
+			}
+			if twoFactor.Locked(now.Add(tt.want)) {
+				t.Errorf("still locked once the lockout ended")
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package chat
+
+import (
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxMessageLength is the number of characters a message may hold.
+const MaxMessageLength = 500
+
+var (
+	ErrInvalidChannel = errors.New("chat channel must be players or spectators")
+	ErrEmptyMessage   = errors.New("message is empty")
+	ErrMessageTooLong = errors.New("message is longer than 500 characters")
+)
+
+// Channel separates what players say to each other from what spectators say.
+type Channel string
+
+const (
+	Players    Channel = "players"
+	Spectators Channel = "spectators"
+)
+
+func (c Channel) IsValid() bool {
+	return c == Players || c == Spectators
+}
+
+type Message struct {
+	ID         int       `json:"id" bson:"_id"`
+	RoomID     int       `json:"room_id" bson:"room_id"`
+	Channel    Channel   `json:"channel" bson:"channel"`
+	PlayerID   int       `json:"player_id" bson:"player_id"`
+	PlayerName string    `json:"player_name" bson:"player_name"`
+	Text       string    `json:"text" bson:"text"`
+	CreatedAt  time.Time `json:"created_at" bson:"created_at"`
+	// DeletedBy is the moderator who removed the message from the history.
+	DeletedBy int `json:"-" bson:"deleted_by,omitempty"`
+}
+
+// NewMessage validates a message of a player, trimming surrounding spaces.
+func NewMessage(roomID int, channel Channel, playerID int, playerName, text string) (*Message, error) {
+	if !channel.IsValid() {
+		return nil, ErrInvalidChannel
+	}
+
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, ErrEmptyMessage
+	}
+	if utf8.RuneCountInString(text) > MaxMessageLength {
+		return nil, ErrMessageTooLong
+	}
+
+	return &Message{
+		RoomID:     roomID,
+		Channel:    channel,
+		PlayerID:   playerID,
+		PlayerName: playerName,
+		Text:       text,
+		CreatedAt:  time.Now().UTC(),
+	}, nil
+}
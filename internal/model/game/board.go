@@ -8,10 +8,33 @@ const (
 	White
 )
 
+func (c CellState) String() string {
+	switch c {
+	case Black:
+		return "black"
+	case White:
+		return "white"
+	}
+	return "empty"
+}
+
+// ParseColor converts "black" or "white" into the matching stone color.
+func ParseColor(s string) (CellState, bool) {
+	switch s {
+	case "black":
+		return Black, true
+	case "white":
+		return White, true
+	}
+	return Empty, false
+}
+
 type Board struct {
-	ID    int           `json:"id" bson:"_id"`
-	Size  int           `json:"size" bson:"size"`
-	Cells [][]CellState `json:"cells" bson:"cells"`
+	ID int `json:"id" bson:"_id"`
+	// OwnerID is the player who created the board and may change it.
+	OwnerID int           `json:"owner_id,omitempty" bson:"owner_id,omitempty"`
+	Size    int           `json:"size" bson:"size"`
+	Cells   [][]CellState `json:"cells" bson:"cells"`
 }
 
 func (b Board) GetSize() int {
@@ -33,3 +56,131 @@ func NewBoard(size int) *Board {
 
 	return board
 }
+
+// Point is an intersection of the board, X is the column and Y the row.
+type Point struct {
+	X int `json:"x" bson:"x"`
+	Y int `json:"y" bson:"y"`
+}
+
+func (b *Board) InBounds(p Point) bool {
+	return p.X >= 0 && p.Y >= 0 && p.X < b.Size && p.Y < b.Size
+}
+
+func (b *Board) Get(p Point) CellState {
+	return b.Cells[p.Y][p.X]
+}
+
+func (b *Board) Set(p Point, state CellState) {
+	b.Cells[p.Y][p.X] = state
+}
+
+func (b *Board) neighbors(p Point) []Point {
+	points := make([]Point, 0, 4)
+	for _, d := range []Point{{X: 1}, {X: -1}, {Y: 1}, {Y: -1}} {
+		n := Point{X: p.X + d.X, Y: p.Y + d.Y}
+		if b.InBounds(n) {
+			points = append(points, n)
+		}
+	}
+	return points
+}
+
+// group returns the stones connected to p and the number of their liberties.
+func (b *Board) group(p Point) ([]Point, int) {
+	color := b.Get(p)
+	stones := []Point{p}
+	visited := map[Point]bool{p: true}
+	liberties := map[Point]bool{}
+	for i := 0; i < len(stones); i++ {
+		for _, n := range b.neighbors(stones[i]) {
+			switch {
+			case b.Get(n) == Empty:
+				liberties[n] = true
+			case b.Get(n) == color && !visited[n]:
+				visited[n] = true
+				stones = append(stones, n)
+			}
+		}
+	}
+	return stones, len(liberties)
+}
+
+// region returns the empty points connected to p and the colors bordering them.
+func (b *Board) region(p Point, visited map[Point]bool) ([]Point, map[CellState]bool) {
+	points := []Point{p}
+	borders := map[CellState]bool{}
+	visited[p] = true
+	for i := 0; i < len(points); i++ {
+		for _, n := range b.neighbors(points[i]) {
+			switch {
+			case b.Get(n) != Empty:
+				borders[b.Get(n)] = true
+			case !visited[n]:
+				visited[n] = true
+				points = append(points, n)
+			}
+		}
+	}
+	return points, borders
+}
+
+// AreaScore counts stones plus the empty points surrounded by a single color.
+func (b *Board) AreaScore() (black, white int) {
+	black, white = b.TerritoryScore()
+	for y := 0; y < b.Size; y++ {
+		for x := 0; x < b.Size; x++ {
+			switch b.Cells[y][x] {
+			case Black:
+				black++
+			case White:
+				white++
+			}
+		}
+	}
+	return black, white
+}
+
+// TerritoryScore counts the empty points surrounded by a single color.
+func (b *Board) TerritoryScore() (black, white int) {
+	visited := map[Point]bool{}
+	for y := 0; y < b.Size; y++ {
+		for x := 0; x < b.Size; x++ {
+			p := Point{X: x, Y: y}
+			if b.Get(p) != Empty || visited[p] {
+				continue
+			}
+			points, borders := b.region(p, visited)
+			if borders[Black] && !borders[White] {
+				black += len(points)
+			} else if borders[White] && !borders[Black] {
+				white += len(points)
+			}
+		}
+	}
+	return black, white
+}
+
+// Rows renders the board one string per row, "." for empty points, "X" for
+// black and "O" for white stones.
+func (b *Board) Rows() []string {
+	if b == nil {
+		return nil
+	}
+	rows := make([]string, b.Size)
+	for y := 0; y < b.Size; y++ {
+		row := make([]byte, b.Size)
+		for x := 0; x < b.Size; x++ {
+			switch b.Cells[y][x] {
+			case Black:
+				row[x] = 'X'
+			case White:
+				row[x] = 'O'
+			default:
+				row[x] = '.'
+			}
+		}
+		rows[y] = string(row)
+	}
+	return rows
+}
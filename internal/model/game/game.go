@@ -1,5 +1,7 @@
 package game
 
+import "slices"
+
 type GameStatus int
 
 const (
@@ -9,11 +11,41 @@ const (
 	Draw
 )
 
+func (s GameStatus) String() string {
+	switch s {
+	case BlackWon:
+		return "black_won"
+	case WhiteWon:
+		return "white_won"
+	case Draw:
+		return "draw"
+	}
+	return "not_decided"
+}
+
 type Game struct {
-	ID          int        `json:"id" bson:"_id"`
-	Board       *Board     `json:"board" bson:"board,omitempty"`
-	CurrentTurn CellState  `json:"current_turn" bson:"current_turn"`
-	Status      GameStatus `json:"status" bson:"status"`
+	ID              int         `json:"id" bson:"_id"`
+	Board           *Board      `json:"board" bson:"board,omitempty"`
+	CurrentTurn     CellState   `json:"current_turn" bson:"current_turn"`
+	Status          GameStatus  `json:"status" bson:"status"`
+	Result          string      `json:"result,omitempty" bson:"result,omitempty"`
+	BlackID         int         `json:"black_id" bson:"black_id"`
+	WhiteID         int         `json:"white_id" bson:"white_id"`
+	BlackPartnerID  int         `json:"black_partner_id,omitempty" bson:"black_partner_id,omitempty"`
+	WhitePartnerID  int         `json:"white_partner_id,omitempty" bson:"white_partner_id,omitempty"`
+	Moves           []Move      `json:"moves" bson:"moves"`
+	Passes          int         `json:"passes" bson:"passes"`
+	Ko              *Point      `json:"ko,omitempty" bson:"ko,omitempty"`
+	BlackCaptures   int         `json:"black_captures" bson:"black_captures"`
+	WhiteCaptures   int         `json:"white_captures" bson:"white_captures"`
+	ScoreAcceptedBy []CellState `json:"score_accepted_by,omitempty" bson:"score_accepted_by,omitempty"`
+	Ruleset         Ruleset     `json:"ruleset" bson:"ruleset"`
+	Komi            float64     `json:"komi" bson:"komi"`
+	Handicap        int         `json:"handicap" bson:"handicap"`
+	TimeControl     TimeControl `json:"time_control" bson:"time_control"`
+	Clock           *Clock      `json:"clock,omitempty" bson:"clock,omitempty"`
+	// TakebackRequest is only current while PendingTakeback reports it.
+	TakebackRequest *TakebackRequest `json:"takeback_request,omitempty" bson:"takeback_request,omitempty"`
 }
 
 type GameOption func(*Game)
@@ -27,6 +59,30 @@ func WithSize(size int) GameOption {
 	}
 }
 
+func WithID(id int) GameOption {
+	return func(g *Game) {
+		g.ID = id
+	}
+}
+
+// WithPlayers records which players hold the black and white stones.
+func WithPlayers(blackID, whiteID int) GameOption {
+	return func(g *Game) {
+		g.BlackID = blackID
+		g.WhiteID = whiteID
+	}
+}
+
+// WithPartners makes a pair go game. The partners play every second move of
+// their color, after BlackID and WhiteID, so moves follow the order B1, W1,
+// B2, W2.
+func WithPartners(blackPartnerID, whitePartnerID int) GameOption {
+	return func(g *Game) {
+		g.BlackPartnerID = blackPartnerID
+		g.WhitePartnerID = whitePartnerID
+	}
+}
+
 func NewGame(opts ...GameOption) *Game {
 	game := &Game{
 		CurrentTurn: Black,
@@ -42,6 +98,11 @@ func NewGame(opts ...GameOption) *Game {
 		game.Board = NewBoard(19)
 	}
 
+	game.placeHandicap()
+	if game.TimeControl.IsTimed() {
+		game.Clock = newClock(game.TimeControl)
+	}
+
 	return game
 }
 
@@ -84,3 +145,58 @@ func (g *Game) IsCurrentTurnBlack() bool {
 func (g *Game) IsCurrentTurnWhite() bool {
 	return g.IsCurrentTurn(White)
 }
+
+func (g *Game) IsPairGo() bool {
+	return g.BlackPartnerID != 0 || g.WhitePartnerID != 0
+}
+
+// GetTeam returns the IDs of the players holding the given color in their
+// playing order.
+func (g *Game) GetTeam(color CellState) []int {
+	var team []int
+	switch color {
+	case Black:
+		team = []int{g.BlackID, g.BlackPartnerID}
+	case White:
+		team = []int{g.WhiteID, g.WhitePartnerID}
+	}
+	return slices.DeleteFunc(team, func(id int) bool {
+		return id == 0
+	})
+}
+
+// GetPlayerID returns the ID of the player who plays the next move of the
+// given color. In pair go the team members take turns.
+func (g *Game) GetPlayerID(color CellState) int {
+	team := g.GetTeam(color)
+	if len(team) == 0 {
+		return 0
+	}
+
+	played := 0
+	for _, m := range g.Moves {
+		if m.Color == color {
+			played++
+		}
+	}
+	return team[played%len(team)]
+}
+
+// GetCurrentPlayerID returns the ID of the player to move.
+func (g *Game) GetCurrentPlayerID() int {
+	return g.GetPlayerID(g.CurrentTurn)
+}
+
+// GetColor returns the color the player holds, or Empty if they do not play in this game.
+func (g *Game) GetColor(playerID int) CellState {
+	if playerID == 0 {
+		return Empty
+	}
+
+	for _, color := range []CellState{Black, White} {
+		if slices.Contains(g.GetTeam(color), playerID) {
+			return color
+		}
+	}
+	return Empty
+}
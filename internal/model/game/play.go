@@ -0,0 +1,390 @@
+package game
+
+import (
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+)
+
+var (
+	ErrGameOver    = errors.New("game is over")
+	ErrNotYourTurn = errors.New("it is not this player's turn")
+	ErrOutOfBoard  = errors.New("move is outside the board")
+	ErrOccupied    = errors.New("point is already occupied")
+	ErrSuicide     = errors.New("move would be suicide")
+	ErrKo          = errors.New("move retakes the ko")
+	ErrScoring     = errors.New("game is being scored, resume it to play on")
+	ErrNotScoring  = errors.New("game is not being scored")
+
+	ErrNothingToTakeBack = errors.New("last move was not played by this player")
+	ErrTakebackPending   = errors.New("a takeback of the last move was already requested")
+	ErrNoTakebackRequest = errors.New("no takeback request awaits this player")
+)
+
+// Move is a stone placed by a player, or a pass when Point is nil. Clock is
+// the clock as it stood before the move, so a takeback can restore it.
+type Move struct {
+	Color    CellState `json:"color" bson:"color"`
+	PlayerID int       `json:"player_id,omitempty" bson:"player_id,omitempty"`
+	Point    *Point    `json:"point,omitempty" bson:"point,omitempty"`
+	At       time.Time `json:"at" bson:"at"`
+	Clock    *Clock    `json:"-" bson:"clock,omitempty"`
+}
+
+// TakebackRequest is a player's request to undo the last move, which they
+// played. It lapses once the game has another move than MoveCount.
+type TakebackRequest struct {
+	PlayerID  int `json:"player_id" bson:"player_id"`
+	MoveCount int `json:"move_count" bson:"move_count"`
+}
+
+func (m Move) IsPass() bool {
+	return m.Point == nil
+}
+
+// checkTurn returns the color the player moves with if it is their turn.
+func (g *Game) checkTurn(playerID int) (CellState, error) {
+	if g.IsOver() {
+		return Empty, ErrGameOver
+	}
+	if g.IsScoring() {
+		return Empty, ErrScoring
+	}
+	if g.GetCurrentPlayerID() != playerID {
+		return Empty, ErrNotYourTurn
+	}
+	return g.CurrentTurn, nil
+}
+
+// Play places a stone for the player to move and returns the captured stones.
+func (g *Game) Play(playerID int, p Point) ([]Point, error) {
+	color, err := g.checkTurn(playerID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().UTC()
+	clock := g.Clock.copy()
+	if err := g.chargeClock(color, now); err != nil {
+		return nil, err
+	}
+
+	captured, err := g.place(color, p)
+	if err != nil {
+		return nil, err
+	}
+
+	g.Moves = append(g.Moves, Move{Color: color, PlayerID: playerID, Point: &p, At: now, Clock: clock})
+	g.TakebackRequest = nil
+	g.SwitchTurn()
+	return captured, nil
+}
+
+// place puts a stone on the board, removing the groups it captures.
+func (g *Game) place(color CellState, p Point) ([]Point, error) {
+	if !g.Board.InBounds(p) {
+		return nil, ErrOutOfBoard
+	}
+	if g.Board.Get(p) != Empty {
+		return nil, ErrOccupied
+	}
+	if g.Ko != nil && *g.Ko == p {
+		return nil, ErrKo
+	}
+
+	g.Board.Set(p, color)
+	var captured []Point
+	for _, n := range g.Board.neighbors(p) {
+		if g.Board.Get(n) != opponent(color) {
+			continue
+		}
+		if stones, liberties := g.Board.group(n); liberties == 0 {
+			for _, s := range stones {
+				g.Board.Set(s, Empty)
+			}
+			captured = append(captured, stones...)
+		}
+	}
+
+	stones, liberties := g.Board.group(p)
+	if liberties == 0 {
+		g.Board.Set(p, Empty)
+		return nil, ErrSuicide
+	}
+
+	g.Ko = nil
+	if len(captured) == 1 && len(stones) == 1 && liberties == 1 {
+		g.Ko = &captured[0]
+	}
+	if color == Black {
+		g.BlackCaptures += len(captured)
+	} else {
+		g.WhiteCaptures += len(captured)
+	}
+	g.Passes = 0
+	return captured, nil
+}
+
+// Pass gives the turn away. Two consecutive passes move the game to scoring.
+func (g *Game) Pass(playerID int) error {
+	color, err := g.checkTurn(playerID)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	clock := g.Clock.copy()
+	if err := g.chargeClock(color, now); err != nil {
+		return err
+	}
+
+	g.Ko = nil
+	g.Passes++
+	g.Moves = append(g.Moves, Move{Color: color, PlayerID: playerID, At: now, Clock: clock})
+	g.TakebackRequest = nil
+	g.SwitchTurn()
+	return nil
+}
+
+// checkInPlay returns an error unless moves can still be played.
+func (g *Game) checkInPlay() error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+	if g.IsScoring() {
+		return ErrScoring
+	}
+	return nil
+}
+
+// RequestTakeback asks the opponent to undo the last move, which must have
+// been played by the player.
+func (g *Game) RequestTakeback(playerID int) error {
+	if err := g.checkInPlay(); err != nil {
+		return err
+	}
+	if len(g.Moves) == 0 || g.Moves[len(g.Moves)-1].PlayerID != playerID {
+		return ErrNothingToTakeBack
+	}
+	if g.PendingTakeback() != 0 {
+		return ErrTakebackPending
+	}
+
+	g.TakebackRequest = &TakebackRequest{PlayerID: playerID, MoveCount: len(g.Moves)}
+	return nil
+}
+
+// PendingTakeback returns the ID of the player whose takeback request awaits
+// an answer, or 0.
+func (g *Game) PendingTakeback() int {
+	if g.TakebackRequest == nil || g.TakebackRequest.MoveCount != len(g.Moves) {
+		return 0
+	}
+	return g.TakebackRequest.PlayerID
+}
+
+// checkTakebackAnswer returns an error unless the player may answer the
+// pending takeback request, which only the other color can.
+func (g *Game) checkTakebackAnswer(playerID int) error {
+	if err := g.checkInPlay(); err != nil {
+		return err
+	}
+	requesterID := g.PendingTakeback()
+	if requesterID == 0 || g.GetColor(playerID) == Empty || g.GetColor(playerID) == g.GetColor(requesterID) {
+		return ErrNoTakebackRequest
+	}
+	return nil
+}
+
+// AcceptTakeback undoes the move of the pending takeback request, gives the
+// turn back to the requester and puts the clock back to where it stood
+// before that move.
+func (g *Game) AcceptTakeback(playerID int) error {
+	if err := g.checkTakebackAnswer(playerID); err != nil {
+		return err
+	}
+
+	last := g.Moves[len(g.Moves)-1]
+	if err := g.replay(g.Moves[:len(g.Moves)-1]); err != nil {
+		return err
+	}
+	g.TakebackRequest = nil
+
+	if g.Clock != nil {
+		if last.Clock != nil {
+			g.Clock = last.Clock.copy()
+		}
+		g.Clock.TurnStartedAt = time.Now().UTC()
+	}
+	return nil
+}
+
+// DeclineTakeback refuses the pending takeback request.
+func (g *Game) DeclineTakeback(playerID int) error {
+	if err := g.checkTakebackAnswer(playerID); err != nil {
+		return err
+	}
+
+	g.TakebackRequest = nil
+	return nil
+}
+
+// Replay returns a copy of the game as it stood after its first n moves,
+// without the clock.
+func (g *Game) Replay(n int) *Game {
+	n = max(0, min(n, len(g.Moves)))
+	replayed := &Game{
+		ID:             g.ID,
+		Board:          NewBoard(g.Board.Size),
+		Status:         NotDecidedYet,
+		BlackID:        g.BlackID,
+		WhiteID:        g.WhiteID,
+		BlackPartnerID: g.BlackPartnerID,
+		WhitePartnerID: g.WhitePartnerID,
+		Ruleset:        g.Ruleset,
+		Komi:           g.Komi,
+		Handicap:       g.Handicap,
+		TimeControl:    g.TimeControl,
+	}
+	// The moves were legal when they were played, so replaying them cannot fail.
+	_ = replayed.replay(g.Moves[:n])
+	return replayed
+}
+
+// replay resets the board to its handicap setup and plays moves on it.
+func (g *Game) replay(moves []Move) error {
+	g.Board = NewBoard(g.Board.Size)
+	g.CurrentTurn = Black
+	g.Moves = nil
+	g.Passes = 0
+	g.Ko = nil
+	g.BlackCaptures = 0
+	g.WhiteCaptures = 0
+	g.placeHandicap()
+	for _, m := range moves {
+		if m.IsPass() {
+			g.Ko = nil
+			g.Passes++
+		} else if _, err := g.place(m.Color, *m.Point); err != nil {
+			return err
+		}
+		g.Moves = append(g.Moves, m)
+		g.SwitchTurn()
+	}
+	return nil
+}
+
+// Resign ends the game in favor of the opponent of the given color.
+func (g *Game) Resign(color CellState) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	if color == Black {
+		g.finish(WhiteWon, "W+R")
+	} else {
+		g.finish(BlackWon, "B+R")
+	}
+	return nil
+}
+
+// Forfeit ends the game in favor of the opponent of a color that left it.
+func (g *Game) Forfeit(color CellState) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	if color == Black {
+		g.finish(WhiteWon, "W+F")
+	} else {
+		g.finish(BlackWon, "B+F")
+	}
+	return nil
+}
+
+// Adjudicate ends the game with the result decided by an admin, a draw if
+// winner is Empty.
+func (g *Game) Adjudicate(winner CellState) error {
+	if g.IsOver() {
+		return ErrGameOver
+	}
+
+	switch winner {
+	case Black:
+		g.finish(BlackWon, "B+Adj")
+	case White:
+		g.finish(WhiteWon, "W+Adj")
+	default:
+		g.finish(Draw, "Draw")
+	}
+	return nil
+}
+
+// IsScoring reports whether both players passed and the score awaits agreement.
+func (g *Game) IsScoring() bool {
+	return !g.IsOver() && g.Passes >= 2
+}
+
+// ResumePlay leaves scoring so disputed stones can be settled on the board.
+func (g *Game) ResumePlay() error {
+	if !g.IsScoring() {
+		return ErrNotScoring
+	}
+
+	g.Passes = 0
+	g.ScoreAcceptedBy = nil
+	return nil
+}
+
+// AcceptScore records that a color agrees with the area count of the
+// current position. The game ends once both colors accepted.
+func (g *Game) AcceptScore(color CellState) error {
+	if !g.IsScoring() {
+		return ErrNotScoring
+	}
+
+	if !slices.Contains(g.ScoreAcceptedBy, color) {
+		g.ScoreAcceptedBy = append(g.ScoreAcceptedBy, color)
+	}
+	if slices.Contains(g.ScoreAcceptedBy, Black) && slices.Contains(g.ScoreAcceptedBy, White) {
+		g.finishByScore()
+	}
+	return nil
+}
+
+// Score counts the current position with the game's ruleset and komi.
+func (g *Game) Score() (black, white float64) {
+	var b, w int
+	if g.Ruleset.UsesAreaScoring() {
+		b, w = g.Board.AreaScore()
+	} else {
+		b, w = g.Board.TerritoryScore()
+		b += g.BlackCaptures
+		w += g.WhiteCaptures
+	}
+	return float64(b), float64(w) + g.Komi
+}
+
+func (g *Game) finishByScore() {
+	black, white := g.Score()
+	switch diff := black - white; {
+	case diff > 0:
+		g.finish(BlackWon, "B+"+strconv.FormatFloat(diff, 'f', -1, 64))
+	case diff < 0:
+		g.finish(WhiteWon, "W+"+strconv.FormatFloat(-diff, 'f', -1, 64))
+	default:
+		g.finish(Draw, "Draw")
+	}
+}
+
+func (g *Game) finish(status GameStatus, result string) {
+	g.Status = status
+	g.Result = result
+	g.Ko = nil
+}
+
+func opponent(color CellState) CellState {
+	if color == Black {
+		return White
+	}
+	return Black
+}
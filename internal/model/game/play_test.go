@@ -0,0 +1,313 @@
+package game
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newPosition returns a game between players 1 (black) and 2 (white) set up
+// with the given rows: "." empty, "X" black and "O" white.
+func newPosition(t *testing.T, rows []string, opts ...GameOption) *Game {
+	t.Helper()
+	g := NewGame(append([]GameOption{WithSize(len(rows)), WithPlayers(1, 2)}, opts...)...)
+	for y, row := range rows {
+		for x, c := range row {
+			switch c {
+			case 'X':
+				g.Board.Set(Point{X: x, Y: y}, Black)
+			case 'O':
+				g.Board.Set(Point{X: x, Y: y}, White)
+			}
+		}
+	}
+	return g
+}
+
+// koPosition is a ko black can start by capturing at (2,1).
+var koPosition = []string{
+	".XO..",
+	"XO.O.",
+	".XO..",
+	".....",
+	".....",
+}
+
+// cornerPosition leaves white no liberty in the corner.
+var cornerPosition = []string{
+	".X...",
+	"X....",
+	".....",
+	".....",
+	".....",
+}
+
+// splitPosition gives black 5 stones and 5 points of territory, white 5
+// stones and 10 points.
+var splitPosition = []string{
+	".XO..",
+	".XO..",
+	".XO..",
+	".XO..",
+	".XO..",
+}
+
+func TestPlay(t *testing.T) {
+	tests := []struct {
+		name         string
+		rows         []string
+		turn         CellState
+		before       []Point
+		point        Point
+		wantErr      error
+		wantCaptured int
+	}{
+		{name: "capture", turn: Black, point: Point{X: 2, Y: 1}, wantCaptured: 1},
+		{name: "suicide", rows: cornerPosition, turn: White, point: Point{X: 0, Y: 0}, wantErr: ErrSuicide},
+		{name: "capture instead of suicide", turn: White, point: Point{X: 0, Y: 0}, wantCaptured: 1},
+		{name: "occupied", turn: Black, point: Point{X: 1, Y: 0}, wantErr: ErrOccupied},
+		{name: "outside the board", turn: Black, point: Point{X: 5, Y: 0}, wantErr: ErrOutOfBoard},
+		{name: "ko retaken at once", turn: Black, before: []Point{{X: 2, Y: 1}}, point: Point{X: 1, Y: 1}, wantErr: ErrKo},
+		{
+			name:         "ko retaken after a threat",
+			turn:         Black,
+			before:       []Point{{X: 2, Y: 1}, {X: 4, Y: 4}, {X: 4, Y: 3}},
+			point:        Point{X: 1, Y: 1},
+			wantCaptured: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := tt.rows
+			if rows == nil {
+				rows = koPosition
+			}
+			g := newPosition(t, rows)
+			g.CurrentTurn = tt.turn
+			for _, p := range tt.before {
+				if _, err := g.Play(g.GetCurrentPlayerID(), p); err != nil {
+					t.Fatalf("Play(%v): %v", p, err)
+				}
+			}
+			board := g.Board.Rows()
+			color := g.CurrentTurn
+
+			captured, err := g.Play(g.GetCurrentPlayerID(), tt.point)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Play() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if !slices.Equal(g.Board.Rows(), board) || g.CurrentTurn != color {
+					t.Fatalf("illegal move changed the game:\n%v", g.Board.Rows())
+				}
+				return
+			}
+			if len(captured) != tt.wantCaptured {
+				t.Errorf("captured %v, want %d stones", captured, tt.wantCaptured)
+			}
+			if g.Board.Get(tt.point) != color {
+				t.Errorf("stone at %v = %s, want %s", tt.point, g.Board.Get(tt.point), color)
+			}
+		})
+	}
+}
+
+func TestPlayOutOfTurn(t *testing.T) {
+	g := newPosition(t, koPosition)
+	if _, err := g.Play(2, Point{X: 4, Y: 4}); !errors.Is(err, ErrNotYourTurn) {
+		t.Fatalf("Play() error = %v, want %v", err, ErrNotYourTurn)
+	}
+}
+
+func TestScoring(t *testing.T) {
+	g := newPosition(t, splitPosition)
+	for _, id := range []int{1, 2} {
+		if err := g.Pass(id); err != nil {
+			t.Fatalf("Pass(%d): %v", id, err)
+		}
+	}
+	if !g.IsScoring() {
+		t.Fatalf("two passes did not start scoring")
+	}
+	if _, err := g.Play(1, Point{X: 0, Y: 0}); !errors.Is(err, ErrScoring) {
+		t.Fatalf("Play() while scoring error = %v, want %v", err, ErrScoring)
+	}
+
+	// A dispute resumes play and drops the acceptances given so far.
+	if err := g.AcceptScore(Black); err != nil {
+		t.Fatalf("AcceptScore(black): %v", err)
+	}
+	if err := g.ResumePlay(); err != nil {
+		t.Fatalf("ResumePlay: %v", err)
+	}
+	if g.IsScoring() || len(g.ScoreAcceptedBy) != 0 {
+		t.Fatalf("ResumePlay left scoring: passes %d, accepted by %v", g.Passes, g.ScoreAcceptedBy)
+	}
+	if err := g.AcceptScore(Black); !errors.Is(err, ErrNotScoring) {
+		t.Fatalf("AcceptScore() after resuming error = %v, want %v", err, ErrNotScoring)
+	}
+
+	for range 2 {
+		if err := g.Pass(g.GetCurrentPlayerID()); err != nil {
+			t.Fatalf("Pass(%d): %v", g.GetCurrentPlayerID(), err)
+		}
+	}
+	if err := g.AcceptScore(White); err != nil {
+		t.Fatalf("AcceptScore(white): %v", err)
+	}
+	if g.IsOver() {
+		t.Fatalf("game ended with one acceptance")
+	}
+	if err := g.AcceptScore(Black); err != nil {
+		t.Fatalf("AcceptScore(black): %v", err)
+	}
+	if g.Status != WhiteWon || g.Result != "W+5" {
+		t.Errorf("status %s, result %q, want white_won, W+5", g.Status, g.Result)
+	}
+}
+
+func TestTakebackRequests(t *testing.T) {
+	tests := []struct {
+		name    string
+		act     func(t *testing.T, g *Game) error
+		wantErr error
+	}{
+		{
+			name: "request before any move",
+			act: func(t *testing.T, g *Game) error {
+				return g.RequestTakeback(1)
+			},
+			wantErr: ErrNothingToTakeBack,
+		},
+		{
+			name: "request the opponent's move",
+			act: func(t *testing.T, g *Game) error {
+				mustPlay(t, g, 1, Point{X: 2, Y: 2})
+				return g.RequestTakeback(2)
+			},
+			wantErr: ErrNothingToTakeBack,
+		},
+		{
+			name: "request twice",
+			act: func(t *testing.T, g *Game) error {
+				mustPlay(t, g, 1, Point{X: 2, Y: 2})
+				mustRequestTakeback(t, g, 1)
+				return g.RequestTakeback(1)
+			},
+			wantErr: ErrTakebackPending,
+		},
+		{
+			name: "accept own request",
+			act: func(t *testing.T, g *Game) error {
+				mustPlay(t, g, 1, Point{X: 2, Y: 2})
+				mustRequestTakeback(t, g, 1)
+				return g.AcceptTakeback(1)
+			},
+			wantErr: ErrNoTakebackRequest,
+		},
+		{
+			name: "accept without request",
+			act: func(t *testing.T, g *Game) error {
+				mustPlay(t, g, 1, Point{X: 2, Y: 2})
+				return g.AcceptTakeback(2)
+			},
+			wantErr: ErrNoTakebackRequest,
+		},
+		{
+			name: "accept after playing on",
+			act: func(t *testing.T, g *Game) error {
+				mustPlay(t, g, 1, Point{X: 2, Y: 2})
+				mustRequestTakeback(t, g, 1)
+				mustPlay(t, g, 2, Point{X: 3, Y: 3})
+				return g.AcceptTakeback(1)
+			},
+			wantErr: ErrNoTakebackRequest,
+		},
+		{
+			name: "accept after declining",
+			act: func(t *testing.T, g *Game) error {
+				mustPlay(t, g, 1, Point{X: 2, Y: 2})
+				mustRequestTakeback(t, g, 1)
+				if err := g.DeclineTakeback(2); err != nil {
+					t.Fatalf("DeclineTakeback: %v", err)
+				}
+				return g.AcceptTakeback(2)
+			},
+			wantErr: ErrNoTakebackRequest,
+		},
+		{
+			name: "request after the game ended",
+			act: func(t *testing.T, g *Game) error {
+				mustPlay(t, g, 1, Point{X: 2, Y: 2})
+				if err := g.Resign(White); err != nil {
+					t.Fatalf("Resign: %v", err)
+				}
+				return g.RequestTakeback(1)
+			},
+			wantErr: ErrGameOver,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(9), WithPlayers(1, 2))
+			if err := tt.act(t, g); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestAcceptTakeback(t *testing.T) {
+	g := NewGame(WithSize(9), WithPlayers(1, 2), WithTimeControl(TimeControl{Type: Fischer, MainTime: 60, Increment: 5}))
+	mustPlay(t, g, 1, Point{X: 2, Y: 2})
+
+	// White thinks for 10 seconds and gets 5 back with the increment, then
+	// black thinks for 20 seconds about the takeback.
+	g.Clock.TurnStartedAt = time.Now().Add(-10 * time.Second)
+	mustPlay(t, g, 2, Point{X: 3, Y: 3})
+	if got := g.Clock.WhiteRemaining.Round(time.Second); got != 55*time.Second {
+		t.Fatalf("WhiteRemaining = %v, want 55s", got)
+	}
+	mustRequestTakeback(t, g, 2)
+	if g.PendingTakeback() != 2 {
+		t.Fatalf("PendingTakeback() = %d, want 2", g.PendingTakeback())
+	}
+	g.Clock.TurnStartedAt = time.Now().Add(-20 * time.Second)
+
+	if err := g.AcceptTakeback(1); err != nil {
+		t.Fatalf("AcceptTakeback: %v", err)
+	}
+
+	if len(g.Moves) != 1 || g.Board.Get(Point{X: 3, Y: 3}) != Empty {
+		t.Errorf("move was not undone: %d moves\n%v", len(g.Moves), g.Board.Rows())
+	}
+	if g.CurrentTurn != White || g.PendingTakeback() != 0 {
+		t.Errorf("turn = %s, pending takeback by %d; want white's turn without request", g.CurrentTurn, g.PendingTakeback())
+	}
+	// The clock is back to where it stood before white's move, and black's
+	// time spent on the takeback is not charged.
+	if got := g.Clock.BlackRemaining.Round(time.Second); got != 65*time.Second {
+		t.Errorf("BlackRemaining = %v, want 1m5s", got)
+	}
+	if got := g.Clock.WhiteRemaining.Round(time.Second); got != 60*time.Second {
+		t.Errorf("WhiteRemaining = %v, want 1m0s", got)
+	}
+	if time.Since(g.Clock.TurnStartedAt) > time.Second {
+		t.Errorf("TurnStartedAt = %v, want now", g.Clock.TurnStartedAt)
+	}
+}
+
+func mustPlay(t *testing.T, g *Game, playerID int, p Point) {
+	t.Helper()
+	if _, err := g.Play(playerID, p); err != nil {
+		t.Fatalf("Play(%d, %v): %v", playerID, p, err)
+	}
+}
+
+func mustRequestTakeback(t *testing.T, g *Game, playerID int) {
+	t.Helper()
+	if err := g.RequestTakeback(playerID); err != nil {
+		t.Fatalf("RequestTakeback(%d): %v", playerID, err)
+	}
+}
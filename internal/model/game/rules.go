@@ -0,0 +1,245 @@
+package game
+
+import (
+	"errors"
+	"time"
+)
+
+// Ruleset decides how a finished game is counted.
+type Ruleset string
+
+const (
+	// Chinese and AGA rules count stones plus territory.
+	Chinese Ruleset = "chinese"
+	AGA     Ruleset = "aga"
+	// Japanese and Korean rules count territory plus prisoners.
+	Japanese Ruleset = "japanese"
+	Korean   Ruleset = "korean"
+)
+
+func (r Ruleset) IsValid() bool {
+	switch r {
+	case Chinese, AGA, Japanese, Korean:
+		return true
+	}
+	return false
+}
+
+// UsesAreaScoring reports whether stones on the board count as points.
+func (r Ruleset) UsesAreaScoring() bool {
+	return r == Chinese || r == AGA || r == ""
+}
+
+// Time control types.
+const (
+	NoTimeControl = "none"
+	// Absolute gives each player MainTime for the whole game.
+	Absolute = "absolute"
+	// Fischer adds Increment to the player's clock after every move.
+	Fischer = "fischer"
+	// Byoyomi gives Periods periods of PeriodTime once MainTime runs out.
+	// A period is only used up when a move takes longer than PeriodTime.
+	Byoyomi = "byoyomi"
+)
+
+var ErrTimeout = errors.New("player ran out of time")
+
+// TimeControl durations are stored in seconds.
+type TimeControl struct {
+	Type       string `json:"type" bson:"type"`
+	MainTime   int    `json:"main_time" bson:"main_time"`
+	Increment  int    `json:"increment,omitempty" bson:"increment,omitempty"`
+	Periods    int    `json:"periods,omitempty" bson:"periods,omitempty"`
+	PeriodTime int    `json:"period_time,omitempty" bson:"period_time,omitempty"`
+}
+
+func (tc TimeControl) IsTimed() bool {
+	return tc.Type != "" && tc.Type != NoTimeControl
+}
+
+// Clock keeps the time left to each color. The player to move is charged
+// from TurnStartedAt on.
+type Clock struct {
+	BlackRemaining time.Duration `json:"black_remaining" bson:"black_remaining"`
+	WhiteRemaining time.Duration `json:"white_remaining" bson:"white_remaining"`
+	BlackPeriods   int           `json:"black_periods" bson:"black_periods"`
+	WhitePeriods   int           `json:"white_periods" bson:"white_periods"`
+	TurnStartedAt  time.Time     `json:"turn_started_at" bson:"turn_started_at"`
+}
+
+func newClock(tc TimeControl) *Clock {
+	main := time.Duration(tc.MainTime) * time.Second
+	return &Clock{
+		BlackRemaining: main,
+		WhiteRemaining: main,
+		BlackPeriods:   tc.Periods,
+		WhitePeriods:   tc.Periods,
+		TurnStartedAt:  time.Now().UTC(),
+	}
+}
+
+// copy returns a copy of the clock, or nil for games without one.
+func (c *Clock) copy() *Clock {
+	if c == nil {
+		return nil
+	}
+	clock := *c
+	return &clock
+}
+
+func (c *Clock) remaining(color CellState) (*time.Duration, *int) {
+	if color == Black {
+		return &c.BlackRemaining, &c.BlackPeriods
+	}
+	return &c.WhiteRemaining, &c.WhitePeriods
+}
+
+// spend charges elapsed time to a color and reports whether its flag fell.
+func (c *Clock) spend(tc TimeControl, color CellState, elapsed time.Duration) bool {
+	remaining, periods := c.remaining(color)
+	if *remaining >= elapsed {
+		*remaining -= elapsed
+		return false
+	}
+
+	if tc.Type != Byoyomi {
+		*remaining = 0
+		return true
+	}
+
+	elapsed -= *remaining
+	*remaining = 0
+	period := time.Duration(tc.PeriodTime) * time.Second
+	for elapsed > period {
+		elapsed -= period
+		*periods--
+		if *periods <= 0 {
+			*periods = 0
+			return true
+		}
+	}
+	return false
+}
+
+// RemainingTime returns the main time and byoyomi periods a color has left
+// at the given moment.
+func (g *Game) RemainingTime(color CellState, now time.Time) (time.Duration, int) {
+	if g.Clock == nil {
+		return 0, 0
+	}
+
+	clock := *g.Clock
+	if g.IsCurrentTurn(color) && !g.IsOver() {
+		clock.spend(g.TimeControl, color, now.Sub(clock.TurnStartedAt))
+	}
+	remaining, periods := clock.remaining(color)
+	return *remaining, *periods
+}
+
+// chargeClock charges the player to move for the time since their turn
+// started. If their time ran out the game is lost on time.
+func (g *Game) chargeClock(color CellState, now time.Time) error {
+	if g.Clock == nil || !g.TimeControl.IsTimed() {
+		return nil
+	}
+
+	if g.Clock.spend(g.TimeControl, color, now.Sub(g.Clock.TurnStartedAt)) {
+		g.loseOnTime(color)
+		return ErrTimeout
+	}
+	if g.TimeControl.Type == Fischer {
+		remaining, _ := g.Clock.remaining(color)
+		*remaining += time.Duration(g.TimeControl.Increment) * time.Second
+	}
+	g.Clock.TurnStartedAt = now
+	return nil
+}
+
+// CheckTimeout ends the game if the player to move ran out of time by now.
+func (g *Game) CheckTimeout(now time.Time) bool {
+	if g.Clock == nil || g.IsOver() || g.IsScoring() {
+		return false
+	}
+
+	remaining, periods := g.RemainingTime(g.CurrentTurn, now)
+	if remaining > 0 || (g.TimeControl.Type == Byoyomi && periods > 0) {
+		return false
+	}
+	g.loseOnTime(g.CurrentTurn)
+	return true
+}
+
+func (g *Game) loseOnTime(color CellState) {
+	if color == Black {
+		g.finish(WhiteWon, "W+T")
+	} else {
+		g.finish(BlackWon, "B+T")
+	}
+}
+
+// handicapPoints lists the star points used for handicap stones, in the
+// order they are added: the two opposite corners first, then the other
+// corners, the center and the sides.
+func handicapPoints(size, stones int) []Point {
+	edge := 3
+	if size < 13 {
+		edge = 2
+	}
+	low, mid, high := edge, size/2, size-1-edge
+
+	var points []Point
+	corners := []Point{{X: high, Y: low}, {X: low, Y: high}, {X: high, Y: high}, {X: low, Y: low}}
+	center := Point{X: mid, Y: mid}
+	sides := []Point{{X: low, Y: mid}, {X: high, Y: mid}, {X: mid, Y: low}, {X: mid, Y: high}}
+
+	points = append(points, corners[:min(stones, 4)]...)
+	if stones > 4 {
+		sideCount := stones - 4
+		if stones%2 == 1 {
+			sideCount--
+			points = append(points, center)
+		}
+		points = append(points, sides[:sideCount]...)
+	}
+	return points
+}
+
+// MaxHandicap is the largest number of handicap stones placed on star points.
+const MaxHandicap = 9
+
+func (g *Game) placeHandicap() {
+	if g.Handicap < 2 {
+		return
+	}
+
+	for _, p := range handicapPoints(g.Board.Size, g.Handicap) {
+		g.Board.Set(p, Black)
+	}
+	g.CurrentTurn = White
+}
+
+func WithKomi(komi float64) GameOption {
+	return func(g *Game) {
+		g.Komi = komi
+	}
+}
+
+// WithHandicap places the given number of black stones on the star points
+// and lets white move first.
+func WithHandicap(stones int) GameOption {
+	return func(g *Game) {
+		g.Handicap = stones
+	}
+}
+
+func WithRuleset(ruleset Ruleset) GameOption {
+	return func(g *Game) {
+		g.Ruleset = ruleset
+	}
+}
+
+func WithTimeControl(tc TimeControl) GameOption {
+	return func(g *Game) {
+		g.TimeControl = tc
+	}
+}
@@ -0,0 +1,189 @@
+package game
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		ruleset    Ruleset
+		komi       float64
+		wantBlack  float64
+		wantWhite  float64
+		wantResult string
+	}{
+		{name: "chinese counts area", ruleset: Chinese, komi: 7.5, wantBlack: 10, wantWhite: 22.5, wantResult: "W+12.5"},
+		{name: "aga counts area", ruleset: AGA, komi: 0.5, wantBlack: 10, wantWhite: 15.5, wantResult: "W+5.5"},
+		{name: "default counts area", komi: 0, wantBlack: 10, wantWhite: 15, wantResult: "W+5"},
+		{name: "japanese counts territory and prisoners", ruleset: Japanese, komi: 6.5, wantBlack: 12, wantWhite: 17.5, wantResult: "W+5.5"},
+		{name: "korean counts territory and prisoners", ruleset: Korean, komi: -5, wantBlack: 12, wantWhite: 6, wantResult: "B+6"},
+		{name: "draw", ruleset: Japanese, komi: 1, wantBlack: 12, wantWhite: 12, wantResult: "Draw"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newPosition(t, splitPosition, WithRuleset(tt.ruleset), WithKomi(tt.komi))
+			g.BlackCaptures, g.WhiteCaptures = 7, 1
+
+			black, white := g.Score()
+			if black != tt.wantBlack || white != tt.wantWhite {
+				t.Fatalf("Score() = %v, %v, want %v, %v", black, white, tt.wantBlack, tt.wantWhite)
+			}
+
+			for _, id := range []int{1, 2} {
+				if err := g.Pass(id); err != nil {
+					t.Fatalf("Pass(%d): %v", id, err)
+				}
+			}
+			for _, color := range []CellState{Black, White} {
+				if err := g.AcceptScore(color); err != nil {
+					t.Fatalf("AcceptScore(%s): %v", color, err)
+				}
+			}
+			if g.Result != tt.wantResult {
+				t.Errorf("Result = %q, want %q", g.Result, tt.wantResult)
+			}
+		})
+	}
+}
+
+func TestHandicap(t *testing.T) {
+	tests := []struct {
+		name   string
+		size   int
+		stones int
+		want   []Point
+	}{
+		{name: "no handicap", size: 19, stones: 0},
+		{name: "one stone is no handicap", size: 19, stones: 1},
+		{name: "two stones", size: 19, stones: 2, want: []Point{{X: 15, Y: 3}, {X: 3, Y: 15}}},
+		{name: "two stones on a small board", size: 9, stones: 2, want: []Point{{X: 6, Y: 2}, {X: 2, Y: 6}}},
+		{
+			name:   "five stones take the center",
+			size:   19,
+			stones: 5,
+			want:   []Point{{X: 15, Y: 3}, {X: 3, Y: 15}, {X: 15, Y: 15}, {X: 3, Y: 3}, {X: 9, Y: 9}},
+		},
+		{
+			name:   "six stones take the sides",
+			size:   19,
+			stones: 6,
+			want:   []Point{{X: 15, Y: 3}, {X: 3, Y: 15}, {X: 15, Y: 15}, {X: 3, Y: 3}, {X: 3, Y: 9}, {X: 15, Y: 9}},
+		},
+		{
+			name:   "nine stones",
+			size:   19,
+			stones: 9,
+			want: []Point{
+				{X: 15, Y: 3}, {X: 3, Y: 15}, {X: 15, Y: 15}, {X: 3, Y: 3}, {X: 9, Y: 9},
+				{X: 3, Y: 9}, {X: 15, Y: 9}, {X: 9, Y: 3}, {X: 9, Y: 15},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(tt.size), WithHandicap(tt.stones))
+
+			var got []Point
+			for y := 0; y < tt.size; y++ {
+				for x := 0; x < tt.size; x++ {
+					if p := (Point{X: x, Y: y}); g.Board.Get(p) == Black {
+						got = append(got, p)
+					}
+				}
+			}
+			want := slices.Clone(tt.want)
+			byRow := func(a, b Point) int {
+				if a.Y != b.Y {
+					return a.Y - b.Y
+				}
+				return a.X - b.X
+			}
+			slices.SortFunc(want, byRow)
+			if !slices.Equal(got, want) {
+				t.Fatalf("black stones = %v, want %v", got, want)
+			}
+
+			wantTurn := Black
+			if len(tt.want) > 0 {
+				wantTurn = White
+			}
+			if g.CurrentTurn != wantTurn {
+				t.Errorf("CurrentTurn = %s, want %s", g.CurrentTurn, wantTurn)
+			}
+		})
+	}
+}
+
+func TestClockSpend(t *testing.T) {
+	byoyomi := TimeControl{Type: Byoyomi, MainTime: 60, Periods: 3, PeriodTime: 30}
+	tests := []struct {
+		name        string
+		tc          TimeControl
+		remaining   time.Duration
+		periods     int
+		elapsed     time.Duration
+		wantLeft    time.Duration
+		wantPeriods int
+		wantFlag    bool
+	}{
+		{name: "absolute", tc: TimeControl{Type: Absolute, MainTime: 60}, remaining: time.Minute, elapsed: 10 * time.Second, wantLeft: 50 * time.Second},
+		{name: "absolute runs out", tc: TimeControl{Type: Absolute, MainTime: 60}, remaining: time.Minute, elapsed: 61 * time.Second, wantFlag: true},
+		{name: "fischer runs out", tc: TimeControl{Type: Fischer, MainTime: 60, Increment: 5}, remaining: 5 * time.Second, elapsed: 6 * time.Second, wantFlag: true},
+		{name: "byoyomi main time", tc: byoyomi, remaining: time.Minute, periods: 3, elapsed: 50 * time.Second, wantLeft: 10 * time.Second, wantPeriods: 3},
+		{name: "byoyomi move within a period", tc: byoyomi, remaining: 10 * time.Second, periods: 3, elapsed: 35 * time.Second, wantPeriods: 3},
+		{name: "byoyomi move of exactly a period", tc: byoyomi, periods: 3, elapsed: 30 * time.Second, wantPeriods: 3},
+		{name: "byoyomi uses up a period", tc: byoyomi, periods: 3, elapsed: 45 * time.Second, wantPeriods: 2},
+		{name: "byoyomi uses up two periods", tc: byoyomi, periods: 3, elapsed: 75 * time.Second, wantPeriods: 1},
+		{name: "byoyomi runs out", tc: byoyomi, periods: 1, elapsed: 31 * time.Second, wantFlag: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Clock{BlackRemaining: tt.remaining, BlackPeriods: tt.periods}
+
+			flagged := c.spend(tt.tc, Black, tt.elapsed)
+			if flagged != tt.wantFlag {
+				t.Errorf("spend() = %v, want %v", flagged, tt.wantFlag)
+			}
+			if c.BlackRemaining != tt.wantLeft || c.BlackPeriods != tt.wantPeriods {
+				t.Errorf("left %v and %d periods, want %v and %d", c.BlackRemaining, c.BlackPeriods, tt.wantLeft, tt.wantPeriods)
+			}
+		})
+	}
+}
+
+func TestChargeClock(t *testing.T) {
+	tests := []struct {
+		name       string
+		tc         TimeControl
+		elapsed    time.Duration
+		wantLeft   time.Duration
+		wantErr    error
+		wantResult string
+	}{
+		{name: "fischer adds the increment", tc: TimeControl{Type: Fischer, MainTime: 60, Increment: 5}, elapsed: 10 * time.Second, wantLeft: 55 * time.Second},
+		{name: "absolute adds nothing", tc: TimeControl{Type: Absolute, MainTime: 60}, elapsed: 10 * time.Second, wantLeft: 50 * time.Second},
+		{name: "no increment after the flag fell", tc: TimeControl{Type: Fischer, MainTime: 60, Increment: 5}, elapsed: 61 * time.Second, wantErr: ErrTimeout, wantResult: "W+T"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewGame(WithSize(9), WithPlayers(1, 2), WithTimeControl(tt.tc))
+			now := time.Now()
+			g.Clock.TurnStartedAt = now.Add(-tt.elapsed)
+
+			err := g.chargeClock(Black, now)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("chargeClock() error = %v, want %v", err, tt.wantErr)
+			}
+			if g.Clock.BlackRemaining != tt.wantLeft {
+				t.Errorf("BlackRemaining = %v, want %v", g.Clock.BlackRemaining, tt.wantLeft)
+			}
+			if g.Result != tt.wantResult {
+				t.Errorf("Result = %q, want %q", g.Result, tt.wantResult)
+			}
+		})
+	}
+}
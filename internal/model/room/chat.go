@@ -0,0 +1,86 @@
+package room
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/model/chat"
+)
+
+var (
+	ErrNotInChatChannel = errors.New("player cannot write in this chat channel")
+	ErrMuted            = errors.New("player is muted in this room")
+	ErrNotModerator     = errors.New("only the room owner or a moderator can moderate the chat")
+	ErrAlreadyMuted     = errors.New("player is already muted")
+	ErrNotMuted         = errors.New("player is not muted")
+)
+
+// CheckChatWriter reports why the player may not write in a chat channel.
+// Seated players write in the players channel and spectators in the
+// spectators channel.
+func (r *Room) CheckChatWriter(playerID int, channel chat.Channel) error {
+	if !channel.IsValid() {
+		return chat.ErrInvalidChannel
+	}
+	if r.IsMuted(playerID) {
+		return ErrMuted
+	}
+
+	switch {
+	case channel == chat.Players && r.HasPlayer(playerID):
+	case channel == chat.Spectators && r.IsSpectating(playerID):
+	default:
+		return ErrNotInChatChannel
+	}
+	return nil
+}
+
+// CanReadChat reports whether the viewer may read a chat channel. Everyone
+// reads the players, but seated players cannot read the spectators while a
+// game is in progress.
+func (r *Room) CanReadChat(viewerID int, channel chat.Channel) bool {
+	switch channel {
+	case chat.Players:
+		return true
+	case chat.Spectators:
+		return !r.isPlaying() || !r.HasPlayer(viewerID)
+	}
+	return false
+}
+
+// CanModerate reports whether the player, whose account has the role, may
+// delete messages and mute players in the room. Moderators moderate every
+// room and players the rooms they own.
+func (r *Room) CanModerate(playerID int, role account.Role) bool {
+	return role.Includes(account.RoleModerator) || (playerID != 0 && r.OwnerID == playerID)
+}
+
+func (r *Room) IsMuted(playerID int) bool {
+	return slices.Contains(r.Muted, playerID)
+}
+
+// Mute stops a player from writing in the chat of the room.
+func (r *Room) Mute(moderatorID int, role account.Role, playerID int) error {
+	if !r.CanModerate(moderatorID, role) {
+		return ErrNotModerator
+	}
+	if r.IsMuted(playerID) {
+		return ErrAlreadyMuted
+	}
+	r.Muted = append(r.Muted, playerID)
+	return nil
+}
+
+func (r *Room) Unmute(moderatorID int, role account.Role, playerID int) error {
+	if !r.CanModerate(moderatorID, role) {
+		return ErrNotModerator
+	}
+	if !r.IsMuted(playerID) {
+		return ErrNotMuted
+	}
+	r.Muted = slices.DeleteFunc(r.Muted, func(id int) bool {
+		return id == playerID
+	})
+	return nil
+}
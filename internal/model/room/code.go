@@ -0,0 +1,52 @@
+package room
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strings"
+)
+
+// codeAlphabet leaves out characters that are easy to confuse when a code is
+// read aloud or typed from a screenshot (0/O, 1/I/L).
+const codeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+const (
+	codeGroups    = 2
+	codeGroupSize = 4
+)
+
+// GenerateCode returns a random invite code such as "K7QM-3XHP".
+func GenerateCode() (string, error) {
+	var sb strings.Builder
+	max := big.NewInt(int64(len(codeAlphabet)))
+	for group := 0; group < codeGroups; group++ {
+		if group > 0 {
+			sb.WriteByte('-')
+		}
+		for i := 0; i < codeGroupSize; i++ {
+			n, err := rand.Int(rand.Reader, max)
+			if err != nil {
+				return "", err
+			}
+			sb.WriteByte(codeAlphabet[n.Int64()])
+		}
+	}
+	return sb.String(), nil
+}
+
+// NormalizeCode turns a code typed by a user into the stored form, so
+// "k7qm 3xhp" and "K7QM-3XHP" find the same room.
+func NormalizeCode(code string) string {
+	var sb strings.Builder
+	for _, c := range strings.ToUpper(code) {
+		if strings.ContainsRune(codeAlphabet, c) {
+			sb.WriteRune(c)
+		}
+	}
+
+	raw := sb.String()
+	if len(raw) != codeGroups*codeGroupSize {
+		return strings.ToUpper(strings.TrimSpace(code))
+	}
+	return raw[:codeGroupSize] + "-" + raw[codeGroupSize:]
+}
@@ -0,0 +1,232 @@
+package room
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/moLIart/go-course/internal/model/game"
+)
+
+// NigiriRevealTimeout is how long the holder has to reveal after the guess.
+// Past it, the guesser may claim the choice of colors.
+const NigiriRevealTimeout = 2 * time.Minute
+
+// Nigiri guesses.
+const (
+	GuessOdd  = "odd"
+	GuessEven = "even"
+)
+
+// Nigiri audit actions.
+const (
+	NigiriCommitted      = "commit"
+	NigiriGuessed        = "guess"
+	NigiriRevealed       = "reveal"
+	NigiriRevealRejected = "reveal_rejected"
+	NigiriUsed           = "used"
+	NigiriForfeited      = "forfeit"
+)
+
+var (
+	ErrNigiriInProgress  = errors.New("a nigiri is already in progress")
+	ErrNoNigiri          = errors.New("no nigiri is in progress")
+	ErrNigiriOutOfTurn   = errors.New("it is not this player's step in the nigiri")
+	ErrInvalidCommitment = errors.New("commitment must be a hex encoded SHA-256 hash")
+	ErrInvalidGuess      = errors.New(`guess must be "odd" or "even"`)
+	ErrInvalidStones     = errors.New("stone count must be positive")
+	ErrRevealMismatch    = errors.New("revealed stones and nonce do not match the commitment")
+	ErrRevealExpired     = errors.New("the time to reveal the nigiri has passed")
+	ErrRevealPending     = errors.New("the stone holder can still reveal the nigiri")
+	ErrNigiriRequired    = errors.New("colors of rated games need a revealed nigiri, hold one first")
+)
+
+// NigiriEvent is one step of the audit trail of a nigiri.
+type NigiriEvent struct {
+	At       time.Time `json:"at" bson:"at"`
+	PlayerID int       `json:"player_id" bson:"player_id"`
+	Action   string    `json:"action" bson:"action"`
+	Detail   string    `json:"detail,omitempty" bson:"detail,omitempty"`
+}
+
+// Nigiri is a verifiable color draw. The holder commits to a secret stone
+// count by publishing SHA-256("<stones>:<nonce>"), the guesser calls odd or
+// even, and the holder then reveals stones and nonce so anyone can check the
+// commitment. A correct guess gives black to the guesser. A holder who does
+// not reveal within NigiriRevealTimeout forfeits, and the guesser picks colors.
+type Nigiri struct {
+	HolderID   int           `json:"holder_id" bson:"holder_id"`
+	GuesserID  int           `json:"guesser_id" bson:"guesser_id"`
+	Commitment string        `json:"commitment" bson:"commitment"`
+	Guess      string        `json:"guess,omitempty" bson:"guess,omitempty"`
+	Stones     int           `json:"stones,omitempty" bson:"stones,omitempty"`
+	Nonce      string        `json:"nonce,omitempty" bson:"nonce,omitempty"`
+	BlackID    int           `json:"black_id,omitempty" bson:"black_id,omitempty"`
+	GameID     int           `json:"game_id,omitempty" bson:"game_id,omitempty"`
+	Log        []NigiriEvent `json:"log" bson:"log"`
+}
+
+// NigiriCommitment computes the commitment a holder publishes for the given
+// stone count and nonce.
+func NigiriCommitment(stones int, nonce string) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d:%s", stones, nonce)))
+	return hex.EncodeToString(sum[:])
+}
+
+func (n *Nigiri) IsRevealed() bool {
+	return n.BlackID != 0
+}
+
+// RevealDeadline returns when the holder stops being able to reveal, or the
+// zero time before the guess.
+func (n *Nigiri) RevealDeadline() time.Time {
+	for _, e := range n.Log {
+		if e.Action == NigiriGuessed {
+			return e.At.Add(NigiriRevealTimeout)
+		}
+	}
+	return time.Time{}
+}
+
+func (n *Nigiri) record(playerID int, action, detail string) {
+	n.Log = append(n.Log, NigiriEvent{
+		At:       time.Now().UTC(),
+		PlayerID: playerID,
+		Action:   action,
+		Detail:   detail,
+	})
+}
+
+// CommitNigiri starts a nigiri with the given player holding the stones.
+func (r *Room) CommitNigiri(holderID int, commitment string) error {
+	if !r.IsFull() {
+		return ErrRoomNotFull
+	}
+	if r.Game != nil && !r.Game.IsOver() {
+		return ErrGameInProgress
+	}
+	if r.Nigiri != nil && !r.Nigiri.IsRevealed() {
+		return ErrNigiriInProgress
+	}
+
+	holder := r.GetPlayerByID(holderID)
+	if holder == nil {
+		return ErrNotInRoom
+	}
+
+	commitment = strings.ToLower(commitment)
+	if decoded, err := hex.DecodeString(commitment); err != nil || len(decoded) != sha256.Size {
+		return ErrInvalidCommitment
+	}
+
+	r.archiveNigiri()
+	r.Nigiri = &Nigiri{
+		HolderID:   holder.ID,
+		GuesserID:  r.GetOpponent(holder).ID,
+		Commitment: commitment,
+	}
+	r.Nigiri.record(holderID, NigiriCommitted, commitment)
+	return nil
+}
+
+// GuessNigiri records the odd or even call of the guesser.
+func (r *Room) GuessNigiri(guesserID int, guess string) error {
+	n := r.Nigiri
+	if n == nil || n.IsRevealed() {
+		return ErrNoNigiri
+	}
+	if n.GuesserID != guesserID || n.Guess != "" {
+		return ErrNigiriOutOfTurn
+	}
+	if guess != GuessOdd && guess != GuessEven {
+		return ErrInvalidGuess
+	}
+
+	n.Guess = guess
+	n.record(guesserID, NigiriGuessed, guess)
+	return nil
+}
+
+// RevealNigiri checks the holder's stones and nonce against the commitment
+// and assigns black. A reveal that does not match is recorded and rejected,
+// leaving the holder free to reveal the committed values.
+func (r *Room) RevealNigiri(holderID int, stones int, nonce string) error {
+	n := r.Nigiri
+	if n == nil || n.IsRevealed() {
+		return ErrNoNigiri
+	}
+	if n.HolderID != holderID || n.Guess == "" {
+		return ErrNigiriOutOfTurn
+	}
+	if stones <= 0 {
+		return ErrInvalidStones
+	}
+	if time.Now().After(n.RevealDeadline()) {
+		return ErrRevealExpired
+	}
+
+	detail := fmt.Sprintf("stones=%d nonce=%s", stones, nonce)
+	if subtle.ConstantTimeCompare([]byte(NigiriCommitment(stones, nonce)), []byte(n.Commitment)) != 1 {
+		n.record(holderID, NigiriRevealRejected, detail)
+		return ErrRevealMismatch
+	}
+
+	n.Stones = stones
+	n.Nonce = nonce
+	guessedOdd := n.Guess == GuessOdd
+	if (stones%2 == 1) == guessedOdd {
+		n.BlackID = n.GuesserID
+	} else {
+		n.BlackID = n.HolderID
+	}
+	n.record(holderID, NigiriRevealed, detail)
+	return nil
+}
+
+// ClaimNigiri lets the guesser pick their color once the holder let the
+// reveal deadline pass.
+func (r *Room) ClaimNigiri(guesserID int, color game.CellState, now time.Time) error {
+	n := r.Nigiri
+	if n == nil || n.IsRevealed() {
+		return ErrNoNigiri
+	}
+	if n.GuesserID != guesserID || n.Guess == "" {
+		return ErrNigiriOutOfTurn
+	}
+	if !now.After(n.RevealDeadline()) {
+		return ErrRevealPending
+	}
+
+	switch color {
+	case game.Black:
+		n.BlackID = n.GuesserID
+	case game.White:
+		n.BlackID = n.HolderID
+	default:
+		return ErrInvalidColorAssignment
+	}
+	n.record(guesserID, NigiriForfeited, "color="+color.String())
+	return nil
+}
+
+// takeNigiriBlack returns the black player drawn by a revealed nigiri that
+// no game has used yet.
+func (r *Room) takeNigiriBlack() *Player {
+	n := r.Nigiri
+	if n == nil || !n.IsRevealed() || n.GameID != 0 {
+		return nil
+	}
+	return r.GetPlayerByID(n.BlackID)
+}
+
+// archiveNigiri moves a finished nigiri into the room's history.
+func (r *Room) archiveNigiri() {
+	if r.Nigiri != nil {
+		r.NigiriHistory = append(r.NigiriHistory, r.Nigiri)
+		r.Nigiri = nil
+	}
+}
@@ -0,0 +1,359 @@
+package room
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/moLIart/go-course/internal/model/game"
+)
+
+func TestCommitNigiri(t *testing.T) {
+	valid := NigiriCommitment(5, "nonce")
+	tests := []struct {
+		name       string
+		holderID   int
+		commitment string
+		wantErr    error
+	}{
+		{name: "valid", holderID: 1, commitment: valid},
+		{name: "upper case hex", holderID: 2, commitment: strings.ToUpper(valid)},
+		{name: "not hex", holderID: 1, commitment: "zz" + valid[2:], wantErr: ErrInvalidCommitment},
+		{name: "too short", holderID: 1, commitment: valid[:10], wantErr: ErrInvalidCommitment},
+		{name: "not in room", holderID: 3, commitment: valid, wantErr: ErrNotInRoom},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newFullRoom(t)
+			err := r.CommitNigiri(tt.holderID, tt.commitment)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CommitNigiri() error = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if r.Nigiri.HolderID != tt.holderID || r.Nigiri.GuesserID != 3-tt.holderID {
+				t.Errorf("holder %d, guesser %d", r.Nigiri.HolderID, r.Nigiri.GuesserID)
+			}
+			if r.Nigiri.Commitment != valid {
+				t.Errorf("Commitment = %q, want the lower case hash", r.Nigiri.Commitment)
+			}
+		})
+	}
+}
+
+func TestCommitNigiriWhileInProgress(t *testing.T) {
+	r := newFullRoom(t)
+	if err := r.CommitNigiri(1, NigiriCommitment(5, "a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.CommitNigiri(2, NigiriCommitment(6, "b")); !errors.Is(err, ErrNigiriInProgress) {
+		t.Fatalf("second commit error = %v, want %v", err, ErrNigiriInProgress)
+	}
+}
+
+func TestGuessNigiri(t *testing.T) {
+	tests := []struct {
+		name      string
+		guesserID int
+		guess     string
+		wantErr   error
+	}{
+		{name: "odd", guesserID: 2, guess: GuessOdd},
+		{name: "even", guesserID: 2, guess: GuessEven},
+		{name: "holder cannot guess", guesserID: 1, guess: GuessOdd, wantErr: ErrNigiriOutOfTurn},
+		{name: "invalid guess", guesserID: 2, guess: "three", wantErr: ErrInvalidGuess},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newFullRoom(t)
+			if err := r.CommitNigiri(1, NigiriCommitment(5, "n")); err != nil {
+				t.Fatal(err)
+			}
+			if err := r.GuessNigiri(tt.guesserID, tt.guess); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("GuessNigiri() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+
+	t.Run("without nigiri", func(t *testing.T) {
+		r := newFullRoom(t)
+		if err := r.GuessNigiri(2, GuessOdd); !errors.Is(err, ErrNoNigiri) {
+			t.Fatalf("GuessNigiri() error = %v, want %v", err, ErrNoNigiri)
+		}
+	})
+	t.Run("twice", func(t *testing.T) {
+		r := newFullRoom(t)
+		if err := r.CommitNigiri(1, NigiriCommitment(5, "n")); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.GuessNigiri(2, GuessOdd); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.GuessNigiri(2, GuessEven); !errors.Is(err, ErrNigiriOutOfTurn) {
+			t.Fatalf("second guess error = %v, want %v", err, ErrNigiriOutOfTurn)
+		}
+	})
+}
+
+func TestRevealNigiri(t *testing.T) {
+	tests := []struct {
+		name      string
+		holderID  int
+		stones    int
+		nonce     string
+		expired   bool
+		wantErr   error
+		wantBlack int
+	}{
+		{name: "matching reveal", holderID: 1, stones: 5, nonce: "n", wantBlack: 2},
+		{name: "wrong stones", holderID: 1, stones: 6, nonce: "n", wantErr: ErrRevealMismatch},
+		{name: "wrong nonce", holderID: 1, stones: 5, nonce: "m", wantErr: ErrRevealMismatch},
+		{name: "no stones", holderID: 1, stones: 0, nonce: "n", wantErr: ErrInvalidStones},
+		{name: "guesser cannot reveal", holderID: 2, stones: 5, nonce: "n", wantErr: ErrNigiriOutOfTurn},
+		{name: "after the deadline", holderID: 1, stones: 5, nonce: "n", expired: true, wantErr: ErrRevealExpired},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newFullRoom(t)
+			if err := r.CommitNigiri(1, NigiriCommitment(5, "n")); err != nil {
+				t.Fatal(err)
+			}
+			if err := r.GuessNigiri(2, GuessOdd); err != nil {
+				t.Fatal(err)
+			}
+			if tt.expired {
+				expireGuess(r.Nigiri)
+			}
+
+			err := r.RevealNigiri(tt.holderID, tt.stones, tt.nonce)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RevealNigiri() error = %v, want %v", err, tt.wantErr)
+			}
+			if r.Nigiri.BlackID != tt.wantBlack {
+				t.Errorf("BlackID = %d, want %d", r.Nigiri.BlackID, tt.wantBlack)
+			}
+		})
+	}
+}
+
+func TestRevealNigiriBeforeGuess(t *testing.T) {
+	r := newFullRoom(t)
+	if err := r.CommitNigiri(1, NigiriCommitment(5, "n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RevealNigiri(1, 5, "n"); !errors.Is(err, ErrNigiriOutOfTurn) {
+		t.Fatalf("RevealNigiri() error = %v, want %v", err, ErrNigiriOutOfTurn)
+	}
+}
+
+func TestClaimNigiri(t *testing.T) {
+	tests := []struct {
+		name      string
+		claimerID int
+		color     game.CellState
+		after     time.Duration
+		wantErr   error
+		wantBlack int
+	}{
+		{name: "guesser takes black", claimerID: 2, color: game.Black, after: NigiriRevealTimeout + time.Second, wantBlack: 2},
+		{name: "guesser takes white", claimerID: 2, color: game.White, after: NigiriRevealTimeout + time.Second, wantBlack: 1},
+		{name: "before the deadline", claimerID: 2, color: game.Black, after: NigiriRevealTimeout - time.Second, wantErr: ErrRevealPending},
+		{name: "holder cannot claim", claimerID: 1, color: game.Black, after: NigiriRevealTimeout + time.Second, wantErr: ErrNigiriOutOfTurn},
+		{name: "no color", claimerID: 2, color: game.Empty, after: NigiriRevealTimeout + time.Second, wantErr: ErrInvalidColorAssignment},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newFullRoom(t)
+			if err := r.CommitNigiri(1, NigiriCommitment(5, "n")); err != nil {
+				t.Fatal(err)
+			}
+			if err := r.GuessNigiri(2, GuessEven); err != nil {
+				t.Fatal(err)
+			}
+
+			guessedAt := r.Nigiri.Log[len(r.Nigiri.Log)-1].At
+			err := r.ClaimNigiri(tt.claimerID, tt.color, guessedAt.Add(tt.after))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ClaimNigiri() error = %v, want %v", err, tt.wantErr)
+			}
+			if r.Nigiri.BlackID != tt.wantBlack {
+				t.Errorf("BlackID = %d, want %d", r.Nigiri.BlackID, tt.wantBlack)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if last := r.Nigiri.Log[len(r.Nigiri.Log)-1]; last.Action != NigiriForfeited || last.PlayerID != 2 {
+				t.Errorf("last audit event = %+v, want the forfeit", last)
+			}
+
+			g, err := r.StartGame(1, NigiriAssignment, game.Empty)
+			if err != nil {
+				t.Fatalf("StartGame: %v", err)
+			}
+			if g.BlackID != tt.wantBlack {
+				t.Errorf("game BlackID = %d, want %d", g.BlackID, tt.wantBlack)
+			}
+		})
+	}
+}
+
+func TestClaimNigiriBeforeGuess(t *testing.T) {
+	r := newFullRoom(t)
+	if err := r.CommitNigiri(1, NigiriCommitment(5, "n")); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.ClaimNigiri(2, game.Black, time.Now().Add(time.Hour)); !errors.Is(err, ErrNigiriOutOfTurn) {
+		t.Fatalf("ClaimNigiri() error = %v, want %v", err, ErrNigiriOutOfTurn)
+	}
+}
+
+func TestNigiriAudit(t *testing.T) {
+	r := newFullRoom(t)
+	commitment := NigiriCommitment(4, "secret")
+	if err := r.CommitNigiri(1, commitment); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.GuessNigiri(2, GuessOdd); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RevealNigiri(1, 5, "secret"); !errors.Is(err, ErrRevealMismatch) {
+		t.Fatalf("cheating reveal error = %v, want %v", err, ErrRevealMismatch)
+	}
+	if err := r.RevealNigiri(1, 4, "secret"); err != nil {
+		t.Fatal(err)
+	}
+	g, err := r.StartGame(2, NigiriAssignment, game.Empty, game.WithID(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []struct {
+		playerID int
+		action   string
+		detail   string
+	}{
+		{1, NigiriCommitted, commitment},
+		{2, NigiriGuessed, GuessOdd},
+		{1, NigiriRevealRejected, "stones=5 nonce=secret"},
+		{1, NigiriRevealed, "stones=4 nonce=secret"},
+		{2, NigiriUsed, "game=7"},
+	}
+	if len(r.Nigiri.Log) != len(want) {
+		t.Fatalf("audit has %d events, want %d: %+v", len(r.Nigiri.Log), len(want), r.Nigiri.Log)
+	}
+	for i, w := range want {
+		e := r.Nigiri.Log[i]
+		if e.PlayerID != w.playerID || e.Action != w.action || e.Detail != w.detail {
+			t.Errorf("event %d = %+v, want %+v", i, e, w)
+		}
+	}
+	if r.Nigiri.BlackID != 1 || g.BlackID != 1 || r.Nigiri.Stones != 4 || r.Nigiri.Nonce != "secret" {
+		t.Errorf("nigiri = %+v, game black %d", r.Nigiri, g.BlackID)
+	}
+
+	// The next nigiri archives this one, keeping it in the audit.
+	if err := r.Resign(1); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.CommitNigiri(2, NigiriCommitment(9, "again")); err != nil {
+		t.Fatal(err)
+	}
+	if len(r.NigiriHistory) != 1 || r.NigiriHistory[0].Commitment != commitment {
+		t.Fatalf("history = %+v", r.NigiriHistory)
+	}
+}
+
+func TestStartGameSkipsUnusableNigiri(t *testing.T) {
+	t.Run("unrevealed nigiri", func(t *testing.T) {
+		r := newFullRoom(t)
+		if err := r.CommitNigiri(1, NigiriCommitment(5, "n")); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.GuessNigiri(2, GuessOdd); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.StartGame(1, NigiriAssignment, game.Empty, game.WithID(7)); err != nil {
+			t.Fatal(err)
+		}
+		if r.Nigiri.GameID != 0 || len(r.Nigiri.Log) != 2 {
+			t.Errorf("unrevealed nigiri was used: %+v", r.Nigiri)
+		}
+	})
+
+	t.Run("used nigiri", func(t *testing.T) {
+		r := newFullRoom(t)
+		playNigiri(t, r, 1, 5, GuessOdd)
+		if _, err := r.StartGame(1, NigiriAssignment, game.Empty, game.WithID(7)); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Resign(1); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.StartGame(1, NigiriAssignment, game.Empty, game.WithID(8)); err != nil {
+			t.Fatal(err)
+		}
+		if r.Nigiri.GameID != 7 || len(r.Nigiri.Log) != 4 {
+			t.Errorf("nigiri was used twice: %+v", r.Nigiri)
+		}
+	})
+}
+
+func TestRatedStartGameNeedsNigiri(t *testing.T) {
+	for _, assignment := range []ColorAssignment{NigiriAssignment, AlternateAssignment} {
+		t.Run(string(assignment), func(t *testing.T) {
+			r := newFullRoom(t)
+			r.Settings.Rated = true
+			if _, err := r.StartGame(1, assignment, game.Empty); !errors.Is(err, ErrNigiriRequired) {
+				t.Fatalf("StartGame() error = %v, want %v", err, ErrNigiriRequired)
+			}
+
+			// A guessed but unrevealed nigiri does not decide colors either.
+			if err := r.CommitNigiri(1, NigiriCommitment(5, "n")); err != nil {
+				t.Fatal(err)
+			}
+			if err := r.GuessNigiri(2, GuessOdd); err != nil {
+				t.Fatal(err)
+			}
+			if _, err := r.StartGame(1, assignment, game.Empty); !errors.Is(err, ErrNigiriRequired) {
+				t.Fatalf("StartGame() error = %v, want %v", err, ErrNigiriRequired)
+			}
+
+			if err := r.RevealNigiri(1, 5, "n"); err != nil {
+				t.Fatal(err)
+			}
+			g, err := r.StartGame(1, assignment, game.Empty, game.WithID(7))
+			if err != nil {
+				t.Fatalf("StartGame() after the reveal: %v", err)
+			}
+			if g.BlackID != 2 {
+				t.Errorf("BlackID = %d, want the guesser", g.BlackID)
+			}
+		})
+	}
+
+	t.Run("used nigiri", func(t *testing.T) {
+		r := newFullRoom(t)
+		r.Settings.Rated = true
+		playNigiri(t, r, 1, 5, GuessOdd)
+		if _, err := r.StartGame(1, NigiriAssignment, game.Empty, game.WithID(7)); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.Resign(1); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := r.StartGame(1, NigiriAssignment, game.Empty); !errors.Is(err, ErrNigiriRequired) {
+			t.Fatalf("StartGame() error = %v, want %v", err, ErrNigiriRequired)
+		}
+	})
+}
+
+// expireGuess moves the guess of the nigiri back past the reveal deadline.
+func expireGuess(n *Nigiri) {
+	for i := range n.Log {
+		if n.Log[i].Action == NigiriGuessed {
+			n.Log[i].At = n.Log[i].At.Add(-NigiriRevealTimeout - time.Second)
+		}
+	}
+}
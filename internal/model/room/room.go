@@ -1,21 +1,47 @@
 package room
 
 import (
+	"errors"
+	"time"
+
 	"github.com/moLIart/go-course/internal/model/game"
 )
 
+var (
+	ErrRoomFull      = errors.New("room is full")
+	ErrAlreadyInRoom = errors.New("player is already in the room")
+	ErrNotInRoom     = errors.New("player is not in the room")
+	ErrNotSeated     = errors.New("only the players of the game can play")
+	ErrCodeExpired   = errors.New("invite code has expired")
+	ErrCodeRequired  = errors.New("joining the room needs its invite code")
+)
+
 type Room struct {
-	ID      int        `json:"id" bson:"_id"`
-	Code    string     `json:"code" bson:"code"`
-	Players [2]*Player `json:"players" bson:"players"`
-	Game    *game.Game `json:"game" bson:"game"`
+	ID            int        `json:"id" bson:"_id"`
+	Code          string     `json:"code" bson:"code"`
+	CodeExpiresAt *time.Time `json:"code_expires_at,omitempty" bson:"code_expires_at,omitempty"`
+	OwnerID       int        `json:"owner_id" bson:"owner_id"`
+	// SimulID is set on the rooms of the boards of a simul.
+	SimulID    int               `json:"simul_id,omitempty" bson:"simul_id,omitempty"`
+	State      State             `json:"state" bson:"state"`
+	Settings   Settings          `json:"settings" bson:"settings"`
+	Players    [MaxSeats]*Player `json:"players" bson:"players"`
+	Spectators []*Player         `json:"spectators,omitempty" bson:"spectators,omitempty"`
+	// Muted lists the players who may not write in the chat.
+	Muted         []int      `json:"muted,omitempty" bson:"muted,omitempty"`
+	Game          *game.Game `json:"game" bson:"game"`
+	Nigiri        *Nigiri    `json:"nigiri,omitempty" bson:"nigiri,omitempty"`
+	NigiriHistory []*Nigiri  `json:"nigiri_history,omitempty" bson:"nigiri_history,omitempty"`
+	Version       int        `json:"-" bson:"version"`
 }
 
 func NewRoom(code string) *Room {
 	return &Room{
-		Code:    code,
-		Players: [2]*Player{},
-		Game:    nil,
+		Code:     code,
+		State:    StateOpen,
+		Settings: DefaultSettings(),
+		Players:  [MaxSeats]*Player{},
+		Game:     nil,
 	}
 }
 
@@ -23,6 +49,35 @@ func (r *Room) GetCode() string {
 	return r.Code
 }
 
+// SetCodeTTL makes the invite code expire after ttl. A zero ttl keeps the
+// code valid for the whole life of the room.
+func (r *Room) SetCodeTTL(ttl time.Duration) {
+	if ttl <= 0 {
+		r.CodeExpiresAt = nil
+		return
+	}
+	expiresAt := time.Now().Add(ttl).UTC()
+	r.CodeExpiresAt = &expiresAt
+}
+
+func (r *Room) IsCodeExpired() bool {
+	return r.CodeExpiresAt != nil && time.Now().After(*r.CodeExpiresAt)
+}
+
+// CheckCode reports whether the player may join the room with the invite
+// code. The owner and players already in the room need no code.
+func (r *Room) CheckCode(playerID int, code string) error {
+	switch {
+	case r.Code == "" || playerID == r.OwnerID || r.HasPlayer(playerID):
+		return nil
+	case code == "":
+		return ErrCodeRequired
+	case code != r.Code || r.IsCodeExpired():
+		return ErrCodeExpired
+	}
+	return nil
+}
+
 func (r *Room) GetGame() *game.Game {
 	return r.Game
 }
@@ -32,47 +87,123 @@ func (r *Room) SetGame(game *game.Game) {
 }
 
 func (r *Room) AddPlayer(player *Player) bool {
-	if r.Players[0] == nil {
-		r.Players[0] = player
-		return true
-	} else if r.Players[1] == nil {
-		r.Players[1] = player
-		return true
+	if r.HasPlayer(player.ID) {
+		return false
+	}
+
+	for i := range r.SeatCount() {
+		if r.Players[i] == nil {
+			r.Players[i] = player
+			return true
+		}
 	}
 	return false
 }
 
 func (r *Room) RemovePlayer(player *Player) bool {
-	if r.Players[0] == player {
-		r.Players[0] = nil
-		return true
-	} else if r.Players[1] == player {
-		r.Players[1] = nil
-		return true
+	return r.RemovePlayerByID(player.ID)
+}
+
+func (r *Room) RemovePlayerByID(id int) bool {
+	for i, p := range r.Players {
+		if p != nil && p.ID == id {
+			r.Players[i] = nil
+			return true
+		}
 	}
 	return false
 }
 
+// Join seats the player, reporting why it is impossible instead of
+// returning false like AddPlayer. A spectator taking a seat stops spectating.
+func (r *Room) Join(player *Player) error {
+	if r.GetState() == StateAbandoned {
+		return ErrRoomAbandoned
+	}
+	if r.HasPlayer(player.ID) {
+		return ErrAlreadyInRoom
+	}
+	if !r.AddPlayer(player) {
+		return ErrRoomFull
+	}
+	r.removeSpectator(player.ID)
+	return r.syncSeatState()
+}
+
+// Leave frees the seat taken by the player with the given ID. Leaving during
+// a game forfeits it and abandons the room.
+func (r *Room) Leave(playerID int) error {
+	if !r.HasPlayer(playerID) {
+		return ErrNotInRoom
+	}
+
+	if r.isPlaying() {
+		if color := r.Game.GetColor(playerID); color != game.Empty {
+			if err := r.Game.Forfeit(color); err != nil {
+				return err
+			}
+		}
+		r.RemovePlayerByID(playerID)
+		return r.setState(StateAbandoned)
+	}
+
+	r.RemovePlayerByID(playerID)
+	if r.GetState() == StateReady || r.GetState() == StateFinished {
+		return r.setState(StateOpen)
+	}
+	return nil
+}
+
+func (r *Room) HasPlayer(id int) bool {
+	return r.GetPlayerByID(id) != nil
+}
+
 func (r *Room) IsFull() bool {
-	return r.Players[0] != nil && r.Players[1] != nil
+	for i := range r.SeatCount() {
+		if r.Players[i] == nil {
+			return false
+		}
+	}
+	return true
 }
 
 func (r *Room) IsEmpty() bool {
-	return r.Players[0] == nil && r.Players[1] == nil
+	for _, player := range r.Players {
+		if player != nil {
+			return false
+		}
+	}
+	return true
 }
 
 func (r *Room) GetCurrentPlayer() *Player {
-	if r.Game.GetCurrentTurn() == game.Black {
-		return r.Players[0]
+	if r.Game == nil {
+		return nil
 	}
-	return r.Players[1]
+	return r.GetPlayerByColor(r.Game.GetCurrentTurn())
 }
 
+// GetOpponent returns the first seated player of the other team.
 func (r *Room) GetOpponent(player *Player) *Player {
-	if r.Players[0] == player {
-		return r.Players[1]
+	team := r.teamOf(player.ID)
+	if team < 0 {
+		return nil
+	}
+	for _, opponent := range r.GetTeam(1 - team) {
+		if opponent != nil {
+			return opponent
+		}
+	}
+	return nil
+}
+
+func (r *Room) GetPlayerByID(id int) *Player {
+	for _, player := range r.Players {
+		if player != nil && player.ID == id {
+			return player
+		}
 	}
-	return r.Players[0]
+	return nil
 }
 
 func (r *Room) GetPlayerByName(name string) *Player {
@@ -84,9 +215,11 @@ func (r *Room) GetPlayerByName(name string) *Player {
 	return nil
 }
 
+// GetPlayerByColor returns the seated player who plays the next move of the
+// color in the room's game, or nil if no game has been started.
 func (r *Room) GetPlayerByColor(color game.CellState) *Player {
-	if color == game.Black {
-		return r.Players[0]
+	if r.Game == nil {
+		return nil
 	}
-	return r.Players[1]
+	return r.GetPlayerByID(r.Game.GetPlayerID(color))
 }
@@ -0,0 +1,42 @@
+package room
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestCheckCode(t *testing.T) {
+	expired := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name      string
+		code      string
+		expiresAt *time.Time
+		playerID  int
+		joinCode  string
+		wantErr   error
+	}{
+		{name: "current code", code: "ABCD-EFGH", playerID: 2, joinCode: "ABCD-EFGH"},
+		{name: "owner without code", code: "ABCD-EFGH", playerID: 1},
+		{name: "missing code", code: "ABCD-EFGH", playerID: 2, wantErr: ErrCodeRequired},
+		{name: "wrong code", code: "ABCD-EFGH", playerID: 2, joinCode: "ABCD-EFGK", wantErr: ErrCodeExpired},
+		{name: "expired code", code: "ABCD-EFGH", expiresAt: &expired, playerID: 2, joinCode: "ABCD-EFGH", wantErr: ErrCodeExpired},
+		{name: "owner with expired code", code: "ABCD-EFGH", expiresAt: &expired, playerID: 1},
+		{name: "room without code", playerID: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRoom(tt.code)
+			r.OwnerID = 1
+			r.CodeExpiresAt = tt.expiresAt
+			if err := r.CheckCode(tt.playerID, tt.joinCode); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CheckCode(%d, %q) = %v, want %v", tt.playerID, tt.joinCode, err, tt.wantErr)
+			}
+		})
+	}
+
+	r := newFullRoom(t)
+	if err := r.CheckCode(2, ""); err != nil {
+		t.Errorf("CheckCode() = %v for a seated player, want nil", err)
+	}
+}
@@ -0,0 +1,173 @@
+package room
+
+import (
+	"errors"
+	"math"
+
+	"github.com/moLIart/go-course/internal/model/game"
+)
+
+// MaxKomi bounds the komi a room may set in either direction.
+const MaxKomi = 150
+
+var (
+	ErrInvalidRuleset     = errors.New("ruleset must be chinese, aga, japanese or korean")
+	ErrInvalidBoardSize   = errors.New("board size must be 9, 13 or 19")
+	ErrInvalidKomi        = errors.New("komi must be a multiple of 0.5 between -150 and 150")
+	ErrInvalidHandicap    = errors.New("handicap must be 0 or between 2 and 9 stones")
+	ErrInvalidTimeControl = errors.New("invalid time control")
+	ErrRatedTakebacks     = errors.New("rated rooms cannot allow takebacks")
+	ErrTakebacksDisabled  = errors.New("takebacks are not allowed in this room")
+	ErrSettingsLocked     = errors.New("settings cannot change while a game is in progress")
+)
+
+// Settings are applied to every game started in the room.
+type Settings struct {
+	Ruleset     game.Ruleset     `json:"ruleset" bson:"ruleset"`
+	BoardSize   int              `json:"board_size" bson:"board_size"`
+	Komi        float64          `json:"komi" bson:"komi"`
+	Handicap    int              `json:"handicap" bson:"handicap"`
+	TimeControl game.TimeControl `json:"time_control" bson:"time_control"`
+	// PairGo seats two teams of two who alternate within their color.
+	PairGo            bool `json:"pair_go" bson:"pair_go"`
+	Rated             bool `json:"rated" bson:"rated"`
+	TakebacksAllowed  bool `json:"takebacks_allowed" bson:"takebacks_allowed"`
+	SpectatorsAllowed bool `json:"spectators_allowed" bson:"spectators_allowed"`
+	MaxSpectators     int  `json:"max_spectators" bson:"max_spectators"`
+	// SpectatorDelay is the number of moves spectators are kept behind.
+	SpectatorDelay int `json:"spectator_delay" bson:"spectator_delay"`
+}
+
+func DefaultSettings() Settings {
+	return Settings{
+		Ruleset:           game.Chinese,
+		BoardSize:         19,
+		Komi:              7.5,
+		TimeControl:       game.TimeControl{Type: game.NoTimeControl},
+		SpectatorsAllowed: true,
+		MaxSpectators:     DefaultMaxSpectators,
+	}
+}
+
+func (s Settings) Validate() error {
+	if !s.Ruleset.IsValid() {
+		return ErrInvalidRuleset
+	}
+	if s.BoardSize != 9 && s.BoardSize != 13 && s.BoardSize != 19 {
+		return ErrInvalidBoardSize
+	}
+	if math.Abs(s.Komi) > MaxKomi || math.Mod(s.Komi*2, 1) != 0 {
+		return ErrInvalidKomi
+	}
+	if s.Handicap < 0 || s.Handicap == 1 || s.Handicap > game.MaxHandicap {
+		return ErrInvalidHandicap
+	}
+	if err := validateTimeControl(s.TimeControl); err != nil {
+		return err
+	}
+	if s.Rated && s.TakebacksAllowed {
+		return ErrRatedTakebacks
+	}
+	if s.MaxSpectators < 0 || s.MaxSpectators > MaxSpectatorsLimit || s.SpectatorDelay < 0 || s.SpectatorDelay > MaxSpectatorDelay {
+		return ErrInvalidSpectators
+	}
+	return nil
+}
+
+func validateTimeControl(tc game.TimeControl) error {
+	if tc.MainTime < 0 || tc.Increment < 0 || tc.Periods < 0 || tc.PeriodTime < 0 {
+		return ErrInvalidTimeControl
+	}
+
+	switch tc.Type {
+	case "", game.NoTimeControl:
+		return nil
+	case game.Absolute:
+		if tc.MainTime == 0 {
+			return ErrInvalidTimeControl
+		}
+	case game.Fischer:
+		if tc.MainTime == 0 || tc.Increment == 0 {
+			return ErrInvalidTimeControl
+		}
+	case game.Byoyomi:
+		if tc.Periods == 0 || tc.PeriodTime == 0 {
+			return ErrInvalidTimeControl
+		}
+	default:
+		return ErrInvalidTimeControl
+	}
+	return nil
+}
+
+// GameOptions turns the settings into options for a new game.
+func (s Settings) GameOptions() []game.GameOption {
+	return []game.GameOption{
+		game.WithSize(s.BoardSize),
+		game.WithRuleset(s.Ruleset),
+		game.WithKomi(s.Komi),
+		game.WithHandicap(s.Handicap),
+		game.WithTimeControl(s.TimeControl),
+	}
+}
+
+// GetSettings returns the settings of the room. Rooms stored before settings
+// existed use the defaults.
+func (r *Room) GetSettings() Settings {
+	if r.Settings.BoardSize == 0 {
+		return DefaultSettings()
+	}
+	return r.Settings
+}
+
+// UpdateSettings replaces the settings of a room that has no game in progress.
+func (r *Room) UpdateSettings(s Settings) error {
+	if err := s.Validate(); err != nil {
+		return err
+	}
+	if r.isPlaying() {
+		return ErrSettingsLocked
+	}
+	if !s.PairGo && (r.Players[2] != nil || r.Players[3] != nil) {
+		return ErrSeatsTaken
+	}
+	r.Settings = s
+	return r.syncSeatState()
+}
+
+// RequestTakeback asks the opponent of a seated player to undo the player's
+// last move when the room allows takebacks.
+func (r *Room) RequestTakeback(playerID int) error {
+	if err := r.checkTakebacks(playerID); err != nil {
+		return err
+	}
+	return r.Game.RequestTakeback(playerID)
+}
+
+// AcceptTakeback undoes the move the opponent of a seated player asked to
+// take back.
+func (r *Room) AcceptTakeback(playerID int) error {
+	if err := r.checkTakebacks(playerID); err != nil {
+		return err
+	}
+	return r.afterGameAction(r.Game.AcceptTakeback(playerID))
+}
+
+// DeclineTakeback refuses the takeback the opponent of a seated player asked
+// for.
+func (r *Room) DeclineTakeback(playerID int) error {
+	if _, err := r.seatColor(playerID); err != nil {
+		return err
+	}
+	return r.Game.DeclineTakeback(playerID)
+}
+
+func (r *Room) checkTakebacks(playerID int) error {
+	if _, err := r.seatColor(playerID); err != nil {
+		return err
+	}
+	if !r.GetSettings().TakebacksAllowed {
+		return ErrTakebacksDisabled
+	}
+	return nil
+}
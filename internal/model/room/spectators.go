@@ -0,0 +1,80 @@
+package room
+
+import (
+	"errors"
+	"slices"
+)
+
+// Spectator limits.
+const (
+	DefaultMaxSpectators = 50
+	MaxSpectatorsLimit   = 500
+	// MaxSpectatorDelay is the largest number of moves spectators can be kept behind.
+	MaxSpectatorDelay = 50
+)
+
+var (
+	ErrSpectatorsNotAllowed = errors.New("room does not allow spectators")
+	ErrSpectatorsFull       = errors.New("room has no spectator places left")
+	ErrAlreadySpectating    = errors.New("player is already spectating the room")
+	ErrNotSpectating        = errors.New("player is not spectating the room")
+	ErrInvalidSpectators    = errors.New("max spectators must be between 0 and 500 and the delay between 0 and 50 moves")
+)
+
+func (r *Room) IsSpectating(playerID int) bool {
+	return slices.ContainsFunc(r.Spectators, func(p *Player) bool {
+		return p.ID == playerID
+	})
+}
+
+// Spectate adds a player who is not seated to the spectators of the room.
+func (r *Room) Spectate(player *Player) error {
+	settings := r.GetSettings()
+	switch {
+	case r.GetState() == StateAbandoned:
+		return ErrRoomAbandoned
+	case !settings.SpectatorsAllowed:
+		return ErrSpectatorsNotAllowed
+	case r.HasPlayer(player.ID):
+		return ErrAlreadyInRoom
+	case r.IsSpectating(player.ID):
+		return ErrAlreadySpectating
+	case len(r.Spectators) >= settings.MaxSpectators:
+		return ErrSpectatorsFull
+	}
+
+	r.Spectators = append(r.Spectators, player)
+	return nil
+}
+
+func (r *Room) StopSpectating(playerID int) error {
+	if !r.IsSpectating(playerID) {
+		return ErrNotSpectating
+	}
+
+	r.removeSpectator(playerID)
+	return nil
+}
+
+func (r *Room) removeSpectator(playerID int) {
+	r.Spectators = slices.DeleteFunc(r.Spectators, func(p *Player) bool {
+		return p.ID == playerID
+	})
+}
+
+// ViewFor returns the room as seen by a viewer, 0 meaning an anonymous one.
+// Anyone who is not seated sees the game SpectatorDelay moves behind until it
+// ends, so live commentary cannot help the players.
+func (r *Room) ViewFor(viewerID int) *Room {
+	if r == nil || r.Game == nil || r.Game.IsOver() || r.HasPlayer(viewerID) {
+		return r
+	}
+	delay := r.GetSettings().SpectatorDelay
+	if delay <= 0 {
+		return r
+	}
+
+	view := *r
+	view.Game = r.Game.Replay(len(r.Game.Moves) - delay)
+	return &view
+}
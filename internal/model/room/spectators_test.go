@@ -0,0 +1,69 @@
+package room
+
+import (
+	"testing"
+
+	"github.com/moLIart/go-course/internal/model/game"
+)
+
+func TestViewForDelaysSpectators(t *testing.T) {
+	r := newFullRoom(t)
+	r.Settings.SpectatorDelay = 2
+	if err := r.Spectate(&Player{ID: 3, Name: "spectator"}); err != nil {
+		t.Fatalf("Spectate: %v", err)
+	}
+	if _, err := r.StartGame(1, ChoiceAssignment, game.Black); err != nil {
+		t.Fatalf("StartGame: %v", err)
+	}
+	moves := []struct {
+		playerID int
+		point    game.Point
+	}{
+		{1, game.Point{X: 3, Y: 3}},
+		{2, game.Point{X: 15, Y: 15}},
+		{1, game.Point{X: 15, Y: 3}},
+	}
+	for _, m := range moves {
+		if _, err := r.PlayMove(m.playerID, m.point); err != nil {
+			t.Fatalf("PlayMove(%d, %v): %v", m.playerID, m.point, err)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		viewerID  int
+		wantMoves int
+	}{
+		{name: "black player", viewerID: 1, wantMoves: 3},
+		{name: "white player", viewerID: 2, wantMoves: 3},
+		{name: "spectator", viewerID: 3, wantMoves: 1},
+		{name: "anonymous viewer", viewerID: 0, wantMoves: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := r.ViewFor(tt.viewerID).Game
+			if len(g.Moves) != tt.wantMoves {
+				t.Fatalf("sees %d moves, want %d", len(g.Moves), tt.wantMoves)
+			}
+			for i, m := range moves {
+				want := game.Empty
+				if i < tt.wantMoves {
+					want = g.Moves[i].Color
+				}
+				if got := g.Board.Get(m.point); got != want {
+					t.Errorf("stone at %v = %s, want %s", m.point, got, want)
+				}
+			}
+		})
+	}
+
+	if err := r.Resign(2); err != nil {
+		t.Fatalf("Resign: %v", err)
+	}
+	if g := r.ViewFor(3).Game; len(g.Moves) != len(moves) {
+		t.Errorf("spectator sees %d moves of the finished game, want %d", len(g.Moves), len(moves))
+	}
+	if r.Game.Board.Get(moves[2].point) != game.Black {
+		t.Errorf("delayed view changed the room's game")
+	}
+}
@@ -0,0 +1,115 @@
+package room
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+
+	"github.com/moLIart/go-course/internal/model/game"
+)
+
+// ColorAssignment selects how StartGame decides who plays black.
+type ColorAssignment string
+
+const (
+	// ChoiceAssignment lets the owner of an unrated room pick their color
+	// when starting the game. Simul hosts pick theirs in rated simuls too,
+	// since opponents agreed to it by joining the simul.
+	ChoiceAssignment ColorAssignment = "choice"
+	// AlternateAssignment gives black to whoever had white in the previous
+	// game of the room, falling back to nigiri for the first game.
+	AlternateAssignment ColorAssignment = "alternate"
+	// NigiriAssignment uses the result of a revealed commit-reveal nigiri
+	// that no game used yet. Without one, unrated rooms pick black at
+	// random while rated rooms refuse to start.
+	NigiriAssignment ColorAssignment = "nigiri"
+)
+
+var (
+	ErrRoomNotFull            = errors.New("room needs two players to start a game")
+	ErrGameInProgress         = errors.New("a game is already in progress in the room")
+	ErrInvalidColorAssignment = errors.New("invalid color assignment")
+	ErrColorChoiceForbidden   = errors.New("only the owner of an unrated room can choose colors")
+)
+
+func (a ColorAssignment) IsValid() bool {
+	switch a {
+	case ChoiceAssignment, AlternateAssignment, NigiriAssignment:
+		return true
+	}
+	return false
+}
+
+// StartGame creates the game of a full room. starterID is the seated player
+// asking to start and color is only used with ChoiceAssignment, where it is
+// the color the starter wants to play, for their whole team in pair go. The
+// room's settings are applied before opts.
+func (r *Room) StartGame(starterID int, assignment ColorAssignment, color game.CellState, opts ...game.GameOption) (*game.Game, error) {
+	if !r.IsFull() {
+		return nil, ErrRoomNotFull
+	}
+	if r.Game != nil && !r.Game.IsOver() {
+		return nil, ErrGameInProgress
+	}
+	if !CanTransition(r.GetState(), StatePlaying) {
+		return nil, fmt.Errorf("%w from %s to %s", ErrInvalidTransition, r.GetState(), StatePlaying)
+	}
+
+	starter := r.GetPlayerByID(starterID)
+	if starter == nil {
+		return nil, ErrNotInRoom
+	}
+
+	black, fromNigiri, err := r.pickBlack(starter, assignment, color)
+	if err != nil {
+		return nil, err
+	}
+	opts = append(r.GetSettings().GameOptions(), opts...)
+	g := game.NewGame(append(opts, r.teamOptions(black)...)...)
+	if fromNigiri {
+		r.Nigiri.GameID = g.ID
+		r.Nigiri.record(starterID, NigiriUsed, fmt.Sprintf("game=%d", g.ID))
+	}
+	r.SetGame(g)
+	return g, r.setState(StatePlaying)
+}
+
+// pickBlack returns the player of black and whether a nigiri decided it.
+func (r *Room) pickBlack(starter *Player, assignment ColorAssignment, color game.CellState) (*Player, bool, error) {
+	switch assignment {
+	case ChoiceAssignment:
+		if starter.ID != r.OwnerID || (r.GetSettings().Rated && r.SimulID == 0) {
+			return nil, false, ErrColorChoiceForbidden
+		}
+		switch color {
+		case game.Black:
+			return starter, false, nil
+		case game.White:
+			return r.GetOpponent(starter), false, nil
+		}
+		return nil, false, ErrInvalidColorAssignment
+	case AlternateAssignment:
+		if r.Game != nil {
+			if previousWhite := r.GetPlayerByID(r.Game.WhiteID); previousWhite != nil {
+				return previousWhite, false, nil
+			}
+			if previousBlack := r.GetPlayerByID(r.Game.BlackID); previousBlack != nil {
+				return r.GetOpponent(previousBlack), false, nil
+			}
+		}
+		return r.nigiriBlack()
+	case NigiriAssignment:
+		return r.nigiriBlack()
+	}
+	return nil, false, ErrInvalidColorAssignment
+}
+
+func (r *Room) nigiriBlack() (*Player, bool, error) {
+	if black := r.takeNigiriBlack(); black != nil {
+		return black, true, nil
+	}
+	if r.GetSettings().Rated {
+		return nil, false, ErrNigiriRequired
+	}
+	return r.Players[rand.IntN(2)], false, nil
+}
@@ -0,0 +1,155 @@
+package room
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/moLIart/go-course/internal/model/game"
+)
+
+// newFullRoom returns a room owned by player 1 with players 1 and 2 seated.
+func newFullRoom(t *testing.T) *Room {
+	t.Helper()
+	r := NewRoom("TEST-CODE")
+	r.OwnerID = 1
+	for _, p := range []*Player{{ID: 1, Name: "owner"}, {ID: 2, Name: "guest"}} {
+		if err := r.Join(p); err != nil {
+			t.Fatalf("Join(%d): %v", p.ID, err)
+		}
+	}
+	return r
+}
+
+func TestStartGameChoice(t *testing.T) {
+	tests := []struct {
+		name      string
+		starterID int
+		rated     bool
+		simul     bool
+		color     game.CellState
+		wantBlack int
+		wantErr   error
+	}{
+		{name: "owner takes black", starterID: 1, color: game.Black, wantBlack: 1},
+		{name: "owner takes white", starterID: 1, color: game.White, wantBlack: 2},
+		{name: "owner without color", starterID: 1, color: game.Empty, wantErr: ErrInvalidColorAssignment},
+		{name: "guest cannot choose", starterID: 2, color: game.Black, wantErr: ErrColorChoiceForbidden},
+		{name: "owner of rated room cannot choose", starterID: 1, rated: true, color: game.Black, wantErr: ErrColorChoiceForbidden},
+		{name: "simul host chooses in rated simul", starterID: 1, rated: true, simul: true, color: game.White, wantBlack: 2},
+		{name: "simul opponent cannot choose", starterID: 2, simul: true, color: game.Black, wantErr: ErrColorChoiceForbidden},
+		{name: "outsider", starterID: 3, color: game.Black, wantErr: ErrNotInRoom},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newFullRoom(t)
+			r.Settings.Rated = tt.rated
+			if tt.simul {
+				r.SimulID = 1
+			}
+
+			g, err := r.StartGame(tt.starterID, ChoiceAssignment, tt.color)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("StartGame() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				if r.Game != nil || r.GetState() != StateReady {
+					t.Fatalf("refused start changed the room: game %v, state %s", r.Game, r.GetState())
+				}
+				return
+			}
+			if g.BlackID != tt.wantBlack {
+				t.Errorf("BlackID = %d, want %d", g.BlackID, tt.wantBlack)
+			}
+		})
+	}
+}
+
+func TestStartGameAlternate(t *testing.T) {
+	r := newFullRoom(t)
+	if _, err := r.StartGame(1, ChoiceAssignment, game.Black); err != nil {
+		t.Fatalf("first game: %v", err)
+	}
+	if err := r.Resign(2); err != nil {
+		t.Fatalf("Resign: %v", err)
+	}
+
+	for i, wantBlack := range []int{2, 1, 2} {
+		// Either player may start the rematch, the colors only depend on the
+		// previous game.
+		g, err := r.StartGame(2-i%2, AlternateAssignment, game.Empty)
+		if err != nil {
+			t.Fatalf("rematch %d: %v", i, err)
+		}
+		if g.BlackID != wantBlack {
+			t.Fatalf("rematch %d: BlackID = %d, want %d", i, g.BlackID, wantBlack)
+		}
+		if err := r.Resign(1); err != nil {
+			t.Fatalf("Resign: %v", err)
+		}
+	}
+}
+
+func TestStartGameNigiri(t *testing.T) {
+	tests := []struct {
+		name      string
+		stones    int
+		guess     string
+		wantBlack int
+	}{
+		{name: "guesser calls odd correctly", stones: 7, guess: GuessOdd, wantBlack: 2},
+		{name: "guesser calls even correctly", stones: 12, guess: GuessEven, wantBlack: 2},
+		{name: "guesser is wrong", stones: 12, guess: GuessOdd, wantBlack: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newFullRoom(t)
+			playNigiri(t, r, 1, tt.stones, tt.guess)
+
+			g, err := r.StartGame(2, NigiriAssignment, game.Empty)
+			if err != nil {
+				t.Fatalf("StartGame: %v", err)
+			}
+			if g.BlackID != tt.wantBlack {
+				t.Errorf("BlackID = %d, want %d", g.BlackID, tt.wantBlack)
+			}
+		})
+	}
+}
+
+func TestStartGameDrawsWithoutNigiri(t *testing.T) {
+	for _, assignment := range []ColorAssignment{AlternateAssignment, NigiriAssignment} {
+		t.Run(string(assignment), func(t *testing.T) {
+			blacks := map[int]bool{}
+			for range 64 {
+				r := newFullRoom(t)
+				g, err := r.StartGame(1, assignment, game.Empty)
+				if err != nil {
+					t.Fatalf("StartGame: %v", err)
+				}
+				if g.BlackID+g.WhiteID != 3 || g.BlackID == g.WhiteID {
+					t.Fatalf("BlackID = %d, WhiteID = %d, want players 1 and 2", g.BlackID, g.WhiteID)
+				}
+				blacks[g.BlackID] = true
+			}
+			if len(blacks) != 2 {
+				t.Errorf("black went to %v in 64 draws, want both players", blacks)
+			}
+		})
+	}
+}
+
+// playNigiri runs a whole nigiri with holderID holding the stones and the
+// other player of the room guessing.
+func playNigiri(t *testing.T, r *Room, holderID, stones int, guess string) {
+	t.Helper()
+	const nonce = "f00d"
+	if err := r.CommitNigiri(holderID, NigiriCommitment(stones, nonce)); err != nil {
+		t.Fatalf("CommitNigiri: %v", err)
+	}
+	if err := r.GuessNigiri(r.Nigiri.GuesserID, guess); err != nil {
+		t.Fatalf("GuessNigiri: %v", err)
+	}
+	if err := r.RevealNigiri(holderID, stones, nonce); err != nil {
+		t.Fatalf("RevealNigiri: %v", err)
+	}
+}
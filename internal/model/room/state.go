@@ -0,0 +1,200 @@
+package room
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/moLIart/go-course/internal/model/game"
+)
+
+// State is the lifecycle stage of a room.
+type State string
+
+const (
+	// StateOpen rooms wait for players to take the free seats.
+	StateOpen State = "open"
+	// StateReady rooms have every seat taken and can start a game.
+	StateReady State = "ready"
+	// StatePlaying rooms have a game in progress.
+	StatePlaying State = "playing"
+	// StateScoring rooms have a game where both players passed and the
+	// score awaits their agreement.
+	StateScoring State = "scoring"
+	// StateFinished rooms hold a finished game and can start a rematch.
+	StateFinished State = "finished"
+	// StateAbandoned rooms were left during a game and accept nothing more.
+	StateAbandoned State = "abandoned"
+)
+
+var (
+	ErrInvalidTransition = errors.New("invalid room state transition")
+	ErrRoomAbandoned     = errors.New("room was abandoned")
+	ErrNoGame            = errors.New("no game is being played in the room")
+	ErrClockRunning      = errors.New("no player ran out of time")
+)
+
+var transitions = map[State][]State{
+	StateOpen:      {StateReady},
+	StateReady:     {StateOpen, StatePlaying},
+	StatePlaying:   {StateScoring, StateFinished, StateAbandoned},
+	StateScoring:   {StatePlaying, StateFinished, StateAbandoned},
+	StateFinished:  {StateOpen, StatePlaying},
+	StateAbandoned: {},
+}
+
+func (s State) IsValid() bool {
+	_, ok := transitions[s]
+	return ok
+}
+
+// CanTransition reports whether a room may move from one state to another.
+func CanTransition(from, to State) bool {
+	return slices.Contains(transitions[from], to)
+}
+
+// GetState returns the state of the room. Rooms stored before states existed
+// count as open.
+func (r *Room) GetState() State {
+	if r.State == "" {
+		return StateOpen
+	}
+	return r.State
+}
+
+func (r *Room) setState(to State) error {
+	from := r.GetState()
+	if from == to {
+		return nil
+	}
+	if !CanTransition(from, to) {
+		return fmt.Errorf("%w from %s to %s", ErrInvalidTransition, from, to)
+	}
+	r.State = to
+	return nil
+}
+
+// syncGameState moves the room along with the game it hosts.
+func (r *Room) syncGameState() error {
+	switch {
+	case r.Game.IsOver():
+		return r.setState(StateFinished)
+	case r.Game.IsScoring():
+		return r.setState(StateScoring)
+	default:
+		return r.setState(StatePlaying)
+	}
+}
+
+// afterGameAction syncs the room with its game after an action that returned
+// err. A player whose time ran out has lost, so the room still follows the
+// game in that case.
+func (r *Room) afterGameAction(err error) error {
+	if err != nil && !errors.Is(err, game.ErrTimeout) {
+		return err
+	}
+	if syncErr := r.syncGameState(); syncErr != nil {
+		return syncErr
+	}
+	return err
+}
+
+// isPlaying reports whether the room hosts a game that has not ended.
+func (r *Room) isPlaying() bool {
+	state := r.GetState()
+	return state == StatePlaying || state == StateScoring
+}
+
+// seatColor returns the color played by a seated player in the room's game.
+func (r *Room) seatColor(playerID int) (game.CellState, error) {
+	if r.Game == nil || !r.isPlaying() {
+		return game.Empty, ErrNoGame
+	}
+	color := r.Game.GetColor(playerID)
+	if color == game.Empty {
+		return game.Empty, ErrNotSeated
+	}
+	return color, nil
+}
+
+// PlayMove places a stone for a seated player.
+func (r *Room) PlayMove(playerID int, p game.Point) ([]game.Point, error) {
+	if _, err := r.seatColor(playerID); err != nil {
+		return nil, err
+	}
+
+	captured, err := r.Game.Play(playerID, p)
+	return captured, r.afterGameAction(err)
+}
+
+// Pass passes the turn of a seated player, moving the room to scoring after
+// two consecutive passes.
+func (r *Room) Pass(playerID int) error {
+	if _, err := r.seatColor(playerID); err != nil {
+		return err
+	}
+
+	return r.afterGameAction(r.Game.Pass(playerID))
+}
+
+func (r *Room) Resign(playerID int) error {
+	color, err := r.seatColor(playerID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.Game.Resign(color); err != nil {
+		return err
+	}
+	return r.syncGameState()
+}
+
+// AcceptScore records a player's agreement with the count, finishing the
+// game once both agree.
+func (r *Room) AcceptScore(playerID int) error {
+	color, err := r.seatColor(playerID)
+	if err != nil {
+		return err
+	}
+
+	if err := r.Game.AcceptScore(color); err != nil {
+		return err
+	}
+	return r.syncGameState()
+}
+
+// FlagTimeout ends the game if the player to move ran out of time by now.
+func (r *Room) FlagTimeout(now time.Time) error {
+	if r.Game == nil || !r.isPlaying() {
+		return ErrNoGame
+	}
+	if !r.Game.CheckTimeout(now) {
+		return ErrClockRunning
+	}
+	return r.syncGameState()
+}
+
+// Adjudicate ends the game of the room with the result decided by an admin.
+func (r *Room) Adjudicate(winner game.CellState) error {
+	if r.Game == nil || !r.isPlaying() {
+		return ErrNoGame
+	}
+
+	if err := r.Game.Adjudicate(winner); err != nil {
+		return err
+	}
+	return r.syncGameState()
+}
+
+// ResumePlay returns a room from scoring to playing.
+func (r *Room) ResumePlay(playerID int) error {
+	if _, err := r.seatColor(playerID); err != nil {
+		return err
+	}
+
+	if err := r.Game.ResumePlay(); err != nil {
+		return err
+	}
+	return r.syncGameState()
+}
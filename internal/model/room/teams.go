@@ -0,0 +1,82 @@
+package room
+
+import (
+	"errors"
+
+	"github.com/moLIart/go-course/internal/model/game"
+)
+
+// MaxSeats is the number of seats of a pair go room. Other rooms only use
+// the first two.
+const MaxSeats = 4
+
+var ErrSeatsTaken = errors.New("pair go cannot be turned off while partners are seated")
+
+// SeatCount returns the number of seats players can take in the room.
+func (r *Room) SeatCount() int {
+	if r.GetSettings().PairGo {
+		return MaxSeats
+	}
+	return 2
+}
+
+// GetTeam returns the seats of a team in playing order. Team 0 sits in
+// seats 0 and 2 and team 1 in seats 1 and 3, so in rooms without pair go
+// each team is a single player.
+func (r *Room) GetTeam(team int) []*Player {
+	var players []*Player
+	for i := team; i < r.SeatCount(); i += 2 {
+		players = append(players, r.Players[i])
+	}
+	return players
+}
+
+// teamOf returns the team of a seated player, or -1.
+func (r *Room) teamOf(playerID int) int {
+	for i, player := range r.Players {
+		if player != nil && player.ID == playerID {
+			return i % 2
+		}
+	}
+	return -1
+}
+
+// GetTeamByColor returns the seated players holding the color in the room's
+// game in their playing order.
+func (r *Room) GetTeamByColor(color game.CellState) []*Player {
+	if r.Game == nil {
+		return nil
+	}
+
+	var players []*Player
+	for _, id := range r.Game.GetTeam(color) {
+		if player := r.GetPlayerByID(id); player != nil {
+			players = append(players, player)
+		}
+	}
+	return players
+}
+
+// teamOptions returns the game options seating the team of black against the
+// other one. Team members play in seat order.
+func (r *Room) teamOptions(black *Player) []game.GameOption {
+	blackTeam := r.GetTeam(r.teamOf(black.ID))
+	whiteTeam := r.GetTeam(1 - r.teamOf(black.ID))
+	opts := []game.GameOption{game.WithPlayers(blackTeam[0].ID, whiteTeam[0].ID)}
+	if len(blackTeam) > 1 {
+		opts = append(opts, game.WithPartners(blackTeam[1].ID, whiteTeam[1].ID))
+	}
+	return opts
+}
+
+// syncSeatState moves the room between open and ready after the number of
+// seats changed.
+func (r *Room) syncSeatState() error {
+	switch state := r.GetState(); {
+	case state == StateOpen && r.IsFull():
+		return r.setState(StateReady)
+	case state == StateReady && !r.IsFull():
+		return r.setState(StateOpen)
+	}
+	return nil
+}
@@ -0,0 +1,197 @@
+package simul
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/moLIart/go-course/internal/model/game"
+	"github.com/moLIart/go-course/internal/model/room"
+)
+
+// MaxOpponents is the largest number of boards a host can play at once.
+const MaxOpponents = 30
+
+// State is the lifecycle stage of a simul.
+type State string
+
+const (
+	// StateOpen simuls accept opponents.
+	StateOpen State = "open"
+	// StateRunning simuls have a room with a game on every board.
+	StateRunning State = "running"
+	// StateFinished simuls have every game over. It is never stored but
+	// derived from the boards.
+	StateFinished State = "finished"
+)
+
+var (
+	ErrInvalidMaxOpponents = errors.New("max opponents must be between 1 and 30")
+	ErrInvalidHostColor    = errors.New(`host color must be "black" or "white"`)
+	ErrPairGoSimul         = errors.New("simul boards cannot use pair go")
+	ErrSimulFull           = errors.New("simul has no boards left")
+	ErrSimulStarted        = errors.New("simul has already started")
+	ErrHostCannotJoin      = errors.New("host cannot join their own simul")
+	ErrAlreadyJoined       = errors.New("player already joined the simul")
+	ErrNotJoined           = errors.New("player did not join the simul")
+	ErrNotHost             = errors.New("only the host can do this")
+	ErrNoOpponents         = errors.New("simul needs at least one opponent to start")
+)
+
+// Board is one game of the simul, played in its own room.
+type Board struct {
+	RoomID     int `json:"room_id" bson:"room_id"`
+	OpponentID int `json:"opponent_id" bson:"opponent_id"`
+}
+
+// Simul is a simultaneous exhibition: one host plays every opponent at once,
+// each on a board of its own that follows the normal rules of a room.
+type Simul struct {
+	ID           int            `json:"id" bson:"_id"`
+	Name         string         `json:"name" bson:"name"`
+	HostID       int            `json:"host_id" bson:"host_id"`
+	HostColor    game.CellState `json:"host_color" bson:"host_color"`
+	MaxOpponents int            `json:"max_opponents" bson:"max_opponents"`
+	Settings     room.Settings  `json:"settings" bson:"settings"`
+	State        State          `json:"state" bson:"state"`
+	Opponents    []*room.Player `json:"opponents" bson:"opponents"`
+	Boards       []Board        `json:"boards,omitempty" bson:"boards,omitempty"`
+	CreatedAt    time.Time      `json:"created_at" bson:"created_at"`
+	Version      int            `json:"-" bson:"version"`
+}
+
+func NewSimul(name string, hostID int, maxOpponents int, hostColor game.CellState, settings room.Settings) *Simul {
+	return &Simul{
+		Name:         name,
+		HostID:       hostID,
+		HostColor:    hostColor,
+		MaxOpponents: maxOpponents,
+		Settings:     settings,
+		State:        StateOpen,
+		CreatedAt:    time.Now().UTC(),
+	}
+}
+
+func (s *Simul) Validate() error {
+	if s.MaxOpponents < 1 || s.MaxOpponents > MaxOpponents {
+		return ErrInvalidMaxOpponents
+	}
+	if s.HostColor != game.Black && s.HostColor != game.White {
+		return ErrInvalidHostColor
+	}
+	if s.Settings.PairGo {
+		return ErrPairGoSimul
+	}
+	return s.Settings.Validate()
+}
+
+func (s *Simul) HasOpponent(playerID int) bool {
+	return slices.ContainsFunc(s.Opponents, func(p *room.Player) bool {
+		return p.ID == playerID
+	})
+}
+
+// Join adds an opponent to an open simul.
+func (s *Simul) Join(player *room.Player) error {
+	switch {
+	case s.State != StateOpen:
+		return ErrSimulStarted
+	case player.ID == s.HostID:
+		return ErrHostCannotJoin
+	case s.HasOpponent(player.ID):
+		return ErrAlreadyJoined
+	case len(s.Opponents) >= s.MaxOpponents:
+		return ErrSimulFull
+	}
+
+	s.Opponents = append(s.Opponents, player)
+	return nil
+}
+
+// Leave removes an opponent before the simul starts. Once it runs, opponents
+// leave their board's room instead.
+func (s *Simul) Leave(playerID int) error {
+	if s.State != StateOpen {
+		return ErrSimulStarted
+	}
+	if !s.HasOpponent(playerID) {
+		return ErrNotJoined
+	}
+
+	s.Opponents = slices.DeleteFunc(s.Opponents, func(p *room.Player) bool {
+		return p.ID == playerID
+	})
+	return nil
+}
+
+// Start marks the simul as running. The rooms of its boards, created by
+// NewBoardRoom, have to be stored with it.
+func (s *Simul) Start(hostID int) error {
+	switch {
+	case hostID != s.HostID:
+		return ErrNotHost
+	case s.State != StateOpen:
+		return ErrSimulStarted
+	case len(s.Opponents) == 0:
+		return ErrNoOpponents
+	}
+
+	s.State = StateRunning
+	return nil
+}
+
+// NewBoardRoom seats the host and an opponent in a new room with the
+// settings of the simul and starts their game. opts are passed to the game.
+func (s *Simul) NewBoardRoom(host, opponent *room.Player, opts ...game.GameOption) (*room.Room, error) {
+	r := room.NewRoom("")
+	r.OwnerID = s.HostID
+	r.SimulID = s.ID
+	r.Settings = s.Settings
+	if err := r.Join(host); err != nil {
+		return nil, err
+	}
+	if err := r.Join(opponent); err != nil {
+		return nil, err
+	}
+	if _, err := r.StartGame(host.ID, room.ChoiceAssignment, s.HostColor, opts...); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetState returns the state of the simul given the rooms of its boards.
+func (s *Simul) GetState(rooms []*room.Room) State {
+	if s.State != StateRunning || len(rooms) == 0 {
+		return s.State
+	}
+	for _, r := range rooms {
+		if r.Game != nil && !r.Game.IsOver() {
+			return StateRunning
+		}
+	}
+	return StateFinished
+}
+
+// Queue returns the rooms where it is the host's turn, the one waiting
+// longest for the host first.
+func (s *Simul) Queue(rooms []*room.Room) []*room.Room {
+	var queue []*room.Room
+	for _, r := range rooms {
+		if r.Game != nil && !r.Game.IsOver() && !r.Game.IsScoring() && r.Game.GetCurrentPlayerID() == s.HostID {
+			queue = append(queue, r)
+		}
+	}
+
+	slices.SortStableFunc(queue, func(a, b *room.Room) int {
+		return waitingSince(a.Game).Compare(waitingSince(b.Game))
+	})
+	return queue
+}
+
+// waitingSince returns when the player to move got the turn.
+func waitingSince(g *game.Game) time.Time {
+	if len(g.Moves) == 0 {
+		return time.Time{}
+	}
+	return g.Moves[len(g.Moves)-1].At
+}
@@ -0,0 +1,234 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/moLIart/go-course/internal/jwks"
+)
+
+// mockCodeTTL is how long the codes of the mock issuer can be exchanged.
+const mockCodeTTL = time.Minute
+
+// mockKeyID is the key ID the mock issuer signs ID tokens with.
+const mockKeyID = "mock"
+
+// MockIssuer is an OpenID provider for local development. It logs in anyone
+// without asking for a password, as the subject given in the "login_hint"
+// parameter, and checks the client, redirect URL and PKCE verifier like a
+// real provider would.
+type MockIssuer struct {
+	issuer       string
+	clientID     string
+	clientSecret string
+	key          *rsa.PrivateKey
+
+	mu    sync.Mutex
+	codes map[string]mockGrant
+}
+
+// mockGrant is what a code of the mock issuer was issued for.
+type mockGrant struct {
+	redirectURI string
+	challenge   string
+	nonce       string
+	subject     string
+	expiresAt   time.Time
+}
+
+// NewMockIssuer creates an issuer at the URL it is served from, for one
+// client. Clients without a secret are public clients.
+func NewMockIssuer(issuer, clientID, clientSecret string) (*MockIssuer, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, err
+	}
+	return &MockIssuer{
+		issuer:       strings.TrimSuffix(issuer, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		key:          key,
+		codes:        map[string]mockGrant{},
+	}, nil
+}
+
+func (m *MockIssuer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	switch r.URL.Path {
+	case "/.well-known/openid-configuration":
+		m.discovery(w)
+	case "/jwks":
+		m.keys(w)
+	case "/authorize":
+		m.authorize(w, r)
+	case "/token":
+		m.token(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (m *MockIssuer) discovery(w http.ResponseWriter) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                m.issuer,
+		"authorization_endpoint":                m.issuer + "/authorize",
+		"token_endpoint":                        m.issuer + "/token",
+		"jwks_uri":                              m.issuer + "/jwks",
+		"response_types_supported":              []string{"code"},
+		"subject_types_supported":               []string{"public"},
+		"id_token_signing_alg_values_supported": []string{jwt.SigningMethodRS256.Alg()},
+		"code_challenge_methods_supported":      []string{"S256"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic", "client_secret_post", "none"},
+	})
+}
+
+func (m *MockIssuer) keys(w http.ResponseWriter) {
+	payload, err := jwks.Marshal(mockKeyID, &m.key.PublicKey)
+	if err != nil {
+		http.Error(w, "Failed to encode keys", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(payload)
+}
+
+// authorize logs the player in at once and sends them back with a code.
+func (m *MockIssuer) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	redirectURI, err := url.Parse(query.Get("redirect_uri"))
+	switch {
+	case query.Get("client_id") != m.clientID:
+		http.Error(w, "Unknown client_id", http.StatusBadRequest)
+		return
+	case err != nil || !redirectURI.IsAbs():
+		http.Error(w, "Invalid redirect_uri", http.StatusBadRequest)
+		return
+	}
+
+	redirect := func(params url.Values) {
+		params.Set("state", query.Get("state"))
+		redirectURI.RawQuery = params.Encode()
+		http.Redirect(w, r, redirectURI.String(), http.StatusFound)
+	}
+	switch {
+	case query.Get("response_type") != "code":
+		redirect(url.Values{"error": {"unsupported_response_type"}})
+		return
+	case !strings.Contains(" "+query.Get("scope")+" ", " openid "):
+		redirect(url.Values{"error": {"invalid_scope"}})
+		return
+	case query.Get("code_challenge_method") != "S256" || query.Get("code_challenge") == "":
+		redirect(url.Values{"error": {"invalid_request"}, "error_description": {"PKCE with S256 is required"}})
+		return
+	}
+
+	subject := strings.TrimSpace(query.Get("login_hint"))
+	if subject == "" {
+		subject = "mock-user"
+	}
+	code := mockRandomString()
+
+	m.mu.Lock()
+	m.codes[code] = mockGrant{
+		redirectURI: query.Get("redirect_uri"),
+		challenge:   query.Get("code_challenge"),
+		nonce:       query.Get("nonce"),
+		subject:     subject,
+		expiresAt:   time.Now().Add(mockCodeTTL),
+	}
+	m.mu.Unlock()
+
+	redirect(url.Values{"code": {code}})
+}
+
+// token exchanges a code for an ID token. Each code can be exchanged once.
+func (m *MockIssuer) token(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost || r.ParseForm() != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	if !m.authenticateClient(r) {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	if r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	m.mu.Lock()
+	grant, ok := m.codes[code]
+	delete(m.codes, code)
+	m.mu.Unlock()
+
+	verifier := r.PostForm.Get("code_verifier")
+	if !ok || time.Now().After(grant.expiresAt) ||
+		grant.redirectURI != r.PostForm.Get("redirect_uri") ||
+		subtle.ConstantTimeCompare([]byte(codeChallenge(verifier)), []byte(grant.challenge)) != 1 {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, idTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    m.issuer,
+			Subject:   grant.subject,
+			Audience:  jwt.ClaimStrings{m.clientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		},
+		Nonce:             grant.nonce,
+		Email:             grant.subject + "@example.com",
+		EmailVerified:     true,
+		Name:              grant.subject,
+		PreferredUsername: grant.subject,
+	})
+	token.Header["kid"] = mockKeyID
+	idToken, err := token.SignedString(m.key)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": mockRandomString(),
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idToken,
+	})
+}
+
+// authenticateClient accepts the client secret in the Authorization header or
+// in the form, and no secret at all from public clients.
+func (m *MockIssuer) authenticateClient(r *http.Request) bool {
+	clientID, secret := r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	if user, password, ok := r.BasicAuth(); ok {
+		clientID, _ = url.QueryUnescape(user)
+		secret, _ = url.QueryUnescape(password)
+	}
+	return clientID == m.clientID && subtle.ConstantTimeCompare([]byte(secret), []byte(m.clientSecret)) == 1
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func mockRandomString() string {
+	b := make([]byte, 24)
+	rand.Read(b)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
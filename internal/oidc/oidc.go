@@ -0,0 +1,386 @@
+// Package oidc logs players in with OpenID Connect providers using the
+// authorization code flow with PKCE.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/moLIart/go-course/internal/jwks"
+)
+
+// clockSkew is how far the clocks of providers may be off when checking the
+// "exp", "nbf" and "iat" claims of ID tokens.
+const clockSkew = 30 * time.Second
+
+// keysRefreshInterval is how often the keys of a provider may be fetched
+// again when a token is signed with a key we do not know.
+const keysRefreshInterval = time.Minute
+
+// maxResponseSize bounds what is read from providers.
+const maxResponseSize = 1 << 20
+
+var (
+	ErrUnknownProvider = errors.New("unknown identity provider")
+	ErrInvalidIDToken  = errors.New("invalid ID token")
+	ErrCodeRefused     = errors.New("provider refused the authorization code")
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9-]{1,32}$`)
+
+// Config configures a provider.
+type Config struct {
+	// Name identifies the provider in routes, as in /auth/oidc/{name}/login.
+	Name      string
+	IssuerURL string
+	ClientID  string
+	// ClientSecret is empty for public clients, which only rely on PKCE.
+	ClientSecret string
+	// RedirectURL is the callback route of the provider as registered with it.
+	RedirectURL string
+	// Scopes are requested along with "openid".
+	Scopes []string
+}
+
+// Claims are what an ID token tells about the player.
+type Claims struct {
+	Issuer            string
+	Subject           string
+	Email             string
+	EmailVerified     bool
+	Name              string
+	PreferredUsername string
+}
+
+// idTokenClaims are the claims of ID tokens we read.
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	Nonce             string `json:"nonce"`
+	AuthorizedParty   string `json:"azp"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	Name              string `json:"name"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// metadata are the endpoints of a provider, from its discovery document.
+type metadata struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider is a configured identity provider. Its endpoints and keys are
+// discovered when first needed, so that a provider being down does not keep
+// the server from starting.
+type Provider struct {
+	cfg    Config
+	client *http.Client
+
+	mu            sync.Mutex
+	metadata      *metadata
+	keys          map[string]jwks.Key
+	keysFetchedAt time.Time
+}
+
+var (
+	providersMu sync.RWMutex
+	providers   = map[string]*Provider{}
+)
+
+// Configure replaces the providers players may log in with.
+func Configure(configs []Config) error {
+	configured := map[string]*Provider{}
+	for _, cfg := range configs {
+		if !namePattern.MatchString(cfg.Name) {
+			return fmt.Errorf("invalid provider name %q, expected lower case letters, digits or dashes", cfg.Name)
+		}
+		if _, ok := configured[cfg.Name]; ok {
+			return fmt.Errorf("provider %q is configured twice", cfg.Name)
+		}
+		if cfg.IssuerURL == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+			return fmt.Errorf("provider %q needs an issuer, a client ID and a redirect URL", cfg.Name)
+		}
+		configured[cfg.Name] = &Provider{cfg: cfg, client: &http.Client{Timeout: 10 * time.Second}}
+	}
+
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	providers = configured
+	return nil
+}
+
+// GetProvider returns the provider configured under the name, or
+// ErrUnknownProvider.
+func GetProvider(name string) (*Provider, error) {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	p, ok := providers[name]
+	if !ok {
+		return nil, ErrUnknownProvider
+	}
+	return p, nil
+}
+
+// ProviderNames returns the names of the configured providers in order.
+func ProviderNames() []string {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	names := make([]string, 0, len(providers))
+	for name := range providers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+func (p *Provider) Name() string {
+	return p.cfg.Name
+}
+
+// AuthCodeURL returns where to send the player to log in. The provider sends
+// them back to the redirect URL with the state and a code that only the
+// holder of the verifier can exchange. The login hint, if any, suggests the
+// account to log in with.
+func (p *Provider) AuthCodeURL(ctx context.Context, state, nonce, verifier, loginHint string) (string, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	query := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {p.cfg.ClientID},
+		"redirect_uri":          {p.cfg.RedirectURL},
+		"scope":                 {p.scope()},
+		"state":                 {state},
+		"nonce":                 {nonce},
+		"code_challenge":        {codeChallenge(verifier)},
+		"code_challenge_method": {"S256"},
+	}
+	if loginHint != "" {
+		query.Set("login_hint", loginHint)
+	}
+	authURL, err := url.Parse(md.AuthorizationEndpoint)
+	if err != nil {
+		return "", err
+	}
+	if authURL.RawQuery != "" {
+		authURL.RawQuery += "&"
+	}
+	authURL.RawQuery += query.Encode()
+	return authURL.String(), nil
+}
+
+// Exchange trades the code the player came back with for an ID token and
+// returns its verified claims.
+func (p *Provider) Exchange(ctx context.Context, code, verifier, nonce string) (*Claims, error) {
+	md, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{
+		"grant_type":    {"authorization_code"},
+		"code":          {code},
+		"redirect_uri":  {p.cfg.RedirectURL},
+		"client_id":     {p.cfg.ClientID},
+		"code_verifier": {verifier},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, md.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if p.cfg.ClientSecret != "" {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	var tokens struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.do(req, &tokens)
+	if err != nil {
+		return nil, err
+	}
+	if status >= http.StatusBadRequest && status < http.StatusInternalServerError {
+		return nil, fmt.Errorf("%w: %s %s", ErrCodeRefused, tokens.Error, tokens.ErrorDescription)
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("exchanging the code with provider %s: status %d", p.cfg.Name, status)
+	}
+	if tokens.IDToken == "" {
+		return nil, fmt.Errorf("%w: provider %s sent no ID token", ErrInvalidIDToken, p.cfg.Name)
+	}
+	return p.verifyIDToken(ctx, md, tokens.IDToken, nonce)
+}
+
+// verifyIDToken checks the signature, issuer, audience, validity period and
+// nonce of an ID token.
+func (p *Provider) verifyIDToken(ctx context.Context, md *metadata, idToken, nonce string) (*Claims, error) {
+	keyFunc := func(token *jwt.Token) (any, error) {
+		key, err := p.key(ctx, md, token)
+		if err != nil {
+			return nil, err
+		}
+		if token.Method.Alg() != key.Alg {
+			return nil, jwt.ErrTokenSignatureInvalid
+		}
+		return key.Key, nil
+	}
+
+	token, err := jwt.ParseWithClaims(idToken, &idTokenClaims{}, keyFunc,
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithIssuer(md.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidIDToken, err)
+	}
+
+	claims := token.Claims.(*idTokenClaims)
+	switch {
+	case claims.Subject == "":
+		return nil, fmt.Errorf("%w: missing sub claim", ErrInvalidIDToken)
+	case claims.Nonce != nonce:
+		return nil, fmt.Errorf("%w: nonce does not match", ErrInvalidIDToken)
+	case len(claims.Audience) > 1 && claims.AuthorizedParty != p.cfg.ClientID:
+		return nil, fmt.Errorf("%w: token was issued to %q", ErrInvalidIDToken, claims.AuthorizedParty)
+	}
+
+	return &Claims{
+		Issuer:            claims.Issuer,
+		Subject:           claims.Subject,
+		Email:             claims.Email,
+		EmailVerified:     claims.EmailVerified,
+		Name:              claims.Name,
+		PreferredUsername: claims.PreferredUsername,
+	}, nil
+}
+
+// key returns the key of the provider a token is signed with, fetching the
+// keys again when the provider may have rotated them. Tokens without a "kid"
+// header are accepted from providers publishing a single key.
+func (p *Provider) key(ctx context.Context, md *metadata, token *jwt.Token) (jwks.Key, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, ok := p.keys[kid]; !ok && time.Since(p.keysFetchedAt) >= keysRefreshInterval {
+		keys, err := p.fetchKeys(ctx, md)
+		if err != nil {
+			return jwks.Key{}, err
+		}
+		p.keys = keys
+		p.keysFetchedAt = time.Now()
+	}
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+	if kid == "" && len(p.keys) == 1 {
+		for _, key := range p.keys {
+			return key, nil
+		}
+	}
+	return jwks.Key{}, fmt.Errorf("unknown key %q of provider %s", kid, p.cfg.Name)
+}
+
+func (p *Provider) fetchKeys(ctx context.Context, md *metadata) (map[string]jwks.Key, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, md.JWKSURI, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var payload json.RawMessage
+	status, err := p.do(req, &payload)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("fetching the keys of provider %s: status %d", p.cfg.Name, status)
+	}
+
+	keys, err := jwks.Parse(payload)
+	if err != nil {
+		return nil, fmt.Errorf("invalid keys of provider %s: %w", p.cfg.Name, err)
+	}
+	return keys, nil
+}
+
+// discover fetches the endpoints of the provider once. Its issuer must be
+// exactly the configured one, so that tokens of another issuer are refused.
+func (p *Provider) discover(ctx context.Context) (*metadata, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.metadata != nil {
+		return p.metadata, nil
+	}
+
+	discoveryURL := strings.TrimSuffix(p.cfg.IssuerURL, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, discoveryURL, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var md metadata
+	status, err := p.do(req, &md)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case status != http.StatusOK:
+		return nil, fmt.Errorf("discovering provider %s: status %d", p.cfg.Name, status)
+	case md.Issuer != p.cfg.IssuerURL:
+		return nil, fmt.Errorf("provider %s claims to be issuer %q instead of %q", p.cfg.Name, md.Issuer, p.cfg.IssuerURL)
+	case md.AuthorizationEndpoint == "" || md.TokenEndpoint == "" || md.JWKSURI == "":
+		return nil, fmt.Errorf("provider %s is missing endpoints", p.cfg.Name)
+	}
+
+	p.metadata = &md
+	return p.metadata, nil
+}
+
+// do sends the request and decodes the JSON response into v, whatever its
+// status, which is returned.
+func (p *Provider) do(req *http.Request, v any) (int, error) {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(v); err != nil {
+		return resp.StatusCode, fmt.Errorf("invalid response of provider %s: %w", p.cfg.Name, err)
+	}
+	return resp.StatusCode, nil
+}
+
+func (p *Provider) scope() string {
+	scopes := []string{"openid"}
+	for _, scope := range p.cfg.Scopes {
+		if scope != "" && !slices.Contains(scopes, scope) {
+			scopes = append(scopes, scope)
+		}
+	}
+	return strings.Join(scopes, " ")
+}
@@ -0,0 +1,14 @@
+package oidc
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+)
+
+// codeChallenge returns the S256 challenge of a PKCE code verifier, which is
+// sent when the login starts while the verifier is only sent to exchange the
+// code, so that a stolen code is useless.
+func codeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
@@ -0,0 +1,101 @@
+package realtime
+
+import (
+	"errors"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/moLIart/go-course/internal/events"
+	"github.com/moLIart/go-course/internal/model/game"
+	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+// clockInterval is how often the clocks of watched rooms are published.
+const clockInterval = time.Second
+
+var (
+	watchedMu   sync.Mutex
+	watched     = map[int]int{}
+	startTicker sync.Once
+)
+
+// watch makes the clock ticker publish the clock of a room until the
+// returned function is called. Rooms are ticked while anyone watches them.
+func watch(roomID int) func() {
+	startTicker.Do(func() {
+		go tickClocks()
+	})
+
+	watchedMu.Lock()
+	watched[roomID]++
+	watchedMu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			watchedMu.Lock()
+			defer watchedMu.Unlock()
+			if watched[roomID]--; watched[roomID] <= 0 {
+				delete(watched, roomID)
+			}
+		})
+	}
+}
+
+func tickClocks() {
+	ticker := time.NewTicker(clockInterval)
+	defer ticker.Stop()
+
+	for now := range ticker.C {
+		watchedMu.Lock()
+		roomIDs := make([]int, 0, len(watched))
+		for id := range watched {
+			roomIDs = append(roomIDs, id)
+		}
+		watchedMu.Unlock()
+
+		for _, id := range roomIDs {
+			tickClock(id, now)
+		}
+	}
+}
+
+// tickClock publishes the clock of a room with a timed game in play, or
+// finishes the game if the player to move ran out of time.
+func tickClock(roomID int, now time.Time) {
+	r, err := repository.GetRoomByID(roomID)
+	if err != nil {
+		log.Printf("Failed to load room %d for its clock: %v", roomID, err)
+		return
+	}
+	if r == nil || r.Game == nil || r.Game.Clock == nil || r.GetState() != room.StatePlaying {
+		return
+	}
+
+	if r.Game.CheckTimeout(now) {
+		_, err := repository.FlagTimeout(roomID)
+		// Another instance may have flagged it first.
+		if err != nil && !errors.Is(err, game.ErrTimeout) && !errors.Is(err, room.ErrClockRunning) && !errors.Is(err, room.ErrNoGame) {
+			log.Printf("Failed to flag timeout in room %d: %v", roomID, err)
+		}
+		return
+	}
+
+	blackRemaining, blackPeriods := r.Game.RemainingTime(game.Black, now)
+	whiteRemaining, whitePeriods := r.Game.RemainingTime(game.White, now)
+	events.PublishLocal(events.Event{
+		Type:   events.ClockTick,
+		RoomID: roomID,
+		GameID: r.Game.ID,
+		Data: events.Clock{
+			CurrentTurn:      r.Game.GetCurrentTurn().String(),
+			BlackRemainingMs: blackRemaining.Milliseconds(),
+			WhiteRemainingMs: whiteRemaining.Milliseconds(),
+			BlackPeriods:     blackPeriods,
+			WhitePeriods:     whitePeriods,
+		},
+		Time: now.UTC(),
+	})
+}
@@ -0,0 +1,213 @@
+package realtime
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/gorilla/websocket"
+
+	"github.com/moLIart/go-course/internal/events"
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/model/chat"
+	"github.com/moLIart/go-course/internal/model/game"
+	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+// Message types only sent over WebSocket.
+const (
+	// RoomSnapshot is the first message of a connection and carries the room
+	// as returned by GET /rooms/:id.
+	RoomSnapshot = "room.snapshot"
+	// CommandFailed answers a command that was refused.
+	CommandFailed = "command.error"
+)
+
+// Command types. Moves, passes and resignations are accepted from seated
+// players, chat messages from seated players and spectators.
+const (
+	CommandMove   = "move"
+	CommandPass   = "pass"
+	CommandResign = "resign"
+	CommandChat   = "chat"
+)
+
+const (
+	writeWait      = 10 * time.Second
+	pongWait       = 60 * time.Second
+	pingPeriod     = pongWait * 9 / 10
+	maxCommandSize = 4096
+)
+
+var (
+	ErrAnonymous = errors.New("token does not identify a player")
+	ErrReadOnly  = errors.New("the API key does not allow playing")
+)
+
+// Command is a message sent by a client.
+type Command struct {
+	Type    string       `json:"type"`
+	X       int          `json:"x"`
+	Y       int          `json:"y"`
+	Channel chat.Channel `json:"channel,omitempty"`
+	Text    string       `json:"text,omitempty"`
+}
+
+// CommandError is the data of CommandFailed messages.
+type CommandError struct {
+	Command string `json:"command"`
+	Error   string `json:"error"`
+}
+
+// ServeRoom pushes the events of a room to the connection and runs the
+// commands it receives on behalf of the principal, whose PlayerID is 0 for
+// anonymous viewers. Bots whose API key lacks the play scope only watch.
+// snapshot builds the room as the viewer sees it and is sent first. ServeRoom
+// returns once the connection is closed.
+func ServeRoom(conn *websocket.Conn, roomID int, principal middlewares.Principal, snapshot func() (any, error)) {
+	defer conn.Close()
+
+	events, unsubscribe := events.Subscribe()
+	defer unsubscribe()
+	defer watch(roomID)()
+
+	c := &roomConn{roomFeed: newRoomFeed(roomID, principal.PlayerID), principal: principal, conn: conn, out: make(chan any, 16)}
+	data, err := snapshot()
+	if err != nil {
+		log.Printf("Failed to build room %d snapshot: %v", roomID, err)
+		return
+	}
+	c.out <- eventOf(RoomSnapshot, roomID, data)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.readCommands()
+	}()
+	c.writeEvents(events, done)
+}
+
+type roomConn struct {
+	*roomFeed
+	principal middlewares.Principal
+	conn      *websocket.Conn
+	out       chan any
+}
+
+func (c *roomConn) writeEvents(subscription <-chan events.Event, done <-chan struct{}) {
+	ping := time.NewTicker(pingPeriod)
+	defer ping.Stop()
+
+	for {
+		select {
+		case <-done:
+			return
+		case msg := <-c.out:
+			if !c.write(msg) {
+				return
+			}
+		case e, ok := <-subscription:
+			if !ok {
+				return
+			}
+			if e.RoomID != c.roomID {
+				continue
+			}
+			for _, msg := range c.visibleEvents(e) {
+				if !c.write(msg) {
+					return
+				}
+			}
+		case <-ping.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (c *roomConn) write(msg any) bool {
+	c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+	return c.conn.WriteJSON(msg) == nil
+}
+
+func (c *roomConn) readCommands() {
+	c.conn.SetReadLimit(maxCommandSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		return c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	})
+
+	for {
+		_, payload, err := c.conn.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var cmd Command
+		if err := json.Unmarshal(payload, &cmd); err != nil {
+			c.reply(CommandError{Error: "invalid command"})
+			continue
+		}
+		if err := c.run(cmd); err != nil {
+			c.reply(CommandError{Command: cmd.Type, Error: err.Error()})
+		}
+	}
+}
+
+func (c *roomConn) reply(data CommandError) {
+	select {
+	case c.out <- eventOf(CommandFailed, c.roomID, data):
+	default:
+	}
+}
+
+// run executes a command. Its effects reach the client as room events.
+func (c *roomConn) run(cmd Command) error {
+	if c.viewerID == 0 {
+		return ErrAnonymous
+	}
+	if !c.principal.HasScope(account.ScopePlay) {
+		return ErrReadOnly
+	}
+
+	var r *room.Room
+	var err error
+	switch cmd.Type {
+	case CommandMove:
+		r, err = repository.PlayMove(c.roomID, c.viewerID, game.Point{X: cmd.X, Y: cmd.Y})
+	case CommandPass:
+		r, err = repository.Pass(c.roomID, c.viewerID)
+	case CommandResign:
+		r, err = repository.Resign(c.roomID, c.viewerID)
+	case CommandChat:
+		return c.chat(cmd.Channel, cmd.Text)
+	default:
+		return errors.New("unknown command")
+	}
+
+	if err == nil && r == nil {
+		return errors.New("room not found")
+	}
+	return err
+}
+
+func (c *roomConn) chat(channel chat.Channel, text string) error {
+	player, err := repository.GetPlayerByID(c.viewerID)
+	if err != nil {
+		return err
+	}
+	if player == nil {
+		return ErrAnonymous
+	}
+
+	msg, err := repository.PostChatMessage(c.roomID, player, channel, text)
+	if err == nil && msg == nil {
+		return errors.New("room not found")
+	}
+	return err
+}
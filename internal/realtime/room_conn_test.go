@@ -0,0 +1,39 @@
+package realtime
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/moLIart/go-course/internal/middlewares"
+	"github.com/moLIart/go-course/internal/model/account"
+)
+
+func TestRunRefusesWithoutPlayScope(t *testing.T) {
+	tests := []struct {
+		name      string
+		principal middlewares.Principal
+	}{
+		{name: "anonymous"},
+		{name: "read-only API key", principal: middlewares.Principal{PlayerID: 2, APIKeyID: "key", Scopes: []account.Scope{account.ScopeRead}}},
+		{name: "API key without scopes", principal: middlewares.Principal{PlayerID: 2, APIKeyID: "key"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &roomConn{
+				roomFeed:  &roomFeed{roomID: 1, viewerID: tt.principal.PlayerID},
+				principal: tt.principal,
+			}
+			for _, cmd := range []Command{
+				{Type: CommandMove, X: 3, Y: 3},
+				{Type: CommandPass},
+				{Type: CommandResign},
+				{Type: CommandChat, Text: "hello"},
+			} {
+				err := c.run(cmd)
+				if !errors.Is(err, ErrAnonymous) && !errors.Is(err, ErrReadOnly) {
+					t.Errorf("run(%s) = %v, want the command refused", cmd.Type, err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package realtime
+
+import (
+	"log"
+	"time"
+
+	"github.com/moLIart/go-course/internal/events"
+	"github.com/moLIart/go-course/internal/model/chat"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+// roomFeed filters the events of a room down to what a viewer may see.
+type roomFeed struct {
+	roomID   int
+	viewerID int
+
+	// delayed is set when the room keeps spectators behind, in which case
+	// moves are sent from the room as the viewer sees it. sentGameID and
+	// sentMoves track what the viewer was sent.
+	delayed    bool
+	sentGameID int
+	sentMoves  int
+}
+
+func newRoomFeed(roomID, viewerID int) *roomFeed {
+	f := &roomFeed{roomID: roomID, viewerID: viewerID}
+	r, err := repository.GetRoomByID(f.roomID)
+	if err != nil || r == nil {
+		return f
+	}
+	f.delayed = r.GetSettings().SpectatorDelay > 0
+	if view := r.ViewFor(f.viewerID); view.Game != nil {
+		f.sentGameID = view.Game.ID
+		f.sentMoves = len(view.Game.Moves)
+	}
+	return f
+}
+
+// visibleEvents returns what the viewer may see of an event. Spectator chat
+// is hidden from the players of a game in progress. In rooms that keep
+// spectators behind, move and takeback events are replaced by the moves of
+// the delayed view, and takeback requests are only sent to the players.
+func (f *roomFeed) visibleEvents(e events.Event) []events.Event {
+	if channel := chatChannel(e); channel == chat.Spectators && !f.canReadChat(channel) {
+		return nil
+	}
+	if !f.delayed {
+		return []events.Event{e}
+	}
+
+	switch e.Type {
+	case events.MovePlayed, events.MovesTakenBack:
+		return f.syncDelayedMoves()
+	case events.TakebackAsked, events.TakebackDeclined:
+		if !f.seesLiveGame() {
+			return nil
+		}
+	case events.GameOver:
+		return append(f.syncDelayedMoves(), e)
+	}
+	return []events.Event{e}
+}
+
+func chatChannel(e events.Event) chat.Channel {
+	switch data := e.Data.(type) {
+	case events.Chat:
+		return chat.Channel(data.Channel)
+	case events.ChatDeletion:
+		return chat.Channel(data.Channel)
+	}
+	return ""
+}
+
+func (f *roomFeed) canReadChat(channel chat.Channel) bool {
+	r, err := repository.GetRoomByID(f.roomID)
+	return err == nil && r != nil && r.CanReadChat(f.viewerID, channel)
+}
+
+func (f *roomFeed) seesLiveGame() bool {
+	r, err := repository.GetRoomByID(f.roomID)
+	return err == nil && r != nil && r.ViewFor(f.viewerID) == r
+}
+
+func (f *roomFeed) syncDelayedMoves() []events.Event {
+	r, err := repository.GetRoomByID(f.roomID)
+	if err != nil || r == nil || r.Game == nil {
+		return nil
+	}
+
+	view := r.ViewFor(f.viewerID).Game
+	if view.ID != f.sentGameID {
+		f.sentGameID, f.sentMoves = view.ID, 0
+	}
+
+	var pending []events.Event
+	if len(view.Moves) < f.sentMoves {
+		pending = append(pending, eventOf(events.MovesTakenBack, f.roomID, events.Takeback{MoveCount: len(view.Moves)}))
+		f.sentMoves = len(view.Moves)
+	}
+	for ; f.sentMoves < len(view.Moves); f.sentMoves++ {
+		pending = append(pending, eventOf(events.MovePlayed, f.roomID, events.NewMove(view, f.sentMoves)))
+	}
+	for i := range pending {
+		pending[i].GameID = view.ID
+	}
+	return pending
+}
+
+func eventOf(eventType string, roomID int, data any) events.Event {
+	return events.Event{Type: eventType, RoomID: roomID, Data: data, Time: time.Now().UTC()}
+}
+
+// WatchRoom returns the events of a room that the viewer may see, including
+// clock ticks, and a function that stops watching it. Like the subscriptions
+// of events, the channel is closed early if the viewer lags behind.
+func WatchRoom(roomID, viewerID int) (<-chan events.Event, func()) {
+	subscription, unsubscribe := events.Subscribe()
+	unwatch := watch(roomID)
+	feed := newRoomFeed(roomID, viewerID)
+
+	visible := make(chan events.Event, cap(subscription))
+	go func() {
+		defer close(visible)
+		for e := range subscription {
+			if e.RoomID != roomID {
+				continue
+			}
+			for _, v := range feed.visibleEvents(e) {
+				select {
+				case visible <- v:
+				default:
+					log.Printf("Closing a watcher of room %d that lags more than %d events behind", roomID, cap(visible))
+					unsubscribe()
+					return
+				}
+			}
+		}
+	}()
+
+	return visible, func() {
+		unwatch()
+		unsubscribe()
+	}
+}
@@ -0,0 +1,147 @@
+package realtime
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/moLIart/go-course/internal/events"
+	"github.com/moLIart/go-course/internal/repository"
+)
+
+// keepAlivePeriod is how often a comment is sent on idle streams so proxies
+// do not close them.
+const keepAlivePeriod = 30 * time.Second
+
+// ServeRoomEvents streams the events of a room as Server-Sent Events until the
+// request is cancelled. Without lastEventID the stream starts with a
+// RoomSnapshot built by snapshot. With it, the logged events that followed
+// lastEventID are sent first, except to viewers kept behind by a spectator
+// delay and when the log was trimmed past lastEventID, who get a new
+// snapshot instead.
+func ServeRoomEvents(w http.ResponseWriter, r *http.Request, roomID, viewerID int, lastEventID string, snapshot func() (any, error)) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "Streaming is not supported", http.StatusInternalServerError)
+		return
+	}
+
+	subscription, unsubscribe := events.Subscribe()
+	defer unsubscribe()
+	defer watch(roomID)()
+
+	feed := newRoomFeed(roomID, viewerID)
+	var missed []events.Event
+	resume := lastEventID != "" && !feed.delayed
+	if resume {
+		var err error
+		missed, err = repository.GetRoomEventsSince(roomID, lastEventID)
+		switch {
+		case errors.Is(err, repository.ErrEventsTrimmed):
+			resume = false
+		case err != nil:
+			http.Error(w, "Invalid Last-Event-ID", http.StatusBadRequest)
+			return
+		}
+	}
+	if !resume {
+		last, err := repository.GetLastRoomEventID(roomID)
+		if err != nil {
+			log.Printf("Failed to read the event log of room %d: %v", roomID, err)
+		}
+		data, err := snapshot()
+		if err != nil {
+			http.Error(w, "Failed to retrieve room", http.StatusInternalServerError)
+			return
+		}
+		missed = []events.Event{eventOf(RoomSnapshot, roomID, data)}
+		missed[0].ID = last
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+
+	lastSent := lastEventID
+	for _, e := range missed {
+		for _, visible := range feed.visibleEvents(e) {
+			if !writeSSE(w, visible) {
+				return
+			}
+		}
+		lastSent = e.ID
+	}
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(keepAlivePeriod)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e, ok := <-subscription:
+			if !ok {
+				return
+			}
+			// Events logged before the replay was read were already sent.
+			if e.RoomID != roomID || (e.ID != "" && !isAfter(e.ID, lastSent)) {
+				continue
+			}
+			for _, visible := range feed.visibleEvents(e) {
+				if !writeSSE(w, visible) {
+					return
+				}
+			}
+			if e.ID != "" {
+				lastSent = e.ID
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// writeSSE writes an event as a message named after its type.
+func writeSSE(w http.ResponseWriter, e events.Event) bool {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		log.Printf("Failed to encode %s event: %v", e.Type, err)
+		return true
+	}
+
+	var msg strings.Builder
+	if e.ID != "" {
+		fmt.Fprintf(&msg, "id: %s\n", e.ID)
+	}
+	fmt.Fprintf(&msg, "event: %s\ndata: %s\n\n", e.Type, payload)
+	_, err = fmt.Fprint(w, msg.String())
+	return err == nil
+}
+
+// isAfter reports whether the event log ID follows last. IDs have the form
+// "<milliseconds>-<sequence>".
+func isAfter(id, last string) bool {
+	if last == "" {
+		return true
+	}
+	idMs, idSeq := splitEventID(id)
+	lastMs, lastSeq := splitEventID(last)
+	return idMs > lastMs || (idMs == lastMs && idSeq > lastSeq)
+}
+
+func splitEventID(id string) (ms, seq uint64) {
+	msPart, seqPart, _ := strings.Cut(id, "-")
+	ms, _ = strconv.ParseUint(msPart, 10, 64)
+	seq, _ = strconv.ParseUint(seqPart, 10, 64)
+	return ms, seq
+}
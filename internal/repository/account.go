@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/model/room"
+)
+
+var ErrUsernameTaken = errors.New("username is already taken")
+
+// Register creates a player named after the username with an account holding
+// its credentials.
+func Register(username, password string) (*room.Player, error) {
+	acc, err := account.NewAccount(username, password)
+	if err != nil {
+		return nil, err
+	}
+
+	return createAccount(acc, strings.TrimSpace(username))
+}
+
+// createAccount creates the player of a new account and stores the account.
+func createAccount(acc *account.Account, name string) (*room.Player, error) {
+	existing, err := GetAccountByUsername(acc.Username)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, ErrUsernameTaken
+	}
+
+	player := room.NewPlayer(name)
+	if err := AddEntity(player); err != nil {
+		return nil, err
+	}
+
+	acc.PlayerID = player.ID
+	if _, err := accountsCol.InsertOne(context.TODO(), acc); err != nil {
+		// Another registration took the username in the meantime.
+		DeletePlayerByID(player.ID)
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, ErrUsernameTaken
+		}
+		return nil, err
+	}
+	logActionToRedis("create", "account", acc.PlayerID)
+	return player, nil
+}
+
+// Login returns the player whose account matches the credentials, or
+// account.ErrInvalidCredentials.
+func Login(username, password string) (*room.Player, error) {
+	acc, err := GetAccountByUsername(username)
+	if err != nil {
+		return nil, err
+	}
+	if err := acc.CheckPassword(password); err != nil {
+		return nil, err
+	}
+
+	player, err := GetPlayerByID(acc.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil {
+		return nil, account.ErrInvalidCredentials
+	}
+	return player, nil
+}
+
+// GetPlayerRole returns the role of the player's account. Players without
+// an account are plain players.
+func GetPlayerRole(playerID int) (account.Role, error) {
+	acc, err := GetAccountByPlayerID(playerID)
+	if err != nil || acc == nil {
+		return account.RolePlayer, err
+	}
+	return acc.GetRole(), nil
+}
+
+func GetAccountByPlayerID(playerID int) (*account.Account, error) {
+	var acc account.Account
+	err := accountsCol.FindOne(context.TODO(), bson.M{"_id": playerID}).Decode(&acc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &acc, nil
+}
+
+func GetAccounts() ([]*account.Account, error) {
+	cursor, err := accountsCol.Find(context.TODO(), bson.D{}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var accounts []*account.Account
+	for cursor.Next(context.TODO()) {
+		var acc account.Account
+		if err := cursor.Decode(&acc); err != nil {
+			return nil, err
+		}
+		accounts = append(accounts, &acc)
+	}
+	return accounts, cursor.Err()
+}
+
+// SetAccountRole gives the player's account another role and logs the player
+// out everywhere, so that tokens carrying the former role stop working. It
+// returns a nil account if the player has none.
+func SetAccountRole(playerID int, role account.Role) (*account.Account, error) {
+	var acc account.Account
+	err := accountsCol.FindOneAndUpdate(
+		context.TODO(),
+		bson.M{"_id": playerID},
+		bson.M{"$set": bson.M{"role": role}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&acc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logActionToRedis("update", "account", playerID)
+	if err := RevokePlayerSessions(playerID); err != nil {
+		return nil, err
+	}
+	return &acc, nil
+}
+
+// DeleteAccount deletes the player with their account, sessions, API keys and
+// linked identities. It reports false if the player did not exist.
+func DeleteAccount(playerID int) (bool, error) {
+	if err := RevokePlayerSessions(playerID); err != nil {
+		return false, err
+	}
+	if _, err := apiKeysCol.DeleteMany(context.TODO(), bson.M{"bot_id": playerID}); err != nil {
+		return false, err
+	}
+	if _, err := identitiesCol.DeleteMany(context.TODO(), bson.M{"player_id": playerID}); err != nil {
+		return false, err
+	}
+
+	result, err := accountsCol.DeleteOne(context.TODO(), bson.M{"_id": playerID})
+	if err != nil {
+		return false, err
+	}
+	if result.DeletedCount > 0 {
+		logActionToRedis("delete", "account", playerID)
+	}
+
+	deleted, err := DeletePlayerByID(playerID)
+	return deleted || result.DeletedCount > 0, err
+}
+
+func GetAccountByUsername(username string) (*account.Account, error) {
+	var acc account.Account
+	err := accountsCol.FindOne(context.TODO(), bson.M{"username": account.NormalizeUsername(username)}).Decode(&acc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &acc, nil
+}
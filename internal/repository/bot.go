@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/moLIart/go-course/internal/model/account"
+)
+
+// apiKeyUsageResolution is how often the last use of an API key is recorded,
+// so that bots do not write to the database on every request.
+const apiKeyUsageResolution = time.Minute
+
+// CreateBot creates a player named after the username with a bot account
+// managed by the player ownerID.
+func CreateBot(username string, ownerID int) (*account.Account, error) {
+	acc, err := account.NewBotAccount(username, ownerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := createAccount(acc, strings.TrimSpace(username)); err != nil {
+		return nil, err
+	}
+	return acc, nil
+}
+
+// GetBot returns the bot account of the player, or nil if the player is not
+// a bot.
+func GetBot(botID int) (*account.Account, error) {
+	acc, err := GetAccountByPlayerID(botID)
+	if err != nil || acc == nil || !acc.Bot {
+		return nil, err
+	}
+	return acc, nil
+}
+
+// GetBots returns the bots managed by the player.
+func GetBots(ownerID int) ([]*account.Account, error) {
+	cursor, err := accountsCol.Find(context.TODO(), bson.M{"bot": true, "owner_id": ownerID}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var bots []*account.Account
+	for cursor.Next(context.TODO()) {
+		var acc account.Account
+		if err := cursor.Decode(&acc); err != nil {
+			return nil, err
+		}
+		bots = append(bots, &acc)
+	}
+	return bots, cursor.Err()
+}
+
+// CreateAPIKey stores a new key of the bot and returns it with the key to
+// hand to the bot.
+func CreateAPIKey(botID int, name string, scopes []account.Scope) (*account.APIKey, string, error) {
+	k, key, err := account.NewAPIKey(botID, name, scopes)
+	if err != nil {
+		return nil, "", err
+	}
+
+	if _, err := apiKeysCol.InsertOne(context.TODO(), k); err != nil {
+		return nil, "", err
+	}
+	logActionToRedis("create", "api_key", k.ID)
+	return k, key, nil
+}
+
+// GetAPIKeys returns the keys of the bot, revoked ones included, the newest first.
+func GetAPIKeys(botID int) ([]*account.APIKey, error) {
+	cursor, err := apiKeysCol.Find(context.TODO(), bson.M{"bot_id": botID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var keys []*account.APIKey
+	for cursor.Next(context.TODO()) {
+		var k account.APIKey
+		if err := cursor.Decode(&k); err != nil {
+			return nil, err
+		}
+		keys = append(keys, &k)
+	}
+	return keys, cursor.Err()
+}
+
+// RevokeAPIKey revokes a key of the bot. It reports false if the bot has no
+// such key or it was already revoked.
+func RevokeAPIKey(botID int, keyID string) (bool, error) {
+	result, err := apiKeysCol.UpdateOne(
+		context.TODO(),
+		bson.M{"_id": keyID, "bot_id": botID, "revoked_at": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"revoked_at": time.Now().UTC()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	if result.ModifiedCount > 0 {
+		logActionToRedis("delete", "api_key", keyID)
+	}
+	return result.ModifiedCount > 0, nil
+}
+
+// AuthenticateAPIKey returns the stored key matching the key sent by a bot
+// and records its use, or account.ErrInvalidAPIKey.
+func AuthenticateAPIKey(key string) (*account.APIKey, error) {
+	id, _, err := account.ParseAPIKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var k account.APIKey
+	err = apiKeysCol.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&k)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, account.ErrInvalidAPIKey
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := k.Check(key); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if k.LastUsedAt == nil || now.Sub(*k.LastUsedAt) >= apiKeyUsageResolution {
+		_, err := apiKeysCol.UpdateOne(context.TODO(), bson.M{"_id": k.ID}, bson.M{"$set": bson.M{"last_used_at": now}})
+		if err != nil {
+			return nil, err
+		}
+		k.LastUsedAt = &now
+	}
+	return &k, nil
+}
@@ -0,0 +1,151 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/moLIart/go-course/internal/events"
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/model/chat"
+	"github.com/moLIart/go-course/internal/model/room"
+)
+
+const (
+	// chatRateLimit messages may be written by a player in a room per
+	// chatRateWindow.
+	chatRateLimit  = 5
+	chatRateWindow = 10 * time.Second
+)
+
+var ErrChatRateLimited = errors.New("too many messages, slow down")
+
+// PostChatMessage stores a message of the player in a chat channel of the
+// room and publishes it. It returns a nil message if the room does not exist.
+func PostChatMessage(roomID int, player *room.Player, channel chat.Channel, text string) (*chat.Message, error) {
+	r, err := GetRoomByID(roomID)
+	if err != nil || r == nil {
+		return nil, err
+	}
+
+	if err := r.CheckChatWriter(player.ID, channel); err != nil {
+		return nil, err
+	}
+	msg, err := chat.NewMessage(roomID, channel, player.ID, player.Name, text)
+	if err != nil {
+		return nil, err
+	}
+	if err := allowChatMessage(roomID, player.ID); err != nil {
+		return nil, err
+	}
+
+	if err := AddEntity(msg); err != nil {
+		return nil, err
+	}
+	publishEvent(events.Event{
+		Type:   events.ChatMessage,
+		RoomID: roomID,
+		Data: events.Chat{
+			MessageID:  msg.ID,
+			Channel:    string(msg.Channel),
+			PlayerID:   msg.PlayerID,
+			PlayerName: msg.PlayerName,
+			Text:       msg.Text,
+		},
+		Time: msg.CreatedAt,
+	})
+	return msg, nil
+}
+
+// allowChatMessage counts a message against the rate limit of the player in
+// the room.
+func allowChatMessage(roomID, playerID int) error {
+	ctx := context.Background()
+	key := fmt.Sprintf("chat:rate:%d:%d", roomID, playerID)
+	count, err := redisClient.Incr(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	if count == 1 {
+		redisClient.Expire(ctx, key, chatRateWindow)
+	}
+	if count > chatRateLimit {
+		return ErrChatRateLimited
+	}
+	return nil
+}
+
+// GetChatMessages returns up to limit messages of a chat channel, oldest
+// first, that were written before the message with the given ID, or the
+// latest ones if before is 0. Deleted messages are left out.
+func GetChatMessages(roomID int, channel chat.Channel, before int, limit int) ([]*chat.Message, error) {
+	filter := bson.M{"room_id": roomID, "channel": channel, "deleted_by": bson.M{"$exists": false}}
+	if before > 0 {
+		filter["_id"] = bson.M{"$lt": before}
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := chatCol.Find(context.TODO(), filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	messages := []*chat.Message{}
+	if err := cursor.All(context.TODO(), &messages); err != nil {
+		return nil, err
+	}
+	slices.Reverse(messages)
+	return messages, nil
+}
+
+// DeleteChatMessage removes a message from the chat of the room on behalf of
+// a moderator. It reports false if the room or the message does not exist.
+func DeleteChatMessage(roomID int, messageID int, moderatorID int, role account.Role) (bool, error) {
+	r, err := GetRoomByID(roomID)
+	if err != nil || r == nil {
+		return false, err
+	}
+	if !r.CanModerate(moderatorID, role) {
+		return false, room.ErrNotModerator
+	}
+
+	var msg chat.Message
+	err = chatCol.FindOneAndUpdate(
+		context.TODO(),
+		bson.M{"_id": messageID, "room_id": roomID, "deleted_by": bson.M{"$exists": false}},
+		bson.M{"$set": bson.M{"deleted_by": moderatorID}},
+	).Decode(&msg)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	logActionToRedis("delete", "chat_message", messageID)
+	publishEvent(events.Event{
+		Type:   events.ChatDeleted,
+		RoomID: roomID,
+		Data:   events.ChatDeletion{MessageID: messageID, Channel: string(msg.Channel)},
+	})
+	return true, nil
+}
+
+func MuteChatPlayer(id int, moderatorID int, role account.Role, playerID int) (*room.Room, error) {
+	return modifyRoom(id, func(r *room.Room) error {
+		return r.Mute(moderatorID, role, playerID)
+	})
+}
+
+func UnmuteChatPlayer(id int, moderatorID int, role account.Role, playerID int) (*room.Room, error) {
+	return modifyRoom(id, func(r *room.Room) error {
+		return r.Unmute(moderatorID, role, playerID)
+	})
+}
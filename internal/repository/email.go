@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/moLIart/go-course/internal/model/account"
+)
+
+var ErrEmailTaken = errors.New("email address is already used by another account")
+
+func mailKey(kind string, playerID int) string {
+	return "mail:" + kind + ":" + strconv.Itoa(playerID)
+}
+
+// SetAccountEmail gives the player's account another email address, which
+// has to be verified again unless it did not change, and returns the account.
+func SetAccountEmail(playerID int, email string) (*account.Account, error) {
+	email, err := account.NormalizeEmail(email)
+	if err != nil {
+		return nil, err
+	}
+
+	acc, err := GetAccountByPlayerID(playerID)
+	if err != nil {
+		return nil, err
+	}
+	if acc == nil {
+		return nil, account.ErrInvalidCredentials
+	}
+	if acc.Email == email {
+		return acc, nil
+	}
+
+	err = accountsCol.FindOneAndUpdate(
+		context.TODO(),
+		bson.M{"_id": playerID},
+		bson.M{"$set": bson.M{"email": email}, "$unset": bson.M{"email_verified": ""}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(acc)
+	switch {
+	case mongo.IsDuplicateKeyError(err):
+		return nil, ErrEmailTaken
+	case errors.Is(err, mongo.ErrNoDocuments):
+		return nil, account.ErrInvalidCredentials
+	case err != nil:
+		return nil, err
+	}
+	logActionToRedis("update", "account", playerID)
+	return acc, nil
+}
+
+// VerifyAccountEmail marks the email address of the player's account as
+// verified. It reports false if the account has another address by now.
+func VerifyAccountEmail(playerID int, email string) (bool, error) {
+	result, err := accountsCol.UpdateOne(
+		context.TODO(),
+		bson.M{"_id": playerID, "email": email},
+		bson.M{"$set": bson.M{"email_verified": true}},
+	)
+	if err != nil {
+		return false, err
+	}
+	if result.ModifiedCount > 0 {
+		logActionToRedis("update", "account", playerID)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+func GetAccountByEmail(email string) (*account.Account, error) {
+	email, err := account.NormalizeEmail(email)
+	if err != nil {
+		return nil, nil
+	}
+
+	var acc account.Account
+	err = accountsCol.FindOne(context.TODO(), bson.M{"email": email}).Decode(&acc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &acc, nil
+}
+
+// ResetPassword replaces the password of the player's account if it still is
+// the one identified by the fingerprint, and logs the player out everywhere.
+// Otherwise it returns account.ErrInvalidActionToken.
+func ResetPassword(playerID int, fingerprint, password string) error {
+	acc, err := GetAccountByPlayerID(playerID)
+	if err != nil {
+		return err
+	}
+	if acc == nil || acc.Bot || acc.PasswordFingerprint() != fingerprint {
+		return account.ErrInvalidActionToken
+	}
+
+	hash, err := account.HashPassword(password)
+	if err != nil {
+		return err
+	}
+
+	// The filter keeps a token from being used twice concurrently.
+	result, err := accountsCol.UpdateOne(
+		context.TODO(),
+		bson.M{"_id": playerID, "password_hash": acc.PasswordHash},
+		bson.M{"$set": bson.M{"password_hash": hash}},
+	)
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return account.ErrInvalidActionToken
+	}
+	logActionToRedis("update", "account", playerID)
+	return RevokePlayerSessions(playerID)
+}
+
+// ReserveMail reports whether an email of the kind may be sent to the player,
+// which is at most once per account.MailInterval.
+func ReserveMail(kind string, playerID int) (bool, error) {
+	return redisClient.SetNX(context.Background(), mailKey(kind, playerID), 1, account.MailInterval).Result()
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"github.com/moLIart/go-course/internal/events"
+)
+
+// eventChannel is the Redis pub/sub channel room and game events are shared
+// on between the server instances.
+const eventChannel = "events"
+
+// redisBus sends events to every instance, including this one, over Redis
+// pub/sub.
+type redisBus struct{}
+
+func (redisBus) Send(e events.Event) error {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return redisClient.Publish(context.Background(), eventChannel, payload).Err()
+}
+
+// startEventBus subscribes to the event channel and routes published events
+// through it once the subscription is confirmed, so no event is lost.
+func startEventBus() {
+	ctx := context.Background()
+	pubsub := redisClient.Subscribe(ctx, eventChannel)
+	if _, err := pubsub.Receive(ctx); err != nil {
+		log.Fatalf("Failed to subscribe to Redis events: %v", err)
+	}
+
+	go func() {
+		for msg := range pubsub.Channel() {
+			var e events.Event
+			if err := json.Unmarshal([]byte(msg.Payload), &e); err != nil {
+				log.Printf("Failed to decode event from Redis: %v", err)
+				continue
+			}
+			events.Deliver(e)
+		}
+	}()
+
+	events.SetBus(redisBus{})
+}
@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/moLIart/go-course/internal/events"
+)
+
+const (
+	// eventLogLength bounds how many events are kept per room. Clients
+	// resuming from an older event miss what was trimmed.
+	eventLogLength = 1000
+	// eventLogTTL drops the log of rooms that stopped producing events.
+	eventLogTTL = 24 * time.Hour
+)
+
+// ErrEventsTrimmed is returned when events following the requested one were
+// already dropped from the log.
+var ErrEventsTrimmed = errors.New("events following this ID are no longer logged")
+
+func eventLogKey(roomID int) string {
+	return fmt.Sprintf("events:room:%d", roomID)
+}
+
+// publishEvent appends the event to the log of its room, which gives it its
+// ID, and publishes it. Events that cannot be logged are still published,
+// without an ID.
+func publishEvent(e events.Event) {
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	if id, err := appendEvent(e); err != nil {
+		log.Printf("Failed to log %s event of room %d: %v", e.Type, e.RoomID, err)
+	} else {
+		e.ID = id
+	}
+	events.Publish(e)
+}
+
+func appendEvent(e events.Event) (string, error) {
+	payload, err := json.Marshal(e)
+	if err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+	key := eventLogKey(e.RoomID)
+	id, err := redisClient.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: eventLogLength,
+		Approx: true,
+		Values: map[string]any{"event": payload},
+	}).Result()
+	if err != nil {
+		return "", err
+	}
+	redisClient.Expire(ctx, key, eventLogTTL)
+	return id, nil
+}
+
+// GetRoomEventsSince returns the logged events of a room that follow the event
+// with the given ID, oldest first. It returns ErrEventsTrimmed if the log no
+// longer reaches back to that event, as some that followed it may be lost.
+func GetRoomEventsSince(roomID int, lastID string) ([]events.Event, error) {
+	ctx := context.Background()
+	key := eventLogKey(roomID)
+	messages, err := redisClient.XRange(ctx, key, "("+lastID, "+").Result()
+	if err != nil {
+		return nil, err
+	}
+	// The log is only trimmed from its start, so if it still holds an event
+	// up to lastID, nothing was dropped from the range read above.
+	older, err := redisClient.XRangeN(ctx, key, "-", lastID, 1).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(older) == 0 {
+		return nil, ErrEventsTrimmed
+	}
+
+	logged := make([]events.Event, 0, len(messages))
+	for _, m := range messages {
+		payload, _ := m.Values["event"].(string)
+		var e events.Event
+		if err := json.Unmarshal([]byte(payload), &e); err != nil {
+			return nil, err
+		}
+		e.ID = m.ID
+		logged = append(logged, e)
+	}
+	return logged, nil
+}
+
+// GetLastRoomEventID returns the ID of the last logged event of a room, or
+// "0" if there is none, so that GetRoomEventsSince returns the whole log.
+func GetLastRoomEventID(roomID int) (string, error) {
+	messages, err := redisClient.XRevRangeN(context.Background(), eventLogKey(roomID), "+", "-", 1).Result()
+	if err != nil || len(messages) == 0 {
+		return "0", err
+	}
+	return messages[0].ID, nil
+}
@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/model/room"
+)
+
+// maxUsernameAttempts bounds how many usernames are tried for a player
+// logging in with a provider for the first time, as "name" and then "name-N"
+// with N from a sequence.
+const maxUsernameAttempts = 5
+
+func oidcLoginKey(state string) string {
+	return "oidc_login:" + state
+}
+
+// SaveOIDCLogin keeps the login until the player comes back from the provider.
+func SaveOIDCLogin(login *account.OIDCLogin) error {
+	payload, err := json.Marshal(login)
+	if err != nil {
+		return err
+	}
+	return redisClient.Set(context.Background(), oidcLoginKey(login.State), payload, account.OIDCLoginTTL).Err()
+}
+
+// TakeOIDCLogin returns the login started with the state and forgets it, so
+// that each callback is only handled once. It returns
+// account.ErrInvalidOIDCState if there is no such login.
+func TakeOIDCLogin(state string) (*account.OIDCLogin, error) {
+	if state == "" {
+		return nil, account.ErrInvalidOIDCState
+	}
+
+	payload, err := redisClient.GetDel(context.Background(), oidcLoginKey(state)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, account.ErrInvalidOIDCState
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var login account.OIDCLogin
+	if err := json.Unmarshal(payload, &login); err != nil {
+		return nil, err
+	}
+	return &login, nil
+}
+
+// LoginWithIdentity returns the player the identity is linked to. Players
+// logging in for the first time get an account without password, named after
+// the first usable of the names.
+func LoginWithIdentity(identity *account.Identity, names ...string) (*room.Player, error) {
+	existing, err := getIdentity(identity.Issuer, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return identityPlayer(existing)
+	}
+
+	player, err := createExternalAccount(account.ExternalUsername(names...))
+	if err != nil {
+		return nil, err
+	}
+
+	identity.PlayerID = player.ID
+	if _, err := identitiesCol.InsertOne(context.TODO(), identity); err != nil {
+		DeleteAccount(player.ID)
+		if !mongo.IsDuplicateKeyError(err) {
+			return nil, err
+		}
+
+		// The same identity logged in twice at once, use the other account.
+		existing, err := getIdentity(identity.Issuer, identity.Subject)
+		if err != nil || existing == nil {
+			return nil, err
+		}
+		return identityPlayer(existing)
+	}
+	logActionToRedis("create", "identity", identity.ID)
+	return player, nil
+}
+
+// LinkIdentity links the identity to the player, unless it already is to
+// another player.
+func LinkIdentity(playerID int, identity *account.Identity) (*account.Identity, error) {
+	existing, err := getIdentity(identity.Issuer, identity.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.PlayerID != playerID {
+			return nil, account.ErrIdentityLinked
+		}
+		return existing, nil
+	}
+
+	identity.PlayerID = playerID
+	if _, err := identitiesCol.InsertOne(context.TODO(), identity); err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return nil, account.ErrIdentityLinked
+		}
+		return nil, err
+	}
+	logActionToRedis("create", "identity", identity.ID)
+	return identity, nil
+}
+
+// GetPlayerIdentities returns the identities linked to the player, the oldest first.
+func GetPlayerIdentities(playerID int) ([]*account.Identity, error) {
+	cursor, err := identitiesCol.Find(context.TODO(), bson.M{"player_id": playerID}, options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	var identities []*account.Identity
+	for cursor.Next(context.TODO()) {
+		var identity account.Identity
+		if err := cursor.Decode(&identity); err != nil {
+			return nil, err
+		}
+		identities = append(identities, &identity)
+	}
+	return identities, cursor.Err()
+}
+
+// UnlinkIdentity unlinks an identity of the player. It reports false if the
+// player has no such identity, and refuses to unlink the last identity of an
+// account without password with account.ErrLastIdentity.
+func UnlinkIdentity(playerID int, identityID string) (bool, error) {
+	acc, err := GetAccountByPlayerID(playerID)
+	if err != nil {
+		return false, err
+	}
+	if acc != nil && !acc.HasPassword() {
+		count, err := identitiesCol.CountDocuments(context.TODO(), bson.M{"player_id": playerID})
+		if err != nil {
+			return false, err
+		}
+		if count <= 1 {
+			return false, account.ErrLastIdentity
+		}
+	}
+
+	result, err := identitiesCol.DeleteOne(context.TODO(), bson.M{"_id": identityID, "player_id": playerID})
+	if err != nil {
+		return false, err
+	}
+	if result.DeletedCount > 0 {
+		logActionToRedis("delete", "identity", identityID)
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// createExternalAccount creates an account without password under the first
+// free username made of the base.
+func createExternalAccount(base string) (*room.Player, error) {
+	for attempt := 1; attempt <= maxUsernameAttempts; attempt++ {
+		username := base
+		if attempt > 1 {
+			n, err := nextID("external_usernames")
+			if err != nil {
+				return nil, err
+			}
+			suffix := fmt.Sprintf("-%d", n)
+			username = base[:min(len(base), 32-len(suffix))] + suffix
+		}
+
+		acc, err := account.NewExternalAccount(username)
+		if err != nil {
+			return nil, err
+		}
+		player, err := createAccount(acc, username)
+		if errors.Is(err, ErrUsernameTaken) {
+			continue
+		}
+		return player, err
+	}
+	return nil, ErrUsernameTaken
+}
+
+func identityPlayer(identity *account.Identity) (*room.Player, error) {
+	player, err := GetPlayerByID(identity.PlayerID)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil {
+		return nil, account.ErrInvalidCredentials
+	}
+	return player, nil
+}
+
+func getIdentity(issuer, subject string) (*account.Identity, error) {
+	var identity account.Identity
+	err := identitiesCol.FindOne(context.TODO(), bson.M{"issuer": issuer, "subject": subject}).Decode(&identity)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
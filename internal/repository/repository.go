@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"time"
 
 	"github.com/redis/go-redis/v9"
@@ -11,17 +13,37 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
+	"github.com/moLIart/go-course/internal/model/chat"
 	"github.com/moLIart/go-course/internal/model/game"
 	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/model/simul"
 )
 
+// maxUpdateAttempts bounds the optimistic concurrency retries of modifyRoom.
+const maxUpdateAttempts = 5
+
+// maxCodeAttempts bounds how many invite codes are tried before giving up on
+// a room because every generated code was already taken.
+const maxCodeAttempts = 5
+
 var (
-	mongoClient *mongo.Client
-	playersCol  *mongo.Collection
-	roomsCol    *mongo.Collection
-	boardsCol   *mongo.Collection
-	gamesCol    *mongo.Collection
-	redisClient *redis.Client
+	ErrConcurrentUpdate = errors.New("entity was modified concurrently, try again")
+	ErrCodeTaken        = errors.New("invite code is already used by another room")
+)
+
+var (
+	mongoClient   *mongo.Client
+	countersCol   *mongo.Collection
+	playersCol    *mongo.Collection
+	roomsCol      *mongo.Collection
+	boardsCol     *mongo.Collection
+	gamesCol      *mongo.Collection
+	simulsCol     *mongo.Collection
+	chatCol       *mongo.Collection
+	accountsCol   *mongo.Collection
+	apiKeysCol    *mongo.Collection
+	identitiesCol *mongo.Collection
+	redisClient   *redis.Client
 )
 
 func Startup(mongoDataSource, redisDataSource string) {
@@ -37,10 +59,18 @@ func Startup(mongoDataSource, redisDataSource string) {
 		log.Fatal(err)
 	}
 
+	countersCol = mongoClient.Database("game_db").Collection("counters")
 	playersCol = mongoClient.Database("game_db").Collection("players")
 	roomsCol = mongoClient.Database("game_db").Collection("rooms")
 	boardsCol = mongoClient.Database("game_db").Collection("boards")
 	gamesCol = mongoClient.Database("game_db").Collection("games")
+	simulsCol = mongoClient.Database("game_db").Collection("simuls")
+	chatCol = mongoClient.Database("game_db").Collection("chat_messages")
+	accountsCol = mongoClient.Database("game_db").Collection("accounts")
+	apiKeysCol = mongoClient.Database("game_db").Collection("api_keys")
+	identitiesCol = mongoClient.Database("game_db").Collection("identities")
+
+	ensureIndexes()
 
 	redisClient = redis.NewClient(&redis.Options{
 		Addr: redisDataSource,
@@ -50,9 +80,92 @@ func Startup(mongoDataSource, redisDataSource string) {
 	if err != nil {
 		log.Fatalf("Failed to connect to Redis: %v", err)
 	}
+	startEventBus()
 	log.Println("Connected to MongoDB and Redis successfully")
 }
 
+func ensureIndexes() {
+	if err := deduplicateRoomCodes(); err != nil {
+		log.Fatalf("Failed to deduplicate room codes: %v", err)
+	}
+
+	_, err := roomsCol.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys:    bson.D{{Key: "code", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		log.Fatalf("Failed to create rooms index: %v", err)
+	}
+
+	_, err = chatCol.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{{Key: "room_id", Value: 1}, {Key: "channel", Value: 1}, {Key: "_id", Value: -1}},
+	})
+	if err != nil {
+		log.Fatalf("Failed to create chat index: %v", err)
+	}
+
+	_, err = accountsCol.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "username", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "email", Value: 1}}, Options: options.Index().SetUnique(true).SetSparse(true)},
+	})
+	if err != nil {
+		log.Fatalf("Failed to create accounts indexes: %v", err)
+	}
+
+	_, err = apiKeysCol.Indexes().CreateOne(context.TODO(), mongo.IndexModel{
+		Keys: bson.D{{Key: "bot_id", Value: 1}},
+	})
+	if err != nil {
+		log.Fatalf("Failed to create API keys index: %v", err)
+	}
+
+	_, err = identitiesCol.Indexes().CreateMany(context.TODO(), []mongo.IndexModel{
+		{Keys: bson.D{{Key: "issuer", Value: 1}, {Key: "subject", Value: 1}}, Options: options.Index().SetUnique(true)},
+		{Keys: bson.D{{Key: "player_id", Value: 1}}},
+	})
+	if err != nil {
+		log.Fatalf("Failed to create identities indexes: %v", err)
+	}
+}
+
+// deduplicateRoomCodes gives fresh invite codes to the rooms sharing a code
+// with an older room, which rooms created before codes were generated may do,
+// so that the unique index on codes can be built.
+func deduplicateRoomCodes() error {
+	cursor, err := roomsCol.Aggregate(context.TODO(), mongo.Pipeline{
+		{{Key: "$sort", Value: bson.D{{Key: "_id", Value: 1}}}},
+		{{Key: "$group", Value: bson.D{
+			{Key: "_id", Value: "$code"},
+			{Key: "ids", Value: bson.D{{Key: "$push", Value: "$_id"}}},
+		}}},
+		{{Key: "$match", Value: bson.D{{Key: "ids.1", Value: bson.D{{Key: "$exists", Value: true}}}}}},
+	})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(context.TODO())
+
+	for cursor.Next(context.TODO()) {
+		var group struct {
+			IDs []int `bson:"ids"`
+		}
+		if err := cursor.Decode(&group); err != nil {
+			return err
+		}
+		for _, id := range group.IDs[1:] {
+			code, err := room.GenerateCode()
+			if err != nil {
+				return err
+			}
+			if _, err := roomsCol.UpdateOne(context.TODO(), bson.M{"_id": id}, bson.M{"$set": bson.M{"code": code}}); err != nil {
+				return err
+			}
+			log.Printf("Gave room %d the invite code %s, its former code was used by another room", id, code)
+		}
+	}
+	return cursor.Err()
+}
+
 func logActionToRedis(action, entityType string, entityID interface{}) {
 	ctx := context.Background()
 	key := "log:" + action + ":" + entityType + ":" + fmt.Sprint(entityID)
@@ -60,32 +173,78 @@ func logActionToRedis(action, entityType string, entityID interface{}) {
 	redisClient.Set(ctx, key, value, time.Minute)
 }
 
+// nextID returns the next value of a named sequence stored in the counters
+// collection, so every inserted entity gets its own ID.
+func nextID(sequence string) (int, error) {
+	var counter struct {
+		Value int `bson:"value"`
+	}
+	err := countersCol.FindOneAndUpdate(
+		context.TODO(),
+		bson.M{"_id": sequence},
+		bson.M{"$inc": bson.M{"value": 1}},
+		options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After),
+	).Decode(&counter)
+	return counter.Value, err
+}
+
 func AddEntity(entity interface{}) error {
+	var err error
 	switch e := entity.(type) {
 	case *room.Player:
+		if e.ID, err = nextID("players"); err != nil {
+			return err
+		}
 		res, err := playersCol.InsertOne(context.TODO(), e)
 		if err == nil {
 			logActionToRedis("create", "player", res.InsertedID)
 		}
 		return err
 	case *room.Room:
+		if e.ID, err = nextID("rooms"); err != nil {
+			return err
+		}
 		res, err := roomsCol.InsertOne(context.TODO(), e)
 		if err == nil {
 			logActionToRedis("create", "room", res.InsertedID)
 		}
 		return err
 	case *game.Board:
+		if e.ID, err = nextID("boards"); err != nil {
+			return err
+		}
 		res, err := boardsCol.InsertOne(context.TODO(), e)
 		if err == nil {
 			logActionToRedis("create", "board", res.InsertedID)
 		}
 		return err
 	case *game.Game:
+		if e.ID, err = nextID("games"); err != nil {
+			return err
+		}
 		res, err := gamesCol.InsertOne(context.TODO(), e)
 		if err == nil {
 			logActionToRedis("create", "game", res.InsertedID)
 		}
 		return err
+	case *simul.Simul:
+		if e.ID, err = nextID("simuls"); err != nil {
+			return err
+		}
+		res, err := simulsCol.InsertOne(context.TODO(), e)
+		if err == nil {
+			logActionToRedis("create", "simul", res.InsertedID)
+		}
+		return err
+	case *chat.Message:
+		if e.ID, err = nextID("chat_messages"); err != nil {
+			return err
+		}
+		res, err := chatCol.InsertOne(context.TODO(), e)
+		if err == nil {
+			logActionToRedis("create", "chat_message", res.InsertedID)
+		}
+		return err
 	default:
 		return nil
 	}
@@ -125,9 +284,19 @@ func GetPlayers() ([]*room.Player, error) {
 	return players, nil
 }
 
-func GetRooms() ([]*room.Room, error) {
+// GetRooms returns all rooms, or only those in one of the given states.
+func GetRooms(states ...room.State) ([]*room.Room, error) {
+	filter := bson.M{}
+	if len(states) > 0 {
+		filter["state"] = bson.M{"$in": states}
+		if slices.Contains(states, room.StateOpen) {
+			// Rooms stored before states existed count as open.
+			filter = bson.M{"$or": bson.A{filter, bson.M{"state": bson.M{"$exists": false}}}}
+		}
+	}
+
 	var rooms []*room.Room
-	cursor, err := roomsCol.Find(context.TODO(), bson.D{{}})
+	cursor, err := roomsCol.Find(context.TODO(), filter)
 	if err != nil {
 		return nil, err
 	}
@@ -182,6 +351,9 @@ func GetGames() ([]*game.Game, error) {
 func GetPlayerByID(id int) (*room.Player, error) {
 	var player room.Player
 	err := playersCol.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&player)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -191,6 +363,34 @@ func GetPlayerByID(id int) (*room.Player, error) {
 func GetRoomByID(id int) (*room.Room, error) {
 	var room room.Room
 	err := roomsCol.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&room)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+// GetRoomByGameID returns the room whose current game is the given one.
+func GetRoomByGameID(gameID int) (*room.Room, error) {
+	var room room.Room
+	err := roomsCol.FindOne(context.TODO(), bson.M{"game._id": gameID}).Decode(&room)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &room, nil
+}
+
+func GetRoomByCode(code string) (*room.Room, error) {
+	var room room.Room
+	err := roomsCol.FindOne(context.TODO(), bson.M{"code": code}).Decode(&room)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -200,6 +400,9 @@ func GetRoomByID(id int) (*room.Room, error) {
 func GetBoardByID(id int) (*game.Board, error) {
 	var board game.Board
 	err := boardsCol.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&board)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -209,12 +412,32 @@ func GetBoardByID(id int) (*game.Board, error) {
 func GetGameByID(id int) (*game.Game, error) {
 	var game game.Game
 	err := gamesCol.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&game)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
 	if err != nil {
 		return nil, err
 	}
 	return &game, nil
 }
 
+// ViewGame returns a game as the viewer sees it. Games in progress are seen
+// through the room hosting them, which keeps spectators behind when it delays
+// them.
+func ViewGame(g *game.Game, viewerID int) (*game.Game, error) {
+	if g.IsOver() {
+		return g, nil
+	}
+	r, err := GetRoomByGameID(g.ID)
+	if err != nil {
+		return nil, err
+	}
+	if r == nil {
+		return g, nil
+	}
+	return r.ViewFor(viewerID).Game, nil
+}
+
 func DeletePlayerByID(id int) (bool, error) {
 	result, err := playersCol.DeleteOne(context.TODO(), bson.M{"_id": id})
 	if err == nil && result.DeletedCount > 0 {
@@ -257,6 +480,9 @@ func UpdatePlayerByID(id int, name string) (bool, error) {
 
 func UpdateRoomByID(id int, code string) (bool, error) {
 	result, err := roomsCol.UpdateOne(context.TODO(), bson.M{"_id": id}, bson.M{"$set": bson.M{"code": code}})
+	if mongo.IsDuplicateKeyError(err) {
+		return false, ErrCodeTaken
+	}
 	if err == nil && result.ModifiedCount > 0 {
 		logActionToRedis("update", "room", id)
 	}
@@ -270,3 +496,275 @@ func UpdateBoardByID(id int, size int) (bool, error) {
 	}
 	return result.ModifiedCount > 0, err
 }
+
+// modifyRoom applies modify to the current state of the room and stores the
+// result only if nobody else changed the room in the meantime. It returns a
+// nil room if the room does not exist.
+func modifyRoom(id int, modify func(r *room.Room) error) (*room.Room, error) {
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		r, err := GetRoomByID(id)
+		if err != nil || r == nil {
+			return nil, err
+		}
+
+		version := r.Version
+		state := r.GetState()
+		before := snapshotRoom(r)
+		if err := modify(r); err != nil {
+			return nil, err
+		}
+		if r.GetState() != state && !room.CanTransition(state, r.GetState()) {
+			return nil, fmt.Errorf("%w from %s to %s", room.ErrInvalidTransition, state, r.GetState())
+		}
+		r.State = r.GetState()
+		r.Version++
+
+		result, err := roomsCol.ReplaceOne(context.TODO(), versionFilter(id, version), r)
+		if err != nil {
+			return nil, err
+		}
+		if result.MatchedCount > 0 {
+			logActionToRedis("update", "room", id)
+			publishRoomChanges(before, r)
+			return r, nil
+		}
+	}
+	return nil, ErrConcurrentUpdate
+}
+
+// versionFilter matches the room if it is still at the version. Rooms stored
+// before they had versions have no version field, which is version 0.
+func versionFilter(id, version int) bson.M {
+	if version == 0 {
+		return bson.M{"_id": id, "version": bson.M{"$in": bson.A{0, nil}}}
+	}
+	return bson.M{"_id": id, "version": version}
+}
+
+// CreateRoom stores the room under a freshly generated invite code, picking a
+// new one whenever the unique index reports a collision.
+func CreateRoom(r *room.Room) error {
+	for attempt := 0; attempt < maxCodeAttempts; attempt++ {
+		code, err := room.GenerateCode()
+		if err != nil {
+			return err
+		}
+
+		r.Code = code
+		err = AddEntity(r)
+		if !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+	}
+	return fmt.Errorf("could not generate a unique invite code in %d attempts", maxCodeAttempts)
+}
+
+// JoinRoomByCode seats the player in the room the invite code belongs to.
+// It returns a nil room if no room uses the code.
+func JoinRoomByCode(code string, player *room.Player) (*room.Room, error) {
+	r, err := GetRoomByCode(code)
+	if err != nil || r == nil {
+		return nil, err
+	}
+
+	return JoinRoom(r.ID, code, player)
+}
+
+// JoinRoom seats the player in the room. Anyone but the owner has to bring
+// the room's current invite code. It returns a nil room if the room does not
+// exist.
+func JoinRoom(id int, code string, player *room.Player) (*room.Room, error) {
+	return modifyRoom(id, func(r *room.Room) error {
+		if err := r.CheckCode(player.ID, code); err != nil {
+			return err
+		}
+		return r.Join(player)
+	})
+}
+
+func Spectate(id int, player *room.Player) (*room.Room, error) {
+	return modifyRoom(id, func(r *room.Room) error {
+		return r.Spectate(player)
+	})
+}
+
+func StopSpectating(id int, playerID int) (*room.Room, error) {
+	return modifyRoom(id, func(r *room.Room) error {
+		return r.StopSpectating(playerID)
+	})
+}
+
+func LeaveRoom(id int, playerID int) (*room.Room, error) {
+	return modifyRoomGame(id, func(r *room.Room) error {
+		return r.Leave(playerID)
+	})
+}
+
+// StartRoomGame starts a new game in the room and keeps a copy of it in the
+// games collection so it can also be looked up by its own ID.
+func StartRoomGame(id int, starterID int, assignment room.ColorAssignment, color game.CellState) (*room.Room, error) {
+	gameID, err := nextID("games")
+	if err != nil {
+		return nil, err
+	}
+
+	r, err := modifyRoom(id, func(r *room.Room) error {
+		_, err := r.StartGame(starterID, assignment, color, game.WithID(gameID))
+		return err
+	})
+	if err != nil || r == nil {
+		return r, err
+	}
+
+	if err := saveGame(r.Game); err != nil {
+		return nil, err
+	}
+	logActionToRedis("create", "game", gameID)
+	return r, nil
+}
+
+// saveGame stores the current state of a game played inside a room.
+func saveGame(g *game.Game) error {
+	_, err := gamesCol.ReplaceOne(context.TODO(), bson.M{"_id": g.ID}, g, options.Replace().SetUpsert(true))
+	return err
+}
+
+func CommitNigiri(id int, playerID int, commitment string) (*room.Room, error) {
+	return modifyRoom(id, func(r *room.Room) error {
+		return r.CommitNigiri(playerID, commitment)
+	})
+}
+
+func GuessNigiri(id int, playerID int, guess string) (*room.Room, error) {
+	return modifyRoom(id, func(r *room.Room) error {
+		return r.GuessNigiri(playerID, guess)
+	})
+}
+
+func ClaimNigiri(id int, playerID int, color game.CellState) (*room.Room, error) {
+	return modifyRoom(id, func(r *room.Room) error {
+		return r.ClaimNigiri(playerID, color, time.Now())
+	})
+}
+
+// RevealNigiri stores the reveal even when it does not match the commitment,
+// so rejected attempts stay in the audit log.
+func RevealNigiri(id int, playerID int, stones int, nonce string) (*room.Room, error) {
+	var revealErr error
+	r, err := modifyRoom(id, func(r *room.Room) error {
+		revealErr = r.RevealNigiri(playerID, stones, nonce)
+		if errors.Is(revealErr, room.ErrRevealMismatch) {
+			return nil
+		}
+		return revealErr
+	})
+	if err != nil {
+		return nil, err
+	}
+	return r, revealErr
+}
+
+// modifyRoomGame applies a game action to the room and mirrors the updated
+// game into the games collection.
+//
+// A player running out of time loses the game, so ErrTimeout is stored along
+// with the finished game before it is returned.
+func modifyRoomGame(id int, modify func(r *room.Room) error) (*room.Room, error) {
+	var timeoutErr error
+	r, err := modifyRoom(id, func(r *room.Room) error {
+		timeoutErr = modify(r)
+		if errors.Is(timeoutErr, game.ErrTimeout) {
+			return nil
+		}
+		return timeoutErr
+	})
+	if err != nil || r == nil || r.Game == nil {
+		return r, err
+	}
+	if err := saveGame(r.Game); err != nil {
+		return nil, err
+	}
+	return r, timeoutErr
+}
+
+func PlayMove(id int, playerID int, p game.Point) (*room.Room, error) {
+	return modifyRoomGame(id, func(r *room.Room) error {
+		_, err := r.PlayMove(playerID, p)
+		return err
+	})
+}
+
+func Pass(id int, playerID int) (*room.Room, error) {
+	return modifyRoomGame(id, func(r *room.Room) error {
+		return r.Pass(playerID)
+	})
+}
+
+func Resign(id int, playerID int) (*room.Room, error) {
+	return modifyRoomGame(id, func(r *room.Room) error {
+		return r.Resign(playerID)
+	})
+}
+
+func AcceptScore(id int, playerID int) (*room.Room, error) {
+	return modifyRoomGame(id, func(r *room.Room) error {
+		return r.AcceptScore(playerID)
+	})
+}
+
+func ResumePlay(id int, playerID int) (*room.Room, error) {
+	return modifyRoomGame(id, func(r *room.Room) error {
+		return r.ResumePlay(playerID)
+	})
+}
+
+// FlagTimeout finishes the game of a room whose player to move ran out of
+// time. It returns room.ErrClockRunning if nobody did.
+func FlagTimeout(id int) (*room.Room, error) {
+	return modifyRoomGame(id, func(r *room.Room) error {
+		return r.FlagTimeout(time.Now())
+	})
+}
+
+// Takeback asks the opponent to undo the last move of the player.
+func Takeback(id int, playerID int) (*room.Room, error) {
+	return modifyRoomGame(id, func(r *room.Room) error {
+		return r.RequestTakeback(playerID)
+	})
+}
+
+func AcceptTakeback(id int, playerID int) (*room.Room, error) {
+	return modifyRoomGame(id, func(r *room.Room) error {
+		return r.AcceptTakeback(playerID)
+	})
+}
+
+func DeclineTakeback(id int, playerID int) (*room.Room, error) {
+	return modifyRoomGame(id, func(r *room.Room) error {
+		return r.DeclineTakeback(playerID)
+	})
+}
+
+// AdjudicateGame ends a game in progress with the result decided by an admin.
+// It returns a nil room if no room hosts the game.
+func AdjudicateGame(gameID int, winner game.CellState) (*room.Room, error) {
+	r, err := GetRoomByGameID(gameID)
+	if err != nil || r == nil {
+		return nil, err
+	}
+
+	return modifyRoomGame(r.ID, func(r *room.Room) error {
+		if r.Game == nil || r.Game.ID != gameID {
+			return room.ErrNoGame
+		}
+		return r.Adjudicate(winner)
+	})
+}
+
+// UpdateRoomSettings replaces the settings of a room with update applied to
+// its current settings. It returns a nil room if the room does not exist.
+func UpdateRoomSettings(id int, update func(s room.Settings) room.Settings) (*room.Room, error) {
+	return modifyRoom(id, func(r *room.Room) error {
+		return r.UpdateSettings(update(r.GetSettings()))
+	})
+}
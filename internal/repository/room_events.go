@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"slices"
+
+	"github.com/moLIart/go-course/internal/events"
+	"github.com/moLIart/go-course/internal/model/room"
+)
+
+// roomSnapshot is what modifyRoom remembers of a room to tell which events
+// a modification caused.
+type roomSnapshot struct {
+	state      room.State
+	players    []int
+	spectators []int
+	muted      []int
+	gameID     int
+	moveCount  int
+	gameOver   bool
+	// takebackBy is the player whose takeback request is pending.
+	takebackBy int
+}
+
+func snapshotRoom(r *room.Room) roomSnapshot {
+	s := roomSnapshot{
+		state:      r.GetState(),
+		players:    playerIDs(r.Players[:]),
+		spectators: playerIDs(r.Spectators),
+		muted:      slices.Clone(r.Muted),
+	}
+	if r.Game != nil {
+		s.gameID = r.Game.ID
+		s.moveCount = len(r.Game.Moves)
+		s.gameOver = r.Game.IsOver()
+		s.takebackBy = r.Game.PendingTakeback()
+	}
+	return s
+}
+
+func playerIDs(players []*room.Player) []int {
+	var ids []int
+	for _, p := range players {
+		if p != nil {
+			ids = append(ids, p.ID)
+		}
+	}
+	return ids
+}
+
+// publishRoomChanges publishes an event for every change between the
+// snapshot taken before a modification and the stored room.
+func publishRoomChanges(before roomSnapshot, r *room.Room) {
+	after := snapshotRoom(r)
+	publish := func(eventType string, data any) {
+		e := events.Event{Type: eventType, RoomID: r.ID, Data: data}
+		if r.Game != nil {
+			e.GameID = r.Game.ID
+		}
+		publishEvent(e)
+	}
+
+	publishMembership(before.players, after.players, r, events.PlayerJoined, events.PlayerLeft, publish)
+	publishMembership(before.spectators, after.spectators, r, events.SpectatorJoined, events.SpectatorLeft, publish)
+	publishMembership(before.muted, after.muted, r, events.ChatMuted, events.ChatUnmuted, publish)
+
+	if before.state != after.state {
+		publish(events.RoomStateChanged, events.StateChange{From: string(before.state), To: string(after.state)})
+	}
+
+	if r.Game != nil {
+		firstNew := before.moveCount
+		if before.gameID != after.gameID {
+			firstNew = 0
+		} else if after.moveCount < before.moveCount {
+			publish(events.MovesTakenBack, events.Takeback{MoveCount: after.moveCount})
+		}
+		for i := firstNew; i < after.moveCount; i++ {
+			publish(events.MovePlayed, events.NewMove(r.Game, i))
+		}
+		if after.takebackBy != 0 && after.takebackBy != before.takebackBy {
+			publish(events.TakebackAsked, events.Player{PlayerID: after.takebackBy})
+		} else if before.takebackBy != 0 && after.takebackBy == 0 && before.gameID == after.gameID && before.moveCount == after.moveCount {
+			publish(events.TakebackDeclined, events.Player{PlayerID: before.takebackBy})
+		}
+		if after.gameOver && (!before.gameOver || before.gameID != after.gameID) {
+			publish(events.GameOver, events.Result{Status: r.Game.GetStatus().String(), Result: r.Game.Result})
+		}
+	}
+}
+
+func publishMembership(before, after []int, r *room.Room, joined, left string, publish func(string, any)) {
+	for _, id := range after {
+		if !slices.Contains(before, id) {
+			data := events.Player{PlayerID: id}
+			if p := findPlayer(r, id); p != nil {
+				data.Name = p.Name
+			}
+			publish(joined, data)
+		}
+	}
+	for _, id := range before {
+		if !slices.Contains(after, id) {
+			publish(left, events.Player{PlayerID: id})
+		}
+	}
+}
+
+func findPlayer(r *room.Room, id int) *room.Player {
+	if p := r.GetPlayerByID(id); p != nil {
+		return p
+	}
+	for _, p := range r.Spectators {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/moLIart/go-course/internal/model/account"
+)
+
+func sessionKey(id string) string {
+	return "session:" + id
+}
+
+func playerSessionsKey(playerID int) string {
+	return fmt.Sprintf("sessions:%d", playerID)
+}
+
+// CreateSession starts a session of the player and returns it with its
+// refresh token.
+func CreateSession(playerID int, userAgent, ip string) (*account.Session, string, error) {
+	s, refreshToken, err := account.NewSession(playerID, userAgent, ip)
+	if err != nil {
+		return nil, "", err
+	}
+
+	payload, err := json.Marshal(s)
+	if err != nil {
+		return nil, "", err
+	}
+
+	ctx := context.Background()
+	_, err = redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, sessionKey(s.ID), payload, account.SessionTTL)
+		pipe.SAdd(ctx, playerSessionsKey(playerID), s.ID)
+		pipe.Expire(ctx, playerSessionsKey(playerID), account.SessionTTL)
+		return nil
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return s, refreshToken, nil
+}
+
+// RefreshSession exchanges a refresh token for a new one. A refresh token
+// that was already used revokes its session, as it must have been stolen.
+func RefreshSession(refreshToken string) (*account.Session, string, error) {
+	sessionID, _, err := account.ParseRefreshToken(refreshToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var s *account.Session
+	var newToken string
+	ctx := context.Background()
+	key := sessionKey(sessionID)
+	err = redisClient.Watch(ctx, func(tx *redis.Tx) error {
+		s, err = getSession(ctx, tx, sessionID)
+		if err != nil {
+			return err
+		}
+		if s == nil {
+			return account.ErrInvalidRefreshToken
+		}
+		if !s.CheckRefreshToken(refreshToken) {
+			revokeSession(ctx, s)
+			return account.ErrInvalidRefreshToken
+		}
+
+		if newToken, err = s.Rotate(); err != nil {
+			return err
+		}
+		payload, err := json.Marshal(s)
+		if err != nil {
+			return err
+		}
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.Set(ctx, key, payload, account.SessionTTL)
+			pipe.Expire(ctx, playerSessionsKey(s.PlayerID), account.SessionTTL)
+			return nil
+		})
+		return err
+	}, key)
+	if errors.Is(err, redis.TxFailedErr) {
+		// A concurrent refresh used the same token first.
+		return nil, "", account.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return s, newToken, nil
+}
+
+// IsSessionActive reports whether the session exists and was not revoked.
+func IsSessionActive(sessionID string) (bool, error) {
+	n, err := redisClient.Exists(context.Background(), sessionKey(sessionID)).Result()
+	return n > 0, err
+}
+
+// GetPlayerSessions returns the active sessions of the player, the most
+// recently used first.
+func GetPlayerSessions(playerID int) ([]*account.Session, error) {
+	ctx := context.Background()
+	ids, err := redisClient.SMembers(ctx, playerSessionsKey(playerID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := []*account.Session{}
+	for _, id := range ids {
+		s, err := getSession(ctx, redisClient, id)
+		if err != nil {
+			return nil, err
+		}
+		if s == nil {
+			// The session expired.
+			redisClient.SRem(ctx, playerSessionsKey(playerID), id)
+			continue
+		}
+		sessions = append(sessions, s)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+	return sessions, nil
+}
+
+// RevokeSession ends a session of the player. It reports false if the player
+// has no such session.
+func RevokeSession(playerID int, sessionID string) (bool, error) {
+	ctx := context.Background()
+	s, err := getSession(ctx, redisClient, sessionID)
+	if err != nil || s == nil || s.PlayerID != playerID {
+		return false, err
+	}
+	return true, revokeSession(ctx, s)
+}
+
+// RevokePlayerSessions ends every session of the player.
+func RevokePlayerSessions(playerID int) error {
+	ctx := context.Background()
+	ids, err := redisClient.SMembers(ctx, playerSessionsKey(playerID)).Result()
+	if err != nil {
+		return err
+	}
+
+	keys := []string{playerSessionsKey(playerID)}
+	for _, id := range ids {
+		keys = append(keys, sessionKey(id))
+	}
+	return redisClient.Del(ctx, keys...).Err()
+}
+
+func revokeSession(ctx context.Context, s *account.Session) error {
+	_, err := redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Del(ctx, sessionKey(s.ID))
+		pipe.SRem(ctx, playerSessionsKey(s.PlayerID), s.ID)
+		return nil
+	})
+	return err
+}
+
+func getSession(ctx context.Context, client redis.Cmdable, id string) (*account.Session, error) {
+	payload, err := client.Get(ctx, sessionKey(id)).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var s account.Session
+	if err := json.Unmarshal(payload, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
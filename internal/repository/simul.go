@@ -0,0 +1,205 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/moLIart/go-course/internal/model/game"
+	"github.com/moLIart/go-course/internal/model/room"
+	"github.com/moLIart/go-course/internal/model/simul"
+)
+
+var ErrHostNotFound = errors.New("simul host is not a known player")
+
+func GetSimuls() ([]*simul.Simul, error) {
+	var simuls []*simul.Simul
+	cursor, err := simulsCol.Find(context.TODO(), bson.D{{}})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	for cursor.Next(context.TODO()) {
+		var s simul.Simul
+		if err := cursor.Decode(&s); err != nil {
+			return nil, err
+		}
+		simuls = append(simuls, &s)
+	}
+	return simuls, nil
+}
+
+func GetSimulByID(id int) (*simul.Simul, error) {
+	var s simul.Simul
+	err := simulsCol.FindOne(context.TODO(), bson.M{"_id": id}).Decode(&s)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// GetSimulRooms returns the rooms of the boards of a simul in board order.
+func GetSimulRooms(id int) ([]*room.Room, error) {
+	var rooms []*room.Room
+	cursor, err := roomsCol.Find(context.TODO(), bson.M{"simul_id": id}, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(context.TODO())
+
+	for cursor.Next(context.TODO()) {
+		var r room.Room
+		if err := cursor.Decode(&r); err != nil {
+			return nil, err
+		}
+		rooms = append(rooms, &r)
+	}
+	return rooms, nil
+}
+
+// modifySimul applies modify to the current state of the simul and stores
+// the result only if nobody else changed the simul in the meantime. It
+// returns a nil simul if the simul does not exist.
+func modifySimul(id int, modify func(s *simul.Simul) error) (*simul.Simul, error) {
+	for attempt := 0; attempt < maxUpdateAttempts; attempt++ {
+		s, err := GetSimulByID(id)
+		if err != nil || s == nil {
+			return nil, err
+		}
+
+		version := s.Version
+		if err := modify(s); err != nil {
+			return nil, err
+		}
+		s.Version++
+
+		result, err := simulsCol.ReplaceOne(context.TODO(), bson.M{"_id": id, "version": version}, s)
+		if err != nil {
+			return nil, err
+		}
+		if result.MatchedCount > 0 {
+			logActionToRedis("update", "simul", id)
+			return s, nil
+		}
+	}
+	return nil, ErrConcurrentUpdate
+}
+
+func JoinSimul(id int, player *room.Player) (*simul.Simul, error) {
+	return modifySimul(id, func(s *simul.Simul) error {
+		return s.Join(player)
+	})
+}
+
+func LeaveSimul(id int, playerID int) (*simul.Simul, error) {
+	return modifySimul(id, func(s *simul.Simul) error {
+		return s.Leave(playerID)
+	})
+}
+
+// StartSimul opens a room with a started game for every opponent, then marks
+// the simul as running with these rooms as its boards. The rooms are deleted
+// again if the simul cannot be stored, so a simul is never running without
+// its boards.
+func StartSimul(id int, hostID int) (*simul.Simul, error) {
+	host, err := GetPlayerByID(hostID)
+	if err != nil {
+		return nil, err
+	}
+	if host == nil {
+		return nil, ErrHostNotFound
+	}
+
+	s, err := GetSimulByID(id)
+	if err != nil || s == nil {
+		return s, err
+	}
+	// Refuse early to spare creating the rooms, the check is repeated when
+	// the simul is stored.
+	if err := s.Start(hostID); err != nil {
+		return nil, err
+	}
+
+	rooms, err := createBoardRooms(s, host)
+	if err != nil {
+		deleteBoardRooms(rooms)
+		return nil, err
+	}
+
+	boards := make([]simul.Board, len(rooms))
+	for i, r := range rooms {
+		boards[i] = simul.Board{RoomID: r.ID, OpponentID: s.Opponents[i].ID}
+	}
+	started, err := modifySimul(id, func(current *simul.Simul) error {
+		if err := current.Start(hostID); err != nil {
+			return err
+		}
+		if !sameOpponents(current.Opponents, s.Opponents) {
+			return ErrConcurrentUpdate
+		}
+		current.Boards = boards
+		return nil
+	})
+	if err != nil || started == nil {
+		deleteBoardRooms(rooms)
+		return started, err
+	}
+
+	for _, r := range rooms {
+		logActionToRedis("create", "game", r.Game.ID)
+	}
+	return started, nil
+}
+
+// createBoardRooms stores a room with a started game for every opponent of
+// the simul. On error it returns the rooms created so far.
+func createBoardRooms(s *simul.Simul, host *room.Player) ([]*room.Room, error) {
+	rooms := make([]*room.Room, 0, len(s.Opponents))
+	for _, opponent := range s.Opponents {
+		gameID, err := nextID("games")
+		if err != nil {
+			return rooms, err
+		}
+
+		r, err := s.NewBoardRoom(host, opponent, game.WithID(gameID))
+		if err != nil {
+			return rooms, err
+		}
+		if err := CreateRoom(r); err != nil {
+			return rooms, err
+		}
+		rooms = append(rooms, r)
+		if err := saveGame(r.Game); err != nil {
+			return rooms, err
+		}
+	}
+	return rooms, nil
+}
+
+// deleteBoardRooms deletes the rooms of a simul that could not start, along
+// with their games.
+func deleteBoardRooms(rooms []*room.Room) {
+	for _, r := range rooms {
+		if _, err := DeleteRoomByID(r.ID); err != nil {
+			log.Printf("Failed to delete room %d of a simul that did not start: %v", r.ID, err)
+		}
+		if _, err := gamesCol.DeleteOne(context.TODO(), bson.M{"_id": r.Game.ID}); err != nil {
+			log.Printf("Failed to delete game %d of a simul that did not start: %v", r.Game.ID, err)
+		}
+	}
+}
+
+func sameOpponents(a, b []*room.Player) bool {
+	return slices.EqualFunc(a, b, func(x, y *room.Player) bool {
+		return x.ID == y.ID
+	})
+}
@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/moLIart/go-course/internal/model/account"
+	"github.com/moLIart/go-course/internal/model/room"
+)
+
+func twoFactorChallengeKey(token string) string {
+	return "two_factor_challenge:" + token
+}
+
+// SetupTwoFactor starts a new pending enrollment of the player's account,
+// replacing any pending one, and returns it with the account.
+func SetupTwoFactor(playerID int) (*account.TwoFactor, *account.Account, error) {
+	acc, err := GetAccountByPlayerID(playerID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if acc == nil {
+		return nil, nil, account.ErrInvalidCredentials
+	}
+	if acc.TwoFactorEnabled() {
+		return nil, nil, account.ErrTwoFactorEnabled
+	}
+
+	twoFactor, err := account.NewTwoFactor()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The filter keeps a concurrent enable from being overwritten.
+	result, err := accountsCol.UpdateOne(
+		context.TODO(),
+		bson.M{"_id": playerID, "two_factor.enabled": bson.M{"$ne": true}},
+		bson.M{"$set": bson.M{"two_factor": twoFactor}},
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	if result.MatchedCount == 0 {
+		return nil, nil, account.ErrTwoFactorEnabled
+	}
+	logActionToRedis("update", "account", playerID)
+	return twoFactor, acc, nil
+}
+
+// EnableTwoFactor enables the pending enrollment of the player once the code
+// proves that their authenticator app has the secret, and returns the
+// recovery codes.
+func EnableTwoFactor(playerID int, code string) ([]string, error) {
+	acc, err := GetAccountByPlayerID(playerID)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case acc == nil || acc.TwoFactor == nil:
+		return nil, account.ErrTwoFactorNotSetUp
+	case acc.TwoFactor.Enabled:
+		return nil, account.ErrTwoFactorEnabled
+	}
+
+	step, ok := acc.TwoFactor.VerifyCode(code, time.Now())
+	if !ok {
+		return nil, account.ErrInvalidTwoFactorCode
+	}
+
+	codes, hashes, err := account.NewRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := accountsCol.UpdateOne(
+		context.TODO(),
+		bson.M{"_id": playerID, "two_factor.secret": acc.TwoFactor.Secret, "two_factor.enabled": false},
+		bson.M{"$set": bson.M{
+			"two_factor.enabled":              true,
+			"two_factor.enabled_at":           time.Now().UTC(),
+			"two_factor.recovery_code_hashes": hashes,
+			"two_factor.last_step":            step,
+		}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	if result.MatchedCount == 0 {
+		// The enrollment was replaced or enabled in the meantime.
+		return nil, account.ErrTwoFactorNotSetUp
+	}
+	logActionToRedis("update", "account", playerID)
+	return codes, nil
+}
+
+// DisableTwoFactor removes the enrollment of the player once they confirm
+// with a code or a recovery code.
+func DisableTwoFactor(playerID int, code string) error {
+	if err := CheckTwoFactorCode(playerID, code); err != nil {
+		return err
+	}
+
+	_, err := accountsCol.UpdateOne(context.TODO(), bson.M{"_id": playerID}, bson.M{"$unset": bson.M{"two_factor": ""}})
+	if err != nil {
+		return err
+	}
+	logActionToRedis("update", "account", playerID)
+	return nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the player once they
+// confirm with a code or a recovery code.
+func RegenerateRecoveryCodes(playerID int, code string) ([]string, error) {
+	if err := CheckTwoFactorCode(playerID, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := account.NewRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = accountsCol.UpdateOne(
+		context.TODO(),
+		bson.M{"_id": playerID, "two_factor.enabled": true},
+		bson.M{"$set": bson.M{"two_factor.recovery_code_hashes": hashes}},
+	)
+	if err != nil {
+		return nil, err
+	}
+	logActionToRedis("update", "account", playerID)
+	return codes, nil
+}
+
+// CheckTwoFactorCode accepts a code of the authenticator app of the player,
+// each at most once, or uses up one of their recovery codes. It returns
+// account.ErrInvalidTwoFactorCode otherwise, and account.ErrTwoFactorLocked
+// without checking the code while too many wrong ones lock the account out.
+func CheckTwoFactorCode(playerID int, code string) error {
+	acc, err := GetAccountByPlayerID(playerID)
+	if err != nil {
+		return err
+	}
+	if acc == nil || !acc.TwoFactorEnabled() {
+		return account.ErrTwoFactorNotEnabled
+	}
+	now := time.Now()
+	if acc.TwoFactor.Locked(now) {
+		return account.ErrTwoFactorLocked
+	}
+
+	err = useTwoFactorCode(acc, code, now)
+	if errors.Is(err, account.ErrInvalidTwoFactorCode) {
+		if err := recordTwoFactorFailure(playerID, now); err != nil {
+			return err
+		}
+	}
+	return err
+}
+
+// useTwoFactorCode accepts the code and clears the wrong codes counted
+// before it.
+func useTwoFactorCode(acc *account.Account, code string, now time.Time) error {
+	accepted := bson.M{"two_factor.failures": "", "two_factor.locked_until": ""}
+
+	// The filters make concurrent requests with the same code accept it once.
+	if step, ok := acc.TwoFactor.VerifyCode(code, now); ok {
+		result, err := accountsCol.UpdateOne(
+			context.TODO(),
+			bson.M{"_id": acc.PlayerID, "two_factor.enabled": true, "two_factor.last_step": bson.M{"$lt": step}},
+			bson.M{"$set": bson.M{"two_factor.last_step": step}, "$unset": accepted},
+		)
+		if err != nil {
+			return err
+		}
+		if result.ModifiedCount == 0 {
+			return account.ErrInvalidTwoFactorCode
+		}
+		return nil
+	}
+
+	hash, ok := acc.TwoFactor.UseRecoveryCode(code)
+	if !ok {
+		return account.ErrInvalidTwoFactorCode
+	}
+	result, err := accountsCol.UpdateOne(
+		context.TODO(),
+		bson.M{"_id": acc.PlayerID, "two_factor.enabled": true, "two_factor.recovery_code_hashes": hash},
+		bson.M{"$pull": bson.M{"two_factor.recovery_code_hashes": hash}, "$unset": accepted},
+	)
+	if err != nil {
+		return err
+	}
+	if result.ModifiedCount == 0 {
+		return account.ErrInvalidTwoFactorCode
+	}
+	logActionToRedis("update", "account", acc.PlayerID)
+	return nil
+}
+
+// recordTwoFactorFailure counts a wrong code of the player and locks codes
+// out once there were too many in a row.
+func recordTwoFactorFailure(playerID int, now time.Time) error {
+	var acc account.Account
+	err := accountsCol.FindOneAndUpdate(
+		context.TODO(),
+		bson.M{"_id": playerID, "two_factor.enabled": true},
+		bson.M{"$inc": bson.M{"two_factor.failures": 1}},
+		options.FindOneAndUpdate().SetReturnDocument(options.After),
+	).Decode(&acc)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		// Two-factor authentication was disabled in the meantime.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	until := account.LockoutAfter(acc.TwoFactor.Failures, now)
+	if until == nil {
+		return nil
+	}
+	// $max keeps concurrent failures from shortening a longer lockout.
+	_, err = accountsCol.UpdateOne(
+		context.TODO(),
+		bson.M{"_id": playerID, "two_factor.enabled": true},
+		bson.M{"$max": bson.M{"two_factor.locked_until": *until}},
+	)
+	if err != nil {
+		return err
+	}
+	logActionToRedis("update", "account", playerID)
+	return nil
+}
+
+// CreateTwoFactorChallenge holds the login of the player until they enter
+// their code.
+func CreateTwoFactorChallenge(playerID int) (*account.TwoFactorChallenge, error) {
+	challenge, err := account.NewTwoFactorChallenge(playerID)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	key := twoFactorChallengeKey(challenge.Token)
+	_, err = redisClient.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.HSet(ctx, key, "player_id", playerID, "attempts", 0)
+		pipe.Expire(ctx, key, account.TwoFactorChallengeTTL)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return challenge, nil
+}
+
+// CompleteTwoFactorChallenge returns the player of the login once the code
+// is accepted. Each login can be completed once, and is dropped after
+// account.MaxTwoFactorAttempts wrong codes. Wrong codes also count towards
+// the lockout of the account, so that new logins do not give more tries.
+func CompleteTwoFactorChallenge(token, code string) (*room.Player, error) {
+	ctx := context.Background()
+	key := twoFactorChallengeKey(token)
+	attempts, err := redisClient.HIncrBy(ctx, key, "attempts", 1).Result()
+	if err != nil {
+		return nil, err
+	}
+	playerID, err := redisClient.HGet(ctx, key, "player_id").Int()
+	if errors.Is(err, redis.Nil) || attempts > account.MaxTwoFactorAttempts {
+		// HIncrBy created the key if the login had expired.
+		redisClient.Del(ctx, key)
+		return nil, account.ErrInvalidTwoFactorToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Logins whose two-factor authentication was disabled in the meantime
+	// only need the password that was already checked.
+	err = CheckTwoFactorCode(playerID, code)
+	if err != nil && !errors.Is(err, account.ErrTwoFactorNotEnabled) {
+		return nil, err
+	}
+
+	if n, err := redisClient.Del(ctx, key).Result(); err != nil || n == 0 {
+		// Another request completed the login first.
+		return nil, account.ErrInvalidTwoFactorToken
+	}
+
+	player, err := GetPlayerByID(playerID)
+	if err != nil {
+		return nil, err
+	}
+	if player == nil {
+		return nil, account.ErrInvalidCredentials
+	}
+	return player, nil
+}